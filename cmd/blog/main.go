@@ -2,21 +2,29 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/infrastructure/auth"
 	"my-blog-engine/internal/infrastructure/database"
+	"my-blog-engine/internal/infrastructure/imaging"
+	"my-blog-engine/internal/infrastructure/mailer"
 	"my-blog-engine/internal/infrastructure/persistence"
 	"my-blog-engine/internal/infrastructure/renderer"
+	"my-blog-engine/internal/infrastructure/storage"
 	"my-blog-engine/internal/interface/handler"
 	"my-blog-engine/internal/interface/middleware"
+	"my-blog-engine/internal/interface/worker"
 	"my-blog-engine/internal/usecase"
 )
 
@@ -57,62 +65,276 @@ func main() {
 	categoryRepo := persistence.NewCategoryRepository(db)
 	tagRepo := persistence.NewTagRepository(db)
 	tokenRepo := persistence.NewTokenRepository(db)
+	refreshTokenRepo := persistence.NewRefreshTokenRepository(db)
+	sessionRepo := persistence.NewSessionRepository(db)
+	accessTokenRepo := persistence.NewPersonalAccessTokenRepository(db)
+	loginThrottleRepo := persistence.NewLoginThrottleRepository(db)
+	loginAttemptRepo := persistence.NewLoginAttemptRepository(db)
+	revisionRepo := persistence.NewPostRevisionRepository(db)
+	postShareRepo := persistence.NewPostShareRepository(db)
+	reviewNoteRepo := persistence.NewReviewNoteRepository(db)
+	mediaRepo := persistence.NewMediaRepository(db)
+	commentRepo := persistence.NewCommentRepository(db)
+	invitationRepo := persistence.NewInvitationRepository(db)
+	passwordResetRepo := persistence.NewPasswordResetRepository(db)
+	mfaRepo := persistence.NewMFARepository(db)
+	mfaPolicyRepo := persistence.NewMFAPolicyRepository(db)
+	auditEventRepo := persistence.NewAuditEventRepository(db)
 
 	// Infrastructure初期化
-	passwordHasher := auth.NewPasswordHasher()
-	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
-		SecretKey:     cfg.JWTSecret,
-		AccessExpiry:  cfg.JWTAccessExpiry,
-		RefreshExpiry: cfg.JWTRefreshExpiry,
+	passwordHasher, err := auth.NewArgon2Hasher(auth.Argon2Params{
+		Memory:      cfg.Argon2Memory,
+		Iterations:  cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism,
 	})
+	if err != nil {
+		log.Fatal("Failed to create password hasher:", err)
+	}
+	passwordPolicy, err := newPasswordPolicy(cfg)
+	if err != nil {
+		log.Fatal("Failed to create password policy:", err)
+	}
+	jwtManager, err := newJWTManager(cfg)
 	if err != nil {
 		log.Fatal("Failed to create JWT manager:", err)
 	}
 
+	mfaSecretBox, err := auth.NewSecretBox(cfg.MFASecretKey)
+	if err != nil {
+		log.Fatal("Failed to create MFA secret box:", err)
+	}
+
+	previewSigner, err := auth.NewPreviewSigner(cfg.PreviewSecretKey)
+	if err != nil {
+		log.Fatal("Failed to create preview signer:", err)
+	}
+
+	mediaStorage, err := storage.NewLocalStorage(cfg.MediaStorageDir)
+	if err != nil {
+		log.Fatal("Failed to create media storage:", err)
+	}
+
+	// cwebpがない環境ではWebPのバリアントを生成しない
+	webpEncoder, err := imaging.NewCWebPEncoder(cfg.MediaWebPEncoder, cfg.MediaWebPQuality)
+	if err != nil {
+		slog.Warn("WebP variants are disabled", "error", err)
+	}
+
+	mailSender, err := newMailer(cfg)
+	if err != nil {
+		log.Fatal("Failed to create mailer:", err)
+	}
+
 	mermaidRenderer := renderer.NewMermaidRenderer()
-	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer)
+	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer, usecase.NewMediaImageResolver(mediaRepo))
+	commentRenderer := renderer.NewCommentRenderer()
 
 	// UseCase初期化
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenRepo, jwtManager, passwordHasher, cfg.JWTAccessExpiry)
-	postUseCase := usecase.NewPostUseCase(postRepo, categoryRepo, tagRepo, mdRenderer)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
-	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	authorizer := usecase.NewAuthorizer()
+	auditUseCase := usecase.NewAuditUseCase(auditEventRepo, usecase.AuditConfig{
+		Retention: cfg.AuditRetention,
+	})
+	mfaUseCase := usecase.NewMFAUseCase(mfaRepo, mfaPolicyRepo, mfaSecretBox, auditUseCase, cfg.SiteTitle)
+	loginProtectionUseCase := usecase.NewLoginProtectionUseCase(loginThrottleRepo, loginAttemptRepo, mailSender, auditUseCase, usecase.LoginProtectionConfig{
+		SiteTitle:     cfg.SiteTitle,
+		MaxFailures:   cfg.LoginMaxFailures,
+		IPMaxFailures: cfg.LoginIPMaxFailures,
+		LockoutBase:   cfg.LoginLockoutBase,
+		LockoutMax:    cfg.LoginLockoutMax,
+		FailureWindow: cfg.LoginFailureWindow,
+	})
+	authUseCase := usecase.NewAuthUseCase(userRepo, tokenRepo, refreshTokenRepo, sessionRepo, jwtManager, passwordHasher, passwordPolicy, mfaUseCase, loginProtectionUseCase, auditUseCase, cfg.JWTAccessExpiry)
+	accessTokenUseCase := usecase.NewPersonalAccessTokenUseCase(accessTokenRepo, userRepo, auditUseCase)
+	postUseCase := usecase.NewPostUseCase(postRepo, categoryRepo, tagRepo, userRepo, revisionRepo, postShareRepo, reviewNoteRepo, authorizer, auditUseCase, mdRenderer)
+	reviewNoteUseCase := usecase.NewReviewNoteUseCase(reviewNoteRepo, postRepo)
+	postPreviewUseCase := usecase.NewPostPreviewUseCase(postRepo, previewSigner, auditUseCase, usecase.PreviewConfig{
+		SiteURL: cfg.SiteURL,
+		TTL:     cfg.PreviewTTL,
+		MaxTTL:  cfg.PreviewMaxTTL,
+	})
+	userUseCase := usecase.NewUserUseCase(userRepo, passwordHasher, passwordPolicy, auditUseCase)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, auditUseCase)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, auditUseCase)
+	sitemapUseCase := usecase.NewSitemapUseCase(postRepo, categoryRepo, tagRepo)
+	mediaUseCase := usecase.NewMediaUseCase(mediaRepo, mediaStorage, webpEncoder, auditUseCase, cfg.MediaMaxUploadSize)
+	commentUseCase := usecase.NewCommentUseCase(commentRepo, postRepo, commentRenderer, auditUseCase)
+	invitationUseCase := usecase.NewInvitationUseCase(invitationRepo, userRepo, passwordHasher, passwordPolicy, mailSender, auditUseCase, usecase.InvitationConfig{
+		SiteURL:   cfg.SiteURL,
+		SiteTitle: cfg.SiteTitle,
+		TTL:       cfg.InvitationTTL,
+	})
+
+	passwordResetUseCase := usecase.NewPasswordResetUseCase(passwordResetRepo, userRepo, passwordHasher, passwordPolicy, mailSender, auditUseCase, usecase.PasswordResetConfig{
+		SiteURL:      cfg.SiteURL,
+		SiteTitle:    cfg.SiteTitle,
+		TTL:          cfg.PasswordResetTTL,
+		ResponseTime: cfg.PasswordResetResponseTime,
+	})
 
 	// Handler初期化
 	healthHandler := handler.NewHealthHandler(db)
-	authHandler := handler.NewAuthHandler(authUseCase)
-	postHandler := handler.NewPostHandler(postUseCase)
-	categoryHandler := handler.NewCategoryHandler(categoryUseCase)
-	tagHandler := handler.NewTagHandler(tagUseCase)
-	publicHandler := handler.NewPublicHandler(postUseCase, categoryUseCase)
+	jwksHandler := handler.NewJWKSHandler(jwtManager)
+	authHandler := handler.NewAuthHandler(authUseCase, passwordResetUseCase)
+	userHandler := handler.NewUserHandler(userUseCase)
+	mfaHandler := handler.NewMFAHandler(mfaUseCase)
+	accessTokenHandler := handler.NewPersonalAccessTokenHandler(accessTokenUseCase)
+	loginProtectionHandler := handler.NewLoginProtectionHandler(loginProtectionUseCase)
+	invitationHandler := handler.NewInvitationHandler(invitationUseCase)
+	auditHandler := handler.NewAuditHandler(auditUseCase)
+	postHandler := handler.NewPostHandler(postUseCase, authorizer)
+	reviewNoteHandler := handler.NewReviewNoteHandler(reviewNoteUseCase, postUseCase)
+	postPreviewHandler := handler.NewPostPreviewHandler(postPreviewUseCase, postUseCase)
+	categoryHandler := handler.NewCategoryHandler(categoryUseCase, authorizer)
+	tagHandler := handler.NewTagHandler(tagUseCase, authorizer)
+	mediaHandler := handler.NewMediaHandler(mediaUseCase, authorizer, cfg.MediaMaxUploadSize)
+	commentHandler := handler.NewCommentHandler(commentUseCase)
+	publicHandler := handler.NewPublicHandler(postUseCase, categoryUseCase, tagUseCase, commentUseCase, postPreviewUseCase)
+	feedHandler := handler.NewFeedHandler(postUseCase, categoryUseCase, tagUseCase, handler.FeedConfig{
+		SiteURL:         cfg.SiteURL,
+		SiteTitle:       cfg.SiteTitle,
+		SiteDescription: cfg.SiteDescription,
+		CacheTTL:        cfg.FeedCacheTTL,
+	})
+	sitemapHandler := handler.NewSitemapHandler(sitemapUseCase, handler.SitemapConfig{
+		SiteURL:        cfg.SiteURL,
+		CacheTTL:       cfg.SitemapCacheTTL,
+		RobotsDisallow: cfg.RobotsDisallow,
+	})
+
+	// バックグラウンドワーカー起動
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	scheduledPublisher := worker.NewScheduledPublisher(postUseCase, cfg.ScheduledPublishInterval)
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		scheduledPublisher.Run(workerCtx)
+	}()
+	// 保持期間が0の場合は監査ログを削除しない
+	if cfg.AuditRetention > 0 {
+		auditPruner := worker.NewAuditPruner(auditUseCase, cfg.AuditPruneInterval)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			auditPruner.Run(workerCtx)
+		}()
+	}
+	workerDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workerDone)
+	}()
 
 	// Middleware初期化
-	authMiddleware := middleware.NewAuthMiddleware(authUseCase)
+	authMiddleware := middleware.NewAuthMiddleware(authUseCase, accessTokenUseCase)
 	rateLimiter := middleware.NewRateLimiter(100, 200)
+	// コメント投稿はIPアドレスごとに1分あたりの件数をさらに制限する
+	commentRateLimiter := middleware.NewRateLimiter(cfg.CommentRateLimit, cfg.CommentRateLimit)
+	// パスワード再設定の申請はメール送信を伴うため、IPアドレスごとに1分あたりの件数を制限する
+	passwordResetRateLimiter := middleware.NewRateLimiter(cfg.PasswordResetRateLimit, cfg.PasswordResetRateLimit)
+	// 二要素認証のコード入力は総当たりを防ぐため、IPアドレスごとに1分あたりの件数を制限する
+	mfaRateLimiter := middleware.NewRateLimiter(cfg.MFARateLimit, cfg.MFARateLimit)
 
 	// ルーター設定
 	mux := http.NewServeMux()
 
 	// 公開HTMLページ
-	mux.HandleFunc("/", publicHandler.Home)
+	mux.HandleFunc("GET /{$}", publicHandler.Home)
+	mux.HandleFunc("GET /page/{page}", publicHandler.Home)
+	mux.HandleFunc("GET /posts/{slug}", publicHandler.Post)
+	mux.HandleFunc("GET /preview/{token}", publicHandler.Preview)
+	mux.Handle("POST /posts/{slug}/comments", commentRateLimiter.Limit(http.HandlerFunc(publicHandler.Comment)))
+	mux.HandleFunc("GET /category/{slug}", publicHandler.Category)
+	mux.HandleFunc("GET /category/{slug}/page/{page}", publicHandler.Category)
+	mux.HandleFunc("GET /tag/{slug}", publicHandler.Tag)
+	mux.HandleFunc("GET /tag/{slug}/page/{page}", publicHandler.Tag)
+	mux.HandleFunc("GET /author/{username}", publicHandler.Author)
+	mux.HandleFunc("GET /author/{username}/page/{page}", publicHandler.Author)
+	mux.HandleFunc("GET /archive/{year}", publicHandler.Archive)
+	mux.HandleFunc("GET /archive/{year}/page/{page}", publicHandler.Archive)
+	mux.HandleFunc("GET /archive/{year}/{month}", publicHandler.Archive)
+	mux.HandleFunc("GET /archive/{year}/{month}/page/{page}", publicHandler.Archive)
+	mux.HandleFunc("GET /search", publicHandler.Search)
+	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
+	mux.HandleFunc("GET /media/{key...}", mediaHandler.Serve)
+
+	// フィード(formatはrss/atom/json)
+	mux.HandleFunc("GET /feed/{format}", feedHandler.Site)
+	mux.HandleFunc("GET /category/{slug}/feed/{format}", feedHandler.Category)
+	mux.HandleFunc("GET /tag/{slug}/feed/{format}", feedHandler.Tag)
+	mux.HandleFunc("GET /author/{username}/feed/{format}", feedHandler.Author)
+
+	// サイトマップ・robots.txt
+	mux.HandleFunc("GET /sitemap.xml", sitemapHandler.Sitemap)
+	mux.HandleFunc("GET /sitemaps/{file}", sitemapHandler.SitemapPage)
+	mux.HandleFunc("GET /robots.txt", sitemapHandler.Robots)
+
+	// 上記以外のパスは404ページ
+	mux.HandleFunc("/", publicHandler.NotFound)
 
 	// 公開エンドポイント
 	mux.HandleFunc("/health", healthHandler.Check)
+	mux.HandleFunc("GET /.well-known/jwks.json", jwksHandler.Keys)
 	mux.HandleFunc("/api/auth/login", authHandler.Login)
 	mux.HandleFunc("/api/auth/refresh", authHandler.RefreshToken)
+	mux.Handle("/api/auth/password/forgot", passwordResetRateLimiter.Limit(http.HandlerFunc(authHandler.ForgotPassword)))
+	mux.HandleFunc("/api/auth/password/reset", authHandler.ResetPassword)
+	mux.HandleFunc("/api/invitations/accept", invitationHandler.Accept)
+
+	// ログインの2段階目(ログイン時に返されたmfaTokenで認証)
+	mux.Handle("/api/auth/mfa/verify", mfaRateLimiter.Limit(http.HandlerFunc(authHandler.VerifyMFA)))
+	mux.Handle("/api/auth/mfa/enroll", mfaRateLimiter.Limit(http.HandlerFunc(authHandler.BeginMFAEnrollment)))
+	mux.Handle("/api/auth/mfa/enroll/confirm", mfaRateLimiter.Limit(http.HandlerFunc(authHandler.CompleteMFAEnrollment)))
 
 	// 認証が必要なエンドポイント
 	mux.Handle("/api/auth/logout", authMiddleware.Authenticate(http.HandlerFunc(authHandler.Logout)))
 	mux.Handle("/api/auth/me", authMiddleware.Authenticate(http.HandlerFunc(authHandler.Me)))
+	mux.Handle("/api/auth/sessions", authMiddleware.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			authHandler.ListSessions(w, r)
+		case http.MethodDelete:
+			authHandler.RevokeSession(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})))
+	mux.Handle("/api/auth/sessions/others", authMiddleware.Authenticate(http.HandlerFunc(authHandler.RevokeOtherSessions)))
+	mux.Handle("/api/auth/password/change", authMiddleware.Authenticate(http.HandlerFunc(authHandler.ChangePassword)))
+
+	// パーソナルアクセストークン(ログイン中のユーザー本人、トークン自体では操作できない)
+	mux.Handle("/api/auth/tokens", authMiddleware.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			accessTokenHandler.List(w, r)
+		case http.MethodPost:
+			accessTokenHandler.Create(w, r)
+		case http.MethodDelete:
+			accessTokenHandler.Revoke(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})))
 
-	// 記事管理エンドポイント(編集権限が必要)
+	// 二要素認証の設定(ログイン中のユーザー本人)
+	mux.Handle("/api/auth/mfa", authMiddleware.Authenticate(http.HandlerFunc(mfaHandler.Status)))
+	mux.Handle("/api/auth/mfa/setup", authMiddleware.Authenticate(http.HandlerFunc(mfaHandler.Setup)))
+	mux.Handle("/api/auth/mfa/confirm", mfaRateLimiter.Limit(authMiddleware.Authenticate(http.HandlerFunc(mfaHandler.Confirm))))
+	mux.Handle("/api/auth/mfa/disable", mfaRateLimiter.Limit(authMiddleware.Authenticate(http.HandlerFunc(mfaHandler.Disable))))
+	mux.Handle("/api/auth/mfa/recovery-codes", mfaRateLimiter.Limit(authMiddleware.Authenticate(http.HandlerFunc(mfaHandler.RegenerateRecoveryCodes))))
+
+	// 記事管理エンドポイント(記事ごとの権限はAuthorizerで著者・共有先・ロールから判定)
 	mux.Handle("/api/admin/posts",
-		authMiddleware.Authenticate(
-			authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor)(
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
 				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					switch r.Method {
 					case http.MethodGet:
-						postHandler.List(w, r)
+						if r.URL.Query().Has("id") {
+							postHandler.Get(w, r)
+						} else {
+							postHandler.List(w, r)
+						}
 					case http.MethodPost:
 						postHandler.Create(w, r)
 					case http.MethodPut:
@@ -128,25 +350,168 @@ func main() {
 	)
 
 	mux.Handle("/api/admin/posts/publish",
-		authMiddleware.Authenticate(
-			authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor)(
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
 				http.HandlerFunc(postHandler.Publish),
 			),
 		),
 	)
 
 	mux.Handle("/api/admin/posts/unpublish",
-		authMiddleware.Authenticate(
-			authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor)(
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
 				http.HandlerFunc(postHandler.Unpublish),
 			),
 		),
 	)
 
+	// 記事の共有エンドポイント(共有先のユーザーは下書きを閲覧できる)
+	mux.Handle("/api/admin/posts/shares",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case http.MethodGet:
+						postHandler.ListShares(w, r)
+					case http.MethodPost:
+						postHandler.Share(w, r)
+					case http.MethodDelete:
+						postHandler.Unshare(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				}),
+			),
+		),
+	)
+
+	// 記事のレビューエンドポイント(承認・差し戻しは担当のレビュー担当者のみ)
+	mux.Handle("/api/admin/posts/review/submit",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.SubmitForReview),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/review/reviewer",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.AssignReviewer),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/review/approve",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.ApproveReview),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/review/reject",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.RejectReview),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/review/queue",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.ReviewQueue),
+			),
+		),
+	)
+
+	// 記事のレビューノートエンドポイント(記事を編集できるユーザーと担当のレビュー担当者のみ)
+	mux.Handle("/api/admin/posts/notes",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case http.MethodGet:
+						reviewNoteHandler.List(w, r)
+					case http.MethodPost:
+						reviewNoteHandler.Create(w, r)
+					case http.MethodPut:
+						reviewNoteHandler.Update(w, r)
+					case http.MethodDelete:
+						reviewNoteHandler.Delete(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				}),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/notes/resolve",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(reviewNoteHandler.Resolve),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/notes/reopen",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(reviewNoteHandler.Reopen),
+			),
+		),
+	)
+
+	// 記事のプレビューURL(POSTで発行、DELETEで記事の発行済みURLをすべて失効)
+	mux.Handle("/api/admin/posts/preview-links",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case http.MethodPost:
+						postPreviewHandler.CreateLink(w, r)
+					case http.MethodDelete:
+						postPreviewHandler.RevokeLinks(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				}),
+			),
+		),
+	)
+
+	// 記事リビジョンエンドポイント(閲覧・復元の権限は記事ごとに判定)
+	mux.Handle("/api/admin/posts/revisions",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.ListRevisions),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/revisions/diff",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.DiffRevisions),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/posts/revisions/restore",
+		authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(
+			authMiddleware.Authenticate(
+				http.HandlerFunc(postHandler.RestoreRevision),
+			),
+		),
+	)
+
 	// 公開記事エンドポイント
 	mux.HandleFunc("/api/posts", postHandler.ListPublished)
 	mux.HandleFunc("/api/posts/id", postHandler.GetByID)
 	mux.HandleFunc("/api/posts/slug", postHandler.GetBySlug)
+	mux.HandleFunc("/api/posts/search", postHandler.Search)
 
 	// カテゴリエンドポイント
 	mux.HandleFunc("/api/categories", func(w http.ResponseWriter, r *http.Request) {
@@ -160,22 +525,24 @@ func main() {
 	mux.HandleFunc("/api/categories/slug", categoryHandler.GetBySlug)
 
 	mux.Handle("/api/admin/categories",
-		authMiddleware.Authenticate(
-			authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor)(
-				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					switch r.Method {
-					case http.MethodGet:
-						categoryHandler.List(w, r)
-					case http.MethodPost:
-						categoryHandler.Create(w, r)
-					case http.MethodPut:
-						categoryHandler.Update(w, r)
-					case http.MethodDelete:
-						categoryHandler.Delete(w, r)
-					default:
-						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-					}
-				}),
+		authMiddleware.AcceptAccessToken(entity.ScopeCategoriesRead, entity.ScopeCategoriesWrite)(
+			authMiddleware.Authenticate(
+				authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor, entity.RoleContributor)(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.Method {
+						case http.MethodGet:
+							categoryHandler.List(w, r)
+						case http.MethodPost:
+							categoryHandler.Create(w, r)
+						case http.MethodPut:
+							categoryHandler.Update(w, r)
+						case http.MethodDelete:
+							categoryHandler.Delete(w, r)
+						default:
+							http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						}
+					}),
+				),
 			),
 		),
 	)
@@ -192,18 +559,88 @@ func main() {
 	mux.HandleFunc("/api/tags/slug", tagHandler.GetBySlug)
 
 	mux.Handle("/api/admin/tags",
+		authMiddleware.AcceptAccessToken(entity.ScopeTagsRead, entity.ScopeTagsWrite)(
+			authMiddleware.Authenticate(
+				authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor, entity.RoleContributor)(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.Method {
+						case http.MethodGet:
+							tagHandler.List(w, r)
+						case http.MethodPost:
+							tagHandler.Create(w, r)
+						case http.MethodPut:
+							tagHandler.Update(w, r)
+						case http.MethodDelete:
+							tagHandler.Delete(w, r)
+						default:
+							http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						}
+					}),
+				),
+			),
+		),
+	)
+
+	// メディアエンドポイント(更新・削除はアップロードしたユーザーと管理者のみ)
+	mux.Handle("/api/admin/media",
+		authMiddleware.AcceptAccessToken(entity.ScopeMediaRead, entity.ScopeMediaWrite)(
+			authMiddleware.Authenticate(
+				authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor, entity.RoleContributor)(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.Method {
+						case http.MethodGet:
+							mediaHandler.List(w, r)
+						case http.MethodPost:
+							mediaHandler.Upload(w, r)
+						case http.MethodPut:
+							mediaHandler.Update(w, r)
+						case http.MethodDelete:
+							mediaHandler.Delete(w, r)
+						default:
+							http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						}
+					}),
+				),
+			),
+		),
+	)
+
+	// コメント管理エンドポイント(編集権限が必要)
+	mux.Handle("/api/admin/comments",
+		authMiddleware.AcceptAccessToken(entity.ScopeCommentsRead, entity.ScopeCommentsWrite)(
+			authMiddleware.Authenticate(
+				authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor)(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.Method {
+						case http.MethodGet:
+							commentHandler.List(w, r)
+						case http.MethodPut:
+							commentHandler.Moderate(w, r)
+						case http.MethodDelete:
+							commentHandler.Delete(w, r)
+						default:
+							http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						}
+					}),
+				),
+			),
+		),
+	)
+
+	// ユーザー管理エンドポイント(管理者のみ)
+	mux.Handle("/api/admin/users",
 		authMiddleware.Authenticate(
-			authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleEditor)(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
 				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					switch r.Method {
 					case http.MethodGet:
-						tagHandler.List(w, r)
+						userHandler.List(w, r)
 					case http.MethodPost:
-						tagHandler.Create(w, r)
+						userHandler.Create(w, r)
 					case http.MethodPut:
-						tagHandler.Update(w, r)
+						userHandler.Update(w, r)
 					case http.MethodDelete:
-						tagHandler.Delete(w, r)
+						userHandler.Deactivate(w, r)
 					default:
 						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 					}
@@ -212,11 +649,110 @@ func main() {
 		),
 	)
 
+	mux.Handle("/api/admin/users/password",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(userHandler.ResetPassword),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/users/sessions",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(authHandler.RevokeUserSessions),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/users/mfa",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(mfaHandler.ResetUser),
+			),
+		),
+	)
+
+	// 二要素認証ポリシーエンドポイント(管理者のみ)
+	mux.Handle("/api/admin/mfa-policies",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case http.MethodGet:
+						mfaHandler.ListPolicies(w, r)
+					case http.MethodPut:
+						mfaHandler.SetPolicy(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				}),
+			),
+		),
+	)
+
+	// ログインのロック・試行履歴エンドポイント(管理者のみ)
+	mux.Handle("/api/admin/login-lockouts",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case http.MethodGet:
+						loginProtectionHandler.ListLockouts(w, r)
+					case http.MethodDelete:
+						loginProtectionHandler.Unlock(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				}),
+			),
+		),
+	)
+
+	mux.Handle("/api/admin/login-attempts",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(loginProtectionHandler.ListAttempts),
+			),
+		),
+	)
+
+	// 招待エンドポイント(管理者のみ)
+	mux.Handle("/api/admin/invitations",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case http.MethodGet:
+						invitationHandler.List(w, r)
+					case http.MethodPost:
+						invitationHandler.Create(w, r)
+					case http.MethodDelete:
+						invitationHandler.Revoke(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				}),
+			),
+		),
+	)
+
+	// 監査ログエンドポイント(管理者のみ)
+	mux.Handle("/api/admin/audit-events",
+		authMiddleware.Authenticate(
+			authMiddleware.RequireRole(entity.RoleAdmin)(
+				http.HandlerFunc(auditHandler.List),
+			),
+		),
+	)
+
 	// ミドルウェアチェーン
 	handler := middleware.Recovery(
 		middleware.Logging(
 			middleware.SecurityHeaders(
-				rateLimiter.Limit(mux),
+				middleware.ClientInfo(
+					rateLimiter.Limit(mux),
+				),
 			),
 		),
 	)
@@ -252,6 +788,14 @@ func main() {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	// ワーカーを停止し、処理中のバッチの終了を待つ
+	stopWorkers()
+	select {
+	case <-workerDone:
+	case <-ctx.Done():
+		slog.Warn("Background workers did not stop in time")
+	}
+
 	slog.Info("Server exited")
 }
 
@@ -265,8 +809,64 @@ type Config struct {
 	JWTSecret        string
 	JWTAccessExpiry  time.Duration
 	JWTRefreshExpiry time.Duration
+	JWTKeysDir       string
+	JWTActiveKeyID   string
+	JWTAcceptHS256   bool
 	ServerHost       string
 	ServerPort       string
+
+	ScheduledPublishInterval time.Duration
+
+	SiteURL         string
+	SiteTitle       string
+	SiteDescription string
+	FeedCacheTTL    time.Duration
+	SitemapCacheTTL time.Duration
+	RobotsDisallow  []string
+
+	MediaStorageDir    string
+	MediaMaxUploadSize int64
+	MediaWebPEncoder   string
+	MediaWebPQuality   int
+
+	CommentRateLimit int
+
+	InvitationTTL time.Duration
+
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+
+	PasswordMinLength   int
+	PasswordMaxBytes    int
+	PasswordBreachedDir string
+
+	PasswordResetTTL          time.Duration
+	PasswordResetResponseTime time.Duration
+	PasswordResetRateLimit    int
+
+	MFASecretKey string
+	MFARateLimit int
+
+	PreviewSecretKey string
+	PreviewTTL       time.Duration
+	PreviewMaxTTL    time.Duration
+
+	LoginMaxFailures   int
+	LoginIPMaxFailures int
+	LoginLockoutBase   time.Duration
+	LoginLockoutMax    time.Duration
+	LoginFailureWindow time.Duration
+
+	AuditRetention     time.Duration
+	AuditPruneInterval time.Duration
+
+	MailDriver   string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
 }
 
 // loadConfig 環境変数から設定を読み込む
@@ -280,8 +880,130 @@ func loadConfig() Config {
 		JWTSecret:        getEnv("JWT_SECRET", "your-secret-key-min-32-chars-long-change-this-in-production"),
 		JWTAccessExpiry:  parseDuration(getEnv("JWT_ACCESS_EXPIRY", "15m"), 15*time.Minute),
 		JWTRefreshExpiry: parseDuration(getEnv("JWT_REFRESH_EXPIRY", "168h"), 168*time.Hour),
+		JWTKeysDir:       getEnv("JWT_KEYS_DIR", ""),
+		JWTActiveKeyID:   getEnv("JWT_ACTIVE_KEY_ID", ""),
+		JWTAcceptHS256:   parseBool(getEnv("JWT_ACCEPT_HS256", "false"), false),
 		ServerHost:       getEnv("SERVER_HOST", "0.0.0.0"),
 		ServerPort:       getEnv("SERVER_PORT", "8080"),
+
+		ScheduledPublishInterval: parseDuration(getEnv("SCHEDULED_PUBLISH_INTERVAL", "30s"), 30*time.Second),
+
+		SiteURL:         getEnv("SITE_URL", "http://localhost:8080"),
+		SiteTitle:       getEnv("SITE_TITLE", "Blog Engine"),
+		SiteDescription: getEnv("SITE_DESCRIPTION", ""),
+		FeedCacheTTL:    parseDuration(getEnv("FEED_CACHE_TTL", "5m"), 5*time.Minute),
+		SitemapCacheTTL: parseDuration(getEnv("SITEMAP_CACHE_TTL", "1h"), time.Hour),
+		RobotsDisallow:  getEnvList("ROBOTS_DISALLOW", []string{"/api/", "/search"}),
+
+		MediaStorageDir:    getEnv("MEDIA_STORAGE_DIR", "uploads"),
+		MediaMaxUploadSize: parseInt64(getEnv("MEDIA_MAX_UPLOAD_SIZE", "10485760"), usecase.DefaultMaxUploadSize),
+		MediaWebPEncoder:   getEnv("MEDIA_WEBP_ENCODER", "cwebp"),
+		MediaWebPQuality:   int(parseInt64(getEnv("MEDIA_WEBP_QUALITY", "80"), 80)),
+
+		CommentRateLimit: int(parseInt64(getEnv("COMMENT_RATE_LIMIT", "5"), 5)),
+
+		InvitationTTL: parseDuration(getEnv("INVITATION_TTL", "72h"), usecase.DefaultInvitationTTL),
+
+		Argon2Memory:      uint32(parseInt64(getEnv("ARGON2_MEMORY", "65536"), auth.DefaultArgon2Memory)),
+		Argon2Iterations:  uint32(parseInt64(getEnv("ARGON2_ITERATIONS", "3"), auth.DefaultArgon2Iterations)),
+		Argon2Parallelism: uint8(parseInt64(getEnv("ARGON2_PARALLELISM", "2"), auth.DefaultArgon2Parallelism)),
+
+		PasswordMinLength:   int(parseInt64(getEnv("PASSWORD_MIN_LENGTH", "8"), usecase.DefaultPasswordMinLength)),
+		PasswordMaxBytes:    int(parseInt64(getEnv("PASSWORD_MAX_BYTES", "72"), 72)),
+		PasswordBreachedDir: getEnv("PASSWORD_BREACHED_DIR", ""),
+
+		PasswordResetTTL:          parseDuration(getEnv("PASSWORD_RESET_TTL", "30m"), usecase.DefaultPasswordResetTTL),
+		PasswordResetResponseTime: parseDuration(getEnv("PASSWORD_RESET_RESPONSE_TIME", "1s"), usecase.DefaultPasswordResetResponseTime),
+		PasswordResetRateLimit:    int(parseInt64(getEnv("PASSWORD_RESET_RATE_LIMIT", "5"), 5)),
+
+		// 未設定の場合はJWT_SECRETを鍵として使用する
+		MFASecretKey: getEnv("MFA_SECRET_KEY", getEnv("JWT_SECRET", "your-secret-key-min-32-chars-long-change-this-in-production")),
+		MFARateLimit: int(parseInt64(getEnv("MFA_RATE_LIMIT", "10"), 10)),
+
+		// 未設定の場合はJWT_SECRETを鍵として使用する
+		PreviewSecretKey: getEnv("PREVIEW_SECRET_KEY", getEnv("JWT_SECRET", "your-secret-key-min-32-chars-long-change-this-in-production")),
+		PreviewTTL:       parseDuration(getEnv("PREVIEW_TTL", "72h"), usecase.DefaultPreviewLinkTTL),
+		PreviewMaxTTL:    parseDuration(getEnv("PREVIEW_MAX_TTL", "720h"), usecase.DefaultPreviewLinkMaxTTL),
+
+		LoginMaxFailures:   int(parseInt64(getEnv("LOGIN_MAX_FAILURES", "5"), usecase.DefaultLoginMaxFailures)),
+		LoginIPMaxFailures: int(parseInt64(getEnv("LOGIN_IP_MAX_FAILURES", "20"), usecase.DefaultLoginIPMaxFailures)),
+		LoginLockoutBase:   parseDuration(getEnv("LOGIN_LOCKOUT_BASE", "1m"), usecase.DefaultLoginLockoutBase),
+		LoginLockoutMax:    parseDuration(getEnv("LOGIN_LOCKOUT_MAX", "1h"), usecase.DefaultLoginLockoutMax),
+		LoginFailureWindow: parseDuration(getEnv("LOGIN_FAILURE_WINDOW", "24h"), usecase.DefaultLoginFailureWindow),
+
+		AuditRetention:     parseDuration(getEnv("AUDIT_RETENTION", "8760h"), usecase.DefaultAuditRetention),
+		AuditPruneInterval: parseDuration(getEnv("AUDIT_PRUNE_INTERVAL", "1h"), time.Hour),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "log"),
+		MailFrom:     getEnv("MAIL_FROM", "Blog Engine <noreply@localhost>"),
+		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     int(parseInt64(getEnv("SMTP_PORT", "587"), 587)),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+	}
+}
+
+// newPasswordPolicy 設定に応じたパスワードポリシーを作成
+// PASSWORD_BREACHED_DIRが未設定の場合は漏洩パスワードを確認しない
+func newPasswordPolicy(cfg Config) (usecase.PasswordPolicy, error) {
+	config := usecase.PasswordPolicyConfig{
+		MinLength: cfg.PasswordMinLength,
+		MaxBytes:  cfg.PasswordMaxBytes,
+	}
+	if cfg.PasswordBreachedDir == "" {
+		return usecase.NewPasswordPolicy(config, nil), nil
+	}
+
+	breached, err := auth.NewBreachedPasswordList(cfg.PasswordBreachedDir)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.NewPasswordPolicy(config, breached), nil
+}
+
+// newJWTManager 設定に応じたJWTManagerを作成
+// JWT_KEYS_DIRを設定した場合はJWT_ACTIVE_KEY_IDの非対称鍵で署名し、未設定の場合はJWT_SECRETのHS256で署名する
+func newJWTManager(cfg Config) (auth.JWTManager, error) {
+	jwtCfg := auth.JWTConfig{
+		SecretKey:     cfg.JWTSecret,
+		AccessExpiry:  cfg.JWTAccessExpiry,
+		RefreshExpiry: cfg.JWTRefreshExpiry,
+	}
+	if cfg.JWTKeysDir == "" {
+		return auth.NewJWTManager(jwtCfg)
+	}
+
+	keys, err := auth.LoadSigningKeys(cfg.JWTKeysDir)
+	if err != nil {
+		return nil, err
+	}
+	jwtCfg.Keys = keys
+	jwtCfg.ActiveKeyID = cfg.JWTActiveKeyID
+
+	// HS256からの移行期間中のみ、JWT_SECRETで署名した既存のトークンを受け付ける
+	if !cfg.JWTAcceptHS256 {
+		jwtCfg.SecretKey = ""
+	}
+
+	return auth.NewJWTManager(jwtCfg)
+}
+
+// newMailer 設定に応じたMailerを作成(MAIL_DRIVERはlogまたはsmtp)
+func newMailer(cfg Config) (mailer.Mailer, error) {
+	switch cfg.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		})
+	case "log":
+		slog.Warn("Mail is not sent; messages are written to the log (MAIL_DRIVER=log)")
+		return mailer.NewLogMailer(slog.Default()), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.MailDriver)
 	}
 }
 
@@ -293,6 +1015,40 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList カンマ区切りの環境変数を取得(未設定の場合はデフォルト値、空文字列の場合は空のリスト)
+func getEnvList(key string, defaultValue []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// parseInt64 文字列をint64にパース
+func parseInt64(s string, defaultValue int64) int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return n
+}
+
+// parseBool 文字列をboolにパース
+func parseBool(s string, defaultValue bool) bool {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return defaultValue
+	}
+	return b
+}
+
 // parseDuration 文字列をtime.Durationにパース
 func parseDuration(s string, defaultValue time.Duration) time.Duration {
 	d, err := time.ParseDuration(s)
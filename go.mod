@@ -12,6 +12,7 @@ require (
 	github.com/uptrace/bun/dialect/mysqldialect v1.2.16
 	github.com/yuin/goldmark v1.7.13
 	golang.org/x/crypto v0.52.0
+	golang.org/x/image v0.25.0
 )
 
 require (
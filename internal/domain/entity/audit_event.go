@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AuditAction 監査ログに記録する操作を表す型
+type AuditAction string
+
+const (
+	AuditPostCreate    AuditAction = "post.create"
+	AuditPostUpdate    AuditAction = "post.update"
+	AuditPostDelete    AuditAction = "post.delete"
+	AuditPostPublish   AuditAction = "post.publish"
+	AuditPostUnpublish AuditAction = "post.unpublish"
+	AuditPostRestore   AuditAction = "post.restore"
+	AuditPostShare     AuditAction = "post.share"
+	AuditPostUnshare   AuditAction = "post.unshare"
+
+	AuditPostSubmit         AuditAction = "post.submit"
+	AuditPostAssignReviewer AuditAction = "post.assign_reviewer"
+	AuditPostApprove        AuditAction = "post.approve"
+	AuditPostReject         AuditAction = "post.reject"
+	AuditPostPreviewLink    AuditAction = "post.preview_link"
+	AuditPostPreviewRevoke  AuditAction = "post.preview_revoke"
+
+	AuditCategoryCreate AuditAction = "category.create"
+	AuditCategoryUpdate AuditAction = "category.update"
+	AuditCategoryDelete AuditAction = "category.delete"
+
+	AuditTagCreate AuditAction = "tag.create"
+	AuditTagUpdate AuditAction = "tag.update"
+	AuditTagDelete AuditAction = "tag.delete"
+
+	AuditCommentModerate AuditAction = "comment.moderate"
+	AuditCommentDelete   AuditAction = "comment.delete"
+
+	AuditMediaUpload AuditAction = "media.upload"
+	AuditMediaUpdate AuditAction = "media.update"
+	AuditMediaDelete AuditAction = "media.delete"
+
+	AuditUserCreate        AuditAction = "user.create"
+	AuditUserUpdate        AuditAction = "user.update"
+	AuditUserDeactivate    AuditAction = "user.deactivate"
+	AuditUserResetPassword AuditAction = "user.reset_password"
+	AuditUserRevokeSession AuditAction = "user.revoke_sessions"
+
+	AuditInvitationCreate AuditAction = "invitation.create"
+	AuditInvitationRevoke AuditAction = "invitation.revoke"
+	AuditInvitationAccept AuditAction = "invitation.accept"
+
+	AuditAuthLogin          AuditAction = "auth.login"
+	AuditAuthLogout         AuditAction = "auth.logout"
+	AuditAuthPasswordReset  AuditAction = "auth.password_reset"
+	AuditAuthPasswordChange AuditAction = "auth.password_change"
+	AuditAuthUnlock         AuditAction = "auth.unlock"
+	AuditAuthMFAEnable      AuditAction = "auth.mfa_enable"
+	AuditAuthMFADisable     AuditAction = "auth.mfa_disable"
+	AuditAuthMFAReset       AuditAction = "auth.mfa_reset"
+	AuditAuthMFAPolicy      AuditAction = "auth.mfa_policy"
+	AuditAuthTokenCreate    AuditAction = "auth.token_create"
+	AuditAuthTokenRevoke    AuditAction = "auth.token_revoke"
+)
+
+// AuditTargetType 監査ログの操作対象の種類を表す型
+type AuditTargetType string
+
+const (
+	AuditTargetPost                AuditTargetType = "post"
+	AuditTargetCategory            AuditTargetType = "category"
+	AuditTargetTag                 AuditTargetType = "tag"
+	AuditTargetComment             AuditTargetType = "comment"
+	AuditTargetMedia               AuditTargetType = "media"
+	AuditTargetUser                AuditTargetType = "user"
+	AuditTargetInvitation          AuditTargetType = "invitation"
+	AuditTargetPersonalAccessToken AuditTargetType = "personal_access_token"
+	AuditTargetMFAPolicy           AuditTargetType = "mfa_policy"
+	AuditTargetLoginThrottle       AuditTargetType = "login_throttle"
+)
+
+// AuditEvent 管理操作の監査ログエンティティ(追記のみで更新しない)
+type AuditEvent struct {
+	bun.BaseModel `bun:"table:audit_events,alias:ae"`
+
+	ID int64 `bun:"id,pk,autoincrement"`
+	// ActorID 操作したユーザー(予約公開などのシステムによる操作、ユーザー削除後はnil)
+	ActorID *int64 `bun:"actor_id"`
+	// ActorName 操作時点のユーザー名
+	ActorName  string          `bun:"actor_name,notnull"`
+	Action     AuditAction     `bun:"action,notnull"`
+	TargetType AuditTargetType `bun:"target_type,notnull"`
+	TargetID   *int64          `bun:"target_id"`
+	// Before 変更前のスナップショット(作成時はnil、更新時は変更された項目のみ)
+	Before map[string]any `bun:"before_data,type:json,nullzero"`
+	// After 変更後のスナップショット(削除時はnil、更新時は変更された項目のみ)
+	After     map[string]any `bun:"after_data,type:json,nullzero"`
+	IPAddress string         `bun:"ip_address,notnull"`
+	UserAgent string         `bun:"user_agent,notnull"`
+	CreatedAt time.Time      `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
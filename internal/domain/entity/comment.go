@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// CommentStatus コメントのステータスを表す型
+type CommentStatus string
+
+const (
+	CommentPending  CommentStatus = "pending"
+	CommentApproved CommentStatus = "approved"
+	CommentSpam     CommentStatus = "spam"
+	CommentDeleted  CommentStatus = "deleted"
+)
+
+// IsValid 定義済みのステータスかどうかを判定
+func (s CommentStatus) IsValid() bool {
+	switch s {
+	case CommentPending, CommentApproved, CommentSpam, CommentDeleted:
+		return true
+	}
+	return false
+}
+
+// Comment 記事へのコメントエンティティ
+type Comment struct {
+	bun.BaseModel `bun:"table:comments,alias:cm"`
+
+	ID     int64 `bun:"id,pk,autoincrement"`
+	PostID int64 `bun:"post_id,notnull"`
+	// ParentID 返信先のコメント(トップレベルのコメントはnil)
+	ParentID    *int64 `bun:"parent_id"`
+	AuthorName  string `bun:"author_name,notnull"`
+	AuthorEmail string `bun:"author_email,notnull"`
+	AuthorURL   string `bun:"author_url,notnull"`
+	Content     string `bun:"content,notnull,type:text"`
+	// RenderedHTML 制限付きのMarkdownレンダラーで変換した本文
+	RenderedHTML string        `bun:"rendered_html,notnull,type:text"`
+	Status       CommentStatus `bun:"status,notnull,default:'pending'"`
+	IPAddress    string        `bun:"ip_address,notnull"`
+	UserAgent    string        `bun:"user_agent,notnull"`
+	CreatedAt    time.Time     `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt    time.Time     `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+
+	// Relations
+	Post *Post `bun:"rel:belongs-to,join:post_id=id"`
+}
+
+// IsApproved 承認済み(公開中)かどうかを判定
+func (c *Comment) IsApproved() bool {
+	return c.Status == CommentApproved
+}
+
+// IsDeleted 削除済みかどうかを判定
+func (c *Comment) IsDeleted() bool {
+	return c.Status == CommentDeleted
+}
@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentStatus_IsValid(t *testing.T) {
+	for _, status := range []entity.CommentStatus{
+		entity.CommentPending,
+		entity.CommentApproved,
+		entity.CommentSpam,
+		entity.CommentDeleted,
+	} {
+		assert.True(t, status.IsValid(), status)
+	}
+
+	assert.False(t, entity.CommentStatus("").IsValid())
+	assert.False(t, entity.CommentStatus("published").IsValid())
+}
+
+func TestComment_IsApproved(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   entity.CommentStatus
+		approved bool
+		deleted  bool
+	}{
+		{name: "pending", status: entity.CommentPending},
+		{name: "approved", status: entity.CommentApproved, approved: true},
+		{name: "spam", status: entity.CommentSpam},
+		{name: "deleted", status: entity.CommentDeleted, deleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment := &entity.Comment{Status: tt.status}
+			assert.Equal(t, tt.approved, comment.IsApproved())
+			assert.Equal(t, tt.deleted, comment.IsDeleted())
+		})
+	}
+}
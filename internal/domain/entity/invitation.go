@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Invitation 新しいユーザーの招待エンティティ
+type Invitation struct {
+	bun.BaseModel `bun:"table:invitations,alias:inv"`
+
+	ID    int64    `bun:"id,pk,autoincrement"`
+	Email string   `bun:"email,notnull"`
+	Role  UserRole `bun:"role,notnull"`
+	// TokenHash 招待トークンのSHA-256ハッシュ(トークン自体は保存しない)
+	TokenHash string `bun:"token_hash,unique,notnull"`
+	// InvitedBy 招待した管理者(ユーザー削除後はnil)
+	InvitedBy *int64 `bun:"invited_by"`
+	// UserID 招待を受諾して作成されたユーザー
+	UserID     *int64     `bun:"user_id"`
+	ExpiresAt  time.Time  `bun:"expires_at,notnull"`
+	AcceptedAt *time.Time `bun:"accepted_at"`
+	CreatedAt  time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	// Relations
+	Inviter *User `bun:"rel:belongs-to,join:invited_by=id"`
+}
+
+// IsExpired 招待が期限切れかどうかを判定
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// IsAccepted 招待が受諾済みかどうかを判定
+func (i *Invitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
+
+// IsPending 招待が受諾待ち(未受諾かつ期限内)かどうかを判定
+func (i *Invitation) IsPending(now time.Time) bool {
+	return !i.IsAccepted() && !i.IsExpired(now)
+}
@@ -0,0 +1,47 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitation_IsPending(t *testing.T) {
+	now := time.Now()
+	accepted := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		invitation entity.Invitation
+		expired    bool
+		accepted   bool
+		pending    bool
+	}{
+		{
+			name:       "pending invitation",
+			invitation: entity.Invitation{ExpiresAt: now.Add(time.Hour)},
+			pending:    true,
+		},
+		{
+			name:       "expired invitation",
+			invitation: entity.Invitation{ExpiresAt: now},
+			expired:    true,
+		},
+		{
+			name:       "accepted invitation",
+			invitation: entity.Invitation{ExpiresAt: now.Add(time.Hour), AcceptedAt: &accepted},
+			accepted:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, tt.invitation.IsExpired(now))
+			assert.Equal(t, tt.accepted, tt.invitation.IsAccepted())
+			assert.Equal(t, tt.pending, tt.invitation.IsPending(now))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// LoginFailureReason ログイン失敗の理由を表す型
+type LoginFailureReason string
+
+const (
+	LoginFailureInvalidCredentials LoginFailureReason = "invalid_credentials"
+	LoginFailureLocked             LoginFailureReason = "locked"
+	LoginFailureInactive           LoginFailureReason = "inactive"
+	// LoginFailureInvalidMFACode パスワードは正しいが二要素認証のコードが誤っている
+	LoginFailureInvalidMFACode LoginFailureReason = "invalid_mfa_code"
+	// LoginFailureMFAPending パスワードは正しいが二要素認証(または登録)が完了しておらず、トークンを発行していない
+	LoginFailureMFAPending LoginFailureReason = "mfa_pending"
+)
+
+// CountsTowardLockout ロックまでの失敗回数に数える理由かどうかを判定
+// (ロック中・無効なユーザーの試行や二要素認証の途中は認証情報を推測する試行ではないため数えない)
+func (r LoginFailureReason) CountsTowardLockout() bool {
+	return r == LoginFailureInvalidCredentials || r == LoginFailureInvalidMFACode
+}
+
+// LoginAttempt ログイン試行の監査ログエンティティ
+type LoginAttempt struct {
+	bun.BaseModel `bun:"table:login_attempts,alias:la"`
+
+	ID int64 `bun:"id,pk,autoincrement"`
+	// Username 入力されたユーザー名(存在しないユーザー名も記録する)
+	Username string `bun:"username,notnull"`
+	// UserID 入力されたユーザー名のユーザー(存在しない場合はnil)
+	UserID        *int64             `bun:"user_id"`
+	IPAddress     string             `bun:"ip_address,notnull"`
+	UserAgent     string             `bun:"user_agent,notnull"`
+	Succeeded     bool               `bun:"succeeded,notnull"`
+	FailureReason LoginFailureReason `bun:"failure_reason,notnull"`
+	CreatedAt     time.Time          `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// LoginThrottleScope ログイン失敗を数える単位を表す型
+type LoginThrottleScope string
+
+const (
+	LoginThrottleUsername LoginThrottleScope = "username"
+	LoginThrottleIP       LoginThrottleScope = "ip"
+	// LoginThrottleMFAToken ログインの2段階目のMFATokenごとのコードの誤り(ロックせず、上限でトークンを使えなくする)
+	LoginThrottleMFAToken LoginThrottleScope = "mfa_token"
+)
+
+// IsValid 管理者がロックを解除できる単位かどうかを判定
+func (s LoginThrottleScope) IsValid() bool {
+	switch s {
+	case LoginThrottleUsername, LoginThrottleIP:
+		return true
+	}
+	return false
+}
+
+// LoginThrottle ユーザー名またはIPアドレスごとのログイン失敗状況エンティティ
+type LoginThrottle struct {
+	bun.BaseModel `bun:"table:login_throttles,alias:lt"`
+
+	Scope LoginThrottleScope `bun:"scope,pk"`
+	// Key ユーザー名(小文字)またはIPアドレス
+	Key string `bun:"throttle_key,pk"`
+	// FailureCount 連続した失敗回数(ログインに成功するか、一定期間失敗がなければリセット)
+	FailureCount int `bun:"failure_count,notnull"`
+	// LockedUntil ロックの期限(ロックされていない場合はnil)
+	LockedUntil   *time.Time `bun:"locked_until"`
+	LastFailureAt time.Time  `bun:"last_failure_at,notnull"`
+}
+
+// IsLocked 指定日時にロックされているかどうかを判定
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
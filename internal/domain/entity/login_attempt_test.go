@@ -0,0 +1,27 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottle_IsLocked(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	assert.False(t, (&entity.LoginThrottle{FailureCount: 3}).IsLocked(now))
+	assert.True(t, (&entity.LoginThrottle{LockedUntil: &future}).IsLocked(now))
+	assert.False(t, (&entity.LoginThrottle{LockedUntil: &past}).IsLocked(now))
+	assert.False(t, (&entity.LoginThrottle{LockedUntil: &now}).IsLocked(now))
+}
+
+func TestLoginThrottleScope_IsValid(t *testing.T) {
+	assert.True(t, entity.LoginThrottleUsername.IsValid())
+	assert.True(t, entity.LoginThrottleIP.IsValid())
+	assert.False(t, entity.LoginThrottleScope("email").IsValid())
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Media アップロードされた画像エンティティ
+type Media struct {
+	bun.BaseModel `bun:"table:media,alias:m"`
+
+	ID int64 `bun:"id,pk,autoincrement"`
+	// OwnerID アップロードしたユーザー(ユーザー削除後はnil)
+	OwnerID *int64 `bun:"owner_id"`
+	// StorageKey ストレージ上のファイルのキー
+	StorageKey   string    `bun:"storage_key,unique,notnull"`
+	OriginalName string    `bun:"original_name,notnull"`
+	MimeType     string    `bun:"mime_type,notnull"`
+	Size         int64     `bun:"size,notnull"`
+	Width        int       `bun:"width,notnull"`
+	Height       int       `bun:"height,notnull"`
+	AltText      string    `bun:"alt_text,notnull"`
+	CreatedAt    time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt    time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+
+	// Relations
+	Owner    *User           `bun:"rel:belongs-to,join:owner_id=id"`
+	Variants []*MediaVariant `bun:"rel:has-many,join:id=media_id"`
+}
+
+// MediaVariant アップロード時に生成した縮小・変換済みの画像エンティティ
+type MediaVariant struct {
+	bun.BaseModel `bun:"table:media_variants,alias:mv"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	MediaID    int64     `bun:"media_id,notnull"`
+	StorageKey string    `bun:"storage_key,unique,notnull"`
+	MimeType   string    `bun:"mime_type,notnull"`
+	Size       int64     `bun:"size,notnull"`
+	Width      int       `bun:"width,notnull"`
+	Height     int       `bun:"height,notnull"`
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// UserMFA ユーザーの二要素認証(TOTP)設定エンティティ
+type UserMFA struct {
+	bun.BaseModel `bun:"table:user_mfa,alias:mfa"`
+
+	UserID int64 `bun:"user_id,pk"`
+	// Secret 暗号化したTOTPシークレット
+	Secret string `bun:"secret,notnull"`
+	// EnabledAt 確認コードの入力で登録が完了した日時(nilは登録途中)
+	EnabledAt *time.Time `bun:"enabled_at"`
+	// LastUsedStep 最後に使用したワンタイムコードのタイムステップ(リプレイ防止)
+	LastUsedStep int64     `bun:"last_used_step,notnull"`
+	CreatedAt    time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt    time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// IsEnabled 二要素認証が有効かどうかを判定
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// RecoveryCode 二要素認証のリカバリーコードエンティティ
+type RecoveryCode struct {
+	bun.BaseModel `bun:"table:mfa_recovery_codes,alias:rc"`
+
+	ID     int64 `bun:"id,pk,autoincrement"`
+	UserID int64 `bun:"user_id,notnull"`
+	// CodeHash リカバリーコードのSHA-256ハッシュ(コード自体は保存しない)
+	CodeHash  string     `bun:"code_hash,notnull"`
+	UsedAt    *time.Time `bun:"used_at"`
+	CreatedAt time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// MFAPolicy ロールごとの二要素認証の必須設定エンティティ
+type MFAPolicy struct {
+	bun.BaseModel `bun:"table:mfa_policies,alias:mp"`
+
+	Role      UserRole  `bun:"role,pk"`
+	Required  bool      `bun:"required,notnull"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
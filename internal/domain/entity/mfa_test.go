@@ -0,0 +1,17 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserMFA_IsEnabled(t *testing.T) {
+	now := time.Now()
+
+	assert.False(t, (&entity.UserMFA{}).IsEnabled())
+	assert.True(t, (&entity.UserMFA{EnabledAt: &now}).IsEnabled())
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// PasswordResetToken パスワード再設定トークンエンティティ
+type PasswordResetToken struct {
+	bun.BaseModel `bun:"table:password_reset_tokens,alias:prt"`
+
+	ID     int64 `bun:"id,pk,autoincrement"`
+	UserID int64 `bun:"user_id,notnull"`
+	// TokenHash 再設定トークンのSHA-256ハッシュ(トークン自体は保存しない)
+	TokenHash string     `bun:"token_hash,unique,notnull"`
+	ExpiresAt time.Time  `bun:"expires_at,notnull"`
+	UsedAt    *time.Time `bun:"used_at"`
+	CreatedAt time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// IsExpired トークンが期限切れかどうかを判定
+func (t *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsUsed トークンが使用済みかどうかを判定
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
@@ -0,0 +1,44 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetToken_State(t *testing.T) {
+	now := time.Now()
+	used := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		token   entity.PasswordResetToken
+		expired bool
+		used    bool
+	}{
+		{
+			name:  "valid token",
+			token: entity.PasswordResetToken{ExpiresAt: now.Add(time.Minute)},
+		},
+		{
+			name:    "expired token",
+			token:   entity.PasswordResetToken{ExpiresAt: now},
+			expired: true,
+		},
+		{
+			name:  "used token",
+			token: entity.PasswordResetToken{ExpiresAt: now.Add(time.Minute), UsedAt: &used},
+			used:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, tt.token.IsExpired(now))
+			assert.Equal(t, tt.used, tt.token.IsUsed())
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// TokenScope パーソナルアクセストークンに許可する操作を表す型
+type TokenScope string
+
+const (
+	ScopePostsRead       TokenScope = "posts:read"
+	ScopePostsWrite      TokenScope = "posts:write"
+	ScopeMediaRead       TokenScope = "media:read"
+	ScopeMediaWrite      TokenScope = "media:write"
+	ScopeCategoriesRead  TokenScope = "categories:read"
+	ScopeCategoriesWrite TokenScope = "categories:write"
+	ScopeTagsRead        TokenScope = "tags:read"
+	ScopeTagsWrite       TokenScope = "tags:write"
+	ScopeCommentsRead    TokenScope = "comments:read"
+	ScopeCommentsWrite   TokenScope = "comments:write"
+)
+
+// TokenScopes 定義済みの全てのスコープ
+var TokenScopes = []TokenScope{
+	ScopePostsRead, ScopePostsWrite,
+	ScopeMediaRead, ScopeMediaWrite,
+	ScopeCategoriesRead, ScopeCategoriesWrite,
+	ScopeTagsRead, ScopeTagsWrite,
+	ScopeCommentsRead, ScopeCommentsWrite,
+}
+
+// IsValid 定義済みのスコープかどうかを判定
+func (s TokenScope) IsValid() bool {
+	for _, scope := range TokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// PersonalAccessToken パーソナルアクセストークンエンティティ
+type PersonalAccessToken struct {
+	bun.BaseModel `bun:"table:personal_access_tokens,alias:pat"`
+
+	ID     int64  `bun:"id,pk,autoincrement"`
+	UserID int64  `bun:"user_id,notnull"`
+	Name   string `bun:"name,notnull"`
+	// TokenHash トークンのSHA-256ハッシュ(トークン自体は保存しない)
+	TokenHash string `bun:"token_hash,unique,notnull"`
+	// TokenPrefix 一覧でトークンを見分けるための先頭の文字列
+	TokenPrefix string       `bun:"token_prefix,notnull"`
+	Scopes      []TokenScope `bun:"scopes,type:json"`
+	// ExpiresAt 有効期限(nilの場合は無期限)
+	ExpiresAt  *time.Time `bun:"expires_at"`
+	LastUsedAt *time.Time `bun:"last_used_at"`
+	RevokedAt  *time.Time `bun:"revoked_at"`
+	CreatedAt  time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// IsExpired トークンが期限切れかどうかを判定
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// IsRevoked トークンが失効済みかどうかを判定
+func (t *PersonalAccessToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsActive トークンが有効かどうかを判定
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	return !t.IsRevoked() && !t.IsExpired(now)
+}
+
+// HasScope 指定されたスコープを持っているかチェック
+func (t *PersonalAccessToken) HasScope(scope TokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessToken_IsActive(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		token   entity.PersonalAccessToken
+		expired bool
+		revoked bool
+		active  bool
+	}{
+		{
+			name:   "token without expiry",
+			token:  entity.PersonalAccessToken{},
+			active: true,
+		},
+		{
+			name:   "token before expiry",
+			token:  entity.PersonalAccessToken{ExpiresAt: &future},
+			active: true,
+		},
+		{
+			name:    "expired token",
+			token:   entity.PersonalAccessToken{ExpiresAt: &now},
+			expired: true,
+		},
+		{
+			name:    "revoked token",
+			token:   entity.PersonalAccessToken{RevokedAt: &now},
+			revoked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, tt.token.IsExpired(now))
+			assert.Equal(t, tt.revoked, tt.token.IsRevoked())
+			assert.Equal(t, tt.active, tt.token.IsActive(now))
+		})
+	}
+}
+
+func TestPersonalAccessToken_HasScope(t *testing.T) {
+	token := entity.PersonalAccessToken{Scopes: []entity.TokenScope{entity.ScopePostsWrite, entity.ScopeMediaWrite}}
+
+	assert.True(t, token.HasScope(entity.ScopePostsWrite))
+	assert.True(t, token.HasScope(entity.ScopeMediaWrite))
+	assert.False(t, token.HasScope(entity.ScopePostsRead))
+}
+
+func TestTokenScope_IsValid(t *testing.T) {
+	assert.True(t, entity.ScopePostsWrite.IsValid())
+	assert.True(t, entity.ScopeCommentsRead.IsValid())
+	assert.False(t, entity.TokenScope("users:write").IsValid())
+	assert.False(t, entity.TokenScope("").IsValid())
+}
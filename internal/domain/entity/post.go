@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -12,8 +14,32 @@ type PostStatus string
 const (
 	StatusDraft     PostStatus = "draft"
 	StatusPublished PostStatus = "published"
+	StatusScheduled PostStatus = "scheduled"
+	// StatusInReview レビュー担当者の承認待ち
+	StatusInReview PostStatus = "in_review"
+	// StatusRejected レビューで差し戻された(修正して再提出するか下書きに戻す)
+	StatusRejected PostStatus = "rejected"
 )
 
+// postTransitions ステータスごとに遷移できるステータス
+// レビュー中の記事の公開・予約・差し戻しはレビュー担当者の承認・差し戻しでのみ行う
+var postTransitions = map[PostStatus][]PostStatus{
+	StatusDraft:     {StatusDraft, StatusInReview, StatusPublished, StatusScheduled},
+	StatusInReview:  {StatusDraft, StatusPublished, StatusScheduled, StatusRejected},
+	StatusRejected:  {StatusDraft, StatusInReview},
+	StatusPublished: {StatusDraft, StatusPublished},
+	StatusScheduled: {StatusDraft, StatusPublished, StatusScheduled},
+}
+
+// ErrInvalidTransition 現在のステータスから遷移できない場合のエラー
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// IsValid 有効なステータスかどうかを判定
+func (s PostStatus) IsValid() bool {
+	_, ok := postTransitions[s]
+	return ok
+}
+
 // Post ブログ記事エンティティ
 type Post struct {
 	bun.BaseModel `bun:"table:posts,alias:p"`
@@ -29,10 +55,20 @@ type Post struct {
 	CreatedAt    time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt    time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 	PublishedAt  *time.Time `bun:"published_at"`
+	ScheduledAt  *time.Time `bun:"scheduled_at"`
+	// ReviewerID レビュー担当者(レビューに提出したことがない記事はnil)
+	ReviewerID *int64 `bun:"reviewer_id"`
+	// ReviewComment 差し戻し時のレビュー担当者のコメント
+	ReviewComment string     `bun:"review_comment,nullzero"`
+	SubmittedAt   *time.Time `bun:"submitted_at"`
+	ReviewedAt    *time.Time `bun:"reviewed_at"`
+	// PreviewVersion プレビューURLの署名に含めるバージョン(上げると発行済みのプレビューURLがすべて無効になる)
+	PreviewVersion int `bun:"preview_version,notnull"`
 
 	// Relations
 	Author   *User     `bun:"rel:belongs-to,join:author_id=id"`
 	Category *Category `bun:"rel:belongs-to,join:category_id=id"`
+	Reviewer *User     `bun:"rel:belongs-to,join:reviewer_id=id"`
 	Tags     []*Tag    `bun:"m2m:post_tags,join:Post=Tag"`
 }
 
@@ -41,16 +77,89 @@ func (p *Post) IsPublished() bool {
 	return p.Status == StatusPublished
 }
 
-// Publish 記事を公開する
+// IsScheduled 公開予約中かどうかを判定
+func (p *Post) IsScheduled() bool {
+	return p.Status == StatusScheduled
+}
+
+// IsInReview レビュー中かどうかを判定
+func (p *Post) IsInReview() bool {
+	return p.Status == StatusInReview
+}
+
+// CanTransitionTo 現在のステータスから指定したステータスに遷移できるかどうかを判定
+func (p *Post) CanTransitionTo(to PostStatus) bool {
+	for _, status := range postTransitions[p.Status] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transitionError 現在のステータスから遷移できない場合のエラーを返す
+func (p *Post) transitionError(to PostStatus) error {
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, p.Status, to)
+}
+
+// SubmitForReview レビュー担当者を指定してレビューに提出する(公開予約・前回の差し戻しコメントは解除される)
+func (p *Post) SubmitForReview(reviewerID int64, now time.Time) error {
+	if p.Status == StatusInReview || !p.CanTransitionTo(StatusInReview) {
+		return p.transitionError(StatusInReview)
+	}
+	p.Status = StatusInReview
+	p.ReviewerID = &reviewerID
+	p.ReviewComment = ""
+	p.ScheduledAt = nil
+	p.SubmittedAt = &now
+	p.ReviewedAt = nil
+	return nil
+}
+
+// Approve レビューを承認し、publishAtを指定した場合はその日時での公開を予約、それ以外は公開する
+func (p *Post) Approve(publishAt *time.Time, now time.Time) error {
+	if !p.IsInReview() {
+		return p.transitionError(StatusPublished)
+	}
+	if publishAt != nil {
+		p.Schedule(*publishAt)
+	} else {
+		p.Publish()
+	}
+	p.ReviewComment = ""
+	p.ReviewedAt = &now
+	return nil
+}
+
+// Reject レビューを差し戻し、レビュー担当者のコメントを残す
+func (p *Post) Reject(comment string, now time.Time) error {
+	if !p.IsInReview() {
+		return p.transitionError(StatusRejected)
+	}
+	p.Status = StatusRejected
+	p.ReviewComment = comment
+	p.ReviewedAt = &now
+	return nil
+}
+
+// Publish 記事を公開する(公開予約は解除される)
 func (p *Post) Publish() {
 	now := time.Now()
 	p.Status = StatusPublished
+	p.ScheduledAt = nil
 	if p.PublishedAt == nil {
 		p.PublishedAt = &now
 	}
 }
 
-// Unpublish 記事を下書きに戻す
+// Schedule 指定日時での公開を予約する
+func (p *Post) Schedule(at time.Time) {
+	p.Status = StatusScheduled
+	p.ScheduledAt = &at
+}
+
+// Unpublish 記事を下書きに戻す(公開予約は解除される)
 func (p *Post) Unpublish() {
 	p.Status = StatusDraft
+	p.ScheduledAt = nil
 }
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// PostRevision 記事リビジョンエンティティ(更新時点の記事のスナップショット)
+type PostRevision struct {
+	bun.BaseModel `bun:"table:post_revisions,alias:pr"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	PostID     int64     `bun:"post_id,notnull"`
+	Title      string    `bun:"title,notnull"`
+	Slug       string    `bun:"slug,notnull"`
+	Content    string    `bun:"content,notnull,type:text"`
+	CategoryID *int64    `bun:"category_id"`
+	TagIDs     []int64   `bun:"tag_ids,type:json"`
+	EditorID   *int64    `bun:"editor_id"`
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	// Relations
+	Editor *User `bun:"rel:belongs-to,join:editor_id=id"`
+}
+
+// NewPostRevision 記事の現在の状態からリビジョンを作成
+// タグはpost.Tagsから取得するため、リレーションを読み込んだ記事を渡すこと
+func NewPostRevision(post *Post, editorID int64) *PostRevision {
+	tagIDs := make([]int64, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	slices.Sort(tagIDs)
+
+	revision := &PostRevision{
+		PostID:     post.ID,
+		Title:      post.Title,
+		Slug:       post.Slug,
+		Content:    post.Content,
+		CategoryID: post.CategoryID,
+		TagIDs:     tagIDs,
+	}
+	if editorID != 0 {
+		revision.EditorID = &editorID
+	}
+
+	return revision
+}
@@ -0,0 +1,44 @@
+package entity_test
+
+import (
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPostRevision(t *testing.T) {
+	categoryID := int64(3)
+	post := &entity.Post{
+		ID:         10,
+		Title:      "Title",
+		Slug:       "title",
+		Content:    "# Content",
+		CategoryID: &categoryID,
+		Tags: []*entity.Tag{
+			{ID: 1},
+			{ID: 2},
+		},
+	}
+
+	revision := entity.NewPostRevision(post, 5)
+	assert.Equal(t, post.ID, revision.PostID)
+	assert.Equal(t, post.Title, revision.Title)
+	assert.Equal(t, post.Slug, revision.Slug)
+	assert.Equal(t, post.Content, revision.Content)
+	assert.Equal(t, post.CategoryID, revision.CategoryID)
+	assert.Equal(t, []int64{1, 2}, revision.TagIDs)
+	require.NotNil(t, revision.EditorID)
+	assert.Equal(t, int64(5), *revision.EditorID)
+}
+
+func TestNewPostRevision_WithoutEditor(t *testing.T) {
+	post := &entity.Post{ID: 10, Title: "Title"}
+
+	revision := entity.NewPostRevision(post, 0)
+	assert.Nil(t, revision.EditorID)
+	assert.NotNil(t, revision.TagIDs)
+	assert.Empty(t, revision.TagIDs)
+}
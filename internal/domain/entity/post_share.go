@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// PostShare 記事を閲覧できるように共有したユーザー(下書きの確認依頼などに使用)
+type PostShare struct {
+	bun.BaseModel `bun:"table:post_shares,alias:ps"`
+
+	PostID int64 `bun:"post_id,pk,notnull"`
+	UserID int64 `bun:"user_id,pk,notnull"`
+	// SharedBy 共有したユーザー(ユーザー削除後はnil)
+	SharedBy  *int64    `bun:"shared_by"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+
+	// Relations
+	User *User `bun:"rel:belongs-to,join:user_id=id"`
+}
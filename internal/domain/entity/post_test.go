@@ -7,6 +7,7 @@ import (
 	"my-blog-engine/internal/domain/entity"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPost_IsPublished(t *testing.T) {
@@ -25,6 +26,11 @@ func TestPost_IsPublished(t *testing.T) {
 			status:   entity.StatusDraft,
 			expected: false,
 		},
+		{
+			name:     "scheduled post",
+			status:   entity.StatusScheduled,
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -64,3 +70,104 @@ func TestPost_Unpublish(t *testing.T) {
 	// PublishedAtはそのまま残る
 	assert.NotNil(t, post.PublishedAt)
 }
+
+func TestPost_Schedule(t *testing.T) {
+	post := &entity.Post{Status: entity.StatusDraft}
+	at := time.Now().Add(24 * time.Hour)
+
+	post.Schedule(at)
+	assert.Equal(t, entity.StatusScheduled, post.Status)
+	assert.True(t, post.IsScheduled())
+	assert.Equal(t, at, *post.ScheduledAt)
+	assert.Nil(t, post.PublishedAt)
+
+	// 即時公開すると予約は解除される
+	post.Publish()
+	assert.Equal(t, entity.StatusPublished, post.Status)
+	assert.Nil(t, post.ScheduledAt)
+	assert.NotNil(t, post.PublishedAt)
+}
+
+func TestPost_Unpublish_CancelsSchedule(t *testing.T) {
+	post := &entity.Post{Status: entity.StatusDraft}
+	post.Schedule(time.Now().Add(time.Hour))
+
+	post.Unpublish()
+	assert.Equal(t, entity.StatusDraft, post.Status)
+	assert.Nil(t, post.ScheduledAt)
+}
+
+func TestPost_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from     entity.PostStatus
+		to       entity.PostStatus
+		expected bool
+	}{
+		{entity.StatusDraft, entity.StatusInReview, true},
+		{entity.StatusDraft, entity.StatusPublished, true},
+		{entity.StatusDraft, entity.StatusRejected, false},
+		{entity.StatusInReview, entity.StatusPublished, true},
+		{entity.StatusInReview, entity.StatusRejected, true},
+		{entity.StatusInReview, entity.StatusDraft, true},
+		{entity.StatusRejected, entity.StatusInReview, true},
+		{entity.StatusRejected, entity.StatusPublished, false},
+		{entity.StatusPublished, entity.StatusInReview, false},
+		{entity.StatusScheduled, entity.StatusRejected, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			post := &entity.Post{Status: tt.from}
+			assert.Equal(t, tt.expected, post.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestPost_ReviewWorkflow(t *testing.T) {
+	now := time.Now()
+	post := &entity.Post{Status: entity.StatusDraft}
+
+	// レビューに提出
+	require.NoError(t, post.SubmitForReview(2, now))
+	assert.True(t, post.IsInReview())
+	assert.Equal(t, int64(2), *post.ReviewerID)
+	assert.Equal(t, now, *post.SubmittedAt)
+
+	// レビュー中の再提出はできない
+	assert.ErrorIs(t, post.SubmitForReview(3, now), entity.ErrInvalidTransition)
+
+	// 差し戻し
+	require.NoError(t, post.Reject("見出しを修正してください", now))
+	assert.Equal(t, entity.StatusRejected, post.Status)
+	assert.Equal(t, "見出しを修正してください", post.ReviewComment)
+	assert.NotNil(t, post.ReviewedAt)
+
+	// 差し戻された記事は承認できない
+	assert.ErrorIs(t, post.Approve(nil, now), entity.ErrInvalidTransition)
+
+	// 再提出するとコメントはクリアされる
+	require.NoError(t, post.SubmitForReview(2, now))
+	assert.Empty(t, post.ReviewComment)
+	assert.Nil(t, post.ReviewedAt)
+
+	// 承認すると公開される
+	require.NoError(t, post.Approve(nil, now))
+	assert.True(t, post.IsPublished())
+	assert.NotNil(t, post.PublishedAt)
+	assert.NotNil(t, post.ReviewedAt)
+
+	// 公開済みの記事はレビューに提出できない
+	assert.ErrorIs(t, post.SubmitForReview(2, now), entity.ErrInvalidTransition)
+}
+
+func TestPost_Approve_WithSchedule(t *testing.T) {
+	now := time.Now()
+	at := now.Add(time.Hour)
+	post := &entity.Post{Status: entity.StatusDraft}
+	require.NoError(t, post.SubmitForReview(2, now))
+
+	require.NoError(t, post.Approve(&at, now))
+	assert.True(t, post.IsScheduled())
+	assert.Equal(t, at, *post.ScheduledAt)
+	assert.Nil(t, post.PublishedAt)
+}
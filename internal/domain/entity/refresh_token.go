@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// RefreshToken 発行済みリフレッシュトークンエンティティ
+// 同じログインから発行されたトークンは同じFamilyIDを持つ
+type RefreshToken struct {
+	bun.BaseModel `bun:"table:refresh_tokens,alias:rt"`
+
+	ID       int64  `bun:"id,pk,autoincrement"`
+	JTI      string `bun:"jti,unique,notnull"`
+	FamilyID string `bun:"family_id,notnull"`
+	UserID   int64  `bun:"user_id,notnull"`
+	// ExpiresAt トークンの有効期限(JWTのexpと同じ)
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	// RotatedAt 新しいトークンと交換した日時
+	RotatedAt *time.Time `bun:"rotated_at"`
+	// RevokedAt ファミリーごと失効させた日時
+	RevokedAt *time.Time `bun:"revoked_at"`
+	CreatedAt time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// IsRotated 新しいトークンと交換済みかどうかを判定
+func (t *RefreshToken) IsRotated() bool {
+	return t.RotatedAt != nil
+}
+
+// IsRevoked 失効済みかどうかを判定
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsUsable リフレッシュに使用できるかどうかを判定
+// 交換済み・失効済みのトークンが提示された場合は再利用(漏洩)とみなす
+func (t *RefreshToken) IsUsable() bool {
+	return !t.IsRotated() && !t.IsRevoked()
+}
@@ -0,0 +1,46 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshToken_State(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		token   entity.RefreshToken
+		rotated bool
+		revoked bool
+		usable  bool
+	}{
+		{
+			name:   "active token",
+			token:  entity.RefreshToken{},
+			usable: true,
+		},
+		{
+			name:    "rotated token",
+			token:   entity.RefreshToken{RotatedAt: &now},
+			rotated: true,
+		},
+		{
+			name:    "revoked token",
+			token:   entity.RefreshToken{RevokedAt: &now},
+			revoked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rotated, tt.token.IsRotated())
+			assert.Equal(t, tt.revoked, tt.token.IsRevoked())
+			assert.Equal(t, tt.usable, tt.token.IsUsable())
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ReviewNote 記事の本文に対する編集者向けのレビューノート(公開ページには表示しない)
+// スレッドの先頭のノートは本文の行範囲・引用に紐付けることができ、返信は先頭のノートにまとめる
+type ReviewNote struct {
+	bun.BaseModel `bun:"table:review_notes,alias:rn"`
+
+	ID     int64 `bun:"id,pk,autoincrement"`
+	PostID int64 `bun:"post_id,notnull"`
+	// ParentID スレッドの先頭のノート(先頭のノートはnil)
+	ParentID *int64 `bun:"parent_id"`
+	// AuthorID 書いたユーザー(ユーザー削除後はnil)
+	AuthorID *int64 `bun:"author_id"`
+	Body     string `bun:"body,notnull,type:text"`
+	// StartLine・EndLine 紐付けたMarkdown本文の行範囲(1始まり、紐付けていない場合はnil)
+	StartLine *int `bun:"start_line"`
+	EndLine   *int `bun:"end_line"`
+	// Quote 紐付けた本文の引用(本文が変わっても引用を探して紐付け直す)
+	Quote string `bun:"quote,nullzero,type:text"`
+	// Outdated 本文の変更で紐付けた箇所が見つからなくなったかどうか
+	Outdated   bool       `bun:"outdated,notnull"`
+	ResolvedAt *time.Time `bun:"resolved_at"`
+	ResolvedBy *int64     `bun:"resolved_by"`
+	CreatedAt  time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt  time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+
+	// Relations
+	Author *User `bun:"rel:belongs-to,join:author_id=id"`
+}
+
+// IsReply スレッドへの返信かどうかを判定
+func (n *ReviewNote) IsReply() bool {
+	return n.ParentID != nil
+}
+
+// IsAnchored 本文の行範囲に紐付いているかどうかを判定
+func (n *ReviewNote) IsAnchored() bool {
+	return n.StartLine != nil && n.EndLine != nil
+}
+
+// IsResolved スレッドが解決済みかどうかを判定
+func (n *ReviewNote) IsResolved() bool {
+	return n.ResolvedAt != nil
+}
+
+// Resolve スレッドを解決済みにする
+func (n *ReviewNote) Resolve(userID int64, now time.Time) {
+	n.ResolvedAt = &now
+	n.ResolvedBy = &userID
+}
+
+// Reopen スレッドを未解決に戻す
+func (n *ReviewNote) Reopen() {
+	n.ResolvedAt = nil
+	n.ResolvedBy = nil
+}
+
+// Reanchor 変更後の本文に合わせて紐付けた行範囲を更新し、変更があった場合はtrueを返す
+// lineMapは変更前の行番号(1始まり)から変更後の行番号への対応で、lineMap[i]が変更前のi+1行目にあたる(削除・変更された行は0)
+// 引用がある場合は対応する行の近くで引用を探し、ない場合は行範囲がそのまま残っているときだけ移動する。
+// どちらも見つからない場合はOutdatedにする(行範囲は変更前のまま残す)
+func (n *ReviewNote) Reanchor(content string, lineMap []int) bool {
+	if !n.IsAnchored() {
+		return false
+	}
+	start, end, outdated := *n.StartLine, *n.EndLine, n.Outdated
+
+	if n.Quote != "" {
+		near := mapLine(lineMap, start)
+		if near == 0 {
+			near = start
+		}
+		if s, e, ok := LocateQuote(content, n.Quote, near); ok {
+			n.setLines(s, e)
+			n.Outdated = false
+		} else {
+			n.Outdated = true
+		}
+	} else if !n.Outdated {
+		// 行範囲のすべての行が変更されずに連続して残っている場合のみ移動する
+		newStart := mapLine(lineMap, start)
+		for line := start; line <= end; line++ {
+			if newStart == 0 || mapLine(lineMap, line) != newStart+(line-start) {
+				n.Outdated = true
+				break
+			}
+		}
+		if !n.Outdated {
+			n.setLines(newStart, newStart+(end-start))
+		}
+	}
+
+	return *n.StartLine != start || *n.EndLine != end || n.Outdated != outdated
+}
+
+// setLines 紐付ける行範囲を設定
+func (n *ReviewNote) setLines(start, end int) {
+	n.StartLine = &start
+	n.EndLine = &end
+}
+
+// mapLine 変更前の行番号を変更後の行番号に変換(範囲外・削除された行は0)
+func mapLine(lineMap []int, line int) int {
+	if line < 1 || line > len(lineMap) {
+		return 0
+	}
+	return lineMap[line-1]
+}
+
+// LocateQuote 本文中の引用を探し、引用を含む行範囲(1始まり)を返す
+// 引用が複数ある場合はnearLineに最も近いものを選ぶ。改行コードの違いは無視する
+func LocateQuote(content, quote string, nearLine int) (int, int, bool) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	quote = strings.ReplaceAll(quote, "\r\n", "\n")
+	if quote == "" {
+		return 0, 0, false
+	}
+
+	bestStart, bestEnd, found := 0, 0, false
+	for offset := 0; ; {
+		index := strings.Index(content[offset:], quote)
+		if index < 0 {
+			break
+		}
+		pos := offset + index
+		start := strings.Count(content[:pos], "\n") + 1
+		end := start + strings.Count(quote, "\n")
+		if !found || absInt(start-nearLine) < absInt(bestStart-nearLine) {
+			bestStart, bestEnd, found = start, end, true
+		}
+		offset = pos + 1
+	}
+
+	return bestStart, bestEnd, found
+}
+
+// absInt 整数の絶対値
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
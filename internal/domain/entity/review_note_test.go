@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func anchoredNote(start, end int, quote string) *entity.ReviewNote {
+	return &entity.ReviewNote{StartLine: &start, EndLine: &end, Quote: quote}
+}
+
+func TestLocateQuote(t *testing.T) {
+	content := "# Title\nfoo bar\nbaz\nfoo bar\n"
+
+	start, end, ok := entity.LocateQuote(content, "bar\nbaz", 1)
+	assert.True(t, ok)
+	assert.Equal(t, 2, start)
+	assert.Equal(t, 3, end)
+
+	// 複数ある場合は指定した行に近いものを選ぶ
+	start, _, ok = entity.LocateQuote(content, "foo bar", 4)
+	assert.True(t, ok)
+	assert.Equal(t, 4, start)
+
+	_, _, ok = entity.LocateQuote(content, "missing", 1)
+	assert.False(t, ok)
+}
+
+func TestReviewNote_Reanchor(t *testing.T) {
+	t.Run("lines move with inserted content", func(t *testing.T) {
+		note := anchoredNote(2, 3, "")
+		// 先頭に1行追加された
+		changed := note.Reanchor("new\none\ntwo\nthree", []int{2, 3, 4})
+		assert.True(t, changed)
+		assert.Equal(t, 3, *note.StartLine)
+		assert.Equal(t, 4, *note.EndLine)
+		assert.False(t, note.Outdated)
+	})
+
+	t.Run("edited lines become outdated", func(t *testing.T) {
+		note := anchoredNote(2, 3, "")
+		changed := note.Reanchor("one\nTWO\nthree", []int{1, 0, 3})
+		assert.True(t, changed)
+		assert.True(t, note.Outdated)
+		assert.Equal(t, 2, *note.StartLine)
+	})
+
+	t.Run("quote is found after edits", func(t *testing.T) {
+		note := anchoredNote(2, 2, "two")
+		changed := note.Reanchor("one\nintro\nsee two here\nthree", []int{1, 0, 4})
+		assert.True(t, changed)
+		assert.Equal(t, 3, *note.StartLine)
+		assert.Equal(t, 3, *note.EndLine)
+		assert.False(t, note.Outdated)
+	})
+
+	t.Run("removed quote becomes outdated and recovers", func(t *testing.T) {
+		note := anchoredNote(2, 2, "two")
+		assert.True(t, note.Reanchor("one\nthree", []int{1, 0, 2}))
+		assert.True(t, note.Outdated)
+
+		assert.True(t, note.Reanchor("one\ntwo\nthree", []int{1, 3}))
+		assert.False(t, note.Outdated)
+		assert.Equal(t, 2, *note.StartLine)
+	})
+
+	t.Run("unanchored note is unchanged", func(t *testing.T) {
+		note := &entity.ReviewNote{}
+		assert.False(t, note.Reanchor("one", []int{1}))
+	})
+}
+
+func TestReviewNote_Resolve(t *testing.T) {
+	note := &entity.ReviewNote{}
+	assert.False(t, note.IsResolved())
+
+	note.Resolve(2, time.Now())
+	assert.True(t, note.IsResolved())
+	assert.Equal(t, int64(2), *note.ResolvedBy)
+
+	note.Reopen()
+	assert.False(t, note.IsResolved())
+	assert.Nil(t, note.ResolvedBy)
+}
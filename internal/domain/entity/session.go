@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Session ログインセッションエンティティ
+// IDはリフレッシュトークンのファミリーIDと同じ
+type Session struct {
+	bun.BaseModel `bun:"table:sessions,alias:s"`
+
+	ID        string `bun:"id,pk"`
+	UserID    int64  `bun:"user_id,notnull"`
+	UserAgent string `bun:"user_agent,notnull"`
+	IPAddress string `bun:"ip_address,notnull"`
+	// ExpiresAt 最新のリフレッシュトークンの有効期限
+	ExpiresAt  time.Time  `bun:"expires_at,notnull"`
+	LastUsedAt time.Time  `bun:"last_used_at,notnull"`
+	RevokedAt  *time.Time `bun:"revoked_at"`
+	CreatedAt  time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// IsRevoked セッションが失効済みかどうかを判定
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsActive セッションが有効かどうかを判定
+func (s *Session) IsActive(now time.Time) bool {
+	return !s.IsRevoked() && now.Before(s.ExpiresAt)
+}
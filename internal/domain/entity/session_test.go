@@ -0,0 +1,43 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_IsActive(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		session entity.Session
+		revoked bool
+		active  bool
+	}{
+		{
+			name:    "active session",
+			session: entity.Session{ExpiresAt: now.Add(time.Hour)},
+			active:  true,
+		},
+		{
+			name:    "expired session",
+			session: entity.Session{ExpiresAt: now},
+		},
+		{
+			name:    "revoked session",
+			session: entity.Session{ExpiresAt: now.Add(time.Hour), RevokedAt: &now},
+			revoked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.revoked, tt.session.IsRevoked())
+			assert.Equal(t, tt.active, tt.session.IsActive(now))
+		})
+	}
+}
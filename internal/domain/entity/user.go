@@ -12,9 +12,20 @@ type UserRole string
 const (
 	RoleAdmin  UserRole = "admin"
 	RoleEditor UserRole = "editor"
-	RoleViewer UserRole = "viewer"
+	// RoleContributor 自分の下書きを作成・編集できるが公開はできない
+	RoleContributor UserRole = "contributor"
+	RoleViewer      UserRole = "viewer"
 )
 
+// IsValid 定義済みのロールかどうかを判定
+func (r UserRole) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleEditor, RoleContributor, RoleViewer:
+		return true
+	}
+	return false
+}
+
 // UserStatus ユーザーのステータスを表す型
 type UserStatus string
 
@@ -23,6 +34,15 @@ const (
 	StatusInactive UserStatus = "inactive"
 )
 
+// IsValid 定義済みのステータスかどうかを判定
+func (s UserStatus) IsValid() bool {
+	switch s {
+	case StatusActive, StatusInactive:
+		return true
+	}
+	return false
+}
+
 // User ユーザーエンティティ
 type User struct {
 	bun.BaseModel `bun:"table:users,alias:u"`
@@ -33,8 +53,10 @@ type User struct {
 	PasswordHash string     `bun:"password_hash,notnull"`
 	Role         UserRole   `bun:"role,notnull,default:'viewer'"`
 	Status       UserStatus `bun:"status,notnull,default:'active'"`
-	CreatedAt    time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
-	UpdatedAt    time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	// PasswordChangedAt 最後にパスワードを変更した日時(これより前に発行されたトークンは無効)
+	PasswordChangedAt *time.Time `bun:"password_changed_at"`
+	CreatedAt         time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt         time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 }
 
 // IsActive ユーザーがアクティブかどうかを判定
@@ -56,3 +78,21 @@ func (u *User) CanEdit() bool {
 func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
 }
+
+// IsActiveAdmin 有効な管理者かチェック
+func (u *User) IsActiveAdmin() bool {
+	return u.IsAdmin() && u.IsActive()
+}
+
+// ChangePassword パスワードハッシュを更新し、変更日時を記録
+// JWTの発行日時は秒単位のため、変更直後に発行したトークンが無効にならないよう秒未満を切り捨てる
+func (u *User) ChangePassword(passwordHash string, now time.Time) {
+	changedAt := now.Truncate(time.Second)
+	u.PasswordHash = passwordHash
+	u.PasswordChangedAt = &changedAt
+}
+
+// IsTokenRevoked 指定日時に発行されたトークンがパスワード変更により無効になっているかチェック
+func (u *User) IsTokenRevoked(issuedAt time.Time) bool {
+	return u.PasswordChangedAt != nil && issuedAt.Before(*u.PasswordChangedAt)
+}
@@ -2,10 +2,12 @@ package entity_test
 
 import (
 	"testing"
+	"time"
 
 	"my-blog-engine/internal/domain/entity"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUser_IsActive(t *testing.T) {
@@ -103,3 +105,41 @@ func TestUser_IsAdmin(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_IsActiveAdmin(t *testing.T) {
+	assert.True(t, (&entity.User{Role: entity.RoleAdmin, Status: entity.StatusActive}).IsActiveAdmin())
+	assert.False(t, (&entity.User{Role: entity.RoleAdmin, Status: entity.StatusInactive}).IsActiveAdmin())
+	assert.False(t, (&entity.User{Role: entity.RoleEditor, Status: entity.StatusActive}).IsActiveAdmin())
+}
+
+func TestUserRole_IsValid(t *testing.T) {
+	assert.True(t, entity.RoleAdmin.IsValid())
+	assert.True(t, entity.RoleEditor.IsValid())
+	assert.True(t, entity.RoleContributor.IsValid())
+	assert.True(t, entity.RoleViewer.IsValid())
+	assert.False(t, entity.UserRole("owner").IsValid())
+	assert.False(t, entity.UserRole("").IsValid())
+}
+
+func TestUserStatus_IsValid(t *testing.T) {
+	assert.True(t, entity.StatusActive.IsValid())
+	assert.True(t, entity.StatusInactive.IsValid())
+	assert.False(t, entity.UserStatus("banned").IsValid())
+}
+
+func TestUser_ChangePassword(t *testing.T) {
+	user := &entity.User{PasswordHash: "old"}
+	assert.False(t, user.IsTokenRevoked(time.Now()))
+
+	changedAt := time.Date(2024, 1, 1, 12, 0, 0, 500_000_000, time.UTC)
+	user.ChangePassword("new", changedAt)
+
+	assert.Equal(t, "new", user.PasswordHash)
+	require.NotNil(t, user.PasswordChangedAt)
+	assert.Equal(t, changedAt.Truncate(time.Second), *user.PasswordChangedAt)
+
+	assert.True(t, user.IsTokenRevoked(changedAt.Add(-time.Second)))
+	// JWTの発行日時は秒単位のため、同じ秒に発行したトークンは有効
+	assert.False(t, user.IsTokenRevoked(changedAt.Truncate(time.Second)))
+	assert.False(t, user.IsTokenRevoked(changedAt.Add(time.Second)))
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// AuditEventFilters 監査ログ一覧の絞り込み条件(ゼロ値の項目は条件に含めない)
+type AuditEventFilters struct {
+	ActorID    *int64
+	Action     entity.AuditAction
+	TargetType entity.AuditTargetType
+	TargetID   *int64
+	// From・To 記録日時の範囲[From, To)
+	From *time.Time
+	To   *time.Time
+}
+
+// AuditEventRepository 監査ログリポジトリのインターフェース(追記のみで更新はできない)
+type AuditEventRepository interface {
+	// Create 監査ログを記録
+	Create(ctx context.Context, event *entity.AuditEvent) error
+
+	// List 監査ログをIDの降順で取得(beforeIDが0より大きい場合はそれより古いものから取得)
+	List(ctx context.Context, filters AuditEventFilters, beforeID int64, limit int) ([]*entity.AuditEvent, error)
+
+	// DeleteBefore 記録日時がbeforeより前の監査ログを最大limit件削除し、削除した件数を返す
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// CommentFilters コメント一覧の絞り込み条件(ゼロ値の項目は条件に含めない)
+type CommentFilters struct {
+	Status entity.CommentStatus
+	PostID *int64
+}
+
+// CommentRepository コメントリポジトリのインターフェース
+type CommentRepository interface {
+	// Create 新しいコメントを作成
+	Create(ctx context.Context, comment *entity.Comment) error
+
+	// FindByID IDでコメントを検索
+	FindByID(ctx context.Context, id int64) (*entity.Comment, error)
+
+	// ListByPost 記事のコメントのうち、指定したステータスのものを投稿順に取得
+	ListByPost(ctx context.Context, postID int64, statuses []entity.CommentStatus) ([]*entity.Comment, error)
+
+	// List コメント一覧を新しい順に取得(記事を含む)
+	List(ctx context.Context, filters CommentFilters, limit, offset int) ([]*entity.Comment, error)
+
+	// Count コメント数を取得
+	Count(ctx context.Context, filters CommentFilters) (int, error)
+
+	// UpdateStatus 複数のコメントのステータスを一括で変更し、変更した件数を返す
+	UpdateStatus(ctx context.Context, ids []int64, status entity.CommentStatus) (int, error)
+
+	// Delete コメントを完全に削除(返信も削除される)
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"my-blog-engine/internal/domain/entity"
+)
+
+// InvitationRepository 招待リポジトリのインターフェース
+type InvitationRepository interface {
+	// Create 新しい招待を作成
+	Create(ctx context.Context, invitation *entity.Invitation) error
+
+	// FindByID IDで招待を検索
+	FindByID(ctx context.Context, id int64) (*entity.Invitation, error)
+
+	// FindByTokenHash トークンのハッシュで招待を検索
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+
+	// List 招待一覧を新しい順に取得(招待した管理者を含む)
+	List(ctx context.Context, limit, offset int) ([]*entity.Invitation, error)
+
+	// Count 招待数を取得
+	Count(ctx context.Context) (int, error)
+
+	// Accept ユーザーを作成して招待を受諾済みにする(受諾済みの場合は何も変更せずエラー)
+	Accept(ctx context.Context, invitation *entity.Invitation, user *entity.User) error
+
+	// Delete 招待を削除
+	Delete(ctx context.Context, id int64) error
+
+	// DeletePendingByEmail メールアドレスの未受諾の招待を削除
+	DeletePendingByEmail(ctx context.Context, email string) error
+}
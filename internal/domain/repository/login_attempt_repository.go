@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// LoginAttemptFilters ログイン試行一覧の絞り込み条件(ゼロ値の項目は条件に含めない)
+type LoginAttemptFilters struct {
+	Username  string
+	IPAddress string
+	UserID    *int64
+}
+
+// LoginAttemptRepository ログイン試行の監査ログリポジトリのインターフェース
+type LoginAttemptRepository interface {
+	// Create ログイン試行を記録
+	Create(ctx context.Context, attempt *entity.LoginAttempt) error
+
+	// List ログイン試行を新しい順に取得
+	List(ctx context.Context, filters LoginAttemptFilters, limit, offset int) ([]*entity.LoginAttempt, error)
+
+	// Count ログイン試行数を取得
+	Count(ctx context.Context, filters LoginAttemptFilters) (int, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// LoginThrottleRepository ログイン失敗状況リポジトリのインターフェース
+type LoginThrottleRepository interface {
+	// Find 単位とキーで失敗状況を検索
+	Find(ctx context.Context, scope entity.LoginThrottleScope, key string) (*entity.LoginThrottle, error)
+
+	// RecordFailure 失敗回数を1増やして更新後の失敗状況を返す
+	// 最後の失敗がresetBeforeより前の場合は、失敗回数を1からやり直してロックを解除する
+	RecordFailure(ctx context.Context, scope entity.LoginThrottleScope, key string, now, resetBefore time.Time) (*entity.LoginThrottle, error)
+
+	// Lock ロックの期限を設定
+	Lock(ctx context.Context, scope entity.LoginThrottleScope, key string, until time.Time) error
+
+	// Reset 失敗状況を削除してロックを解除する(該当する失敗状況がない場合はfalse)
+	Reset(ctx context.Context, scope entity.LoginThrottleScope, key string) (bool, error)
+
+	// ListLocked 指定日時にロックされている失敗状況をロックの期限の遅い順に取得
+	ListLocked(ctx context.Context, now time.Time) ([]*entity.LoginThrottle, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"my-blog-engine/internal/domain/entity"
+)
+
+// MediaRepository メディアリポジトリのインターフェース
+type MediaRepository interface {
+	// Create 新しいメディアをバリアントとあわせて作成
+	Create(ctx context.Context, media *entity.Media) error
+
+	// FindByID IDでメディアを検索
+	FindByID(ctx context.Context, id int64) (*entity.Media, error)
+
+	// FindByStorageKey 原本のストレージキーでメディアを検索
+	FindByStorageKey(ctx context.Context, key string) (*entity.Media, error)
+
+	// Update メディアの代替テキストを更新
+	Update(ctx context.Context, media *entity.Media) error
+
+	// Delete メディアを削除
+	Delete(ctx context.Context, id int64) error
+
+	// List メディア一覧を新しい順に取得
+	List(ctx context.Context, limit, offset int) ([]*entity.Media, error)
+
+	// Count メディア数を取得
+	Count(ctx context.Context) (int, error)
+}
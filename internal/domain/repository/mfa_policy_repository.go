@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"my-blog-engine/internal/domain/entity"
+)
+
+// MFAPolicyRepository 二要素認証ポリシーリポジトリのインターフェース
+type MFAPolicyRepository interface {
+	// List 設定済みのポリシーを全て取得
+	List(ctx context.Context) ([]*entity.MFAPolicy, error)
+
+	// IsRequired ロールで二要素認証が必須かどうかを取得(未設定の場合はfalse)
+	IsRequired(ctx context.Context, role entity.UserRole) (bool, error)
+
+	// Save ポリシーを保存
+	Save(ctx context.Context, policy *entity.MFAPolicy) error
+}
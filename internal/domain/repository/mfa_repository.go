@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"my-blog-engine/internal/domain/entity"
+)
+
+// MFARepository 二要素認証リポジトリのインターフェース
+type MFARepository interface {
+	// FindByUserID ユーザーの二要素認証設定を取得
+	FindByUserID(ctx context.Context, userID int64) (*entity.UserMFA, error)
+
+	// SavePending 登録途中の設定を保存(既存の設定とリカバリーコードは置き換える)
+	SavePending(ctx context.Context, mfa *entity.UserMFA) error
+
+	// Enable 登録を完了し、リカバリーコードを置き換える
+	Enable(ctx context.Context, mfa *entity.UserMFA, codeHashes []string) error
+
+	// Delete ユーザーの二要素認証設定とリカバリーコードを削除
+	Delete(ctx context.Context, userID int64) error
+
+	// UseStep ワンタイムコードのタイムステップを使用済みにする
+	// (既に同じかそれより新しいステップを使用済みの場合はfalse)
+	UseStep(ctx context.Context, userID, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes リカバリーコードを置き換える
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+
+	// UseRecoveryCode 未使用のリカバリーコードを使用済みにする(該当するコードがない場合はfalse)
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+
+	// CountUnusedRecoveryCodes 未使用のリカバリーコード数を取得
+	CountUnusedRecoveryCodes(ctx context.Context, userID int64) (int, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"my-blog-engine/internal/domain/entity"
+)
+
+// PasswordResetRepository パスワード再設定トークンリポジトリのインターフェース
+type PasswordResetRepository interface {
+	// Create 新しい再設定トークンを作成
+	Create(ctx context.Context, token *entity.PasswordResetToken) error
+
+	// FindByTokenHash トークンのハッシュで再設定トークンを検索
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+
+	// DeleteByUserID ユーザーの再設定トークンを全て削除
+	DeleteByUserID(ctx context.Context, userID int64) error
+
+	// Consume トークンを使用済みにしてユーザーのパスワードを更新し、ユーザーの他のトークンを削除する
+	// ユーザーのセッションとリフレッシュトークンも同じトランザクションで失効させる(使用済みの場合は何も変更せずエラー)
+	Consume(ctx context.Context, token *entity.PasswordResetToken, user *entity.User) error
+}
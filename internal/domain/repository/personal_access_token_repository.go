@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// PersonalAccessTokenRepository パーソナルアクセストークンリポジトリのインターフェース
+type PersonalAccessTokenRepository interface {
+	// Create 新しいトークンを作成
+	Create(ctx context.Context, token *entity.PersonalAccessToken) error
+
+	// FindByTokenHash トークンのハッシュでトークンを検索
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error)
+
+	// ListByUserID ユーザーの失効していないトークンを作成日時の新しい順に取得
+	ListByUserID(ctx context.Context, userID int64) ([]*entity.PersonalAccessToken, error)
+
+	// Touch 最終利用日時を更新
+	Touch(ctx context.Context, id int64, lastUsedAt time.Time) error
+
+	// Revoke ユーザーのトークンを失効させる(該当する失効していないトークンがない場合はfalse)
+	Revoke(ctx context.Context, userID, id int64) (bool, error)
+}
@@ -2,9 +2,44 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"my-blog-engine/internal/domain/entity"
 )
 
+// PostSearchFilters 記事検索の絞り込み条件(ゼロ値の項目は条件に含めない)
+type PostSearchFilters struct {
+	Status     entity.PostStatus
+	CategoryID *int64
+	TagID      *int64
+	AuthorID   *int64
+}
+
+// PostSearchResult 関連度スコア付きの記事検索結果
+type PostSearchResult struct {
+	Post  *entity.Post
+	Score float64
+}
+
+// MonthlyPostCount 月別の公開記事数
+type MonthlyPostCount struct {
+	Year  int `bun:"year"`
+	Month int `bun:"month"`
+	Count int `bun:"count"`
+}
+
+// PostSitemapEntry サイトマップに掲載する公開記事の情報
+type PostSitemapEntry struct {
+	Slug      string    `bun:"slug"`
+	UpdatedAt time.Time `bun:"updated_at"`
+}
+
+// PostGroupUpdate カテゴリ・タグごとの公開済み記事の最終更新日時
+type PostGroupUpdate struct {
+	GroupID   int64     `bun:"group_id"`
+	UpdatedAt time.Time `bun:"updated_at"`
+}
+
 // PostRepository 記事リポジトリのインターフェース
 type PostRepository interface {
 	// Create 新しい記事を作成
@@ -22,9 +57,18 @@ type PostRepository interface {
 	// Delete 記事を削除
 	Delete(ctx context.Context, id int64) error
 
+	// IncrementPreviewVersion プレビューのバージョンを上げて発行済みのプレビューURLを無効にし、新しいバージョンを返す
+	IncrementPreviewVersion(ctx context.Context, id int64) (int, error)
+
 	// List 記事一覧を取得
 	List(ctx context.Context, limit, offset int) ([]*entity.Post, error)
 
+	// ListAccessible ユーザーが著者の記事と共有された記事の一覧を取得
+	ListAccessible(ctx context.Context, userID int64, limit, offset int) ([]*entity.Post, error)
+
+	// ListAwaitingReview レビュー担当者のレビュー中の記事を提出日時の古い順に取得
+	ListAwaitingReview(ctx context.Context, reviewerID int64, limit, offset int) ([]*entity.Post, error)
+
 	// ListPublished 公開済み記事一覧を取得
 	ListPublished(ctx context.Context, limit, offset int) ([]*entity.Post, error)
 
@@ -34,15 +78,58 @@ type PostRepository interface {
 	// ListByTag タグ別記事一覧を取得
 	ListByTag(ctx context.Context, tagID int64, limit, offset int) ([]*entity.Post, error)
 
-	// ListByAuthor 著者別記事一覧を取得
+	// ListByAuthor 著者別の公開済み記事一覧を取得
 	ListByAuthor(ctx context.Context, authorID int64, limit, offset int) ([]*entity.Post, error)
 
+	// ListPublishedBetween 公開日時が[from, to)の範囲の公開済み記事一覧を取得
+	ListPublishedBetween(ctx context.Context, from, to time.Time, limit, offset int) ([]*entity.Post, error)
+
+	// PublishDue 公開予定日時を過ぎた予約記事を最大limit件公開し、公開した記事IDを返す
+	// 複数のアプリケーションインスタンスから同時に呼び出されても同じ記事を重複して処理しない
+	PublishDue(ctx context.Context, now time.Time, limit int) ([]int64, error)
+
+	// Search 全文検索で記事を関連度の高い順に取得
+	Search(ctx context.Context, query string, filters PostSearchFilters, limit, offset int) ([]*PostSearchResult, error)
+
+	// CountSearch 全文検索に一致する記事数を取得
+	CountSearch(ctx context.Context, query string, filters PostSearchFilters) (int, error)
+
 	// Count 記事数を取得
 	Count(ctx context.Context) (int, error)
 
+	// CountAccessible ユーザーが著者の記事と共有された記事の数を取得
+	CountAccessible(ctx context.Context, userID int64) (int, error)
+
+	// CountAwaitingReview レビュー担当者のレビュー中の記事数を取得
+	CountAwaitingReview(ctx context.Context, reviewerID int64) (int, error)
+
 	// CountPublished 公開済み記事数を取得
 	CountPublished(ctx context.Context) (int, error)
 
+	// CountByCategory カテゴリ別の公開済み記事数を取得
+	CountByCategory(ctx context.Context, categoryID int64) (int, error)
+
+	// CountByTag タグ別の公開済み記事数を取得
+	CountByTag(ctx context.Context, tagID int64) (int, error)
+
+	// CountByAuthor 著者別の公開済み記事数を取得
+	CountByAuthor(ctx context.Context, authorID int64) (int, error)
+
+	// CountPublishedBetween 公開日時が[from, to)の範囲の公開済み記事数を取得
+	CountPublishedBetween(ctx context.Context, from, to time.Time) (int, error)
+
+	// CountPublishedByMonth 月別の公開済み記事数を新しい月から順に取得
+	CountPublishedByMonth(ctx context.Context) ([]*MonthlyPostCount, error)
+
+	// ListPublishedSitemapEntries サイトマップ用に公開済み記事のスラッグと更新日時を公開日時の新しい順に取得
+	ListPublishedSitemapEntries(ctx context.Context, limit, offset int) ([]*PostSitemapEntry, error)
+
+	// LastUpdatedByCategory カテゴリごとの公開済み記事の最終更新日時を取得
+	LastUpdatedByCategory(ctx context.Context) ([]*PostGroupUpdate, error)
+
+	// LastUpdatedByTag タグごとの公開済み記事の最終更新日時を取得
+	LastUpdatedByTag(ctx context.Context) ([]*PostGroupUpdate, error)
+
 	// AddTags 記事にタグを追加
 	AddTags(ctx context.Context, postID int64, tagIDs []int64) error
 
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"my-blog-engine/internal/domain/entity"
+)
+
+// PostRevisionRepository 記事リビジョンリポジトリのインターフェース
+type PostRevisionRepository interface {
+	// Create 新しいリビジョンを作成
+	Create(ctx context.Context, revision *entity.PostRevision) error
+
+	// FindByID IDでリビジョンを検索
+	FindByID(ctx context.Context, id int64) (*entity.PostRevision, error)
+
+	// ListByPost 記事のリビジョン一覧を新しい順に取得
+	ListByPost(ctx context.Context, postID int64, limit, offset int) ([]*entity.PostRevision, error)
+
+	// CountByPost 記事のリビジョン数を取得
+	CountByPost(ctx context.Context, postID int64) (int, error)
+}
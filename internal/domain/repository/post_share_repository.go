@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// PostShareRepository 記事の共有リポジトリのインターフェース
+type PostShareRepository interface {
+	// Save 記事を共有(既に共有済みの場合は何もしない)
+	Save(ctx context.Context, share *entity.PostShare) error
+
+	// Delete 共有を解除(該当する共有がない場合はfalse)
+	Delete(ctx context.Context, postID, userID int64) (bool, error)
+
+	// Exists 記事がユーザーに共有されているかどうかを判定
+	Exists(ctx context.Context, postID, userID int64) (bool, error)
+
+	// ListByPost 記事の共有先をユーザー付きで共有した順に取得
+	ListByPost(ctx context.Context, postID int64) ([]*entity.PostShare, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// RefreshTokenRepository 発行済みリフレッシュトークンリポジトリのインターフェース
+type RefreshTokenRepository interface {
+	// Create リフレッシュトークンを登録
+	Create(ctx context.Context, token *entity.RefreshToken) error
+
+	// FindByJTI JTIでリフレッシュトークンを検索
+	FindByJTI(ctx context.Context, jti string) (*entity.RefreshToken, error)
+
+	// Rotate 使用可能なトークンを交換済みにして、同じファミリーの新しいトークンを登録
+	// (既に交換済み・失効済みの場合はfalse)
+	Rotate(ctx context.Context, jti string, next *entity.RefreshToken) (bool, error)
+
+	// RevokeFamily ファミリーの全てのトークンを失効させる
+	RevokeFamily(ctx context.Context, familyID string) error
+}
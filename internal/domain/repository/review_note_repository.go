@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// ReviewNoteRepository レビューノートリポジトリのインターフェース
+type ReviewNoteRepository interface {
+	// Create 新しいレビューノートを作成
+	Create(ctx context.Context, note *entity.ReviewNote) error
+
+	// FindByID IDでレビューノートを書いたユーザー付きで検索
+	FindByID(ctx context.Context, id int64) (*entity.ReviewNote, error)
+
+	// Update 本文と解決状態を更新
+	Update(ctx context.Context, note *entity.ReviewNote) error
+
+	// Delete レビューノートを削除(スレッドの先頭の場合は返信も削除される)
+	Delete(ctx context.Context, id int64) error
+
+	// ListByPost 記事のレビューノートを書いたユーザー付きで作成順に取得
+	ListByPost(ctx context.Context, postID int64) ([]*entity.ReviewNote, error)
+
+	// ListAnchored 記事の本文に紐付いたスレッドの先頭のノートを取得
+	ListAnchored(ctx context.Context, postID int64) ([]*entity.ReviewNote, error)
+
+	// UpdateAnchors 複数のレビューノートの紐付けた行範囲とOutdatedを一括で更新
+	UpdateAnchors(ctx context.Context, notes []*entity.ReviewNote) error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// SessionRepository ログインセッションリポジトリのインターフェース
+type SessionRepository interface {
+	// Create セッションを作成
+	Create(ctx context.Context, session *entity.Session) error
+
+	// FindByID IDでセッションを検索
+	FindByID(ctx context.Context, id string) (*entity.Session, error)
+
+	// ListActiveByUserID ユーザーの有効なセッションを最終利用日時の新しい順に取得
+	ListActiveByUserID(ctx context.Context, userID int64) ([]*entity.Session, error)
+
+	// Touch 最終利用日時を更新(expiresAtがゼロ値でなければ有効期限も更新)
+	Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error
+
+	// Revoke ユーザーのセッションを失効させる(該当する有効なセッションがない場合はfalse)
+	Revoke(ctx context.Context, userID int64, id string) (bool, error)
+
+	// RevokeByUserID ユーザーの全てのセッションを失効させる(exceptIDのセッションは除く)
+	RevokeByUserID(ctx context.Context, userID int64, exceptID string) (int, error)
+}
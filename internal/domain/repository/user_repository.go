@@ -22,6 +22,18 @@ type UserRepository interface {
 	// Update ユーザー情報を更新
 	Update(ctx context.Context, user *entity.User) error
 
+	// UpdateKeepingActiveAdmin 他に有効な管理者がいる場合のみユーザー情報を更新
+	// (有効な管理者を行ロックして確認するため、同時に実行しても有効な管理者はいなくならない。更新しなかった場合はfalse)
+	UpdateKeepingActiveAdmin(ctx context.Context, user *entity.User) (bool, error)
+
+	// UpdatePassword パスワードを更新し、ユーザーのセッションとリフレッシュトークンのファミリーを全て失効させる
+	// (1つのトランザクションで行うため、変更前のログインが有効なまま残ることはない)
+	UpdatePassword(ctx context.Context, user *entity.User) error
+
+	// UpdatePasswordHash パスワードのハッシュを同じパスワードの新しいハッシュに置き換える
+	// (その間にパスワードが変更されていた場合は置き換えずにfalse)
+	UpdatePasswordHash(ctx context.Context, id int64, currentHash, newHash string) (bool, error)
+
 	// Delete ユーザーを削除
 	Delete(ctx context.Context, id int64) error
 
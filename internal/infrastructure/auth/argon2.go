@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// DefaultArgon2Memory Argon2idの既定のメモリ使用量(KiB)
+	DefaultArgon2Memory = 64 * 1024
+	// DefaultArgon2Iterations Argon2idの既定の反復回数
+	DefaultArgon2Iterations = 3
+	// DefaultArgon2Parallelism Argon2idの既定の並列度
+	DefaultArgon2Parallelism = 2
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// Argon2Params Argon2idのパラメータ(0の項目は既定値を使う)
+type Argon2Params struct {
+	// Memory メモリ使用量(KiB)
+	Memory uint32
+	// Iterations 反復回数
+	Iterations uint32
+	// Parallelism 並列度
+	Parallelism uint8
+}
+
+// argon2Hasher Argon2idを使ったPasswordHasherの実装
+// ハッシュはPHC文字列形式($argon2id$v=19$m=65536,t=3,p=2$ソルト$ハッシュ)で保存し、
+// 以前のbcryptのハッシュも検証できる
+type argon2Hasher struct {
+	params Argon2Params
+}
+
+// NewArgon2Hasher 新しいArgon2idのPasswordHasherを作成
+func NewArgon2Hasher(params Argon2Params) (PasswordHasher, error) {
+	if params.Memory == 0 {
+		params.Memory = DefaultArgon2Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = DefaultArgon2Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = DefaultArgon2Parallelism
+	}
+	if params.Memory < 8*uint32(params.Parallelism) {
+		return nil, fmt.Errorf("argon2 memory must be at least 8 KiB per thread")
+	}
+
+	return &argon2Hasher{params: params}, nil
+}
+
+// Hash パスワードをハッシュ化
+func (h *argon2Hasher) Hash(password string) (string, error) {
+	if password == "" {
+		return "", fmt.Errorf("password cannot be empty")
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify パスワードを検証(bcryptのハッシュはbcryptで検証する)
+func (h *argon2Hasher) Verify(hashedPassword, password string) error {
+	if hashedPassword == "" || password == "" {
+		return fmt.Errorf("password and hash cannot be empty")
+	}
+
+	if isBcryptHash(hashedPassword) {
+		return verifyBcrypt(hashedPassword, password)
+	}
+
+	params, salt, key, err := decodeArgon2Hash(hashedPassword)
+	if err != nil {
+		return fmt.Errorf("invalid password: %w", err)
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return fmt.Errorf("invalid password: hash mismatch")
+	}
+
+	return nil
+}
+
+// NeedsRehash Argon2id以外のハッシュ、またはパラメータが現在の設定と異なるハッシュの場合はtrue
+func (h *argon2Hasher) NeedsRehash(hashedPassword string) bool {
+	params, salt, key, err := decodeArgon2Hash(hashedPassword)
+	if err != nil {
+		return true
+	}
+
+	return params != h.params || len(salt) != argon2SaltLength || len(key) != argon2KeyLength
+}
+
+// decodeArgon2Hash PHC文字列形式のArgon2idのハッシュからパラメータ・ソルト・ハッシュを取り出す
+func decodeArgon2Hash(hashedPassword string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return params, nil, nil, fmt.Errorf("unsupported hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version")
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2 parameters: %w", err)
+	}
+	if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return params, nil, nil, fmt.Errorf("invalid argon2 parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, fmt.Errorf("invalid argon2 hash")
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,94 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testArgon2Params テストを速くするための小さなパラメータ
+var testArgon2Params = auth.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func TestArgon2Hasher_HashAndVerify(t *testing.T) {
+	hasher, err := auth.NewArgon2Hasher(testArgon2Params)
+	require.NoError(t, err)
+
+	hash, err := hasher.Hash("my-secure-password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	// 同じパスワードでもソルトが異なる
+	other, err := hasher.Hash("my-secure-password")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	assert.NoError(t, hasher.Verify(hash, "my-secure-password"))
+	assert.Error(t, hasher.Verify(hash, "wrong-password"))
+	assert.Error(t, hasher.Verify(hash, ""))
+	assert.Error(t, hasher.Verify("", "my-secure-password"))
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	_, err = hasher.Hash("")
+	assert.Error(t, err)
+}
+
+func TestArgon2Hasher_VerifyBcrypt(t *testing.T) {
+	hasher, err := auth.NewArgon2Hasher(testArgon2Params)
+	require.NoError(t, err)
+
+	// 以前のbcryptのハッシュも検証でき、ハッシュ化し直す対象になる
+	bcryptHash, err := auth.NewPasswordHasher().Hash("password123")
+	require.NoError(t, err)
+
+	assert.NoError(t, hasher.Verify(bcryptHash, "password123"))
+	assert.Error(t, hasher.Verify(bcryptHash, "wrong-password"))
+	assert.True(t, hasher.NeedsRehash(bcryptHash))
+}
+
+func TestArgon2Hasher_NeedsRehash(t *testing.T) {
+	hasher, err := auth.NewArgon2Hasher(testArgon2Params)
+	require.NoError(t, err)
+
+	hash, err := hasher.Hash("password123")
+	require.NoError(t, err)
+
+	// パラメータを変更すると、古いパラメータのハッシュも検証できるがハッシュ化し直す対象になる
+	stronger, err := auth.NewArgon2Hasher(auth.Argon2Params{Memory: 2048, Iterations: 2, Parallelism: 1})
+	require.NoError(t, err)
+	assert.NoError(t, stronger.Verify(hash, "password123"))
+	assert.True(t, stronger.NeedsRehash(hash))
+
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{"empty", ""},
+		{"unknown algorithm", "$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$aGFzaA"},
+		{"unknown version", "$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$aGFzaA"},
+		{"invalid parameters", "$argon2id$v=19$m=0,t=1,p=1$c2FsdA$aGFzaA"},
+		{"invalid salt", "$argon2id$v=19$m=1024,t=1,p=1$!!!$aGFzaA"},
+		{"missing hash", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, hasher.NeedsRehash(tt.hash))
+			assert.Error(t, hasher.Verify(tt.hash, "password123"))
+		})
+	}
+}
+
+func TestNewArgon2Hasher(t *testing.T) {
+	// 0の項目は既定値を使う
+	hasher, err := auth.NewArgon2Hasher(auth.Argon2Params{})
+	require.NoError(t, err)
+	hash, err := hasher.Hash("password123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$"))
+
+	_, err = auth.NewArgon2Hasher(auth.Argon2Params{Memory: 8, Parallelism: 4})
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// breachedHashPrefixLength ファイル名にするSHA-1ハッシュの先頭の文字数
+const breachedHashPrefixLength = 5
+
+// BreachedPasswordChecker 漏洩したことが知られているパスワードかどうかを判定するインターフェース
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// breachedPasswordList ローカルに保存した漏洩パスワードのハッシュ一覧を使ったBreachedPasswordCheckerの実装
+// Have I Been PwnedのRange APIと同じk-匿名性の形式で、SHA-1ハッシュ(16進数大文字)の先頭5文字ごとに
+// <先頭5文字>.txtのファイルを置き、各行に残り35文字と出現回数を「SUFFIX:COUNT」の形式で記録する
+// パスワードごとに該当するファイルのみを読むため、一覧全体をメモリに読み込まない
+type breachedPasswordList struct {
+	dir string
+}
+
+// NewBreachedPasswordList 指定したディレクトリの漏洩パスワードのハッシュ一覧を使うBreachedPasswordCheckerを作成
+func NewBreachedPasswordList(dir string) (BreachedPasswordChecker, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("breached password list must be a directory: %s", dir)
+	}
+
+	return &breachedPasswordList{dir: dir}, nil
+}
+
+// IsBreached パスワードが漏洩パスワードの一覧に含まれるかどうかを判定
+// 先頭5文字のファイルがない場合は含まれないものとする(一覧の一部のみを置く場合を考慮)
+func (l *breachedPasswordList) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedHashPrefixLength], hash[breachedHashPrefixLength:]
+
+	file, err := os.Open(filepath.Join(l.dir, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		candidate, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		// 出現回数0の行はRange APIの応答の長さをそろえるための詰め物
+		if strings.EqualFold(candidate, suffix) && strings.TrimSpace(count) != "0" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+
+	return false, nil
+}
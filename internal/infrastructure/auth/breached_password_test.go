@@ -0,0 +1,69 @@
+package auth_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBreachedPasswords k-匿名性の形式で漏洩パスワードの一覧を作成
+func writeBreachedPasswords(t *testing.T, dir string, lines map[string]string) {
+	t.Helper()
+
+	files := map[string][]string{}
+	for password, count := range lines {
+		sum := sha1.Sum([]byte(password))
+		hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+		files[hash[:5]] = append(files[hash[:5]], hash[5:]+":"+count)
+	}
+	for prefix, entries := range files {
+		content := "0000000000000000000000000000000000A:3\r\n" + strings.Join(entries, "\r\n") + "\r\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, prefix+".txt"), []byte(content), 0o644))
+	}
+}
+
+func TestBreachedPasswordList_IsBreached(t *testing.T) {
+	dir := t.TempDir()
+	writeBreachedPasswords(t, dir, map[string]string{
+		"password123":  "2431680",
+		"padding-only": "0",
+	})
+
+	list, err := auth.NewBreachedPasswordList(dir)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{"breached password", "password123", true},
+		{"padding entry", "padding-only", false},
+		{"prefix file missing", "correct horse battery staple", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breached, err := list.IsBreached(tt.password)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, breached)
+		})
+	}
+}
+
+func TestNewBreachedPasswordList_Invalid(t *testing.T) {
+	_, err := auth.NewBreachedPasswordList(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "hashes.txt")
+	require.NoError(t, os.WriteFile(file, []byte(""), 0o644))
+	_, err = auth.NewBreachedPasswordList(file)
+	assert.Error(t, err)
+}
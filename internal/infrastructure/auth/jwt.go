@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"my-blog-engine/internal/domain/entity"
@@ -10,76 +11,162 @@ import (
 	"github.com/google/uuid"
 )
 
+// TokenType アクセストークンとリフレッシュトークンの区別
+type TokenType string
+
+const (
+	// TokenTypeAccess アクセストークン
+	TokenTypeAccess TokenType = "access"
+	// TokenTypeRefresh リフレッシュトークン
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// TokenPurpose 二要素認証の途中で発行するトークンの用途
+type TokenPurpose string
+
+const (
+	// PurposeMFA ワンタイムコードの入力待ち
+	PurposeMFA TokenPurpose = "mfa"
+	// PurposeMFAEnrollment 二要素認証の登録待ち(ポリシーで必須のロールで未登録の場合)
+	PurposeMFAEnrollment TokenPurpose = "mfa_enrollment"
+)
+
+// MFATokenExpiry 二要素認証の途中で発行するトークンの有効期間
+const MFATokenExpiry = 5 * time.Minute
+
 // Claims JWTクレーム
 type Claims struct {
 	UserID   int64           `json:"sub"`
 	Username string          `json:"username"`
 	Role     entity.UserRole `json:"role"`
+	// Type トークンの種類(二要素認証の途中で発行したトークンでは空)
+	Type TokenType `json:"typ,omitempty"`
+	// Purpose 二要素認証の途中で発行したトークンの用途(アクセストークン・リフレッシュトークンでは空)
+	Purpose TokenPurpose `json:"purpose,omitempty"`
+	// SessionID トークンを発行したログインセッションのID
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // JWTManagerJWT 管理インターフェース
 type JWTManager interface {
-	GenerateAccessToken(user *entity.User) (string, error)
-	GenerateRefreshToken(user *entity.User) (string, error)
+	GenerateAccessToken(user *entity.User, sessionID string) (string, error)
+	GenerateRefreshToken(user *entity.User, sessionID string) (string, error)
+	GenerateMFAToken(user *entity.User, purpose TokenPurpose) (string, error)
 	ValidateToken(tokenString string) (*Claims, error)
 	GetJTI(tokenString string) (string, error)
+	// JWKS 検証に使用する公開鍵の一覧(共通鍵は含まない)
+	JWKS() *JWKSet
 }
 
 // jwtManager JWTManagerの実装
 type jwtManager struct {
-	secretKey     []byte
+	// signingKey 署名に使用する鍵
+	signingKey *verificationKey
+	// keys 検証に使用する鍵(kidごと、kidのない共通鍵は空文字列)
+	keys          map[string]*verificationKey
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
-	signingMethod jwt.SigningMethod
 }
 
 // JWTConfigJWT 設定
 type JWTConfig struct {
-	SecretKey     string
+	// SecretKey HS256の共通鍵
+	// Keysを設定した場合は、kidのない既存のトークンを検証するためだけに使用する(空文字列なら受け付けない)
+	SecretKey string
+	// Keys 非対称鍵(RS256・ES256・EdDSA)。設定した場合はActiveKeyIDの鍵で署名する
+	Keys []SigningKey
+	// ActiveKeyID 署名に使用する鍵のkid(他の鍵は検証にのみ使用する)
+	ActiveKeyID   string
 	AccessExpiry  time.Duration
 	RefreshExpiry time.Duration
 }
 
 // NewJWTManager 新しいJWTManagerを作成
 func NewJWTManager(cfg JWTConfig) (JWTManager, error) {
-	if cfg.SecretKey == "" {
-		return nil, fmt.Errorf("secret key cannot be empty")
+	m := &jwtManager{
+		keys:          make(map[string]*verificationKey),
+		accessExpiry:  cfg.AccessExpiry,
+		refreshExpiry: cfg.RefreshExpiry,
 	}
 
-	if len(cfg.SecretKey) < 32 {
-		return nil, fmt.Errorf("secret key must be at least 32 characters")
+	if len(cfg.Keys) == 0 || cfg.SecretKey != "" {
+		if cfg.SecretKey == "" {
+			return nil, fmt.Errorf("secret key cannot be empty")
+		}
+
+		if len(cfg.SecretKey) < 32 {
+			return nil, fmt.Errorf("secret key must be at least 32 characters")
+		}
+
+		m.keys[""] = &verificationKey{
+			method:    jwt.SigningMethodHS256,
+			signKey:   []byte(cfg.SecretKey),
+			verifyKey: []byte(cfg.SecretKey),
+		}
 	}
 
-	return &jwtManager{
-		secretKey:     []byte(cfg.SecretKey),
-		accessExpiry:  cfg.AccessExpiry,
-		refreshExpiry: cfg.RefreshExpiry,
-		signingMethod: jwt.SigningMethodHS256,
-	}, nil
+	if len(cfg.Keys) == 0 {
+		m.signingKey = m.keys[""]
+		return m, nil
+	}
+
+	for _, key := range cfg.Keys {
+		k, err := newVerificationKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := m.keys[k.id]; exists {
+			return nil, fmt.Errorf("duplicate signing key id: %s", k.id)
+		}
+		m.keys[k.id] = k
+	}
+
+	active, ok := m.keys[cfg.ActiveKeyID]
+	if cfg.ActiveKeyID == "" || !ok {
+		return nil, fmt.Errorf("active signing key not found: %q", cfg.ActiveKeyID)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("active signing key %q has no private key", cfg.ActiveKeyID)
+	}
+	m.signingKey = active
+
+	return m, nil
 }
 
 // GenerateAccessToken アクセストークンを生成
-func (m *jwtManager) GenerateAccessToken(user *entity.User) (string, error) {
-	return m.generateToken(user, m.accessExpiry)
+func (m *jwtManager) GenerateAccessToken(user *entity.User, sessionID string) (string, error) {
+	return m.generateToken(user, m.accessExpiry, TokenTypeAccess, "", sessionID)
 }
 
 // GenerateRefreshToken リフレッシュトークンを生成
-func (m *jwtManager) GenerateRefreshToken(user *entity.User) (string, error) {
-	return m.generateToken(user, m.refreshExpiry)
+func (m *jwtManager) GenerateRefreshToken(user *entity.User, sessionID string) (string, error) {
+	return m.generateToken(user, m.refreshExpiry, TokenTypeRefresh, "", sessionID)
+}
+
+// GenerateMFAToken 二要素認証の途中で使用する短命のトークンを生成
+// Purposeを持つトークンはアクセストークン・リフレッシュトークンとしては使用できない
+func (m *jwtManager) GenerateMFAToken(user *entity.User, purpose TokenPurpose) (string, error) {
+	if purpose == "" {
+		return "", fmt.Errorf("token purpose is required")
+	}
+	return m.generateToken(user, MFATokenExpiry, "", purpose, "")
 }
 
 // generateToken トークンを生成
-func (m *jwtManager) generateToken(user *entity.User, expiry time.Duration) (string, error) {
+func (m *jwtManager) generateToken(user *entity.User, expiry time.Duration, tokenType TokenType, purpose TokenPurpose, sessionID string) (string, error) {
 	if user == nil {
 		return "", fmt.Errorf("user cannot be nil")
 	}
 
 	now := time.Now()
 	claims := Claims{
-		UserID:   user.ID,
-		Username: user.Username,
-		Role:     user.Role,
+		UserID:    user.ID,
+		Username:  user.Username,
+		Role:      user.Role,
+		Type:      tokenType,
+		Purpose:   purpose,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -88,8 +175,11 @@ func (m *jwtManager) generateToken(user *entity.User, expiry time.Duration) (str
 		},
 	}
 
-	token := jwt.NewWithClaims(m.signingMethod, claims)
-	tokenString, err := token.SignedString(m.secretKey)
+	token := jwt.NewWithClaims(m.signingKey.method, claims)
+	if m.signingKey.id != "" {
+		token.Header["kid"] = m.signingKey.id
+	}
+	tokenString, err := token.SignedString(m.signingKey.signKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -104,11 +194,16 @@ func (m *jwtManager) ValidateToken(tokenString string) (*Claims, error) {
 	}
 
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// 署名方法の検証
-		if token.Method != m.signingMethod {
+		// kidで鍵を選び、鍵のアルゴリズムと署名方法が一致することを検証
+		kid, _ := token.Header["kid"].(string)
+		key, ok := m.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %q", kid)
+		}
+		if token.Method != key.method {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return m.secretKey, nil
+		return key.verifyKey, nil
 	})
 
 	if err != nil {
@@ -136,3 +231,23 @@ func (m *jwtManager) GetJTI(tokenString string) (string, error) {
 
 	return claims.ID, nil
 }
+
+// JWKS 検証に使用する公開鍵の一覧(署名に使用中の鍵が先頭、共通鍵は含まない)
+func (m *jwtManager) JWKS() *JWKSet {
+	ids := make([]string, 0, len(m.keys))
+	for id := range m.keys {
+		if id != m.signingKey.id {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	ids = append([]string{m.signingKey.id}, ids...)
+
+	set := &JWKSet{Keys: []JWK{}}
+	for _, id := range ids {
+		if jwk, ok := m.keys[id].jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
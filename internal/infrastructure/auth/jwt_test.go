@@ -27,7 +27,7 @@ func TestJWTManager_GenerateAccessToken(t *testing.T) {
 		Role:     entity.RoleAdmin,
 	}
 
-	token, err := manager.GenerateAccessToken(user)
+	token, err := manager.GenerateAccessToken(user, "session-1")
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 }
@@ -48,11 +48,41 @@ func TestJWTManager_GenerateRefreshToken(t *testing.T) {
 		Role:     entity.RoleAdmin,
 	}
 
-	token, err := manager.GenerateRefreshToken(user)
+	token, err := manager.GenerateRefreshToken(user, "session-1")
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 }
 
+func TestJWTManager_TokenType(t *testing.T) {
+	manager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	user := &entity.User{ID: 1, Username: "testuser", Role: entity.RoleEditor}
+
+	access, err := manager.GenerateAccessToken(user, "session-1")
+	require.NoError(t, err)
+	claims, err := manager.ValidateToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, auth.TokenTypeAccess, claims.Type)
+
+	refresh, err := manager.GenerateRefreshToken(user, "session-1")
+	require.NoError(t, err)
+	claims, err = manager.ValidateToken(refresh)
+	require.NoError(t, err)
+	assert.Equal(t, auth.TokenTypeRefresh, claims.Type)
+
+	// 二要素認証の途中で発行したトークンには種類を設定しない
+	mfa, err := manager.GenerateMFAToken(user, auth.PurposeMFA)
+	require.NoError(t, err)
+	claims, err = manager.ValidateToken(mfa)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Type)
+}
+
 func TestJWTManager_ValidateToken(t *testing.T) {
 	cfg := auth.JWTConfig{
 		SecretKey:     "test-secret-key-min-32-chars-long",
@@ -69,7 +99,7 @@ func TestJWTManager_ValidateToken(t *testing.T) {
 		Role:     entity.RoleEditor,
 	}
 
-	token, err := manager.GenerateAccessToken(user)
+	token, err := manager.GenerateAccessToken(user, "session-1")
 	require.NoError(t, err)
 
 	// トークン検証成功
@@ -78,6 +108,7 @@ func TestJWTManager_ValidateToken(t *testing.T) {
 	assert.Equal(t, user.ID, claims.UserID)
 	assert.Equal(t, user.Username, claims.Username)
 	assert.Equal(t, user.Role, claims.Role)
+	assert.Equal(t, "session-1", claims.SessionID)
 }
 
 func TestJWTManager_ValidateToken_InvalidToken(t *testing.T) {
@@ -132,7 +163,7 @@ func TestJWTManager_GetJTI(t *testing.T) {
 		Role:     entity.RoleAdmin,
 	}
 
-	token, err := manager.GenerateAccessToken(user)
+	token, err := manager.GenerateAccessToken(user, "session-1")
 	require.NoError(t, err)
 
 	jti, err := manager.GetJTI(token)
@@ -170,3 +201,32 @@ func TestNewJWTManager_InvalidConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestJWTManager_GenerateMFAToken(t *testing.T) {
+	manager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	user := &entity.User{ID: 1, Username: "testuser", Role: entity.RoleEditor}
+
+	token, err := manager.GenerateMFAToken(user, auth.PurposeMFA)
+	require.NoError(t, err)
+
+	claims, err := manager.ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, auth.PurposeMFA, claims.Purpose)
+	assert.WithinDuration(t, time.Now().Add(auth.MFATokenExpiry), claims.ExpiresAt.Time, 2*time.Second)
+
+	// アクセストークンには用途を設定しない
+	access, err := manager.GenerateAccessToken(user, "session-1")
+	require.NoError(t, err)
+	claims, err = manager.ValidateToken(access)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Purpose)
+
+	_, err = manager.GenerateMFAToken(user, "")
+	assert.Error(t, err)
+}
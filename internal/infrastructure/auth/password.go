@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -12,6 +13,8 @@ const bcryptCost = 12
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Verify(hashedPassword, password string) error
+	// NeedsRehash 現在のアルゴリズム・パラメータでハッシュ化し直すべきかどうかを判定
+	NeedsRehash(hashedPassword string) bool
 }
 
 // bcryptHasher bcryptを使ったPasswordHasherの実装
@@ -42,6 +45,22 @@ func (h *bcryptHasher) Verify(hashedPassword, password string) error {
 		return fmt.Errorf("password and hash cannot be empty")
 	}
 
+	return verifyBcrypt(hashedPassword, password)
+}
+
+// NeedsRehash bcrypt以外のハッシュ、またはコストが異なるハッシュの場合はtrue
+func (h *bcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != bcryptCost
+}
+
+// isBcryptHash bcryptのハッシュ($2a$・$2b$・$2y$)かどうかを判定
+func isBcryptHash(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2")
+}
+
+// verifyBcrypt bcryptのハッシュでパスワードを検証
+func verifyBcrypt(hashedPassword, password string) error {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	if err != nil {
 		return fmt.Errorf("invalid password: %w", err)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestPasswordHasher_Hash(t *testing.T) {
@@ -119,3 +120,18 @@ func TestPasswordHasher_HashAndVerify(t *testing.T) {
 	err = hasher.Verify(hash, "wrong-password")
 	assert.Error(t, err)
 }
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	hasher := auth.NewPasswordHasher()
+
+	hash, err := hasher.Hash("password123")
+	require.NoError(t, err)
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	// コストの低いハッシュやbcrypt以外のハッシュはハッシュ化し直す
+	weak, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	assert.NoError(t, hasher.Verify(string(weak), "password123"))
+	assert.True(t, hasher.NeedsRehash(string(weak)))
+	assert.True(t, hasher.NeedsRehash("$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$aGFzaA"))
+}
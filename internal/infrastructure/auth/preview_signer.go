@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// previewSignaturePrefix 署名対象に付加する用途の識別子
+// 鍵を他の用途(JWT_SECRETなど)と共用しても、別の用途の署名として受け付けないようにする
+const previewSignaturePrefix = "post-preview\n"
+
+var (
+	// ErrInvalidPreviewToken プレビューのトークンの形式・署名が正しくない場合のエラー
+	ErrInvalidPreviewToken = errors.New("invalid preview token")
+	// ErrPreviewTokenExpired プレビューのトークンの有効期限が切れている場合のエラー
+	ErrPreviewTokenExpired = errors.New("preview token has expired")
+)
+
+// PreviewClaims プレビューのトークンに含める内容
+type PreviewClaims struct {
+	PostID int64
+	// Version 記事のプレビューのバージョン(記事ごとに失効させるとバージョンが上がり、以前のトークンは無効になる)
+	Version   int
+	ExpiresAt time.Time
+}
+
+// PreviewSigner 記事のプレビューURLに含めるトークンを署名・検証するインターフェース
+type PreviewSigner interface {
+	// Sign 内容に署名したURLに含められるトークンを返す
+	Sign(claims PreviewClaims) string
+	// Verify トークンの署名と有効期限を検証して内容を返す
+	Verify(token string, now time.Time) (*PreviewClaims, error)
+}
+
+// hmacPreviewSigner HMAC-SHA256によるPreviewSignerの実装
+type hmacPreviewSigner struct {
+	key []byte
+}
+
+// NewPreviewSigner 新しいPreviewSignerを作成
+func NewPreviewSigner(key string) (PreviewSigner, error) {
+	if len(key) < 32 {
+		return nil, fmt.Errorf("preview signing key must be at least 32 characters")
+	}
+	return &hmacPreviewSigner{key: []byte(key)}, nil
+}
+
+// Sign 内容に署名したトークン({記事ID}.{バージョン}.{有効期限のUNIX時間}.{署名})を返す
+func (s *hmacPreviewSigner) Sign(claims PreviewClaims) string {
+	payload := fmt.Sprintf("%d.%d.%d", claims.PostID, claims.Version, claims.ExpiresAt.Unix())
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify トークンの署名と有効期限を検証して内容を返す
+func (s *hmacPreviewSigner) Verify(token string, now time.Time) (*PreviewClaims, error) {
+	index := strings.LastIndex(token, ".")
+	if index < 0 {
+		return nil, ErrInvalidPreviewToken
+	}
+	payload, encoded := token[:index], token[index+1:]
+
+	signature, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || !hmac.Equal(signature, s.mac(payload)) {
+		return nil, ErrInvalidPreviewToken
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidPreviewToken
+	}
+	postID, errID := strconv.ParseInt(parts[0], 10, 64)
+	version, errVersion := strconv.Atoi(parts[1])
+	expiresAt, errExpiry := strconv.ParseInt(parts[2], 10, 64)
+	if errID != nil || errVersion != nil || errExpiry != nil {
+		return nil, ErrInvalidPreviewToken
+	}
+
+	claims := &PreviewClaims{PostID: postID, Version: version, ExpiresAt: time.Unix(expiresAt, 0)}
+	if !now.Before(claims.ExpiresAt) {
+		return nil, ErrPreviewTokenExpired
+	}
+
+	return claims, nil
+}
+
+// mac 署名対象のHMAC-SHA256を計算
+func (s *hmacPreviewSigner) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(previewSignaturePrefix + payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,46 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewSigner(t *testing.T) {
+	signer, err := auth.NewPreviewSigner("test-secret-key-min-32-chars-long")
+	require.NoError(t, err)
+
+	now := time.Now()
+	claims := auth.PreviewClaims{PostID: 42, Version: 3, ExpiresAt: now.Add(time.Hour)}
+	token := signer.Sign(claims)
+
+	verified, err := signer.Verify(token, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), verified.PostID)
+	assert.Equal(t, 3, verified.Version)
+	assert.Equal(t, claims.ExpiresAt.Unix(), verified.ExpiresAt.Unix())
+
+	// 有効期限切れ
+	_, err = signer.Verify(token, now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, auth.ErrPreviewTokenExpired)
+
+	// 内容を書き換えたトークン
+	_, err = signer.Verify("43"+token[2:], now)
+	assert.ErrorIs(t, err, auth.ErrInvalidPreviewToken)
+
+	// 別の鍵で署名したトークン
+	other, err := auth.NewPreviewSigner("another-secret-key-min-32-chars-long")
+	require.NoError(t, err)
+	_, err = other.Verify(token, now)
+	assert.ErrorIs(t, err, auth.ErrInvalidPreviewToken)
+
+	_, err = signer.Verify("not-a-token", now)
+	assert.ErrorIs(t, err, auth.ErrInvalidPreviewToken)
+
+	_, err = auth.NewPreviewSigner("short")
+	assert.Error(t, err)
+}
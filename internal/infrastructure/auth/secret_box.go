@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// SecretBox 保存する秘密情報を暗号化するインターフェース
+type SecretBox interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// aesSecretBox AES-256-GCMによるSecretBoxの実装
+type aesSecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox 新しいSecretBoxを作成(鍵は任意の長さの文字列からSHA-256で導出する)
+func NewSecretBox(key string) (SecretBox, error) {
+	if len(key) < 32 {
+		return nil, fmt.Errorf("secret box key must be at least 32 characters")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return &aesSecretBox{aead: aead}, nil
+}
+
+// Encrypt 平文を暗号化してBase64で返す(ノンスを先頭に付加する)
+func (b *aesSecretBox) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt Encryptで暗号化した文字列を復号
+func (b *aesSecretBox) Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+	if len(data) < b.aead.NonceSize() {
+		return "", fmt.Errorf("ciphertext is too short")
+	}
+
+	nonce, sealed := data[:b.aead.NonceSize()], data[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,32 @@
+package auth_test
+
+import (
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretBox(t *testing.T) {
+	box, err := auth.NewSecretBox("test-secret-key-min-32-chars-long")
+	require.NoError(t, err)
+
+	encrypted, err := box.Encrypt(rfc6238Secret)
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, rfc6238Secret)
+
+	decrypted, err := box.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, rfc6238Secret, decrypted)
+
+	// 別の鍵では復号できない
+	other, err := auth.NewSecretBox("another-secret-key-min-32-chars-long")
+	require.NoError(t, err)
+	_, err = other.Decrypt(encrypted)
+	assert.Error(t, err)
+
+	_, err = auth.NewSecretBox("short")
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits RS256で使用するRSA鍵の最小ビット数
+const minRSAKeyBits = 2048
+
+// SigningKey JWTの署名鍵(kidで識別する)
+type SigningKey struct {
+	// ID JWTヘッダーのkid
+	ID string
+	// PrivateKey 署名に使用する秘密鍵(*rsa.PrivateKey、*ecdsa.PrivateKey、ed25519.PrivateKey)
+	// 退役済みで検証にのみ使用する鍵ではnil
+	PrivateKey crypto.Signer
+	// PublicKey 検証に使用する公開鍵(PrivateKeyがある場合は省略可)
+	PublicKey crypto.PublicKey
+}
+
+// JWK 公開鍵のJSON Web Key(RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC・OKP
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet JSON Web Key Set
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// verificationKey 署名・検証に使用する鍵
+type verificationKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// newVerificationKey 鍵の種類から署名アルゴリズムを決めて検証用の鍵を作成
+// RSA(2048ビット以上)はRS256、ECDSA(P-256)はES256、Ed25519はEdDSA
+func newVerificationKey(key SigningKey) (*verificationKey, error) {
+	if key.ID == "" {
+		return nil, fmt.Errorf("signing key id cannot be empty")
+	}
+
+	publicKey := key.PublicKey
+	if key.PrivateKey != nil {
+		publicKey = key.PrivateKey.Public()
+	}
+	if publicKey == nil {
+		return nil, fmt.Errorf("signing key %q has no key material", key.ID)
+	}
+
+	var method jwt.SigningMethod
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("signing key %q: RSA key must be at least %d bits", key.ID, minRSAKeyBits)
+		}
+		method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("signing key %q: only P-256 ECDSA keys are supported", key.ID)
+		}
+		method = jwt.SigningMethodES256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("signing key %q: unsupported key type %T", key.ID, publicKey)
+	}
+
+	k := &verificationKey{
+		id:        key.ID,
+		method:    method,
+		verifyKey: publicKey,
+	}
+	if key.PrivateKey != nil {
+		k.signKey = key.PrivateKey
+	}
+	return k, nil
+}
+
+// jwk 公開鍵をJWKに変換
+func (k *verificationKey) jwk() (JWK, bool) {
+	jwk := JWK{Kid: k.id, Use: "sig", Alg: k.method.Alg()}
+
+	switch pub := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64URL(pub.N.Bytes())
+		jwk.E = base64URL(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		// 座標は曲線のバイト長に揃える
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = base64URL(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64URL(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64URL(pub)
+	default:
+		// 共通鍵(HS256)は公開しない
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
+
+// base64URL パディングなしのBase64URLエンコード
+func base64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// LoadSigningKeys ディレクトリ内のPEMファイルから署名鍵を読み込む
+// ファイル名(拡張子.pemと.pubを除く)をkidとし、秘密鍵のファイルは署名と検証、公開鍵のファイルは検証にのみ使用する
+func LoadSigningKeys(dir string) ([]SigningKey, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	sort.Strings(files)
+
+	keys := make([]SigningKey, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key %s: %w", filepath.Base(file), err)
+		}
+
+		id := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(file), ".pem"), ".pub")
+		key, err := ParseSigningKey(id, data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys found in %s", dir)
+	}
+
+	return keys, nil
+}
+
+// ParseSigningKey PEM形式の秘密鍵(PKCS#8、PKCS#1、SEC 1)または公開鍵(PKIX)を読み込む
+func ParseSigningKey(id string, data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("signing key %q: no PEM data found", id)
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("signing key %q: unsupported PEM type %q", id, block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("signing key %q: %w", id, err)
+	}
+
+	if signer, ok := parsed.(crypto.Signer); ok {
+		return SigningKey{ID: id, PrivateKey: signer}, nil
+	}
+	return SigningKey{ID: id, PublicKey: parsed}, nil
+}
@@ -0,0 +1,245 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSigner テスト用の秘密鍵を生成
+func newTestSigner(t *testing.T, alg string) crypto.Signer {
+	t.Helper()
+
+	var (
+		signer crypto.Signer
+		err    error
+	)
+	switch alg {
+	case "RS256":
+		signer, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "EdDSA":
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+	require.NotNil(t, signer)
+	return signer
+}
+
+// writePEM 鍵をPEMファイルとして書き出す
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestJWTManager_AsymmetricAlgorithms(t *testing.T) {
+	user := &entity.User{ID: 1, Username: "testuser", Role: entity.RoleEditor}
+
+	for _, alg := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			manager, err := auth.NewJWTManager(auth.JWTConfig{
+				Keys:         []auth.SigningKey{{ID: "key-1", PrivateKey: newTestSigner(t, alg)}},
+				ActiveKeyID:  "key-1",
+				AccessExpiry: 15 * time.Minute,
+			})
+			require.NoError(t, err)
+
+			token, err := manager.GenerateAccessToken(user, "session-1")
+			require.NoError(t, err)
+
+			claims, err := manager.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, claims.UserID)
+
+			jwks := manager.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, "key-1", jwks.Keys[0].Kid)
+			assert.Equal(t, alg, jwks.Keys[0].Alg)
+			assert.Equal(t, "sig", jwks.Keys[0].Use)
+		})
+	}
+}
+
+func TestJWTManager_KeyRotation(t *testing.T) {
+	user := &entity.User{ID: 1, Username: "testuser", Role: entity.RoleEditor}
+	oldKey := newTestSigner(t, "RS256")
+	newKey := newTestSigner(t, "ES256")
+
+	oldManager, err := auth.NewJWTManager(auth.JWTConfig{
+		Keys:         []auth.SigningKey{{ID: "2025-01", PrivateKey: oldKey}},
+		ActiveKeyID:  "2025-01",
+		AccessExpiry: 15 * time.Minute,
+	})
+	require.NoError(t, err)
+	oldToken, err := oldManager.GenerateAccessToken(user, "")
+	require.NoError(t, err)
+
+	// 古い鍵は公開鍵のみを残して検証に使う
+	manager, err := auth.NewJWTManager(auth.JWTConfig{
+		Keys: []auth.SigningKey{
+			{ID: "2025-01", PublicKey: oldKey.Public()},
+			{ID: "2025-06", PrivateKey: newKey},
+		},
+		ActiveKeyID:  "2025-06",
+		AccessExpiry: 15 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	_, err = manager.ValidateToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, err := manager.GenerateAccessToken(user, "")
+	require.NoError(t, err)
+	_, err = manager.ValidateToken(newToken)
+	require.NoError(t, err)
+
+	// 新しい鍵で署名したトークンは古い設定では検証できない
+	_, err = oldManager.ValidateToken(newToken)
+	assert.Error(t, err)
+
+	jwks := manager.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "2025-06", jwks.Keys[0].Kid)
+	assert.Equal(t, "EC", jwks.Keys[0].Kty)
+	assert.Equal(t, "P-256", jwks.Keys[0].Crv)
+	assert.Equal(t, "2025-01", jwks.Keys[1].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func TestJWTManager_LegacySecretKey(t *testing.T) {
+	user := &entity.User{ID: 1, Username: "testuser", Role: entity.RoleEditor}
+	secret := "test-secret-key-min-32-chars-long"
+
+	hsManager, err := auth.NewJWTManager(auth.JWTConfig{SecretKey: secret, AccessExpiry: 15 * time.Minute})
+	require.NoError(t, err)
+	hsToken, err := hsManager.GenerateAccessToken(user, "")
+	require.NoError(t, err)
+	assert.Empty(t, hsManager.JWKS().Keys)
+
+	signer := newTestSigner(t, "EdDSA")
+
+	// 共通鍵を設定しない場合、HS256のトークンは受け付けない
+	manager, err := auth.NewJWTManager(auth.JWTConfig{
+		Keys:        []auth.SigningKey{{ID: "key-1", PrivateKey: signer}},
+		ActiveKeyID: "key-1",
+	})
+	require.NoError(t, err)
+	_, err = manager.ValidateToken(hsToken)
+	assert.Error(t, err)
+
+	// 移行期間中は共通鍵でも検証できるが、署名には使わない
+	manager, err = auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:    secret,
+		Keys:         []auth.SigningKey{{ID: "key-1", PrivateKey: signer}},
+		ActiveKeyID:  "key-1",
+		AccessExpiry: 15 * time.Minute,
+	})
+	require.NoError(t, err)
+	_, err = manager.ValidateToken(hsToken)
+	require.NoError(t, err)
+
+	token, err := manager.GenerateAccessToken(user, "")
+	require.NoError(t, err)
+	_, err = hsManager.ValidateToken(token)
+	assert.Error(t, err)
+	assert.Len(t, manager.JWKS().Keys, 1)
+}
+
+func TestNewJWTManager_InvalidKeys(t *testing.T) {
+	signer := newTestSigner(t, "ES256")
+	weakRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		cfg  auth.JWTConfig
+	}{
+		{
+			name: "missing active key",
+			cfg:  auth.JWTConfig{Keys: []auth.SigningKey{{ID: "key-1", PrivateKey: signer}}, ActiveKeyID: "key-2"},
+		},
+		{
+			name: "active key without private key",
+			cfg:  auth.JWTConfig{Keys: []auth.SigningKey{{ID: "key-1", PublicKey: signer.Public()}}, ActiveKeyID: "key-1"},
+		},
+		{
+			name: "duplicate key id",
+			cfg: auth.JWTConfig{
+				Keys:        []auth.SigningKey{{ID: "key-1", PrivateKey: signer}, {ID: "key-1", PublicKey: signer.Public()}},
+				ActiveKeyID: "key-1",
+			},
+		},
+		{
+			name: "weak RSA key",
+			cfg:  auth.JWTConfig{Keys: []auth.SigningKey{{ID: "key-1", PrivateKey: weakRSA}}, ActiveKeyID: "key-1"},
+		},
+		{
+			name: "unsupported curve",
+			cfg:  auth.JWTConfig{Keys: []auth.SigningKey{{ID: "key-1", PrivateKey: p384}}, ActiveKeyID: "key-1"},
+		},
+		{
+			name: "short legacy secret",
+			cfg:  auth.JWTConfig{SecretKey: "short", Keys: []auth.SigningKey{{ID: "key-1", PrivateKey: signer}}, ActiveKeyID: "key-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.NewJWTManager(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	active := newTestSigner(t, "EdDSA")
+	der, err := x509.MarshalPKCS8PrivateKey(active)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "2025-06.pem"), "PRIVATE KEY", der)
+
+	retired := newTestSigner(t, "RS256")
+	der, err = x509.MarshalPKIXPublicKey(retired.Public())
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "2025-01.pub.pem"), "PUBLIC KEY", der)
+
+	keys, err := auth.LoadSigningKeys(dir)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "2025-01", keys[0].ID)
+	assert.Nil(t, keys[0].PrivateKey)
+	assert.NotNil(t, keys[0].PublicKey)
+	assert.Equal(t, "2025-06", keys[1].ID)
+	assert.NotNil(t, keys[1].PrivateKey)
+
+	_, err = auth.NewJWTManager(auth.JWTConfig{Keys: keys, ActiveKeyID: "2025-06"})
+	require.NoError(t, err)
+
+	_, err = auth.LoadSigningKeys(t.TempDir())
+	assert.Error(t, err)
+
+	_, err = auth.ParseSigningKey("broken", []byte("not a pem"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// tokenBytes ランダムトークンのバイト数(256ビット)
+const tokenBytes = 32
+
+// GenerateToken URLに含められるランダムなトークンと、保存用のハッシュを生成
+// トークンは利用者にのみ渡し、データベースにはハッシュのみを保存する
+func GenerateToken() (token, hash string, err error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken トークンのSHA-256ハッシュ(16進数)を返す
+// トークンは十分なエントロピーを持つため、パスワードと異なりソルトやストレッチングは不要
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,28 @@
+package auth_test
+
+import (
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateToken(t *testing.T) {
+	token, hash, err := auth.GenerateToken()
+	require.NoError(t, err)
+
+	assert.Len(t, token, 43)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, auth.HashToken(token), hash)
+	assert.NotEqual(t, token, hash)
+
+	other, _, err := auth.GenerateToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestHashToken(t *testing.T) {
+	assert.Equal(t, "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", auth.HashToken("foo"))
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// totpSecretBytes TOTPシークレットのバイト数(RFC 4226の推奨値160ビット)
+	totpSecretBytes = 20
+	// TOTPDigits ワンタイムコードの桁数
+	TOTPDigits = 6
+	// TOTPPeriod ワンタイムコードの更新間隔
+	TOTPPeriod = 30 * time.Second
+	// totpSkew 時刻のずれを許容するステップ数(前後1ステップ)
+	totpSkew = 1
+	// recoveryCodeBytes リカバリーコードのバイト数(80ビット)
+	recoveryCodeBytes = 10
+)
+
+// totpEncoding TOTPシークレットの表記(認証アプリに合わせてパディングなしのBase32)
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret 新しいTOTPシークレット(Base32)を生成
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPURI 認証アプリに登録するotpauth URI(QRコードの内容)を返す
+func TOTPURI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTPDigits))
+	query.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// TOTPStep 指定日時のタイムステップを返す
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+// TOTPCode 指定したタイムステップのワンタイムコードを生成(RFC 6238, HMAC-SHA1)
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid totp secret: %w", err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// RFC 4226の動的切り捨て
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod), nil
+}
+
+// ValidateTOTP ワンタイムコードを検証し、一致したタイムステップを返す
+// 時刻のずれを考慮して前後1ステップまで許容する。リプレイを防ぐため、呼び出し側は返されたステップ以前のコードを拒否すること
+func ValidateTOTP(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	current := TOTPStep(now)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		step := current + int64(i)
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCode 新しいリカバリーコード(xxxx-xxxx-xxxx-xxxx形式)を生成
+// 十分なエントロピーを持つため、保存時はHashTokenでハッシュ化する
+func GenerateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+
+	raw := strings.ToLower(totpEncoding.EncodeToString(b))
+	parts := make([]string, 0, len(raw)/4)
+	for i := 0; i < len(raw); i += 4 {
+		parts = append(parts, raw[i:i+4])
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// NormalizeRecoveryCode 入力されたリカバリーコードを保存時と同じ表記に揃える
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+
+	parts := make([]string, 0, len(code)/4+1)
+	for i := 0; i < len(code); i += 4 {
+		parts = append(parts, code[i:min(i+4, len(code))])
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,96 @@
+package auth_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret RFC 6238の試験用シークレット("12345678901234567890")のBase32表記
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238(t *testing.T) {
+	// RFC 6238 付録Bの値(SHA-1)の下6桁
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := auth.TOTPCode(rfc6238Secret, auth.TOTPStep(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code, "unix=%d", tt.unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Unix(1700000000, 0)
+	step := auth.TOTPStep(now)
+
+	code, err := auth.TOTPCode(secret, step)
+	require.NoError(t, err)
+	matched, ok := auth.ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, step, matched)
+
+	// 前後1ステップのずれは許容する
+	previous, err := auth.TOTPCode(secret, step-1)
+	require.NoError(t, err)
+	matched, ok = auth.ValidateTOTP(secret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, matched)
+
+	old, err := auth.TOTPCode(secret, step-3)
+	require.NoError(t, err)
+	_, ok = auth.ValidateTOTP(secret, old, now)
+	assert.False(t, ok)
+
+	_, ok = auth.ValidateTOTP(secret, "12345", now)
+	assert.False(t, ok)
+	_, ok = auth.ValidateTOTP("not base32!", "123456", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := auth.TOTPURI("My Blog", "editor@example.com", rfc6238Secret)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/My Blog:editor@example.com", u.Path)
+	assert.Equal(t, rfc6238Secret, u.Query().Get("secret"))
+	assert.Equal(t, "My Blog", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	code, err := auth.GenerateRecoveryCode()
+	require.NoError(t, err)
+	assert.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+
+	other, err := auth.GenerateRecoveryCode()
+	require.NoError(t, err)
+	assert.NotEqual(t, code, other)
+
+	// 大文字・区切りなしで入力しても同じ表記になる
+	assert.Equal(t, code, auth.NormalizeRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", ""))))
+	assert.Equal(t, code, auth.NormalizeRecoveryCode(" "+code+" "))
+}
@@ -0,0 +1,302 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxEditDistance Myersアルゴリズムで探索する最大編集距離
+// これを超える差分は共通の前後を除いた中間部分を「全削除+全追加」として扱う。
+// トレースのメモリ使用量がO(D^2)となるため、巨大な書き換えでメモリを使い切らないよう制限する
+const maxEditDistance = 1024
+
+// OpKind 差分操作の種類
+type OpKind int
+
+const (
+	// Equal 両方に存在する行
+	Equal OpKind = iota
+	// Delete 旧テキストのみに存在する行
+	Delete
+	// Insert 新テキストのみに存在する行
+	Insert
+)
+
+// Op 行単位の差分操作
+type Op struct {
+	Kind OpKind
+	Text string
+	// OldLine 旧テキストでの行番号(1始まり、Insertの場合は0)
+	OldLine int
+	// NewLine 新テキストでの行番号(1始まり、Deleteの場合は0)
+	NewLine int
+}
+
+// SplitLines テキストを行に分割する(末尾の改行は空行として扱わない)
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Lines 2つの行リストの差分を計算する
+func Lines(a, b []string) []Op {
+	// 共通の先頭・末尾を除外して探索範囲を狭める
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]Op, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, Op{Kind: Equal, Text: a[i]})
+	}
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for i := len(a) - suffix; i < len(a); i++ {
+		ops = append(ops, Op{Kind: Equal, Text: a[i]})
+	}
+
+	// 行番号を付与
+	oldLine, newLine := 0, 0
+	for i := range ops {
+		switch ops[i].Kind {
+		case Equal:
+			oldLine++
+			newLine++
+			ops[i].OldLine = oldLine
+			ops[i].NewLine = newLine
+		case Delete:
+			oldLine++
+			ops[i].OldLine = oldLine
+		case Insert:
+			newLine++
+			ops[i].NewLine = newLine
+		}
+	}
+
+	return ops
+}
+
+// LineMap 差分から旧テキストの各行が新テキストの何行目にあたるかを求める
+// 戻り値のi番目が旧テキストのi+1行目に対応し、削除・変更された行は0となる
+func LineMap(ops []Op) []int {
+	lineMap := make([]int, 0, len(ops))
+	for _, op := range ops {
+		switch op.Kind {
+		case Equal:
+			lineMap = append(lineMap, op.NewLine)
+		case Delete:
+			lineMap = append(lineMap, 0)
+		}
+	}
+	return lineMap
+}
+
+// myers Myersの差分アルゴリズムで最短編集スクリプトを求める
+func myers(a, b []string) []Op {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	limit := n + m
+	if limit > maxEditDistance {
+		limit = maxEditDistance
+	}
+
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	// trace[d] にはd回目の探索開始時点のk∈[-d-1, d+1]の状態を保存
+	trace := make([][]int, 0, limit+1)
+
+	for d := 0; d <= limit; d++ {
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+
+	// 編集距離が上限を超えた場合は全置換として扱う
+	ops := make([]Op, 0, n+m)
+	for _, line := range a {
+		ops = append(ops, Op{Kind: Delete, Text: line})
+	}
+	for _, line := range b {
+		ops = append(ops, Op{Kind: Insert, Text: line})
+	}
+	return ops
+}
+
+// backtrack 探索トレースから編集スクリプトを復元する
+func backtrack(trace [][]int, a, b []string) []Op {
+	x, y := len(a), len(b)
+	reversed := make([]Op, 0, x+y)
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		snapshot := trace[d]
+		get := func(k int) int { return snapshot[k+d+1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && get(k-1) < get(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := get(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, Op{Kind: Equal, Text: a[x-1]})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, Op{Kind: Insert, Text: b[y-1]})
+			} else {
+				reversed = append(reversed, Op{Kind: Delete, Text: a[x-1]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	ops := make([]Op, len(reversed))
+	for i, op := range reversed {
+		ops[len(reversed)-1-i] = op
+	}
+	return ops
+}
+
+// Unified unified diff形式の文字列を生成する
+// 差分がない場合は空文字列を返す
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	ops := Lines(SplitLines(oldText), SplitLines(newText))
+
+	hasChange := false
+	for _, op := range ops {
+		if op.Kind != Equal {
+			hasChange = true
+			break
+		}
+	}
+	if !hasChange {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n", oldName)
+	fmt.Fprintf(&sb, "+++ %s\n", newName)
+
+	// 各操作の直前までに消費した旧/新の行数
+	oldPos := make([]int, len(ops)+1)
+	newPos := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldPos[i+1] = oldPos[i]
+		newPos[i+1] = newPos[i]
+		if op.Kind != Insert {
+			oldPos[i+1]++
+		}
+		if op.Kind != Delete {
+			newPos[i+1]++
+		}
+	}
+
+	i := 0
+	for i < len(ops) {
+		for i < len(ops) && ops[i].Kind == Equal {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+
+		start := max(i-context, 0)
+
+		// 変更間の共通行が2*context以下なら同じハンクにまとめる
+		lastChange := i
+		j := i
+		for j < len(ops) {
+			if ops[j].Kind != Equal {
+				lastChange = j
+				j++
+				continue
+			}
+			run := 0
+			for j+run < len(ops) && ops[j+run].Kind == Equal {
+				run++
+			}
+			if j+run == len(ops) || run > 2*context {
+				break
+			}
+			j += run
+		}
+		end := min(lastChange+1+context, len(ops))
+
+		oldCount := oldPos[end] - oldPos[start]
+		newCount := newPos[end] - newPos[start]
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(oldPos[start], oldCount),
+			hunkRange(newPos[start], newCount))
+
+		for _, op := range ops[start:end] {
+			switch op.Kind {
+			case Equal:
+				sb.WriteString(" ")
+			case Delete:
+				sb.WriteString("-")
+			case Insert:
+				sb.WriteString("+")
+			}
+			sb.WriteString(op.Text)
+			sb.WriteString("\n")
+		}
+
+		i = end
+	}
+
+	return sb.String()
+}
+
+// hunkRange ハンクヘッダーの範囲表記を生成(GNU diff互換)
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	default:
+		return fmt.Sprintf("%d,%d", before+1, count)
+	}
+}
@@ -0,0 +1,132 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/diff"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "trailing newline",
+			input:    "a\nb\n",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "crlf",
+			input:    "a\r\nb",
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diff.SplitLines(tt.input))
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"a", "c", "d", "e"}
+
+	ops := diff.Lines(a, b)
+
+	var kinds []diff.OpKind
+	for _, op := range ops {
+		kinds = append(kinds, op.Kind)
+	}
+	assert.Equal(t, []diff.OpKind{diff.Equal, diff.Delete, diff.Equal, diff.Equal, diff.Insert}, kinds)
+
+	// 行番号
+	assert.Equal(t, 2, ops[1].OldLine)
+	assert.Equal(t, 0, ops[1].NewLine)
+	assert.Equal(t, 3, ops[2].OldLine)
+	assert.Equal(t, 2, ops[2].NewLine)
+	assert.Equal(t, 4, ops[4].NewLine)
+}
+
+func TestLines_Reconstructs(t *testing.T) {
+	a := diff.SplitLines("one\ntwo\nthree\nfour\nfive\nsix")
+	b := diff.SplitLines("zero\none\nthree\nFOUR\nfive\nsix\nseven")
+
+	var gotOld, gotNew []string
+	for _, op := range diff.Lines(a, b) {
+		if op.Kind != diff.Insert {
+			gotOld = append(gotOld, op.Text)
+		}
+		if op.Kind != diff.Delete {
+			gotNew = append(gotNew, op.Text)
+		}
+	}
+
+	assert.Equal(t, a, gotOld)
+	assert.Equal(t, b, gotNew)
+}
+
+func TestLineMap(t *testing.T) {
+	a := diff.SplitLines("one\ntwo\nthree\nfour")
+	b := diff.SplitLines("zero\none\nthree\nFOUR")
+
+	// two・fourは削除(変更)され、one・threeは1行ずつ後ろにずれる
+	assert.Equal(t, []int{2, 0, 3, 0}, diff.LineMap(diff.Lines(a, b)))
+}
+
+func TestUnified(t *testing.T) {
+	oldText := "line1\nline2\nline3\nline4\nline5\nline6\nline7\nline8\nline9\nline10\n"
+	newText := "line1\nline2 changed\nline3\nline4\nline5\nline6\nline7\nline8\nline9\nline10\nline11\n"
+
+	result := diff.Unified("a", "b", oldText, newText, 3)
+
+	expected := "--- a\n" +
+		"+++ b\n" +
+		"@@ -1,5 +1,5 @@\n" +
+		" line1\n" +
+		"-line2\n" +
+		"+line2 changed\n" +
+		" line3\n" +
+		" line4\n" +
+		" line5\n" +
+		"@@ -8,3 +8,4 @@\n" +
+		" line8\n" +
+		" line9\n" +
+		" line10\n" +
+		"+line11\n"
+	assert.Equal(t, expected, result)
+}
+
+func TestUnified_NoChanges(t *testing.T) {
+	assert.Empty(t, diff.Unified("a", "b", "same\ntext", "same\ntext", 3))
+}
+
+func TestUnified_FromEmpty(t *testing.T) {
+	result := diff.Unified("a", "b", "", "new\n", 3)
+	assert.Contains(t, result, "@@ -0,0 +1 @@\n+new\n")
+}
+
+func TestUnified_LargeRewrite(t *testing.T) {
+	// 上限を超える編集距離でも全置換として差分を生成できる
+	var oldLines, newLines []string
+	for i := 0; i < 2000; i++ {
+		oldLines = append(oldLines, "old")
+		newLines = append(newLines, "new")
+	}
+
+	result := diff.Unified("a", "b", strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"), 3)
+	assert.Contains(t, result, "@@ -1,2000 +1,2000 @@")
+	assert.Equal(t, 2000, strings.Count(result, "\n-old"))
+	assert.Equal(t, 2000, strings.Count(result, "\n+new"))
+}
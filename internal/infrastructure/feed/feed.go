@@ -0,0 +1,310 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Format フィードの形式
+type Format string
+
+const (
+	FormatRSS  Format = "rss"
+	FormatAtom Format = "atom"
+	FormatJSON Format = "json"
+)
+
+// ContentType 形式ごとのContent-Type
+func (f Format) ContentType() string {
+	switch f {
+	case FormatRSS:
+		return "application/rss+xml; charset=utf-8"
+	case FormatAtom:
+		return "application/atom+xml; charset=utf-8"
+	case FormatJSON:
+		return "application/feed+json; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// ParseFormat 文字列からフィード形式を取得
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatRSS, FormatAtom, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported feed format: %s", s)
+	}
+}
+
+// Feed 形式に依存しないフィードの内容
+type Feed struct {
+	Title       string
+	Description string
+	// Link サイト(またはカテゴリ等の一覧ページ)のURL
+	Link string
+	// FeedURL このフィード自身のURL
+	FeedURL string
+	Updated time.Time
+	Items   []*Item
+}
+
+// Item フィードの項目
+type Item struct {
+	// ID 項目を一意に識別するURL(パーマリンク)
+	ID          string
+	Title       string
+	Link        string
+	ContentHTML string
+	Summary     string
+	Author      string
+	Categories  []string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// Render 指定された形式でフィードを出力
+func Render(f *Feed, format Format) ([]byte, error) {
+	switch format {
+	case FormatRSS:
+		return RSS(f)
+	case FormatAtom:
+		return Atom(f)
+	case FormatJSON:
+		return JSON(f)
+	default:
+		return nil, fmt.Errorf("unsupported feed format: %s", format)
+	}
+}
+
+// rss RSS 2.0のルート要素
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Author      string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"content:encoded"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS RSS 2.0形式で出力
+func RSS(f *Feed) ([]byte, error) {
+	doc := rss{
+		Version:   "2.0",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			AtomLink:      rssLink{Href: f.FeedURL, Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, len(f.Items)),
+		},
+	}
+
+	for i, item := range f.Items {
+		doc.Channel.Items[i] = rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: item.ID == item.Link, Value: item.ID},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Author:      item.Author,
+			Categories:  item.Categories,
+			Description: item.Summary,
+			Content:     item.ContentHTML,
+		}
+	}
+
+	return marshalXML(doc)
+}
+
+// atomFeed Atom 1.0のルート要素
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomPerson     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    atomText       `xml:"content"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom Atom 1.0形式で出力
+func Atom(f *Feed) ([]byte, error) {
+	doc := atomFeed{
+		Title:    f.Title,
+		Subtitle: f.Description,
+		ID:       f.FeedURL,
+		Updated:  f.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+			{Href: f.FeedURL, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, len(f.Items)),
+	}
+
+	for i, item := range f.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Author:    atomPerson{Name: item.Author},
+			Content:   atomText{Type: "html", Value: item.ContentHTML},
+		}
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+		doc.Entries[i] = entry
+	}
+
+	return marshalXML(doc)
+}
+
+// jsonFeed JSON Feed 1.1のルート要素
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Description string     `json:"description,omitempty"`
+	Language    string     `json:"language"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	ContentHTML   string       `json:"content_html"`
+	Summary       string       `json:"summary,omitempty"`
+	DatePublished string       `json:"date_published"`
+	DateModified  string       `json:"date_modified"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+// JSON JSON Feed 1.1形式で出力
+func JSON(f *Feed) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedURL,
+		Description: f.Description,
+		Language:    "ja",
+		Items:       make([]jsonItem, len(f.Items)),
+	}
+
+	for i, item := range f.Items {
+		jsonItem := jsonItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.ContentHTML,
+			Summary:       item.Summary,
+			DatePublished: item.Published.UTC().Format(time.RFC3339),
+			DateModified:  item.Updated.UTC().Format(time.RFC3339),
+			Tags:          item.Categories,
+		}
+		if item.Author != "" {
+			jsonItem.Authors = []jsonAuthor{{Name: item.Author}}
+		}
+		doc.Items[i] = jsonItem
+	}
+
+	// content_htmlを読みやすく保つため、HTMLの特殊文字は\uエスケープしない
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal json feed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalXML XML宣言付きで出力
+func marshalXML(v interface{}) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal feed: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
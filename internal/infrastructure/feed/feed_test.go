@@ -0,0 +1,147 @@
+package feed_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/infrastructure/feed"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFeed() *feed.Feed {
+	published := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	return &feed.Feed{
+		Title:       "Test Blog",
+		Description: "テスト用のブログ",
+		Link:        "https://example.com/",
+		FeedURL:     "https://example.com/feed/rss",
+		Updated:     updated,
+		Items: []*feed.Item{
+			{
+				ID:          "https://example.com/posts/hello",
+				Title:       "Hello & Welcome",
+				Link:        "https://example.com/posts/hello",
+				ContentHTML: "<p>Hello <strong>world</strong></p>",
+				Summary:     "Hello world",
+				Author:      "alice",
+				Categories:  []string{"Go"},
+				Published:   published,
+				Updated:     updated,
+			},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"rss", "atom", "json"} {
+		f, err := feed.ParseFormat(s)
+		require.NoError(t, err)
+		assert.Equal(t, feed.Format(s), f)
+	}
+
+	_, err := feed.ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestRSS(t *testing.T) {
+	data, err := feed.RSS(newTestFeed())
+	require.NoError(t, err)
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string `xml:"title"`
+				GUID        string `xml:"guid"`
+				PubDate     string `xml:"pubDate"`
+				Description string `xml:"description"`
+				Content     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+				Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "Test Blog", doc.Channel.Title)
+	assert.Equal(t, "Fri, 03 Jan 2025 00:00:00 +0000", doc.Channel.LastBuildDate)
+	require.Len(t, doc.Channel.Items, 1)
+
+	item := doc.Channel.Items[0]
+	assert.Equal(t, "Hello & Welcome", item.Title)
+	assert.Equal(t, "https://example.com/posts/hello", item.GUID)
+	assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 +0000", item.PubDate)
+	assert.Equal(t, "Hello world", item.Description)
+	assert.Equal(t, "<p>Hello <strong>world</strong></p>", item.Content)
+	assert.Equal(t, "alice", item.Creator)
+}
+
+func TestAtom(t *testing.T) {
+	data, err := feed.Atom(newTestFeed())
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Published string `xml:"published"`
+			Updated   string `xml:"updated"`
+			Author    string `xml:"author>name"`
+			Content   struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+
+	assert.Equal(t, "https://example.com/feed/rss", doc.ID)
+	assert.Equal(t, "2025-01-03T00:00:00Z", doc.Updated)
+	require.Len(t, doc.Entries, 1)
+
+	entry := doc.Entries[0]
+	assert.Equal(t, "https://example.com/posts/hello", entry.ID)
+	assert.Equal(t, "2025-01-02T03:04:05Z", entry.Published)
+	assert.Equal(t, "2025-01-03T00:00:00Z", entry.Updated)
+	assert.Equal(t, "alice", entry.Author)
+	assert.Equal(t, "html", entry.Content.Type)
+	assert.Equal(t, "<p>Hello <strong>world</strong></p>", entry.Content.Value)
+}
+
+func TestJSON(t *testing.T) {
+	data, err := feed.JSON(newTestFeed())
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+	assert.Equal(t, "https://example.com/", doc["home_page_url"])
+
+	items := doc["items"].([]interface{})
+	require.Len(t, items, 1)
+	item := items[0].(map[string]interface{})
+	assert.Equal(t, "https://example.com/posts/hello", item["id"])
+	assert.Equal(t, "<p>Hello <strong>world</strong></p>", item["content_html"])
+	assert.Equal(t, "2025-01-02T03:04:05Z", item["date_published"])
+	assert.Equal(t, "2025-01-03T00:00:00Z", item["date_modified"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "alice"}}, item["authors"])
+}
+
+func TestJSON_EmptyItems(t *testing.T) {
+	f := newTestFeed()
+	f.Items = nil
+
+	data, err := feed.JSON(f)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"items": []`)
+}
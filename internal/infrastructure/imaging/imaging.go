@@ -0,0 +1,74 @@
+package imaging
+
+import (
+	"image"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Resize 縦横比を保ったまま指定した幅に縮小した画像を返す
+func Resize(img image.Image, width int) *image.RGBA {
+	bounds := img.Bounds()
+	height := (bounds.Dy()*width + bounds.Dx()/2) / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, xdraw.Src, nil)
+	return dst
+}
+
+// HasAlpha 画像に透過部分があるか
+func HasAlpha(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return !opaque.Opaque()
+	}
+	return true
+}
+
+// ApplyOrientation EXIFのOrientation(1〜8)に従って画像を回転・反転する
+// 1または範囲外の値の場合は元の画像をそのまま返す
+func ApplyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		// 5〜8は90度単位の回転を含むため幅と高さが入れ替わる
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 左右反転
+				dx, dy = w-1-x, y
+			case 3: // 180度回転
+				dx, dy = w-1-x, h-1-y
+			case 4: // 上下反転
+				dx, dy = x, h-1-y
+			case 5: // 左上と右下を結ぶ対角線で反転
+				dx, dy = y, x
+			case 6: // 時計回りに90度回転
+				dx, dy = h-1-y, x
+			case 7: // 右上と左下を結ぶ対角線で反転
+				dx, dy = h-1-y, w-1-x
+			case 8: // 反時計回りに90度回転
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+
+	return dst
+}
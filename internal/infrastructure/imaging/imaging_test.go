@@ -0,0 +1,69 @@
+package imaging_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/imaging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cornerImage 左上だけ赤い不透明な画像を生成
+func cornerImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	return img
+}
+
+func TestResize(t *testing.T) {
+	resized := imaging.Resize(cornerImage(1000, 750), 320)
+	assert.Equal(t, 320, resized.Bounds().Dx())
+	assert.Equal(t, 240, resized.Bounds().Dy())
+
+	// 極端に横長でも高さは1px以上
+	resized = imaging.Resize(cornerImage(2000, 1), 320)
+	assert.Equal(t, 1, resized.Bounds().Dy())
+}
+
+func TestHasAlpha(t *testing.T) {
+	assert.False(t, imaging.HasAlpha(cornerImage(2, 2)))
+	assert.True(t, imaging.HasAlpha(image.NewRGBA(image.Rect(0, 0, 2, 2))))
+}
+
+func TestApplyOrientation(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		// redX, redY 元画像の左上の画素の移動先
+		redX int
+		redY int
+	}{
+		{orientation: 1, width: 3, height: 2, redX: 0, redY: 0},
+		{orientation: 2, width: 3, height: 2, redX: 2, redY: 0},
+		{orientation: 3, width: 3, height: 2, redX: 2, redY: 1},
+		{orientation: 4, width: 3, height: 2, redX: 0, redY: 1},
+		{orientation: 5, width: 2, height: 3, redX: 0, redY: 0},
+		{orientation: 6, width: 2, height: 3, redX: 1, redY: 0},
+		{orientation: 7, width: 2, height: 3, redX: 1, redY: 2},
+		{orientation: 8, width: 2, height: 3, redX: 0, redY: 2},
+		{orientation: 9, width: 3, height: 2, redX: 0, redY: 0},
+	}
+
+	for _, tt := range tests {
+		oriented := imaging.ApplyOrientation(cornerImage(3, 2), tt.orientation)
+		bounds := oriented.Bounds()
+		assert.Equal(t, tt.width, bounds.Dx(), "orientation %d", tt.orientation)
+		assert.Equal(t, tt.height, bounds.Dy(), "orientation %d", tt.orientation)
+		assert.Equal(t, red, color.RGBAModel.Convert(oriented.At(bounds.Min.X+tt.redX, bounds.Min.Y+tt.redY)), "orientation %d", tt.orientation)
+	}
+}
@@ -0,0 +1,227 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// pngSignature PNGファイルの先頭8バイト
+const pngSignature = "\x89PNG\r\n\x1a\n"
+
+// pngMetadataChunks 除去するPNGのチャンク(テキスト・EXIF・更新日時)
+var pngMetadataChunks = map[string]bool{
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"eXIf": true,
+	"tIME": true,
+}
+
+// WebPのVP8Xチャンクのフラグ
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// JPEGOrientation JPEGのEXIFからOrientationを取得(見つからない場合は1)
+func JPEGOrientation(data []byte) int {
+	orientation := 1
+	_, _ = walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+		if marker != 0xE1 || len(segment) < 4 {
+			return true
+		}
+		payload := segment[4:]
+		if !bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			return true
+		}
+		orientation = exifOrientation(payload[6:])
+		return false
+	})
+	return orientation
+}
+
+// exifOrientation TIFF形式のEXIFデータのIFD0からOrientation(タグ0x0112)を取得
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != 0x0112 {
+			continue
+		}
+		// 型はSHORT(3)で、値はエントリの値フィールドの先頭2バイトに格納される
+		if order.Uint16(tiff[entry+2:]) != 3 {
+			return 1
+		}
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+
+	return 1
+}
+
+// StripJPEGMetadata JPEGからEXIF・XMP・IPTC・コメントを取り除く(画像データは再圧縮しない)
+// 色の解釈に必要なJFIF・ICCプロファイル・Adobeセグメントは残す
+func StripJPEGMetadata(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+
+	pos, err := walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+		switch marker {
+		case 0xE1, 0xED, 0xFE: // APP1(EXIF・XMP)、APP13(IPTC)、COM
+		default:
+			out = append(out, segment...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, data[pos:]...), nil
+}
+
+// walkJPEGSegments 画像データ(SOS)より前のJPEGのセグメントを順にfnに渡す
+// fnがfalseを返した場合は中断する。戻り値は最後に読んだセグメントの次の位置
+func walkJPEGSegments(data []byte, fn func(marker byte, segment []byte) bool) (int, error) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, fmt.Errorf("not a JPEG image")
+	}
+
+	pos := 2
+	for {
+		if pos+2 > len(data) || data[pos] != 0xFF {
+			return 0, fmt.Errorf("invalid JPEG segment at %d", pos)
+		}
+
+		marker := data[pos+1]
+		switch {
+		case marker == 0xFF:
+			// マーカー前の埋め草
+			pos++
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			// SOS以降は画像データ
+			return pos, nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// 長さを持たないマーカー
+			if !fn(marker, data[pos:pos+2]) {
+				return pos + 2, nil
+			}
+			pos += 2
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return 0, fmt.Errorf("invalid JPEG segment at %d", pos)
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return 0, fmt.Errorf("invalid JPEG segment length at %d", pos)
+		}
+
+		if !fn(marker, data[pos:end]) {
+			return end, nil
+		}
+		pos = end
+	}
+}
+
+// StripPNGMetadata PNGからテキスト・EXIF・更新日時のチャンクを取り除く(画像データは再圧縮しない)
+func StripPNGMetadata(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(pngSignature)) {
+		return nil, fmt.Errorf("not a PNG image")
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+
+	pos := len(pngSignature)
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("invalid PNG chunk length at %d", pos)
+		}
+
+		chunkType := string(data[pos+4 : pos+8])
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+
+		if chunkType == "IEND" {
+			return out, nil
+		}
+	}
+
+	return nil, fmt.Errorf("PNG image is truncated")
+}
+
+// StripWebPMetadata WebPからEXIF・XMPのチャンクを取り除く(画像データは再圧縮しない)
+func StripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("not a WebP image")
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:12]...)
+
+	pos := 12
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("invalid WebP chunk at %d", pos)
+		}
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		// チャンクは偶数バイトに揃えられる
+		end := pos + 8 + size + size&1
+		if size < 0 || end > len(data) {
+			return nil, fmt.Errorf("invalid WebP chunk length at %d", pos)
+		}
+
+		switch string(data[pos : pos+4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[pos:end]...)
+			if size > 0 {
+				out[start+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
@@ -0,0 +1,140 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/imaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exifSegment Orientationのみを持つEXIF(APP1)セグメントを生成
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)           // エントリ数
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)      // Orientation
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)           // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)           // 個数
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation) // 値
+	tiff = append(tiff, 0, 0)
+	tiff = binary.BigEndian.AppendUint32(tiff, 0) // 次のIFDなし
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// testJPEG EXIFを埋め込んだJPEGを生成
+func testJPEG(t *testing.T, orientation uint16) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, cornerImage(8, 4), nil))
+	data := buf.Bytes()
+
+	withExif := append([]byte{}, data[:2]...)
+	withExif = append(withExif, exifSegment(orientation)...)
+	return append(withExif, data[2:]...)
+}
+
+// pngChunk PNGのチャンクを生成
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// riffChunk WebP(RIFF)のチャンクを生成
+func riffChunk(fourCC string, data []byte) []byte {
+	chunk := append([]byte(fourCC), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	assert.Equal(t, 6, imaging.JPEGOrientation(testJPEG(t, 6)))
+	assert.Equal(t, 1, imaging.JPEGOrientation(testJPEG(t, 0)))
+	assert.Equal(t, 1, imaging.JPEGOrientation([]byte("not a jpeg")))
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, cornerImage(8, 4), nil))
+	assert.Equal(t, 1, imaging.JPEGOrientation(buf.Bytes()))
+}
+
+func TestStripJPEGMetadata(t *testing.T) {
+	data := testJPEG(t, 6)
+
+	stripped, err := imaging.StripJPEGMetadata(data)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stripped), "Exif")
+	assert.Equal(t, 1, imaging.JPEGOrientation(stripped))
+
+	// 画像データはそのまま
+	img, err := jpeg.Decode(bytes.NewReader(stripped))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 8, 4), img.Bounds())
+
+	_, err = imaging.StripJPEGMetadata([]byte("not a jpeg"))
+	assert.Error(t, err)
+	_, err = imaging.StripJPEGMetadata(data[:30])
+	assert.Error(t, err)
+}
+
+func TestStripPNGMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, cornerImage(4, 4)))
+	data := buf.Bytes()
+
+	// IENDの直前にテキストとEXIFのチャンクを挿入
+	iend := len(data) - 12
+	withMeta := append([]byte{}, data[:iend]...)
+	withMeta = append(withMeta, pngChunk("tEXt", []byte("Comment\x00secret"))...)
+	withMeta = append(withMeta, pngChunk("eXIf", []byte("MM\x00\x2a"))...)
+	withMeta = append(withMeta, data[iend:]...)
+
+	stripped, err := imaging.StripPNGMetadata(withMeta)
+	require.NoError(t, err)
+	assert.Equal(t, data, stripped)
+
+	_, err = png.Decode(bytes.NewReader(stripped))
+	require.NoError(t, err)
+
+	_, err = imaging.StripPNGMetadata(data[:len(data)-12])
+	assert.Error(t, err)
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	vp8x := []byte{0x08 | 0x04 | 0x10, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	body := []byte("WEBP")
+	body = append(body, riffChunk("VP8X", vp8x)...)
+	body = append(body, riffChunk("VP8L", []byte{1, 2, 3})...)
+	body = append(body, riffChunk("EXIF", []byte("exif"))...)
+	body = append(body, riffChunk("XMP ", []byte("<xmp/>x"))...)
+	data := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	data = append(data, body...)
+
+	stripped, err := imaging.StripWebPMetadata(data)
+	require.NoError(t, err)
+
+	want := []byte("WEBP")
+	want = append(want, riffChunk("VP8X", []byte{0x10, 0, 0, 0, 0, 0, 0, 0, 0, 0})...)
+	want = append(want, riffChunk("VP8L", []byte{1, 2, 3})...)
+	assert.Equal(t, append(append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(want)))...), want...), stripped)
+
+	_, err = imaging.StripWebPMetadata([]byte("RIFF\x00\x00\x00\x00WAVE"))
+	assert.Error(t, err)
+	_, err = imaging.StripWebPMetadata(data[:len(data)-4])
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package imaging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+// WebPEncoder 画像をWebPにエンコードするインターフェース
+type WebPEncoder interface {
+	EncodeWebP(ctx context.Context, img image.Image) ([]byte, error)
+}
+
+// cwebpEncoder cwebpコマンドを使ったWebPEncoderの実装
+type cwebpEncoder struct {
+	path    string
+	quality int
+	tmpDir  string
+}
+
+// NewCWebPEncoder cwebpコマンドを使うWebPEncoderを作成(コマンドが見つからない場合はエラー)
+func NewCWebPEncoder(command string, quality int) (WebPEncoder, error) {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s: %w", command, err)
+	}
+
+	return &cwebpEncoder{
+		path:    path,
+		quality: quality,
+		tmpDir:  os.TempDir(),
+	}, nil
+}
+
+// EncodeWebP 画像をWebPにエンコード(メタデータは含めない)
+func (e *cwebpEncoder) EncodeWebP(ctx context.Context, img image.Image) ([]byte, error) {
+	dir, err := os.MkdirTemp(e.tmpDir, "cwebp-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	// 透過を保つため入力はPNGで渡す
+	var input bytes.Buffer
+	if err := png.Encode(&input, img); err != nil {
+		return nil, fmt.Errorf("failed to encode input image: %w", err)
+	}
+
+	inputFile := filepath.Join(dir, "input.png")
+	outputFile := filepath.Join(dir, "output.webp")
+	if err := os.WriteFile(inputFile, input.Bytes(), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write input file: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, e.path,
+		"-quiet",
+		"-q", strconv.Itoa(e.quality),
+		"-metadata", "none",
+		inputFile,
+		"-o", outputFile,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to execute cwebp: %w, stderr: %s", err, stderr.String())
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output file: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,36 @@
+package imaging_test
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/imaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+func TestNewCWebPEncoder_NotFound(t *testing.T) {
+	_, err := imaging.NewCWebPEncoder("cwebp-command-that-does-not-exist", 80)
+	assert.Error(t, err)
+}
+
+func TestCWebPEncoder_EncodeWebP(t *testing.T) {
+	if _, err := exec.LookPath("cwebp"); err != nil {
+		t.Skip("cwebp is not installed")
+	}
+
+	encoder, err := imaging.NewCWebPEncoder("cwebp", 80)
+	require.NoError(t, err)
+
+	data, err := encoder.EncodeWebP(context.Background(), cornerImage(16, 8))
+	require.NoError(t, err)
+
+	config, err := webp.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 16, config.Width)
+	assert.Equal(t, 8, config.Height)
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logMailer メールを送信せずログに出力するMailerの実装(開発用)
+type logMailer struct {
+	logger *slog.Logger
+}
+
+// NewLogMailer 新しいログ出力のMailerを作成
+// 本文(招待トークンなどを含む)もログに出力するため、本番環境では使用しない
+func NewLogMailer(logger *slog.Logger) Mailer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &logMailer{logger: logger}
+}
+
+// Send メールの内容をログに出力
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	m.logger.InfoContext(ctx, "Mail not sent (log mailer)",
+		"to", msg.To,
+		"subject", msg.Subject,
+		"body", msg.Body,
+	)
+	return nil
+}
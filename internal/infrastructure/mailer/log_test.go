@@ -0,0 +1,32 @@
+package mailer_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogMailer_Send(t *testing.T) {
+	var buf bytes.Buffer
+	m := mailer.NewLogMailer(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	err := m.Send(context.Background(), &mailer.Message{
+		To:      "invitee@example.com",
+		Subject: "Invitation",
+		Body:    "token=abc",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "to=invitee@example.com")
+	assert.Contains(t, buf.String(), "subject=Invitation")
+	assert.Contains(t, buf.String(), `body="token=abc"`)
+
+	err = m.Send(context.Background(), &mailer.Message{To: "not an address"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Mailer メール送信のインターフェース
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Message 送信するメール(本文はプレーンテキスト)
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// validate メールの宛先と件名を検証
+// ヘッダーインジェクションを防ぐため、改行を含む宛先・件名は拒否する
+func (m *Message) validate() error {
+	if _, err := mail.ParseAddress(m.To); err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+	if strings.ContainsAny(m.To, "\r\n") || strings.ContainsAny(m.Subject, "\r\n") {
+		return fmt.Errorf("invalid message header: contains line break")
+	}
+	return nil
+}
+
+// build RFC 5322形式のメールを組み立てる
+// 件名はMIMEエンコードワード、本文はquoted-printableでエンコードする(日本語を含むため)
+func (m *Message) build(from string, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", from},
+		{"To", m.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h[0], h[1])
+	}
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(m.Body, "\n", "\r\n"))); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,122 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// smtpTimeout SMTPサーバーとのやり取り全体のタイムアウト(コンテキストに期限がない場合)
+const smtpTimeout = 30 * time.Second
+
+// SMTPConfig SMTPサーバーの設定
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From 送信元(表示名付きも可)
+	From string
+}
+
+// smtpMailer SMTPでメールを送信するMailerの実装
+type smtpMailer struct {
+	config SMTPConfig
+	from   *mail.Address
+}
+
+// NewSMTPMailer 新しいSMTPのMailerを作成
+// サーバーがSTARTTLSに対応している場合は暗号化し、ユーザー名が設定されている場合はPLAIN認証を行う
+func NewSMTPMailer(config SMTPConfig) (Mailer, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	return &smtpMailer{config: config, from: from}, nil
+}
+
+// Send メールを送信
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+	to, _ := mail.ParseAddress(msg.To)
+
+	data, err := msg.build(m.from.String(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return fmt.Errorf("failed to set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if m.config.Username != "" {
+		// smtp.PlainAuthはTLSで保護されていない接続(localhostを除く)での認証を拒否する
+		auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate with smtp server: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.from.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("failed to close smtp session: %w", err)
+	}
+
+	return nil
+}
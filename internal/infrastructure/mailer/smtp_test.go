@@ -0,0 +1,153 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer 受信したコマンドとメールを記録するテスト用のSMTPサーバー
+type fakeSMTPServer struct {
+	listener net.Listener
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+// startFakeSMTPServer テスト用のSMTPサーバーを起動(1回の接続のみ処理する)
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	s := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP fake")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.commands = append(s.commands, line)
+
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL", "RCPT":
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.data = data.String()
+			reply("250 OK: queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	server := startFakeSMTPServer(t)
+
+	m, err := mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		Username: "user",
+		Password: "secret",
+		From:     "Blog Engine <noreply@example.com>",
+	})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), &mailer.Message{
+		To:      "invitee@example.com",
+		Subject: "ブログへの招待",
+		Body:    "こんにちは\n招待URL: https://example.com/accept?token=abc",
+	})
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Contains(t, server.commands, "MAIL FROM:<noreply@example.com>")
+	assert.Contains(t, server.commands, "RCPT TO:<invitee@example.com>")
+	assert.Contains(t, strings.Join(server.commands, "\n"), "AUTH PLAIN")
+
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "ブログへの招待", subject)
+	assert.Equal(t, "invitee@example.com", msg.Header.Get("To"))
+	assert.Equal(t, `"Blog Engine" <noreply@example.com>`, msg.Header.Get("From"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	require.NoError(t, err)
+	assert.Equal(t, "こんにちは\r\n招待URL: https://example.com/accept?token=abc\r\n", string(body))
+}
+
+func TestSMTPMailer_InvalidMessage(t *testing.T) {
+	m, err := mailer.NewSMTPMailer(mailer.SMTPConfig{Host: "127.0.0.1", Port: 1, From: "noreply@example.com"})
+	require.NoError(t, err)
+
+	// 接続前に拒否する
+	err = m.Send(context.Background(), &mailer.Message{To: "invitee@example.com\r\nBcc: victim@example.com", Subject: "hi"})
+	assert.Error(t, err)
+	err = m.Send(context.Background(), &mailer.Message{To: "invitee@example.com", Subject: "hi\r\nBcc: victim@example.com"})
+	assert.Error(t, err)
+}
+
+func TestNewSMTPMailer_InvalidConfig(t *testing.T) {
+	_, err := mailer.NewSMTPMailer(mailer.SMTPConfig{From: "noreply@example.com"})
+	assert.Error(t, err)
+
+	_, err = mailer.NewSMTPMailer(mailer.SMTPConfig{Host: "localhost", From: "not an address"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// auditEventRepositoryImpl AuditEventRepositoryの実装
+type auditEventRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewAuditEventRepository 新しいAuditEventRepositoryを作成
+func NewAuditEventRepository(db *bun.DB) repository.AuditEventRepository {
+	return &auditEventRepositoryImpl{db: db}
+}
+
+// Create 監査ログを記録
+func (r *auditEventRepositoryImpl) Create(ctx context.Context, event *entity.AuditEvent) error {
+	_, err := r.db.NewInsert().
+		Model(event).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// List 監査ログをIDの降順で取得(beforeIDが0より大きい場合はそれより古いものから取得)
+func (r *auditEventRepositoryImpl) List(ctx context.Context, filters repository.AuditEventFilters, beforeID int64, limit int) ([]*entity.AuditEvent, error) {
+	events := make([]*entity.AuditEvent, 0)
+	query := r.db.NewSelect().
+		Model(&events)
+	applyAuditEventFilters(query, filters)
+
+	if beforeID > 0 {
+		query.Where("ae.id < ?", beforeID)
+	}
+
+	err := query.
+		Order("ae.id DESC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, nil
+}
+
+// DeleteBefore 記録日時がbeforeより前の監査ログを最大limit件削除し、削除した件数を返す
+func (r *auditEventRepositoryImpl) DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.AuditEvent)(nil)).
+		Where("created_at < ?", before).
+		OrderExpr("id ASC").
+		Limit(limit).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete audit events: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete audit events: %w", err)
+	}
+
+	return int(affected), nil
+}
+
+// applyAuditEventFilters 監査ログ一覧の絞り込み条件をクエリに追加
+func applyAuditEventFilters(query *bun.SelectQuery, filters repository.AuditEventFilters) {
+	if filters.ActorID != nil {
+		query.Where("ae.actor_id = ?", *filters.ActorID)
+	}
+	if filters.Action != "" {
+		query.Where("ae.action = ?", filters.Action)
+	}
+	if filters.TargetType != "" {
+		query.Where("ae.target_type = ?", filters.TargetType)
+	}
+	if filters.TargetID != nil {
+		query.Where("ae.target_id = ?", *filters.TargetID)
+	}
+	if filters.From != nil {
+		query.Where("ae.created_at >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query.Where("ae.created_at < ?", *filters.To)
+	}
+}
@@ -0,0 +1,108 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventRepository_CreateAndList(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	user := &entity.User{
+		Username:     "admin",
+		Email:        "admin@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleAdmin,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(ctx, user))
+
+	postID, categoryID := int64(10), int64(20)
+	repo := persistence.NewAuditEventRepository(db)
+	events := []*entity.AuditEvent{
+		{ActorID: &user.ID, ActorName: "admin", Action: entity.AuditPostCreate, TargetType: entity.AuditTargetPost, TargetID: &postID, After: map[string]any{"title": "Hello"}},
+		{ActorID: &user.ID, ActorName: "admin", Action: entity.AuditPostUpdate, TargetType: entity.AuditTargetPost, TargetID: &postID, Before: map[string]any{"title": "Hello"}, After: map[string]any{"title": "Hello, world"}},
+		{Action: entity.AuditPostPublish, TargetType: entity.AuditTargetPost, TargetID: &postID},
+		{ActorID: &user.ID, ActorName: "admin", Action: entity.AuditCategoryCreate, TargetType: entity.AuditTargetCategory, TargetID: &categoryID, IPAddress: "192.0.2.1"},
+	}
+	for _, event := range events {
+		require.NoError(t, repo.Create(ctx, event))
+		assert.NotZero(t, event.ID)
+	}
+
+	list, err := repo.List(ctx, repository.AuditEventFilters{}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 4)
+	assert.Equal(t, entity.AuditCategoryCreate, list[0].Action)
+	assert.Equal(t, "192.0.2.1", list[0].IPAddress)
+	assert.Nil(t, list[1].ActorID)
+	assert.Nil(t, list[1].Before)
+	assert.Equal(t, map[string]any{"title": "Hello"}, list[2].Before)
+	assert.Equal(t, map[string]any{"title": "Hello, world"}, list[2].After)
+
+	// beforeIDより古いものから取得
+	older, err := repo.List(ctx, repository.AuditEventFilters{}, list[1].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, older, 2)
+	assert.Equal(t, list[2].ID, older[0].ID)
+
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name    string
+		filters repository.AuditEventFilters
+		want    int
+	}{
+		{"操作したユーザーで絞り込み", repository.AuditEventFilters{ActorID: &user.ID}, 3},
+		{"操作で絞り込み", repository.AuditEventFilters{Action: entity.AuditPostUpdate}, 1},
+		{"対象で絞り込み", repository.AuditEventFilters{TargetType: entity.AuditTargetPost, TargetID: &postID}, 3},
+		{"記録日時で絞り込み", repository.AuditEventFilters{From: &future}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := repo.List(ctx, tt.filters, 0, 10)
+			require.NoError(t, err)
+			assert.Len(t, list, tt.want)
+		})
+	}
+}
+
+func TestAuditEventRepository_DeleteBefore(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := persistence.NewAuditEventRepository(db)
+
+	now := time.Now().Truncate(time.Second)
+	for _, createdAt := range []time.Time{now.Add(-72 * time.Hour), now.Add(-48 * time.Hour), now.Add(-48 * time.Hour), now} {
+		require.NoError(t, repo.Create(ctx, &entity.AuditEvent{
+			Action:     entity.AuditPostPublish,
+			TargetType: entity.AuditTargetPost,
+			CreatedAt:  createdAt,
+		}))
+	}
+
+	// 件数の上限を超える分は残す
+	deleted, err := repo.DeleteBefore(ctx, now.Add(-24*time.Hour), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	deleted, err = repo.DeleteBefore(ctx, now.Add(-24*time.Hour), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	list, err := repo.List(ctx, repository.AuditEventFilters{}, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// commentRepositoryImpl CommentRepositoryの実装
+type commentRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewCommentRepository 新しいCommentRepositoryを作成
+func NewCommentRepository(db *bun.DB) repository.CommentRepository {
+	return &commentRepositoryImpl{db: db}
+}
+
+// Create 新しいコメントを作成
+func (r *commentRepositoryImpl) Create(ctx context.Context, comment *entity.Comment) error {
+	_, err := r.db.NewInsert().
+		Model(comment).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID IDでコメントを検索
+func (r *commentRepositoryImpl) FindByID(ctx context.Context, id int64) (*entity.Comment, error) {
+	comment := new(entity.Comment)
+	err := r.db.NewSelect().
+		Model(comment).
+		Where("cm.id = ?", id).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("comment not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// ListByPost 記事のコメントのうち、指定したステータスのものを投稿順に取得
+func (r *commentRepositoryImpl) ListByPost(ctx context.Context, postID int64, statuses []entity.CommentStatus) ([]*entity.Comment, error) {
+	comments := make([]*entity.Comment, 0)
+	if len(statuses) == 0 {
+		return comments, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&comments).
+		Where("cm.post_id = ?", postID).
+		Where("cm.status IN (?)", bun.In(statuses)).
+		Order("cm.created_at ASC", "cm.id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments by post: %w", err)
+	}
+
+	return comments, nil
+}
+
+// List コメント一覧を新しい順に取得(記事を含む)
+func (r *commentRepositoryImpl) List(ctx context.Context, filters repository.CommentFilters, limit, offset int) ([]*entity.Comment, error) {
+	comments := make([]*entity.Comment, 0)
+	query := r.db.NewSelect().
+		Model(&comments).
+		Relation("Post", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Column("id", "title", "slug")
+		})
+	applyCommentFilters(query, filters)
+
+	err := query.
+		Order("cm.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// Count コメント数を取得
+func (r *commentRepositoryImpl) Count(ctx context.Context, filters repository.CommentFilters) (int, error) {
+	query := r.db.NewSelect().
+		Model((*entity.Comment)(nil))
+	applyCommentFilters(query, filters)
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	return count, nil
+}
+
+// applyCommentFilters コメント一覧の絞り込み条件をクエリに追加
+func applyCommentFilters(query *bun.SelectQuery, filters repository.CommentFilters) {
+	if filters.Status != "" {
+		query.Where("cm.status = ?", filters.Status)
+	}
+	if filters.PostID != nil {
+		query.Where("cm.post_id = ?", *filters.PostID)
+	}
+}
+
+// UpdateStatus 複数のコメントのステータスを一括で変更し、変更した件数を返す
+func (r *commentRepositoryImpl) UpdateStatus(ctx context.Context, ids []int64, status entity.CommentStatus) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.db.NewUpdate().
+		Model((*entity.Comment)(nil)).
+		Set("status = ?", status).
+		Set("updated_at = ?", time.Now()).
+		Where("id IN (?)", bun.In(ids)).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to update comment status: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(affected), nil
+}
+
+// Delete コメントを完全に削除(返信も削除される)
+func (r *commentRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.NewDelete().
+		Model((*entity.Comment)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
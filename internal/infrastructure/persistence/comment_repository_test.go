@@ -0,0 +1,155 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCommentTest(t *testing.T) (repository.CommentRepository, *entity.Post, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	post := &entity.Post{
+		Title:    "Test Post",
+		Slug:     "test-post",
+		Content:  "Test content",
+		Status:   entity.StatusPublished,
+		AuthorID: user.ID,
+	}
+	require.NoError(t, postRepo.Create(ctx, post))
+
+	return persistence.NewCommentRepository(db), post, cleanup
+}
+
+// newTestComment テスト用のコメントを作成
+func newTestComment(postID int64, parentID *int64, status entity.CommentStatus, content string) *entity.Comment {
+	return &entity.Comment{
+		PostID:       postID,
+		ParentID:     parentID,
+		AuthorName:   "reader",
+		Content:      content,
+		RenderedHTML: "<p>" + content + "</p>",
+		Status:       status,
+	}
+}
+
+func TestCommentRepository_CreateAndFind(t *testing.T) {
+	repo, post, cleanup := setupCommentTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	comment := newTestComment(post.ID, nil, entity.CommentPending, "hello")
+	comment.AuthorEmail = "reader@example.com"
+	comment.IPAddress = "192.0.2.1"
+	require.NoError(t, repo.Create(ctx, comment))
+	assert.NotZero(t, comment.ID)
+
+	reply := newTestComment(post.ID, &comment.ID, entity.CommentPending, "reply")
+	require.NoError(t, repo.Create(ctx, reply))
+
+	found, err := repo.FindByID(ctx, reply.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.ParentID)
+	assert.Equal(t, comment.ID, *found.ParentID)
+	assert.Equal(t, entity.CommentPending, found.Status)
+
+	found, err = repo.FindByID(ctx, comment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "reader@example.com", found.AuthorEmail)
+	assert.Equal(t, "192.0.2.1", found.IPAddress)
+
+	_, err = repo.FindByID(ctx, 99999)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestCommentRepository_ListAndModerate(t *testing.T) {
+	repo, post, cleanup := setupCommentTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	var ids []int64
+	for _, content := range []string{"first", "second", "third"} {
+		comment := newTestComment(post.ID, nil, entity.CommentPending, content)
+		require.NoError(t, repo.Create(ctx, comment))
+		ids = append(ids, comment.ID)
+	}
+
+	// 一括承認
+	updated, err := repo.UpdateStatus(ctx, ids[:2], entity.CommentApproved)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	approved, err := repo.ListByPost(ctx, post.ID, []entity.CommentStatus{entity.CommentApproved})
+	require.NoError(t, err)
+	require.Len(t, approved, 2)
+	// 投稿順
+	assert.Equal(t, "first", approved[0].Content)
+	assert.Equal(t, "second", approved[1].Content)
+
+	none, err := repo.ListByPost(ctx, post.ID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	// モデレーション待ち
+	pending, err := repo.List(ctx, repository.CommentFilters{Status: entity.CommentPending}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "third", pending[0].Content)
+	require.NotNil(t, pending[0].Post)
+	assert.Equal(t, "test-post", pending[0].Post.Slug)
+
+	// 新しい順
+	all, err := repo.List(ctx, repository.CommentFilters{PostID: &post.ID}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "third", all[0].Content)
+
+	count, err := repo.Count(ctx, repository.CommentFilters{Status: entity.CommentApproved})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = repo.Count(ctx, repository.CommentFilters{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	updated, err = repo.UpdateStatus(ctx, nil, entity.CommentSpam)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
+
+func TestCommentRepository_Delete(t *testing.T) {
+	repo, post, cleanup := setupCommentTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	comment := newTestComment(post.ID, nil, entity.CommentSpam, "spam")
+	require.NoError(t, repo.Create(ctx, comment))
+
+	require.NoError(t, repo.Delete(ctx, comment.ID))
+
+	_, err := repo.FindByID(ctx, comment.ID)
+	assert.Error(t, err)
+}
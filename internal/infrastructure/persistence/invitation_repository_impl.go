@@ -0,0 +1,165 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// invitationRepositoryImpl InvitationRepositoryの実装
+type invitationRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewInvitationRepository 新しいInvitationRepositoryを作成
+func NewInvitationRepository(db *bun.DB) repository.InvitationRepository {
+	return &invitationRepositoryImpl{db: db}
+}
+
+// Create 新しい招待を作成
+func (r *invitationRepositoryImpl) Create(ctx context.Context, invitation *entity.Invitation) error {
+	_, err := r.db.NewInsert().
+		Model(invitation).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID IDで招待を検索
+func (r *invitationRepositoryImpl) FindByID(ctx context.Context, id int64) (*entity.Invitation, error) {
+	return r.findOne(ctx, "inv.id = ?", id)
+}
+
+// FindByTokenHash トークンのハッシュで招待を検索
+func (r *invitationRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	return r.findOne(ctx, "inv.token_hash = ?", tokenHash)
+}
+
+// findOne 条件に一致する招待を1件取得
+func (r *invitationRepositoryImpl) findOne(ctx context.Context, query string, args ...interface{}) (*entity.Invitation, error) {
+	invitation := new(entity.Invitation)
+	err := r.db.NewSelect().
+		Model(invitation).
+		Where(query, args...).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invitation not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// List 招待一覧を新しい順に取得(招待した管理者を含む)
+func (r *invitationRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*entity.Invitation, error) {
+	invitations := make([]*entity.Invitation, 0)
+	err := r.db.NewSelect().
+		Model(&invitations).
+		Relation("Inviter").
+		Order("inv.created_at DESC", "inv.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// Count 招待数を取得
+func (r *invitationRepositoryImpl) Count(ctx context.Context) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.Invitation)(nil)).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	return count, nil
+}
+
+// Accept ユーザーを作成して招待を受諾済みにする
+// 同じ招待で同時に受諾された場合に備え、未受諾の招待のみを更新し、更新できなければロールバックする
+func (r *invitationRepositoryImpl) Accept(ctx context.Context, invitation *entity.Invitation, user *entity.User) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(user).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		now := time.Now()
+		res, err := tx.NewUpdate().
+			Model((*entity.Invitation)(nil)).
+			Set("accepted_at = ?", now).
+			Set("user_id = ?", user.ID).
+			Where("id = ?", invitation.ID).
+			Where("accepted_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("invitation has already been used")
+		}
+
+		invitation.AcceptedAt = &now
+		invitation.UserID = &user.ID
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	return nil
+}
+
+// Delete 招待を削除
+func (r *invitationRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.NewDelete().
+		Model((*entity.Invitation)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePendingByEmail メールアドレスの未受諾の招待を削除
+func (r *invitationRepositoryImpl) DeletePendingByEmail(ctx context.Context, email string) error {
+	_, err := r.db.NewDelete().
+		Model((*entity.Invitation)(nil)).
+		Where("email = ?", email).
+		Where("accepted_at IS NULL").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete invitations: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupInvitationTest(t *testing.T) (repository.InvitationRepository, repository.UserRepository, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	admin := &entity.User{
+		Username:     "admin",
+		Email:        "admin@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleAdmin,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), admin))
+
+	return persistence.NewInvitationRepository(db), userRepo, admin, cleanup
+}
+
+// newTestInvitation テスト用の招待を作成
+func newTestInvitation(email, tokenHash string, invitedBy int64) *entity.Invitation {
+	return &entity.Invitation{
+		Email:     email,
+		Role:      entity.RoleEditor,
+		TokenHash: tokenHash,
+		InvitedBy: &invitedBy,
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+}
+
+func TestInvitationRepository_CreateAndFind(t *testing.T) {
+	repo, _, admin, cleanup := setupInvitationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	invitation := newTestInvitation("editor@example.com", "hash-1", admin.ID)
+	require.NoError(t, repo.Create(ctx, invitation))
+	assert.NotZero(t, invitation.ID)
+
+	found, err := repo.FindByTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, invitation.ID, found.ID)
+	assert.Equal(t, entity.RoleEditor, found.Role)
+	assert.False(t, found.IsAccepted())
+
+	_, err = repo.FindByTokenHash(ctx, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	require.NoError(t, repo.Create(ctx, newTestInvitation("viewer@example.com", "hash-2", admin.ID)))
+
+	invitations, err := repo.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, invitations, 2)
+	require.NotNil(t, invitations[0].Inviter)
+	assert.Equal(t, "admin", invitations[0].Inviter.Username)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestInvitationRepository_Accept(t *testing.T) {
+	repo, userRepo, admin, cleanup := setupInvitationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	invitation := newTestInvitation("editor@example.com", "hash-1", admin.ID)
+	require.NoError(t, repo.Create(ctx, invitation))
+
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         invitation.Role,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, repo.Accept(ctx, invitation, user))
+	assert.NotZero(t, user.ID)
+
+	found, err := repo.FindByID(ctx, invitation.ID)
+	require.NoError(t, err)
+	assert.True(t, found.IsAccepted())
+	require.NotNil(t, found.UserID)
+	assert.Equal(t, user.ID, *found.UserID)
+
+	// 受諾済みの招待ではユーザーを作成しない
+	second := &entity.User{
+		Username:     "editor2",
+		Email:        "editor2@example.com",
+		PasswordHash: "hash",
+		Role:         invitation.Role,
+		Status:       entity.StatusActive,
+	}
+	err = repo.Accept(ctx, found, second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already been used")
+
+	_, err = userRepo.FindByUsername(ctx, "editor2")
+	assert.Error(t, err)
+}
+
+func TestInvitationRepository_DeletePendingByEmail(t *testing.T) {
+	repo, userRepo, admin, cleanup := setupInvitationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	accepted := newTestInvitation("editor@example.com", "hash-1", admin.ID)
+	require.NoError(t, repo.Create(ctx, accepted))
+	require.NoError(t, repo.Accept(ctx, accepted, &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}))
+	pending := newTestInvitation("editor@example.com", "hash-2", admin.ID)
+	require.NoError(t, repo.Create(ctx, pending))
+
+	require.NoError(t, repo.DeletePendingByEmail(ctx, "editor@example.com"))
+
+	_, err := repo.FindByID(ctx, pending.ID)
+	assert.Error(t, err)
+	_, err = repo.FindByID(ctx, accepted.ID)
+	assert.NoError(t, err)
+
+	_, err = userRepo.FindByUsername(ctx, "editor")
+	assert.NoError(t, err)
+}
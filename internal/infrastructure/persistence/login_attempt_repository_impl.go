@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// loginAttemptRepositoryImpl LoginAttemptRepositoryの実装
+type loginAttemptRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewLoginAttemptRepository 新しいLoginAttemptRepositoryを作成
+func NewLoginAttemptRepository(db *bun.DB) repository.LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{db: db}
+}
+
+// Create ログイン試行を記録
+func (r *loginAttemptRepositoryImpl) Create(ctx context.Context, attempt *entity.LoginAttempt) error {
+	_, err := r.db.NewInsert().
+		Model(attempt).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create login attempt: %w", err)
+	}
+
+	return nil
+}
+
+// List ログイン試行を新しい順に取得
+func (r *loginAttemptRepositoryImpl) List(ctx context.Context, filters repository.LoginAttemptFilters, limit, offset int) ([]*entity.LoginAttempt, error) {
+	attempts := make([]*entity.LoginAttempt, 0)
+	query := r.db.NewSelect().
+		Model(&attempts)
+	applyLoginAttemptFilters(query, filters)
+
+	err := query.
+		Order("la.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// Count ログイン試行数を取得
+func (r *loginAttemptRepositoryImpl) Count(ctx context.Context, filters repository.LoginAttemptFilters) (int, error) {
+	query := r.db.NewSelect().
+		Model((*entity.LoginAttempt)(nil))
+	applyLoginAttemptFilters(query, filters)
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count login attempts: %w", err)
+	}
+
+	return count, nil
+}
+
+// applyLoginAttemptFilters ログイン試行一覧の絞り込み条件をクエリに追加
+func applyLoginAttemptFilters(query *bun.SelectQuery, filters repository.LoginAttemptFilters) {
+	if filters.Username != "" {
+		query.Where("la.username = ?", filters.Username)
+	}
+	if filters.IPAddress != "" {
+		query.Where("la.ip_address = ?", filters.IPAddress)
+	}
+	if filters.UserID != nil {
+		query.Where("la.user_id = ?", *filters.UserID)
+	}
+}
@@ -0,0 +1,70 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttemptRepository_CreateAndList(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(ctx, user))
+
+	repo := persistence.NewLoginAttemptRepository(db)
+	attempts := []*entity.LoginAttempt{
+		{Username: "editor", UserID: &user.ID, IPAddress: "192.0.2.1", FailureReason: entity.LoginFailureInvalidCredentials},
+		{Username: "unknown", IPAddress: "192.0.2.1", FailureReason: entity.LoginFailureInvalidCredentials},
+		{Username: "editor", UserID: &user.ID, IPAddress: "192.0.2.2", Succeeded: true},
+	}
+	for _, attempt := range attempts {
+		require.NoError(t, repo.Create(ctx, attempt))
+		assert.NotZero(t, attempt.ID)
+	}
+
+	list, err := repo.List(ctx, repository.LoginAttemptFilters{}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.True(t, list[0].Succeeded)
+	assert.Equal(t, "unknown", list[1].Username)
+	assert.Nil(t, list[1].UserID)
+
+	tests := []struct {
+		name    string
+		filters repository.LoginAttemptFilters
+		want    int
+	}{
+		{"ユーザー名で絞り込み", repository.LoginAttemptFilters{Username: "editor"}, 2},
+		{"IPアドレスで絞り込み", repository.LoginAttemptFilters{IPAddress: "192.0.2.1"}, 2},
+		{"ユーザーIDで絞り込み", repository.LoginAttemptFilters{UserID: &user.ID}, 2},
+		{"複数条件で絞り込み", repository.LoginAttemptFilters{Username: "editor", IPAddress: "192.0.2.2"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := repo.Count(ctx, tt.filters)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+		})
+	}
+
+	page, err := repo.List(ctx, repository.LoginAttemptFilters{}, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, attempts[1].ID, page[0].ID)
+}
@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// loginThrottleRepositoryImpl LoginThrottleRepositoryの実装
+type loginThrottleRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewLoginThrottleRepository 新しいLoginThrottleRepositoryを作成
+func NewLoginThrottleRepository(db *bun.DB) repository.LoginThrottleRepository {
+	return &loginThrottleRepositoryImpl{db: db}
+}
+
+// Find 単位とキーで失敗状況を検索
+func (r *loginThrottleRepositoryImpl) Find(ctx context.Context, scope entity.LoginThrottleScope, key string) (*entity.LoginThrottle, error) {
+	return r.find(ctx, r.db, scope, key)
+}
+
+// find 単位とキーで失敗状況を検索(トランザクション内でも使用する)
+func (r *loginThrottleRepositoryImpl) find(ctx context.Context, db bun.IDB, scope entity.LoginThrottleScope, key string) (*entity.LoginThrottle, error) {
+	throttle := new(entity.LoginThrottle)
+	err := db.NewSelect().
+		Model(throttle).
+		Where("lt.scope = ?", scope).
+		Where("lt.throttle_key = ?", key).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("login throttle not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find login throttle: %w", err)
+	}
+
+	return throttle, nil
+}
+
+// RecordFailure 失敗回数を1増やして更新後の失敗状況を返す
+// 同時に失敗した場合も回数を取りこぼさないよう、INSERT ... ON DUPLICATE KEY UPDATEで加算する
+// (MySQLは左から順に代入するため、last_failure_atは更新前の値で判定される)
+func (r *loginThrottleRepositoryImpl) RecordFailure(ctx context.Context, scope entity.LoginThrottleScope, key string, now, resetBefore time.Time) (*entity.LoginThrottle, error) {
+	var throttle *entity.LoginThrottle
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().
+			Model(&entity.LoginThrottle{Scope: scope, Key: key, FailureCount: 1, LastFailureAt: now}).
+			On("DUPLICATE KEY UPDATE").
+			Set("failure_count = IF(last_failure_at < ?, 1, failure_count + 1)", resetBefore).
+			Set("locked_until = IF(last_failure_at < ?, NULL, locked_until)", resetBefore).
+			Set("last_failure_at = VALUES(last_failure_at)").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		throttle, err = r.find(ctx, tx, scope, key)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return throttle, nil
+}
+
+// Lock ロックの期限を設定
+func (r *loginThrottleRepositoryImpl) Lock(ctx context.Context, scope entity.LoginThrottleScope, key string, until time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.LoginThrottle)(nil)).
+		Set("locked_until = ?", until).
+		Where("scope = ?", scope).
+		Where("throttle_key = ?", key).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return nil
+}
+
+// Reset 失敗状況を削除してロックを解除する(該当する失敗状況がない場合はfalse)
+func (r *loginThrottleRepositoryImpl) Reset(ctx context.Context, scope entity.LoginThrottleScope, key string) (bool, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.LoginThrottle)(nil)).
+		Where("scope = ?", scope).
+		Where("throttle_key = ?", key).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// ListLocked 指定日時にロックされている失敗状況をロックの期限の遅い順に取得
+func (r *loginThrottleRepositoryImpl) ListLocked(ctx context.Context, now time.Time) ([]*entity.LoginThrottle, error) {
+	throttles := make([]*entity.LoginThrottle, 0)
+	err := r.db.NewSelect().
+		Model(&throttles).
+		Where("lt.locked_until > ?", now).
+		Order("lt.locked_until DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login lockouts: %w", err)
+	}
+
+	return throttles, nil
+}
@@ -0,0 +1,90 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginThrottleRepository_RecordFailure(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	repo := persistence.NewLoginThrottleRepository(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	window := 24 * time.Hour
+
+	_, err := repo.Find(ctx, entity.LoginThrottleUsername, "editor")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	for i := 1; i <= 3; i++ {
+		throttle, err := repo.RecordFailure(ctx, entity.LoginThrottleUsername, "editor", now, now.Add(-window))
+		require.NoError(t, err)
+		assert.Equal(t, i, throttle.FailureCount)
+	}
+
+	// 単位が異なれば別に数える
+	throttle, err := repo.RecordFailure(ctx, entity.LoginThrottleIP, "editor", now, now.Add(-window))
+	require.NoError(t, err)
+	assert.Equal(t, 1, throttle.FailureCount)
+
+	until := now.Add(time.Minute)
+	require.NoError(t, repo.Lock(ctx, entity.LoginThrottleUsername, "editor", until))
+	throttle, err = repo.Find(ctx, entity.LoginThrottleUsername, "editor")
+	require.NoError(t, err)
+	assert.True(t, throttle.IsLocked(now))
+
+	// 最後の失敗が集計期間より前なら回数とロックをリセットする
+	later := now.Add(window + time.Hour)
+	throttle, err = repo.RecordFailure(ctx, entity.LoginThrottleUsername, "editor", later, later.Add(-window))
+	require.NoError(t, err)
+	assert.Equal(t, 1, throttle.FailureCount)
+	assert.Nil(t, throttle.LockedUntil)
+	assert.True(t, later.Equal(throttle.LastFailureAt))
+}
+
+func TestLoginThrottleRepository_ListLockedAndReset(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	repo := persistence.NewLoginThrottleRepository(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	for _, key := range []string{"editor", "admin", "author"} {
+		_, err := repo.RecordFailure(ctx, entity.LoginThrottleUsername, key, now, now.Add(-time.Hour))
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.Lock(ctx, entity.LoginThrottleUsername, "editor", now.Add(time.Minute)))
+	require.NoError(t, repo.Lock(ctx, entity.LoginThrottleUsername, "admin", now.Add(time.Hour)))
+	require.NoError(t, repo.Lock(ctx, entity.LoginThrottleUsername, "author", now.Add(-time.Minute)))
+
+	// 期限切れのロックは含めない
+	locked, err := repo.ListLocked(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, locked, 2)
+	assert.Equal(t, "admin", locked[0].Key)
+	assert.Equal(t, "editor", locked[1].Key)
+
+	reset, err := repo.Reset(ctx, entity.LoginThrottleUsername, "admin")
+	require.NoError(t, err)
+	assert.True(t, reset)
+
+	reset, err = repo.Reset(ctx, entity.LoginThrottleUsername, "admin")
+	require.NoError(t, err)
+	assert.False(t, reset)
+
+	locked, err = repo.ListLocked(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, locked, 1)
+	assert.Equal(t, "editor", locked[0].Key)
+}
@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// mediaRepositoryImpl MediaRepositoryの実装
+type mediaRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewMediaRepository 新しいMediaRepositoryを作成
+func NewMediaRepository(db *bun.DB) repository.MediaRepository {
+	return &mediaRepositoryImpl{db: db}
+}
+
+// Create 新しいメディアをバリアントとあわせて作成
+func (r *mediaRepositoryImpl) Create(ctx context.Context, media *entity.Media) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(media).Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(media.Variants) == 0 {
+			return nil
+		}
+
+		for _, variant := range media.Variants {
+			variant.MediaID = media.ID
+		}
+		_, err := tx.NewInsert().Model(&media.Variants).Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create media: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID IDでメディアを検索
+func (r *mediaRepositoryImpl) FindByID(ctx context.Context, id int64) (*entity.Media, error) {
+	return r.findOne(ctx, "m.id = ?", id)
+}
+
+// FindByStorageKey 原本のストレージキーでメディアを検索
+func (r *mediaRepositoryImpl) FindByStorageKey(ctx context.Context, key string) (*entity.Media, error) {
+	return r.findOne(ctx, "m.storage_key = ?", key)
+}
+
+// findOne 条件に一致するメディアをアップロードしたユーザー・バリアントとあわせて取得
+func (r *mediaRepositoryImpl) findOne(ctx context.Context, query string, args ...interface{}) (*entity.Media, error) {
+	media := new(entity.Media)
+	err := r.db.NewSelect().
+		Model(media).
+		Relation("Owner").
+		Relation("Variants", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("mv.width ASC", "mv.id ASC")
+		}).
+		Where(query, args...).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("media not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find media: %w", err)
+	}
+
+	return media, nil
+}
+
+// Update メディアの代替テキストを更新
+// 代替テキストは空文字列に戻せるようOmitZeroを使わない
+func (r *mediaRepositoryImpl) Update(ctx context.Context, media *entity.Media) error {
+	media.UpdatedAt = time.Now()
+	_, err := r.db.NewUpdate().
+		Model(media).
+		Column("alt_text", "updated_at").
+		WherePK().
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update media: %w", err)
+	}
+
+	return nil
+}
+
+// Delete メディアを削除
+func (r *mediaRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.NewDelete().
+		Model((*entity.Media)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete media: %w", err)
+	}
+
+	return nil
+}
+
+// List メディア一覧を新しい順に取得
+func (r *mediaRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*entity.Media, error) {
+	media := make([]*entity.Media, 0)
+	err := r.db.NewSelect().
+		Model(&media).
+		Relation("Owner").
+		Relation("Variants", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("mv.width ASC", "mv.id ASC")
+		}).
+		Order("m.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media: %w", err)
+	}
+
+	return media, nil
+}
+
+// Count メディア数を取得
+func (r *mediaRepositoryImpl) Count(ctx context.Context) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.Media)(nil)).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count media: %w", err)
+	}
+
+	return count, nil
+}
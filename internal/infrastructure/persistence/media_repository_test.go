@@ -0,0 +1,117 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaRepository_CreateAndFind(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := persistence.NewMediaRepository(db)
+	userRepo := persistence.NewUserRepository(db)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	for _, key := range []string{"2025/01/a.png", "2025/01/b.jpg"} {
+		media := &entity.Media{
+			OwnerID:      &user.ID,
+			StorageKey:   key,
+			OriginalName: "original",
+			MimeType:     "image/png",
+			Size:         100,
+			Width:        10,
+			Height:       20,
+			AltText:      "alt",
+		}
+		require.NoError(t, repo.Create(ctx, media))
+		assert.NotZero(t, media.ID)
+	}
+
+	// 新しい順
+	list, err := repo.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "2025/01/b.jpg", list[0].StorageKey)
+	require.NotNil(t, list[0].Owner)
+	assert.Equal(t, "testuser", list[0].Owner.Username)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	found, err := repo.FindByID(ctx, list[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "2025/01/a.png", found.StorageKey)
+	assert.Equal(t, 10, found.Width)
+	assert.Equal(t, 20, found.Height)
+
+	found.AltText = ""
+	require.NoError(t, repo.Update(ctx, found))
+
+	found, err = repo.FindByID(ctx, found.ID)
+	require.NoError(t, err)
+	assert.Empty(t, found.AltText)
+
+	_, err = repo.FindByID(ctx, 99999)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestMediaRepository_Variants(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := persistence.NewMediaRepository(db)
+
+	media := &entity.Media{
+		StorageKey:   "2025/01/c.jpg",
+		OriginalName: "photo.jpg",
+		MimeType:     "image/jpeg",
+		Size:         1000,
+		Width:        800,
+		Height:       600,
+		Variants: []*entity.MediaVariant{
+			{StorageKey: "2025/01/c-640w.jpg", MimeType: "image/jpeg", Size: 300, Width: 640, Height: 480},
+			{StorageKey: "2025/01/c-320w.jpg", MimeType: "image/jpeg", Size: 100, Width: 320, Height: 240},
+			{StorageKey: "2025/01/c-320w.webp", MimeType: "image/webp", Size: 80, Width: 320, Height: 240},
+		},
+	}
+	require.NoError(t, repo.Create(ctx, media))
+
+	// 幅の小さい順
+	found, err := repo.FindByStorageKey(ctx, "2025/01/c.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, media.ID, found.ID)
+	require.Len(t, found.Variants, 3)
+	assert.Equal(t, "2025/01/c-320w.jpg", found.Variants[0].StorageKey)
+	assert.Equal(t, "2025/01/c-320w.webp", found.Variants[1].StorageKey)
+	assert.Equal(t, "2025/01/c-640w.jpg", found.Variants[2].StorageKey)
+	assert.Equal(t, media.ID, found.Variants[2].MediaID)
+
+	list, err := repo.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Len(t, list[0].Variants, 3)
+
+	_, err = repo.FindByStorageKey(ctx, "2025/01/c-320w.jpg")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// mfaPolicyRepositoryImpl MFAPolicyRepositoryの実装
+type mfaPolicyRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewMFAPolicyRepository 新しいMFAPolicyRepositoryを作成
+func NewMFAPolicyRepository(db *bun.DB) repository.MFAPolicyRepository {
+	return &mfaPolicyRepositoryImpl{db: db}
+}
+
+// List 設定済みのポリシーを全て取得
+func (r *mfaPolicyRepositoryImpl) List(ctx context.Context) ([]*entity.MFAPolicy, error) {
+	policies := make([]*entity.MFAPolicy, 0)
+	err := r.db.NewSelect().
+		Model(&policies).
+		Order("mp.role ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mfa policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// IsRequired ロールで二要素認証が必須かどうかを取得(未設定の場合はfalse)
+func (r *mfaPolicyRepositoryImpl) IsRequired(ctx context.Context, role entity.UserRole) (bool, error) {
+	policy := new(entity.MFAPolicy)
+	err := r.db.NewSelect().
+		Model(policy).
+		Where("mp.role = ?", role).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to find mfa policy: %w", err)
+	}
+
+	return policy.Required, nil
+}
+
+// Save ポリシーを保存
+func (r *mfaPolicyRepositoryImpl) Save(ctx context.Context, policy *entity.MFAPolicy) error {
+	_, err := r.db.NewInsert().
+		Model(policy).
+		On("DUPLICATE KEY UPDATE").
+		Set("required = VALUES(required)").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to save mfa policy: %w", err)
+	}
+
+	return nil
+}
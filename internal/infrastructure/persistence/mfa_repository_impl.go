@@ -0,0 +1,213 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// mfaRepositoryImpl MFARepositoryの実装
+type mfaRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewMFARepository 新しいMFARepositoryを作成
+func NewMFARepository(db *bun.DB) repository.MFARepository {
+	return &mfaRepositoryImpl{db: db}
+}
+
+// FindByUserID ユーザーの二要素認証設定を取得
+func (r *mfaRepositoryImpl) FindByUserID(ctx context.Context, userID int64) (*entity.UserMFA, error) {
+	mfa := new(entity.UserMFA)
+	err := r.db.NewSelect().
+		Model(mfa).
+		Where("mfa.user_id = ?", userID).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("two-factor authentication not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find two-factor authentication: %w", err)
+	}
+
+	return mfa, nil
+}
+
+// SavePending 登録途中の設定を保存(既存の設定とリカバリーコードは置き換える)
+func (r *mfaRepositoryImpl) SavePending(ctx context.Context, mfa *entity.UserMFA) error {
+	mfa.EnabledAt = nil
+	mfa.LastUsedStep = 0
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().
+			Model(mfa).
+			On("DUPLICATE KEY UPDATE").
+			Set("secret = VALUES(secret)").
+			Set("enabled_at = NULL").
+			Set("last_used_step = 0").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewDelete().
+			Model((*entity.RecoveryCode)(nil)).
+			Where("user_id = ?", mfa.UserID).
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to save two-factor authentication: %w", err)
+	}
+
+	return nil
+}
+
+// Enable 登録を完了し、リカバリーコードを置き換える
+func (r *mfaRepositoryImpl) Enable(ctx context.Context, mfa *entity.UserMFA, codeHashes []string) error {
+	now := time.Now()
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().
+			Model((*entity.UserMFA)(nil)).
+			Set("enabled_at = ?", now).
+			Set("last_used_step = ?", mfa.LastUsedStep).
+			Where("user_id = ?", mfa.UserID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		return replaceRecoveryCodes(ctx, tx, mfa.UserID, codeHashes)
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	mfa.EnabledAt = &now
+	return nil
+}
+
+// Delete ユーザーの二要素認証設定とリカバリーコードを削除
+func (r *mfaRepositoryImpl) Delete(ctx context.Context, userID int64) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model((*entity.RecoveryCode)(nil)).
+			Where("user_id = ?", userID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewDelete().
+			Model((*entity.UserMFA)(nil)).
+			Where("user_id = ?", userID).
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to delete two-factor authentication: %w", err)
+	}
+
+	return nil
+}
+
+// UseStep ワンタイムコードのタイムステップを使用済みにする
+// 同じコードが同時に使われた場合に備え、条件付きの更新で1回だけ成功させる
+func (r *mfaRepositoryImpl) UseStep(ctx context.Context, userID, step int64) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*entity.UserMFA)(nil)).
+		Set("last_used_step = ?", step).
+		Where("user_id = ?", userID).
+		Where("last_used_step < ?", step).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to update two-factor authentication: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update two-factor authentication: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// ReplaceRecoveryCodes リカバリーコードを置き換える
+func (r *mfaRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return replaceRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to replace recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// replaceRecoveryCodes トランザクション内でリカバリーコードを置き換える
+func replaceRecoveryCodes(ctx context.Context, tx bun.Tx, userID int64, codeHashes []string) error {
+	if _, err := tx.NewDelete().
+		Model((*entity.RecoveryCode)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := make([]*entity.RecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = &entity.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	_, err := tx.NewInsert().
+		Model(&codes).
+		Exec(ctx)
+	return err
+}
+
+// UseRecoveryCode 未使用のリカバリーコードを使用済みにする
+func (r *mfaRepositoryImpl) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*entity.RecoveryCode)(nil)).
+		Set("used_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Where("code_hash = ?", codeHash).
+		Where("used_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// CountUnusedRecoveryCodes 未使用のリカバリーコード数を取得
+func (r *mfaRepositoryImpl) CountUnusedRecoveryCodes(ctx context.Context, userID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.RecoveryCode)(nil)).
+		Where("user_id = ?", userID).
+		Where("used_at IS NULL").
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,147 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMFATest(t *testing.T) (repository.MFARepository, repository.MFAPolicyRepository, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(context.Background(), user))
+
+	return persistence.NewMFARepository(db), persistence.NewMFAPolicyRepository(db), user, cleanup
+}
+
+func TestMFARepository_SavePendingAndEnable(t *testing.T) {
+	repo, _, user, cleanup := setupMFATest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := repo.FindByUserID(ctx, user.ID)
+	require.Error(t, err)
+
+	require.NoError(t, repo.SavePending(ctx, &entity.UserMFA{UserID: user.ID, Secret: "secret-1"}))
+	// 登録途中の設定は上書きできる
+	require.NoError(t, repo.SavePending(ctx, &entity.UserMFA{UserID: user.ID, Secret: "secret-2"}))
+
+	found, err := repo.FindByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-2", found.Secret)
+	assert.False(t, found.IsEnabled())
+
+	now := time.Now().Truncate(time.Second)
+	found.EnabledAt = &now
+	found.LastUsedStep = 100
+	require.NoError(t, repo.Enable(ctx, found, []string{"code-1", "code-2"}))
+
+	found, err = repo.FindByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, found.IsEnabled())
+	assert.Equal(t, int64(100), found.LastUsedStep)
+
+	count, err := repo.CountUnusedRecoveryCodes(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, repo.Delete(ctx, user.ID))
+	_, err = repo.FindByUserID(ctx, user.ID)
+	assert.Error(t, err)
+	count, err = repo.CountUnusedRecoveryCodes(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestMFARepository_UseStep(t *testing.T) {
+	repo, _, user, cleanup := setupMFATest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	require.NoError(t, repo.SavePending(ctx, &entity.UserMFA{UserID: user.ID, Secret: "secret"}))
+
+	used, err := repo.UseStep(ctx, user.ID, 101)
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	// 同じステップや古いステップは再利用できない
+	used, err = repo.UseStep(ctx, user.ID, 101)
+	require.NoError(t, err)
+	assert.False(t, used)
+	used, err = repo.UseStep(ctx, user.ID, 100)
+	require.NoError(t, err)
+	assert.False(t, used)
+}
+
+func TestMFARepository_RecoveryCodes(t *testing.T) {
+	repo, _, user, cleanup := setupMFATest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	require.NoError(t, repo.SavePending(ctx, &entity.UserMFA{UserID: user.ID, Secret: "secret"}))
+	require.NoError(t, repo.ReplaceRecoveryCodes(ctx, user.ID, []string{"code-1", "code-2"}))
+
+	used, err := repo.UseRecoveryCode(ctx, user.ID, "code-1")
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	// 使用済みのコードは再利用できない
+	used, err = repo.UseRecoveryCode(ctx, user.ID, "code-1")
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	count, err := repo.CountUnusedRecoveryCodes(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// 置き換えると古いコードは使えない
+	require.NoError(t, repo.ReplaceRecoveryCodes(ctx, user.ID, []string{"code-3"}))
+	used, err = repo.UseRecoveryCode(ctx, user.ID, "code-2")
+	require.NoError(t, err)
+	assert.False(t, used)
+	used, err = repo.UseRecoveryCode(ctx, user.ID, "code-3")
+	require.NoError(t, err)
+	assert.True(t, used)
+}
+
+func TestMFAPolicyRepository_SaveAndIsRequired(t *testing.T) {
+	_, repo, _, cleanup := setupMFATest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	required, err := repo.IsRequired(ctx, entity.RoleAdmin)
+	require.NoError(t, err)
+	assert.False(t, required)
+
+	require.NoError(t, repo.Save(ctx, &entity.MFAPolicy{Role: entity.RoleAdmin, Required: true}))
+	required, err = repo.IsRequired(ctx, entity.RoleAdmin)
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	require.NoError(t, repo.Save(ctx, &entity.MFAPolicy{Role: entity.RoleAdmin, Required: false}))
+	required, err = repo.IsRequired(ctx, entity.RoleAdmin)
+	require.NoError(t, err)
+	assert.False(t, required)
+
+	policies, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, entity.RoleAdmin, policies[0].Role)
+}
@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// passwordResetRepositoryImpl PasswordResetRepositoryの実装
+type passwordResetRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewPasswordResetRepository 新しいPasswordResetRepositoryを作成
+func NewPasswordResetRepository(db *bun.DB) repository.PasswordResetRepository {
+	return &passwordResetRepositoryImpl{db: db}
+}
+
+// Create 新しい再設定トークンを作成
+func (r *passwordResetRepositoryImpl) Create(ctx context.Context, token *entity.PasswordResetToken) error {
+	_, err := r.db.NewInsert().
+		Model(token).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+// FindByTokenHash トークンのハッシュで再設定トークンを検索
+func (r *passwordResetRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	token := new(entity.PasswordResetToken)
+	err := r.db.NewSelect().
+		Model(token).
+		Where("prt.token_hash = ?", tokenHash).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("password reset token not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find password reset token: %w", err)
+	}
+
+	return token, nil
+}
+
+// DeleteByUserID ユーザーの再設定トークンを全て削除
+func (r *passwordResetRepositoryImpl) DeleteByUserID(ctx context.Context, userID int64) error {
+	_, err := r.db.NewDelete().
+		Model((*entity.PasswordResetToken)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete password reset tokens: %w", err)
+	}
+
+	return nil
+}
+
+// Consume トークンを使用済みにしてユーザーのパスワードを更新し、ユーザーの他のトークンを削除する
+// 再設定前のログインはセッション一覧にも残らないよう、セッションとリフレッシュトークンのファミリーを全て失効させる
+// 同じトークンで同時に再設定された場合に備え、未使用のトークンのみを更新し、更新できなければロールバックする
+func (r *passwordResetRepositoryImpl) Consume(ctx context.Context, token *entity.PasswordResetToken, user *entity.User) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		now := time.Now()
+		res, err := tx.NewUpdate().
+			Model((*entity.PasswordResetToken)(nil)).
+			Set("used_at = ?", now).
+			Where("id = ?", token.ID).
+			Where("used_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("password reset token has already been used")
+		}
+
+		if _, err := tx.NewUpdate().
+			Model(user).
+			Column("password_hash", "password_changed_at").
+			WherePK().
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		if _, err := tx.NewDelete().
+			Model((*entity.PasswordResetToken)(nil)).
+			Where("user_id = ?", user.ID).
+			Where("id <> ?", token.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if err := revokeUserSessions(ctx, tx, user.ID, now); err != nil {
+			return err
+		}
+
+		token.UsedAt = &now
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPasswordResetTest(t *testing.T) (repository.PasswordResetRepository, repository.UserRepository, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "old-hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), user))
+
+	return persistence.NewPasswordResetRepository(db), userRepo, user, cleanup
+}
+
+// newTestPasswordResetToken テスト用の再設定トークンを作成
+func newTestPasswordResetToken(userID int64, tokenHash string) *entity.PasswordResetToken {
+	return &entity.PasswordResetToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+}
+
+func TestPasswordResetRepository_CreateAndFind(t *testing.T) {
+	repo, _, user, cleanup := setupPasswordResetTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	token := newTestPasswordResetToken(user.ID, "hash-1")
+	require.NoError(t, repo.Create(ctx, token))
+	assert.NotZero(t, token.ID)
+
+	found, err := repo.FindByTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, found.ID)
+	assert.Equal(t, user.ID, found.UserID)
+	assert.False(t, found.IsUsed())
+
+	_, err = repo.FindByTokenHash(ctx, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	require.NoError(t, repo.DeleteByUserID(ctx, user.ID))
+	_, err = repo.FindByTokenHash(ctx, "hash-1")
+	assert.Error(t, err)
+}
+
+func TestPasswordResetRepository_Consume(t *testing.T) {
+	repo, userRepo, user, cleanup := setupPasswordResetTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	token := newTestPasswordResetToken(user.ID, "hash-1")
+	require.NoError(t, repo.Create(ctx, token))
+	other := newTestPasswordResetToken(user.ID, "hash-2")
+	require.NoError(t, repo.Create(ctx, other))
+
+	user.ChangePassword("new-hash", time.Now())
+	require.NoError(t, repo.Consume(ctx, token, user))
+
+	found, err := repo.FindByTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.True(t, found.IsUsed())
+
+	// 他の未使用のトークンも使えなくなる
+	_, err = repo.FindByTokenHash(ctx, "hash-2")
+	assert.Error(t, err)
+
+	updated, err := userRepo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", updated.PasswordHash)
+	require.NotNil(t, updated.PasswordChangedAt)
+
+	// 使用済みのトークンではパスワードを変更しない
+	updated.ChangePassword("another-hash", time.Now())
+	err = repo.Consume(ctx, found, updated)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already been used")
+
+	updated, err = userRepo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", updated.PasswordHash)
+}
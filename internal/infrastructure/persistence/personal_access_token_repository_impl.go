@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// personalAccessTokenRepositoryImpl PersonalAccessTokenRepositoryの実装
+type personalAccessTokenRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewPersonalAccessTokenRepository 新しいPersonalAccessTokenRepositoryを作成
+func NewPersonalAccessTokenRepository(db *bun.DB) repository.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepositoryImpl{db: db}
+}
+
+// Create 新しいトークンを作成
+func (r *personalAccessTokenRepositoryImpl) Create(ctx context.Context, token *entity.PersonalAccessToken) error {
+	_, err := r.db.NewInsert().
+		Model(token).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	return nil
+}
+
+// FindByTokenHash トークンのハッシュでトークンを検索
+func (r *personalAccessTokenRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	token := new(entity.PersonalAccessToken)
+	err := r.db.NewSelect().
+		Model(token).
+		Where("pat.token_hash = ?", tokenHash).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("personal access token not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListByUserID ユーザーの失効していないトークンを作成日時の新しい順に取得
+func (r *personalAccessTokenRepositoryImpl) ListByUserID(ctx context.Context, userID int64) ([]*entity.PersonalAccessToken, error) {
+	var tokens []*entity.PersonalAccessToken
+	err := r.db.NewSelect().
+		Model(&tokens).
+		Where("pat.user_id = ?", userID).
+		Where("pat.revoked_at IS NULL").
+		Order("pat.created_at DESC", "pat.id DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Touch 最終利用日時を更新
+func (r *personalAccessTokenRepositoryImpl) Touch(ctx context.Context, id int64, lastUsedAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.PersonalAccessToken)(nil)).
+		Set("last_used_at = ?", lastUsedAt).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update personal access token: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke ユーザーのトークンを失効させる(該当する失効していないトークンがない場合はfalse)
+func (r *personalAccessTokenRepositoryImpl) Revoke(ctx context.Context, userID, id int64) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*entity.PersonalAccessToken)(nil)).
+		Set("revoked_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke personal access token: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke personal access token: %w", err)
+	}
+
+	return affected > 0, nil
+}
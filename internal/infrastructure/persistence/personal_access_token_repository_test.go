@@ -0,0 +1,108 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPersonalAccessTokenTest(t *testing.T) (repository.PersonalAccessTokenRepository, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(context.Background(), user))
+
+	return persistence.NewPersonalAccessTokenRepository(db), user, cleanup
+}
+
+func TestPersonalAccessTokenRepository_CreateAndFind(t *testing.T) {
+	repo, user, cleanup := setupPersonalAccessTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	token := &entity.PersonalAccessToken{
+		UserID:      user.ID,
+		Name:        "deploy",
+		TokenHash:   "hash-1",
+		TokenPrefix: "pat_abcd",
+		Scopes:      []entity.TokenScope{entity.ScopePostsWrite, entity.ScopeMediaWrite},
+		ExpiresAt:   &expiresAt,
+	}
+	require.NoError(t, repo.Create(ctx, token))
+	assert.NotZero(t, token.ID)
+
+	found, err := repo.FindByTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, "deploy", found.Name)
+	assert.Equal(t, []entity.TokenScope{entity.ScopePostsWrite, entity.ScopeMediaWrite}, found.Scopes)
+	require.NotNil(t, found.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*found.ExpiresAt))
+	assert.Nil(t, found.LastUsedAt)
+
+	_, err = repo.FindByTokenHash(ctx, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	now := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.Touch(ctx, token.ID, now))
+	found, err = repo.FindByTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, found.LastUsedAt)
+	assert.True(t, now.Equal(*found.LastUsedAt))
+}
+
+func TestPersonalAccessTokenRepository_ListAndRevoke(t *testing.T) {
+	repo, user, cleanup := setupPersonalAccessTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	first := &entity.PersonalAccessToken{UserID: user.ID, Name: "first", TokenHash: "hash-1", TokenPrefix: "pat_1", Scopes: []entity.TokenScope{entity.ScopePostsRead}}
+	second := &entity.PersonalAccessToken{UserID: user.ID, Name: "second", TokenHash: "hash-2", TokenPrefix: "pat_2", Scopes: []entity.TokenScope{entity.ScopePostsRead}}
+	require.NoError(t, repo.Create(ctx, first))
+	require.NoError(t, repo.Create(ctx, second))
+
+	tokens, err := repo.ListByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "second", tokens[0].Name)
+
+	// 他のユーザーのトークンは失効できない
+	revoked, err := repo.Revoke(ctx, user.ID+1, first.ID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = repo.Revoke(ctx, user.ID, first.ID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// 失効済みのトークンは再度失効できない
+	revoked, err = repo.Revoke(ctx, user.ID, first.ID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	tokens, err = repo.ListByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "second", tokens[0].Name)
+
+	found, err := repo.FindByTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.True(t, found.IsRevoked())
+}
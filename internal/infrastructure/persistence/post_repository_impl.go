@@ -5,13 +5,18 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/search"
 
 	"github.com/uptrace/bun"
 )
 
+// searchMatchExpr 全文検索の一致条件(ft_posts_title_contentインデックスと同じ列を指定する必要がある)
+const searchMatchExpr = "MATCH(p.title, p.content) AGAINST (? IN BOOLEAN MODE)"
+
 // postRepositoryImpl PostRepositoryの実装
 type postRepositoryImpl struct {
 	db *bun.DB
@@ -78,11 +83,13 @@ func (r *postRepositoryImpl) FindBySlug(ctx context.Context, slug string) (*enti
 }
 
 // Update 記事を更新
+// カテゴリの解除(NULL)も反映するため、ゼロ値の列も含めて更新する
 func (r *postRepositoryImpl) Update(ctx context.Context, post *entity.Post) error {
+	post.UpdatedAt = time.Now()
 	_, err := r.db.NewUpdate().
 		Model(post).
-		OmitZero().
-		Column("title", "slug", "content", "category_id", "author_id", "status", "published_at", "updated_at").
+		Column("title", "slug", "content", "rendered_html", "category_id", "author_id", "status", "published_at", "scheduled_at",
+			"reviewer_id", "review_comment", "submitted_at", "reviewed_at", "updated_at").
 		WherePK().
 		Exec(ctx)
 
@@ -93,6 +100,43 @@ func (r *postRepositoryImpl) Update(ctx context.Context, post *entity.Post) erro
 	return nil
 }
 
+// IncrementPreviewVersion プレビューのバージョンを上げて新しいバージョンを返す
+// 記事の内容は変わらないため、updated_at(フィード・サイトマップの更新日時)は変更しない
+func (r *postRepositoryImpl) IncrementPreviewVersion(ctx context.Context, id int64) (int, error) {
+	post := &entity.Post{ID: id}
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*entity.Post)(nil)).
+			Set("preview_version = preview_version + 1").
+			Set("updated_at = updated_at").
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		return tx.NewSelect().
+			Model(post).
+			Column("preview_version").
+			WherePK().
+			Scan(ctx)
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("post not found: %w", err)
+		}
+		return 0, fmt.Errorf("failed to increment preview version: %w", err)
+	}
+
+	return post.PreviewVersion, nil
+}
+
 // Delete 記事を削除
 func (r *postRepositoryImpl) Delete(ctx context.Context, id int64) error {
 	_, err := r.db.NewDelete().
@@ -127,6 +171,51 @@ func (r *postRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*en
 	return posts, nil
 }
 
+// ListAccessible ユーザーが著者の記事と共有された記事の一覧を取得
+func (r *postRepositoryImpl) ListAccessible(ctx context.Context, userID int64, limit, offset int) ([]*entity.Post, error) {
+	posts := make([]*entity.Post, 0)
+	query := r.db.NewSelect().
+		Model(&posts).
+		Relation("Author").
+		Relation("Category").
+		Relation("Tags")
+	applyAccessibleFilter(query, userID)
+
+	err := query.
+		Order("p.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accessible posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// ListAwaitingReview レビュー担当者のレビュー中の記事を提出日時の古い順に取得
+func (r *postRepositoryImpl) ListAwaitingReview(ctx context.Context, reviewerID int64, limit, offset int) ([]*entity.Post, error) {
+	posts := make([]*entity.Post, 0)
+	err := r.db.NewSelect().
+		Model(&posts).
+		Relation("Author").
+		Relation("Category").
+		Relation("Tags").
+		Where("p.reviewer_id = ?", reviewerID).
+		Where("p.status = ?", entity.StatusInReview).
+		Order("p.submitted_at ASC", "p.id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list posts awaiting review: %w", err)
+	}
+
+	return posts, nil
+}
+
 // ListPublished 公開済み記事一覧を取得
 func (r *postRepositoryImpl) ListPublished(ctx context.Context, limit, offset int) ([]*entity.Post, error) {
 	posts := make([]*entity.Post, 0)
@@ -193,7 +282,7 @@ func (r *postRepositoryImpl) ListByTag(ctx context.Context, tagID int64, limit,
 	return posts, nil
 }
 
-// ListByAuthor 著者別記事一覧を取得
+// ListByAuthor 著者別の公開済み記事一覧を取得
 func (r *postRepositoryImpl) ListByAuthor(ctx context.Context, authorID int64, limit, offset int) ([]*entity.Post, error) {
 	posts := make([]*entity.Post, 0)
 	err := r.db.NewSelect().
@@ -202,7 +291,8 @@ func (r *postRepositoryImpl) ListByAuthor(ctx context.Context, authorID int64, l
 		Relation("Category").
 		Relation("Tags").
 		Where("p.author_id = ?", authorID).
-		Order("created_at DESC").
+		Where("p.status = ?", entity.StatusPublished).
+		Order("published_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Scan(ctx)
@@ -214,6 +304,172 @@ func (r *postRepositoryImpl) ListByAuthor(ctx context.Context, authorID int64, l
 	return posts, nil
 }
 
+// ListPublishedBetween 公開日時が[from, to)の範囲の公開済み記事一覧を取得
+func (r *postRepositoryImpl) ListPublishedBetween(ctx context.Context, from, to time.Time, limit, offset int) ([]*entity.Post, error) {
+	posts := make([]*entity.Post, 0)
+	err := r.db.NewSelect().
+		Model(&posts).
+		Relation("Author").
+		Relation("Category").
+		Relation("Tags").
+		Where("p.status = ?", entity.StatusPublished).
+		Where("p.published_at >= ?", from).
+		Where("p.published_at < ?", to).
+		Order("published_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list posts by date: %w", err)
+	}
+
+	return posts, nil
+}
+
+// PublishDue 公開予定日時を過ぎた予約記事を公開
+// SELECT ... FOR UPDATE SKIP LOCKED で対象行をロックするため、複数のインスタンスが
+// 同時に実行しても、各記事はいずれか1つのトランザクションでのみ公開される
+func (r *postRepositoryImpl) PublishDue(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	ids := make([]int64, 0)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Model((*entity.Post)(nil)).
+			Column("p.id").
+			Where("p.status = ?", entity.StatusScheduled).
+			Where("p.scheduled_at <= ?", now).
+			Order("p.scheduled_at ASC").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx, &ids)
+		if err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		// 公開日時は実際の処理時刻ではなく予約日時とする
+		_, err = tx.NewUpdate().
+			Model((*entity.Post)(nil)).
+			Set("status = ?", entity.StatusPublished).
+			Set("published_at = COALESCE(published_at, scheduled_at)").
+			Set("scheduled_at = NULL").
+			Set("updated_at = ?", now).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish scheduled posts: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Search 全文検索で記事を関連度の高い順に取得
+func (r *postRepositoryImpl) Search(ctx context.Context, query string, filters repository.PostSearchFilters, limit, offset int) ([]*repository.PostSearchResult, error) {
+	results := make([]*repository.PostSearchResult, 0)
+
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return results, nil
+	}
+	booleanQuery := search.BooleanQuery(terms)
+
+	// 一致した記事IDとスコアを取得してから、リレーションを含めて記事を読み込む
+	var hits []struct {
+		ID    int64   `bun:"id"`
+		Score float64 `bun:"score"`
+	}
+	err := r.newSearchQuery(booleanQuery, filters).
+		Column("p.id").
+		ColumnExpr(searchMatchExpr+" AS score", booleanQuery).
+		OrderExpr("score DESC").
+		Order("p.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx, &hits)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	if len(hits) == 0 {
+		return results, nil
+	}
+
+	ids := make([]int64, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+
+	posts := make([]*entity.Post, 0, len(ids))
+	err = r.db.NewSelect().
+		Model(&posts).
+		Relation("Author").
+		Relation("Category").
+		Relation("Tags").
+		Where("p.id IN (?)", bun.In(ids)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	postsByID := make(map[int64]*entity.Post, len(posts))
+	for _, post := range posts {
+		postsByID[post.ID] = post
+	}
+
+	for _, hit := range hits {
+		if post, ok := postsByID[hit.ID]; ok {
+			results = append(results, &repository.PostSearchResult{Post: post, Score: hit.Score})
+		}
+	}
+
+	return results, nil
+}
+
+// CountSearch 全文検索に一致する記事数を取得
+func (r *postRepositoryImpl) CountSearch(ctx context.Context, query string, filters repository.PostSearchFilters) (int, error) {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return 0, nil
+	}
+
+	count, err := r.newSearchQuery(search.BooleanQuery(terms), filters).Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	return count, nil
+}
+
+// newSearchQuery 全文検索と絞り込み条件を適用したクエリを作成
+func (r *postRepositoryImpl) newSearchQuery(booleanQuery string, filters repository.PostSearchFilters) *bun.SelectQuery {
+	q := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Where(searchMatchExpr, booleanQuery)
+
+	if filters.Status != "" {
+		q = q.Where("p.status = ?", filters.Status)
+	}
+	if filters.CategoryID != nil {
+		q = q.Where("p.category_id = ?", *filters.CategoryID)
+	}
+	if filters.AuthorID != nil {
+		q = q.Where("p.author_id = ?", *filters.AuthorID)
+	}
+	if filters.TagID != nil {
+		q = q.Where("EXISTS (SELECT 1 FROM post_tags AS pt WHERE pt.post_id = p.id AND pt.tag_id = ?)", *filters.TagID)
+	}
+
+	return q
+}
+
 // Count 記事数を取得
 func (r *postRepositoryImpl) Count(ctx context.Context) (int, error) {
 	count, err := r.db.NewSelect().
@@ -227,6 +483,44 @@ func (r *postRepositoryImpl) Count(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// CountAccessible ユーザーが著者の記事と共有された記事の数を取得
+func (r *postRepositoryImpl) CountAccessible(ctx context.Context, userID int64) (int, error) {
+	query := r.db.NewSelect().
+		Model((*entity.Post)(nil))
+	applyAccessibleFilter(query, userID)
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count accessible posts: %w", err)
+	}
+
+	return count, nil
+}
+
+// applyAccessibleFilter ユーザーが著者の記事と共有された記事に絞り込む条件をクエリに追加
+func applyAccessibleFilter(query *bun.SelectQuery, userID int64) {
+	query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("p.author_id = ?", userID).
+			WhereOr("EXISTS (SELECT 1 FROM post_shares AS ps WHERE ps.post_id = p.id AND ps.user_id = ?)", userID)
+	})
+}
+
+// CountAwaitingReview レビュー担当者のレビュー中の記事数を取得
+func (r *postRepositoryImpl) CountAwaitingReview(ctx context.Context, reviewerID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Where("reviewer_id = ?", reviewerID).
+		Where("status = ?", entity.StatusInReview).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count posts awaiting review: %w", err)
+	}
+
+	return count, nil
+}
+
 // CountPublished 公開済み記事数を取得
 func (r *postRepositoryImpl) CountPublished(ctx context.Context) (int, error) {
 	count, err := r.db.NewSelect().
@@ -241,6 +535,147 @@ func (r *postRepositoryImpl) CountPublished(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// CountByCategory カテゴリ別の公開済み記事数を取得
+func (r *postRepositoryImpl) CountByCategory(ctx context.Context, categoryID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Where("category_id = ?", categoryID).
+		Where("status = ?", entity.StatusPublished).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count posts by category: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByTag タグ別の公開済み記事数を取得
+func (r *postRepositoryImpl) CountByTag(ctx context.Context, tagID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Join("JOIN post_tags AS pt ON pt.post_id = p.id").
+		Where("pt.tag_id = ?", tagID).
+		Where("p.status = ?", entity.StatusPublished).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count posts by tag: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByAuthor 著者別の公開済み記事数を取得
+func (r *postRepositoryImpl) CountByAuthor(ctx context.Context, authorID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Where("author_id = ?", authorID).
+		Where("status = ?", entity.StatusPublished).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count posts by author: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountPublishedBetween 公開日時が[from, to)の範囲の公開済み記事数を取得
+func (r *postRepositoryImpl) CountPublishedBetween(ctx context.Context, from, to time.Time) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Where("status = ?", entity.StatusPublished).
+		Where("published_at >= ?", from).
+		Where("published_at < ?", to).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count posts by date: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountPublishedByMonth 月別の公開済み記事数を新しい月から順に取得
+func (r *postRepositoryImpl) CountPublishedByMonth(ctx context.Context) ([]*repository.MonthlyPostCount, error) {
+	counts := make([]*repository.MonthlyPostCount, 0)
+	err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		ColumnExpr("YEAR(p.published_at) AS year").
+		ColumnExpr("MONTH(p.published_at) AS month").
+		ColumnExpr("COUNT(*) AS count").
+		Where("p.status = ?", entity.StatusPublished).
+		Where("p.published_at IS NOT NULL").
+		GroupExpr("year, month").
+		OrderExpr("year DESC, month DESC").
+		Scan(ctx, &counts)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count posts by month: %w", err)
+	}
+
+	return counts, nil
+}
+
+// ListPublishedSitemapEntries サイトマップ用に公開済み記事のスラッグと更新日時を公開日時の新しい順に取得
+// 記事数が多くても軽量に取得できるよう、本文やリレーションは読み込まない
+func (r *postRepositoryImpl) ListPublishedSitemapEntries(ctx context.Context, limit, offset int) ([]*repository.PostSitemapEntry, error) {
+	entries := make([]*repository.PostSitemapEntry, 0)
+	err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Column("p.slug", "p.updated_at").
+		Where("p.status = ?", entity.StatusPublished).
+		Order("p.published_at DESC", "p.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx, &entries)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sitemap entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// LastUpdatedByCategory カテゴリごとの公開済み記事の最終更新日時を取得
+func (r *postRepositoryImpl) LastUpdatedByCategory(ctx context.Context) ([]*repository.PostGroupUpdate, error) {
+	updates := make([]*repository.PostGroupUpdate, 0)
+	err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		ColumnExpr("p.category_id AS group_id").
+		ColumnExpr("MAX(p.updated_at) AS updated_at").
+		Where("p.status = ?", entity.StatusPublished).
+		Where("p.category_id IS NOT NULL").
+		GroupExpr("p.category_id").
+		Scan(ctx, &updates)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last updated by category: %w", err)
+	}
+
+	return updates, nil
+}
+
+// LastUpdatedByTag タグごとの公開済み記事の最終更新日時を取得
+func (r *postRepositoryImpl) LastUpdatedByTag(ctx context.Context) ([]*repository.PostGroupUpdate, error) {
+	updates := make([]*repository.PostGroupUpdate, 0)
+	err := r.db.NewSelect().
+		Model((*entity.Post)(nil)).
+		Join("JOIN post_tags AS pt ON pt.post_id = p.id").
+		ColumnExpr("pt.tag_id AS group_id").
+		ColumnExpr("MAX(p.updated_at) AS updated_at").
+		Where("p.status = ?", entity.StatusPublished).
+		GroupExpr("pt.tag_id").
+		Scan(ctx, &updates)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last updated by tag: %w", err)
+	}
+
+	return updates, nil
+}
+
 // AddTags 記事にタグを追加
 func (r *postRepositoryImpl) AddTags(ctx context.Context, postID int64, tagIDs []int64) error {
 	if len(tagIDs) == 0 {
@@ -305,3 +305,385 @@ func TestPostRepository_RemoveTags(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, tags, 0)
 }
+
+func TestPostRepository_PublishDue(t *testing.T) {
+	repo, user, cleanup := setupPostTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// 公開日時を過ぎた予約記事
+	due := &entity.Post{
+		Title:        "Due Post",
+		Slug:         "due-post",
+		Content:      "Due content",
+		RenderedHTML: "<p>Due content</p>",
+		AuthorID:     user.ID,
+	}
+	due.Schedule(now.Add(-time.Minute))
+	require.NoError(t, repo.Create(ctx, due))
+
+	// 公開日時前の予約記事
+	future := &entity.Post{
+		Title:        "Future Post",
+		Slug:         "future-post",
+		Content:      "Future content",
+		RenderedHTML: "<p>Future content</p>",
+		AuthorID:     user.ID,
+	}
+	future.Schedule(now.Add(time.Hour))
+	require.NoError(t, repo.Create(ctx, future))
+
+	ids, err := repo.PublishDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{due.ID}, ids)
+
+	published, err := repo.FindByID(ctx, due.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusPublished, published.Status)
+	assert.Nil(t, published.ScheduledAt)
+	require.NotNil(t, published.PublishedAt)
+	assert.True(t, published.PublishedAt.Equal(now.Add(-time.Minute)))
+
+	stillScheduled, err := repo.FindByID(ctx, future.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusScheduled, stillScheduled.Status)
+
+	// 2回目の実行では何も公開されない
+	ids, err = repo.PublishDue(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestPostRepository_Search(t *testing.T) {
+	repo, user, cleanup := setupPostTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	posts := []*entity.Post{
+		{Title: "東京タワーの夜景", Slug: "tokyo-tower", Content: "東京タワーから見た夜景について"},
+		{Title: "京都の紅葉", Slug: "kyoto", Content: "東京から新幹線で京都へ"},
+		{Title: "大阪の食べ歩き", Slug: "osaka", Content: "たこ焼きとお好み焼き"},
+	}
+	for _, post := range posts {
+		post.RenderedHTML = "<p>" + post.Content + "</p>"
+		post.Status = entity.StatusPublished
+		post.AuthorID = user.ID
+		post.PublishedAt = &now
+		require.NoError(t, repo.Create(ctx, post))
+	}
+
+	draft := &entity.Post{
+		Title:        "東京の下書き",
+		Slug:         "tokyo-draft",
+		Content:      "東京について",
+		RenderedHTML: "<p>東京について</p>",
+		Status:       entity.StatusDraft,
+		AuthorID:     user.ID,
+	}
+	require.NoError(t, repo.Create(ctx, draft))
+
+	filters := repository.PostSearchFilters{Status: entity.StatusPublished}
+
+	// 日本語の語句で検索でき、タイトルと本文の両方に一致する記事が上位になる
+	results, err := repo.Search(ctx, "東京", filters, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, posts[0].ID, results[0].Post.ID)
+	assert.Equal(t, posts[1].ID, results[1].Post.ID)
+	assert.Greater(t, results[0].Score, results[1].Score)
+	assert.NotNil(t, results[0].Post.Author)
+
+	count, err := repo.CountSearch(ctx, "東京", filters)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// 複数語はすべてを含む記事のみに一致する
+	results, err = repo.Search(ctx, "東京 夜景", filters, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, posts[0].ID, results[0].Post.ID)
+
+	// 一致しない語
+	results, err = repo.Search(ctx, "北海道", filters, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// 検索語がない場合は空
+	results, err = repo.Search(ctx, `  ""  `, filters, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestPostRepository_ListPublishedBetween(t *testing.T) {
+	repo, user, cleanup := setupPostTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	dates := []time.Time{
+		time.Date(2025, time.January, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.February, 28, 12, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for i, date := range dates {
+		publishedAt := date
+		post := &entity.Post{
+			Title:        "Post",
+			Slug:         "post-" + publishedAt.Format("20060102150405"),
+			Content:      "content",
+			RenderedHTML: "<p>content</p>",
+			Status:       entity.StatusPublished,
+			AuthorID:     user.ID,
+			PublishedAt:  &publishedAt,
+		}
+		require.NoError(t, repo.Create(ctx, post), "post %d", i)
+	}
+
+	from := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	posts, err := repo.ListPublishedBetween(ctx, from, to, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	// 公開日時の新しい順
+	assert.True(t, posts[0].PublishedAt.Equal(dates[2]))
+	assert.True(t, posts[1].PublishedAt.Equal(dates[1]))
+
+	count, err := repo.CountPublishedBetween(ctx, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	monthly, err := repo.CountPublishedByMonth(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*repository.MonthlyPostCount{
+		{Year: 2025, Month: 3, Count: 1},
+		{Year: 2025, Month: 2, Count: 2},
+		{Year: 2025, Month: 1, Count: 1},
+	}, monthly)
+}
+
+func TestPostRepository_CountByFilters(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := persistence.NewPostRepository(db)
+	userRepo := persistence.NewUserRepository(db)
+	categoryRepo := persistence.NewCategoryRepository(db)
+	tagRepo := persistence.NewTagRepository(db)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	category := &entity.Category{Name: "Go", Slug: "go"}
+	require.NoError(t, categoryRepo.Create(ctx, category))
+
+	tag := &entity.Tag{Name: "Backend", Slug: "backend"}
+	require.NoError(t, tagRepo.Create(ctx, tag))
+
+	now := time.Now()
+	for i, status := range []entity.PostStatus{entity.StatusPublished, entity.StatusPublished, entity.StatusDraft} {
+		post := &entity.Post{
+			Title:        "Post",
+			Slug:         "post-" + string(rune('a'+i)),
+			Content:      "content",
+			RenderedHTML: "<p>content</p>",
+			Status:       status,
+			AuthorID:     user.ID,
+			CategoryID:   &category.ID,
+			PublishedAt:  &now,
+		}
+		require.NoError(t, repo.Create(ctx, post))
+		require.NoError(t, repo.AddTags(ctx, post.ID, []int64{tag.ID}))
+	}
+
+	// 下書きは含まれない
+	count, err := repo.CountByCategory(ctx, category.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = repo.CountByTag(ctx, tag.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = repo.CountByAuthor(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	posts, err := repo.ListByAuthor(ctx, user.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+}
+
+func TestPostRepository_SitemapEntries(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := persistence.NewPostRepository(db)
+	userRepo := persistence.NewUserRepository(db)
+	categoryRepo := persistence.NewCategoryRepository(db)
+	tagRepo := persistence.NewTagRepository(db)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	category := &entity.Category{Name: "Go", Slug: "go"}
+	require.NoError(t, categoryRepo.Create(ctx, category))
+
+	tag := &entity.Tag{Name: "Backend", Slug: "backend"}
+	require.NoError(t, tagRepo.Create(ctx, tag))
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i, status := range []entity.PostStatus{entity.StatusPublished, entity.StatusPublished, entity.StatusDraft} {
+		publishedAt := base.Add(time.Duration(i) * time.Minute)
+		post := &entity.Post{
+			Title:        "Post",
+			Slug:         "post-" + string(rune('a'+i)),
+			Content:      "content",
+			RenderedHTML: "<p>content</p>",
+			Status:       status,
+			AuthorID:     user.ID,
+			CategoryID:   &category.ID,
+			PublishedAt:  &publishedAt,
+		}
+		require.NoError(t, repo.Create(ctx, post))
+		require.NoError(t, repo.AddTags(ctx, post.ID, []int64{tag.ID}))
+	}
+
+	// 公開済みのみ、公開日時の新しい順
+	entries, err := repo.ListPublishedSitemapEntries(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "post-b", entries[0].Slug)
+	assert.Equal(t, "post-a", entries[1].Slug)
+	assert.False(t, entries[0].UpdatedAt.IsZero())
+
+	entries, err = repo.ListPublishedSitemapEntries(ctx, 10, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "post-a", entries[0].Slug)
+
+	categoryUpdates, err := repo.LastUpdatedByCategory(ctx)
+	require.NoError(t, err)
+	require.Len(t, categoryUpdates, 1)
+	assert.Equal(t, category.ID, categoryUpdates[0].GroupID)
+	assert.False(t, categoryUpdates[0].UpdatedAt.IsZero())
+
+	tagUpdates, err := repo.LastUpdatedByTag(ctx)
+	require.NoError(t, err)
+	require.Len(t, tagUpdates, 1)
+	assert.Equal(t, tag.ID, tagUpdates[0].GroupID)
+}
+
+func TestPostRepository_ListAccessible(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	shareRepo := persistence.NewPostShareRepository(db)
+
+	author := &entity.User{Username: "author", Email: "author@example.com", PasswordHash: "hash", Role: entity.RoleEditor, Status: entity.StatusActive}
+	require.NoError(t, userRepo.Create(ctx, author))
+	contributor := &entity.User{Username: "contributor", Email: "contributor@example.com", PasswordHash: "hash", Role: entity.RoleContributor, Status: entity.StatusActive}
+	require.NoError(t, userRepo.Create(ctx, contributor))
+
+	own := &entity.Post{Title: "Own", Slug: "own", Content: "content", Status: entity.StatusDraft, AuthorID: contributor.ID}
+	require.NoError(t, postRepo.Create(ctx, own))
+	shared := &entity.Post{Title: "Shared", Slug: "shared", Content: "content", Status: entity.StatusDraft, AuthorID: author.ID}
+	require.NoError(t, postRepo.Create(ctx, shared))
+	other := &entity.Post{Title: "Other", Slug: "other", Content: "content", Status: entity.StatusDraft, AuthorID: author.ID}
+	require.NoError(t, postRepo.Create(ctx, other))
+
+	require.NoError(t, shareRepo.Save(ctx, &entity.PostShare{PostID: shared.ID, UserID: contributor.ID}))
+
+	posts, err := postRepo.ListAccessible(ctx, contributor.ID, 10, 0)
+	require.NoError(t, err)
+	slugs := make([]string, len(posts))
+	for i, post := range posts {
+		slugs[i] = post.Slug
+	}
+	assert.ElementsMatch(t, []string{"own", "shared"}, slugs)
+
+	count, err := postRepo.CountAccessible(ctx, contributor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestPostRepository_ListAwaitingReview(t *testing.T) {
+	postRepo, author, cleanup := setupPostTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	older := now.Add(-time.Hour)
+	first := &entity.Post{Title: "First", Slug: "first", Content: "content", Status: entity.StatusInReview, AuthorID: author.ID, ReviewerID: &author.ID, SubmittedAt: &older}
+	require.NoError(t, postRepo.Create(ctx, first))
+	second := &entity.Post{Title: "Second", Slug: "second", Content: "content", Status: entity.StatusInReview, AuthorID: author.ID, ReviewerID: &author.ID, SubmittedAt: &now}
+	require.NoError(t, postRepo.Create(ctx, second))
+	rejected := &entity.Post{Title: "Rejected", Slug: "rejected", Content: "content", Status: entity.StatusRejected, AuthorID: author.ID, ReviewerID: &author.ID, SubmittedAt: &older}
+	require.NoError(t, postRepo.Create(ctx, rejected))
+
+	posts, err := postRepo.ListAwaitingReview(ctx, author.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, "first", posts[0].Slug)
+	assert.Equal(t, "second", posts[1].Slug)
+
+	count, err := postRepo.CountAwaitingReview(ctx, author.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = postRepo.CountAwaitingReview(ctx, author.ID+1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestPostRepository_IncrementPreviewVersion(t *testing.T) {
+	postRepo, author, cleanup := setupPostTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	post := &entity.Post{Title: "Draft", Slug: "draft", Content: "content", Status: entity.StatusDraft, AuthorID: author.ID}
+	require.NoError(t, postRepo.Create(ctx, post))
+	before, err := postRepo.FindByID(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, before.PreviewVersion)
+
+	version, err := postRepo.IncrementPreviewVersion(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	version, err = postRepo.IncrementPreviewVersion(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	// 記事の更新日時は変わらない
+	found, err := postRepo.FindByID(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.PreviewVersion)
+	assert.Equal(t, before.UpdatedAt, found.UpdatedAt)
+
+	_, err = postRepo.IncrementPreviewVersion(ctx, post.ID+1000)
+	assert.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// postRevisionRepositoryImpl PostRevisionRepositoryの実装
+type postRevisionRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewPostRevisionRepository 新しいPostRevisionRepositoryを作成
+func NewPostRevisionRepository(db *bun.DB) repository.PostRevisionRepository {
+	return &postRevisionRepositoryImpl{db: db}
+}
+
+// Create 新しいリビジョンを作成
+func (r *postRevisionRepositoryImpl) Create(ctx context.Context, revision *entity.PostRevision) error {
+	_, err := r.db.NewInsert().
+		Model(revision).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create post revision: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID IDでリビジョンを検索
+func (r *postRevisionRepositoryImpl) FindByID(ctx context.Context, id int64) (*entity.PostRevision, error) {
+	revision := new(entity.PostRevision)
+	err := r.db.NewSelect().
+		Model(revision).
+		Relation("Editor").
+		Where("pr.id = ?", id).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("post revision not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find post revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// ListByPost 記事のリビジョン一覧を新しい順に取得
+func (r *postRevisionRepositoryImpl) ListByPost(ctx context.Context, postID int64, limit, offset int) ([]*entity.PostRevision, error) {
+	revisions := make([]*entity.PostRevision, 0)
+	err := r.db.NewSelect().
+		Model(&revisions).
+		Relation("Editor").
+		Where("pr.post_id = ?", postID).
+		Order("pr.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// CountByPost 記事のリビジョン数を取得
+func (r *postRevisionRepositoryImpl) CountByPost(ctx context.Context, postID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*entity.PostRevision)(nil)).
+		Where("post_id = ?", postID).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count post revisions: %w", err)
+	}
+
+	return count, nil
+}
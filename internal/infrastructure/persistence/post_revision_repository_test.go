@@ -0,0 +1,108 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostRevisionRepository_CreateAndFind(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	repo := persistence.NewPostRevisionRepository(db)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	post := &entity.Post{
+		Title:    "Test Post",
+		Slug:     "test-post",
+		Content:  "Test content",
+		Status:   entity.StatusDraft,
+		AuthorID: user.ID,
+	}
+	require.NoError(t, postRepo.Create(ctx, post))
+
+	revision := entity.NewPostRevision(post, user.ID)
+	revision.TagIDs = []int64{1, 2}
+	require.NoError(t, repo.Create(ctx, revision))
+	assert.NotZero(t, revision.ID)
+
+	found, err := repo.FindByID(ctx, revision.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, found.PostID)
+	assert.Equal(t, "Test content", found.Content)
+	assert.Equal(t, []int64{1, 2}, found.TagIDs)
+	require.NotNil(t, found.Editor)
+	assert.Equal(t, user.Username, found.Editor.Username)
+}
+
+func TestPostRevisionRepository_ListByPost(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	repo := persistence.NewPostRevisionRepository(db)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	post := &entity.Post{
+		Title:    "Test Post",
+		Slug:     "test-post",
+		Content:  "v1",
+		Status:   entity.StatusDraft,
+		AuthorID: user.ID,
+	}
+	require.NoError(t, postRepo.Create(ctx, post))
+
+	for _, content := range []string{"v1", "v2", "v3"} {
+		post.Content = content
+		require.NoError(t, repo.Create(ctx, entity.NewPostRevision(post, user.ID)))
+	}
+
+	revisions, err := repo.ListByPost(ctx, post.ID, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, "v3", revisions[0].Content)
+	assert.Equal(t, "v2", revisions[1].Content)
+
+	count, err := repo.CountByPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestPostRevisionRepository_FindByID_NotFound(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	repo := persistence.NewPostRevisionRepository(db)
+
+	_, err := repo.FindByID(context.Background(), 99999)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// postShareRepositoryImpl PostShareRepositoryの実装
+type postShareRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewPostShareRepository 新しいPostShareRepositoryを作成
+func NewPostShareRepository(db *bun.DB) repository.PostShareRepository {
+	return &postShareRepositoryImpl{db: db}
+}
+
+// Save 記事を共有(既に共有済みの場合は最初に共有した日時と共有者を維持する)
+func (r *postShareRepositoryImpl) Save(ctx context.Context, share *entity.PostShare) error {
+	_, err := r.db.NewInsert().
+		Model(share).
+		On("DUPLICATE KEY UPDATE").
+		Set("post_id = post_id").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to save post share: %w", err)
+	}
+
+	return nil
+}
+
+// Delete 共有を解除(該当する共有がない場合はfalse)
+func (r *postShareRepositoryImpl) Delete(ctx context.Context, postID, userID int64) (bool, error) {
+	res, err := r.db.NewDelete().
+		Model((*entity.PostShare)(nil)).
+		Where("post_id = ?", postID).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete post share: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete post share: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Exists 記事がユーザーに共有されているかどうかを判定
+func (r *postShareRepositoryImpl) Exists(ctx context.Context, postID, userID int64) (bool, error) {
+	exists, err := r.db.NewSelect().
+		Model((*entity.PostShare)(nil)).
+		Where("ps.post_id = ?", postID).
+		Where("ps.user_id = ?", userID).
+		Exists(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check post share: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListByPost 記事の共有先をユーザー付きで共有した順に取得
+func (r *postShareRepositoryImpl) ListByPost(ctx context.Context, postID int64) ([]*entity.PostShare, error) {
+	shares := make([]*entity.PostShare, 0)
+	err := r.db.NewSelect().
+		Model(&shares).
+		Relation("User").
+		Where("ps.post_id = ?", postID).
+		Order("ps.created_at ASC", "ps.user_id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post shares: %w", err)
+	}
+
+	return shares, nil
+}
@@ -0,0 +1,59 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostShareRepository_SaveAndDelete(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	repo := persistence.NewPostShareRepository(db)
+
+	author := &entity.User{Username: "author", Email: "author@example.com", PasswordHash: "hash", Role: entity.RoleContributor, Status: entity.StatusActive}
+	require.NoError(t, userRepo.Create(ctx, author))
+	reviewer := &entity.User{Username: "reviewer", Email: "reviewer@example.com", PasswordHash: "hash", Role: entity.RoleViewer, Status: entity.StatusActive}
+	require.NoError(t, userRepo.Create(ctx, reviewer))
+
+	post := &entity.Post{Title: "Draft", Slug: "draft", Content: "content", Status: entity.StatusDraft, AuthorID: author.ID}
+	require.NoError(t, postRepo.Create(ctx, post))
+
+	exists, err := repo.Exists(ctx, post.ID, reviewer.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, repo.Save(ctx, &entity.PostShare{PostID: post.ID, UserID: reviewer.ID, SharedBy: &author.ID}))
+	// 共有済みの場合はエラーにならない
+	require.NoError(t, repo.Save(ctx, &entity.PostShare{PostID: post.ID, UserID: reviewer.ID}))
+
+	exists, err = repo.Exists(ctx, post.ID, reviewer.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	shares, err := repo.ListByPost(ctx, post.ID)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	require.NotNil(t, shares[0].User)
+	assert.Equal(t, "reviewer", shares[0].User.Username)
+	require.NotNil(t, shares[0].SharedBy)
+	assert.Equal(t, author.ID, *shares[0].SharedBy)
+
+	deleted, err := repo.Delete(ctx, post.ID, reviewer.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = repo.Delete(ctx, post.ID, reviewer.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// errRefreshTokenRotated 交換済み・失効済みのトークンを交換しようとした(トランザクションのロールバック用)
+var errRefreshTokenRotated = errors.New("refresh token has already been rotated")
+
+// refreshTokenRepositoryImpl RefreshTokenRepositoryの実装
+type refreshTokenRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewRefreshTokenRepository 新しいRefreshTokenRepositoryを作成
+func NewRefreshTokenRepository(db *bun.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{db: db}
+}
+
+// Create リフレッシュトークンを登録
+func (r *refreshTokenRepositoryImpl) Create(ctx context.Context, token *entity.RefreshToken) error {
+	_, err := r.db.NewInsert().
+		Model(token).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// FindByJTI JTIでリフレッシュトークンを検索
+func (r *refreshTokenRepositoryImpl) FindByJTI(ctx context.Context, jti string) (*entity.RefreshToken, error) {
+	token := new(entity.RefreshToken)
+	err := r.db.NewSelect().
+		Model(token).
+		Where("rt.jti = ?", jti).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("refresh token not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+// Rotate 使用可能なトークンを交換済みにして、同じファミリーの新しいトークンを登録
+// 同じトークンが同時に使われた場合に備え、条件付きの更新で1回だけ成功させる
+func (r *refreshTokenRepositoryImpl) Rotate(ctx context.Context, jti string, next *entity.RefreshToken) (bool, error) {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*entity.RefreshToken)(nil)).
+			Set("rotated_at = ?", time.Now()).
+			Where("jti = ?", jti).
+			Where("rotated_at IS NULL").
+			Where("revoked_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errRefreshTokenRotated
+		}
+
+		_, err = tx.NewInsert().
+			Model(next).
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, errRefreshTokenRotated) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return true, nil
+}
+
+// RevokeFamily ファミリーの全てのトークンを失効させる
+func (r *refreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := r.db.NewUpdate().
+		Model((*entity.RefreshToken)(nil)).
+		Set("revoked_at = ?", time.Now()).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRefreshTokenTest(t *testing.T) (repository.RefreshTokenRepository, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(context.Background(), user))
+
+	// リフレッシュトークンのファミリーはセッションと同じID
+	sessionRepo := persistence.NewSessionRepository(db)
+	for _, id := range []string{"family-1", "family-2"} {
+		require.NoError(t, sessionRepo.Create(context.Background(), &entity.Session{
+			ID:        id,
+			UserID:    user.ID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}))
+	}
+
+	return persistence.NewRefreshTokenRepository(db), user, cleanup
+}
+
+// newTestRefreshToken テスト用のリフレッシュトークンを作成
+func newTestRefreshToken(userID int64, jti, familyID string) *entity.RefreshToken {
+	return &entity.RefreshToken{
+		JTI:       jti,
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+}
+
+func TestRefreshTokenRepository_CreateAndFind(t *testing.T) {
+	repo, user, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	token := newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000001", "family-1")
+	require.NoError(t, repo.Create(ctx, token))
+	assert.NotZero(t, token.ID)
+
+	found, err := repo.FindByJTI(ctx, token.JTI)
+	require.NoError(t, err)
+	assert.Equal(t, "family-1", found.FamilyID)
+	assert.Equal(t, user.ID, found.UserID)
+	assert.True(t, found.IsUsable())
+
+	_, err = repo.FindByJTI(ctx, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestRefreshTokenRepository_Rotate(t *testing.T) {
+	repo, user, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	first := newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000001", "family-1")
+	require.NoError(t, repo.Create(ctx, first))
+
+	second := newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000002", "family-1")
+	rotated, err := repo.Rotate(ctx, first.JTI, second)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	found, err := repo.FindByJTI(ctx, first.JTI)
+	require.NoError(t, err)
+	assert.True(t, found.IsRotated())
+	found, err = repo.FindByJTI(ctx, second.JTI)
+	require.NoError(t, err)
+	assert.True(t, found.IsUsable())
+
+	// 交換済みのトークンは再度交換できず、新しいトークンも登録されない
+	third := newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000003", "family-1")
+	rotated, err = repo.Rotate(ctx, first.JTI, third)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+	_, err = repo.FindByJTI(ctx, third.JTI)
+	assert.Error(t, err)
+}
+
+func TestRefreshTokenRepository_RevokeFamily(t *testing.T) {
+	repo, user, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	first := newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000001", "family-1")
+	second := newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000002", "family-1")
+	other := newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000003", "family-2")
+	for _, token := range []*entity.RefreshToken{first, second, other} {
+		require.NoError(t, repo.Create(ctx, token))
+	}
+
+	require.NoError(t, repo.RevokeFamily(ctx, "family-1"))
+
+	for _, jti := range []string{first.JTI, second.JTI} {
+		found, err := repo.FindByJTI(ctx, jti)
+		require.NoError(t, err)
+		assert.True(t, found.IsRevoked())
+	}
+
+	found, err := repo.FindByJTI(ctx, other.JTI)
+	require.NoError(t, err)
+	assert.True(t, found.IsUsable())
+
+	// 失効済みのトークンは交換できない
+	rotated, err := repo.Rotate(ctx, second.JTI, newTestRefreshToken(user.ID, "00000000-0000-0000-0000-000000000004", "family-1"))
+	require.NoError(t, err)
+	assert.False(t, rotated)
+}
@@ -0,0 +1,145 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// reviewNoteRepositoryImpl ReviewNoteRepositoryの実装
+type reviewNoteRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewReviewNoteRepository 新しいReviewNoteRepositoryを作成
+func NewReviewNoteRepository(db *bun.DB) repository.ReviewNoteRepository {
+	return &reviewNoteRepositoryImpl{db: db}
+}
+
+// Create 新しいレビューノートを作成
+func (r *reviewNoteRepositoryImpl) Create(ctx context.Context, note *entity.ReviewNote) error {
+	_, err := r.db.NewInsert().
+		Model(note).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create review note: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID IDでレビューノートを書いたユーザー付きで検索
+func (r *reviewNoteRepositoryImpl) FindByID(ctx context.Context, id int64) (*entity.ReviewNote, error) {
+	note := new(entity.ReviewNote)
+	err := r.db.NewSelect().
+		Model(note).
+		Relation("Author").
+		Where("rn.id = ?", id).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("review note not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find review note: %w", err)
+	}
+
+	return note, nil
+}
+
+// Update 本文と解決状態を更新
+func (r *reviewNoteRepositoryImpl) Update(ctx context.Context, note *entity.ReviewNote) error {
+	_, err := r.db.NewUpdate().
+		Model(note).
+		Column("body", "resolved_at", "resolved_by").
+		WherePK().
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update review note: %w", err)
+	}
+
+	return nil
+}
+
+// Delete レビューノートを削除(返信は外部キーのON DELETE CASCADEで削除される)
+func (r *reviewNoteRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.NewDelete().
+		Model((*entity.ReviewNote)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete review note: %w", err)
+	}
+
+	return nil
+}
+
+// ListByPost 記事のレビューノートを書いたユーザー付きで作成順に取得
+func (r *reviewNoteRepositoryImpl) ListByPost(ctx context.Context, postID int64) ([]*entity.ReviewNote, error) {
+	notes := make([]*entity.ReviewNote, 0)
+	err := r.db.NewSelect().
+		Model(&notes).
+		Relation("Author").
+		Where("rn.post_id = ?", postID).
+		Order("rn.created_at ASC", "rn.id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// ListAnchored 記事の本文に紐付いたスレッドの先頭のノートを取得
+func (r *reviewNoteRepositoryImpl) ListAnchored(ctx context.Context, postID int64) ([]*entity.ReviewNote, error) {
+	notes := make([]*entity.ReviewNote, 0)
+	err := r.db.NewSelect().
+		Model(&notes).
+		Where("rn.post_id = ?", postID).
+		Where("rn.parent_id IS NULL").
+		Where("rn.start_line IS NOT NULL").
+		Order("rn.id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anchored review notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// UpdateAnchors 複数のレビューノートの紐付けた行範囲とOutdatedを1つのトランザクションで更新
+func (r *reviewNoteRepositoryImpl) UpdateAnchors(ctx context.Context, notes []*entity.ReviewNote) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, note := range notes {
+			if _, err := tx.NewUpdate().
+				Model(note).
+				Column("start_line", "end_line", "outdated").
+				WherePK().
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update review note anchors: %w", err)
+	}
+
+	return nil
+}
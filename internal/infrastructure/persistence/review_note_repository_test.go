@@ -0,0 +1,67 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewNoteRepository(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	noteRepo := persistence.NewReviewNoteRepository(db)
+
+	author := &entity.User{Username: "author", Email: "author@example.com", PasswordHash: "hash", Role: entity.RoleEditor, Status: entity.StatusActive}
+	require.NoError(t, userRepo.Create(ctx, author))
+	post := &entity.Post{Title: "Post", Slug: "post", Content: "one\ntwo", Status: entity.StatusDraft, AuthorID: author.ID}
+	require.NoError(t, postRepo.Create(ctx, post))
+
+	start, end := 1, 2
+	thread := &entity.ReviewNote{PostID: post.ID, AuthorID: &author.ID, Body: "note", StartLine: &start, EndLine: &end}
+	require.NoError(t, noteRepo.Create(ctx, thread))
+	reply := &entity.ReviewNote{PostID: post.ID, ParentID: &thread.ID, AuthorID: &author.ID, Body: "reply"}
+	require.NoError(t, noteRepo.Create(ctx, reply))
+	unanchored := &entity.ReviewNote{PostID: post.ID, AuthorID: &author.ID, Body: "general"}
+	require.NoError(t, noteRepo.Create(ctx, unanchored))
+
+	found, err := noteRepo.FindByID(ctx, thread.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "note", found.Body)
+	require.NotNil(t, found.Author)
+	assert.Equal(t, "author", found.Author.Username)
+
+	notes, err := noteRepo.ListByPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Len(t, notes, 3)
+
+	// 本文に紐付いたスレッドの先頭のノートのみ
+	anchored, err := noteRepo.ListAnchored(ctx, post.ID)
+	require.NoError(t, err)
+	require.Len(t, anchored, 1)
+	assert.Equal(t, thread.ID, anchored[0].ID)
+
+	newStart, newEnd := 3, 4
+	anchored[0].StartLine, anchored[0].EndLine, anchored[0].Outdated = &newStart, &newEnd, true
+	require.NoError(t, noteRepo.UpdateAnchors(ctx, anchored))
+	found, err = noteRepo.FindByID(ctx, thread.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, *found.StartLine)
+	assert.Equal(t, 4, *found.EndLine)
+	assert.True(t, found.Outdated)
+
+	// スレッドの先頭を削除すると返信も削除される
+	require.NoError(t, noteRepo.Delete(ctx, thread.ID))
+	_, err = noteRepo.FindByID(ctx, reply.ID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
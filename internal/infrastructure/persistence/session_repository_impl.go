@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// sessionRepositoryImpl SessionRepositoryの実装
+type sessionRepositoryImpl struct {
+	db *bun.DB
+}
+
+// NewSessionRepository 新しいSessionRepositoryを作成
+func NewSessionRepository(db *bun.DB) repository.SessionRepository {
+	return &sessionRepositoryImpl{db: db}
+}
+
+// Create セッションを作成
+func (r *sessionRepositoryImpl) Create(ctx context.Context, session *entity.Session) error {
+	if session.LastUsedAt.IsZero() {
+		session.LastUsedAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(session).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID IDでセッションを検索
+func (r *sessionRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	session := new(entity.Session)
+	err := r.db.NewSelect().
+		Model(session).
+		Where("s.id = ?", id).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("session not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActiveByUserID ユーザーの有効なセッションを最終利用日時の新しい順に取得
+func (r *sessionRepositoryImpl) ListActiveByUserID(ctx context.Context, userID int64) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	err := r.db.NewSelect().
+		Model(&sessions).
+		Where("s.user_id = ?", userID).
+		Where("s.revoked_at IS NULL").
+		Where("s.expires_at > ?", time.Now()).
+		Order("s.last_used_at DESC", "s.created_at DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Touch 最終利用日時を更新(expiresAtがゼロ値でなければ有効期限も更新)
+func (r *sessionRepositoryImpl) Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error {
+	query := r.db.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("last_used_at = ?", lastUsedAt).
+		Where("id = ?", id)
+	if !expiresAt.IsZero() {
+		query = query.Set("expires_at = ?", expiresAt)
+	}
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke ユーザーのセッションを失効させる(該当する有効なセッションがない場合はfalse)
+func (r *sessionRepositoryImpl) Revoke(ctx context.Context, userID int64, id string) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("revoked_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// RevokeByUserID ユーザーの全てのセッションを失効させる(exceptIDのセッションは除く)
+func (r *sessionRepositoryImpl) RevokeByUserID(ctx context.Context, userID int64, exceptID string) (int, error) {
+	query := r.db.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("revoked_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL")
+	if exceptID != "" {
+		query = query.Where("id <> ?", exceptID)
+	}
+
+	res, err := query.Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return int(affected), nil
+}
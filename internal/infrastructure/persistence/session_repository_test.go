@@ -0,0 +1,130 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSessionTest(t *testing.T) (repository.SessionRepository, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(context.Background(), user))
+
+	return persistence.NewSessionRepository(db), user, cleanup
+}
+
+// newTestSession テスト用のセッションを作成
+func newTestSession(userID int64, id string) *entity.Session {
+	return &entity.Session{
+		ID:        id,
+		UserID:    userID,
+		UserAgent: "Mozilla/5.0",
+		IPAddress: "192.0.2.1",
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+}
+
+func TestSessionRepository_CreateAndList(t *testing.T) {
+	repo, user, cleanup := setupSessionTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	active := newTestSession(user.ID, "session-1")
+	expired := newTestSession(user.ID, "session-2")
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	require.NoError(t, repo.Create(ctx, active))
+	require.NoError(t, repo.Create(ctx, expired))
+
+	found, err := repo.FindByID(ctx, "session-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Mozilla/5.0", found.UserAgent)
+	assert.Equal(t, "192.0.2.1", found.IPAddress)
+	assert.False(t, found.LastUsedAt.IsZero())
+
+	_, err = repo.FindByID(ctx, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	// 期限切れのセッションは一覧に含まない
+	sessions, err := repo.ListActiveByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "session-1", sessions[0].ID)
+}
+
+func TestSessionRepository_Touch(t *testing.T) {
+	repo, user, cleanup := setupSessionTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	session := newTestSession(user.ID, "session-1")
+	require.NoError(t, repo.Create(ctx, session))
+
+	lastUsedAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	expiresAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	require.NoError(t, repo.Touch(ctx, session.ID, lastUsedAt, expiresAt))
+
+	found, err := repo.FindByID(ctx, session.ID)
+	require.NoError(t, err)
+	assert.True(t, lastUsedAt.Equal(found.LastUsedAt))
+	assert.True(t, expiresAt.Equal(found.ExpiresAt))
+
+	// 有効期限を指定しない場合は最終利用日時のみ更新する
+	require.NoError(t, repo.Touch(ctx, session.ID, lastUsedAt.Add(time.Minute), time.Time{}))
+	found, err = repo.FindByID(ctx, session.ID)
+	require.NoError(t, err)
+	assert.True(t, expiresAt.Equal(found.ExpiresAt))
+}
+
+func TestSessionRepository_Revoke(t *testing.T) {
+	repo, user, cleanup := setupSessionTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, id := range []string{"session-1", "session-2", "session-3"} {
+		require.NoError(t, repo.Create(ctx, newTestSession(user.ID, id)))
+	}
+
+	revoked, err := repo.Revoke(ctx, user.ID, "session-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// 失効済みのセッションや他のユーザーのセッションは失効できない
+	revoked, err = repo.Revoke(ctx, user.ID, "session-1")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	revoked, err = repo.Revoke(ctx, user.ID+1, "session-2")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	count, err := repo.RevokeByUserID(ctx, user.ID, "session-2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	sessions, err := repo.ListActiveByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "session-2", sessions[0].ID)
+
+	count, err = repo.RevokeByUserID(ctx, user.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/domain/repository"
@@ -91,10 +92,15 @@ func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*en
 
 // Update ユーザー情報を更新
 func (r *userRepositoryImpl) Update(ctx context.Context, user *entity.User) error {
-	_, err := r.db.NewUpdate().
+	return r.update(ctx, r.db, user)
+}
+
+// update ユーザー情報を更新(トランザクション内でも使用する)
+func (r *userRepositoryImpl) update(ctx context.Context, db bun.IDB, user *entity.User) error {
+	_, err := db.NewUpdate().
 		Model(user).
 		OmitZero().
-		Column("username", "email", "password_hash", "role", "status", "updated_at").
+		Column("username", "email", "password_hash", "role", "status", "password_changed_at", "updated_at").
 		WherePK().
 		Exec(ctx)
 
@@ -105,6 +111,114 @@ func (r *userRepositoryImpl) Update(ctx context.Context, user *entity.User) erro
 	return nil
 }
 
+// UpdateKeepingActiveAdmin 他に有効な管理者がいる場合のみユーザー情報を更新
+// 有効な管理者の行をSELECT ... FOR UPDATEでロックしてから数えるため、
+// 最後の2人の管理者を同時に降格・無効化しても、後のトランザクションは先の変更後の人数で判定する
+func (r *userRepositoryImpl) UpdateKeepingActiveAdmin(ctx context.Context, user *entity.User) (bool, error) {
+	updated := false
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var adminIDs []int64
+		if err := tx.NewSelect().
+			Model((*entity.User)(nil)).
+			Column("id").
+			Where("role = ?", entity.RoleAdmin).
+			Where("status = ?", entity.StatusActive).
+			Order("id").
+			For("UPDATE").
+			Scan(ctx, &adminIDs); err != nil {
+			return err
+		}
+
+		others := 0
+		for _, id := range adminIDs {
+			if id != user.ID {
+				others++
+			}
+		}
+		if others == 0 {
+			return nil
+		}
+
+		if err := r.update(ctx, tx, user); err != nil {
+			return err
+		}
+		updated = true
+		return nil
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return updated, nil
+}
+
+// UpdatePassword パスワードを更新し、ユーザーのセッションとリフレッシュトークンのファミリーを全て失効させる
+// JWTの発行日時は秒単位のため、パスワードの変更と同じ秒に発行されたトークンもセッションの失効で無効にする
+func (r *userRepositoryImpl) UpdatePassword(ctx context.Context, user *entity.User) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().
+			Model(user).
+			Column("password_hash", "password_changed_at").
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		return revokeUserSessions(ctx, tx, user.ID, time.Now())
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
+
+// revokeUserSessions ユーザーのセッションとリフレッシュトークンのファミリーを全て失効させる(トランザクション内で使用する)
+func revokeUserSessions(ctx context.Context, db bun.IDB, userID int64, now time.Time) error {
+	if _, err := db.NewUpdate().
+		Model((*entity.Session)(nil)).
+		Set("revoked_at = ?", now).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if _, err := db.NewUpdate().
+		Model((*entity.RefreshToken)(nil)).
+		Set("revoked_at = ?", now).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePasswordHash パスワードのハッシュを同じパスワードの新しいハッシュに置き換える
+// パスワード自体は変わらないため、password_changed_atは更新しない(発行済みのトークンも有効なまま)
+func (r *userRepositoryImpl) UpdatePasswordHash(ctx context.Context, id int64, currentHash, newHash string) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*entity.User)(nil)).
+		Set("password_hash = ?", newHash).
+		Where("id = ?", id).
+		Where("password_hash = ?", currentHash).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to update password hash: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update password hash: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
 // Delete ユーザーを削除
 func (r *userRepositoryImpl) Delete(ctx context.Context, id int64) error {
 	_, err := r.db.NewDelete().
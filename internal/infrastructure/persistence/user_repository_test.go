@@ -111,6 +111,37 @@ func TestUserRepository_Update(t *testing.T) {
 	assert.Equal(t, "updated@example.com", found.Email)
 }
 
+func TestUserRepository_UpdatePasswordHash(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	repo := persistence.NewUserRepository(db)
+	ctx := context.Background()
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "old-hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	updated, err := repo.UpdatePasswordHash(ctx, user.ID, "old-hash", "new-hash")
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// 現在のハッシュが一致しない場合は置き換えない
+	updated, err = repo.UpdatePasswordHash(ctx, user.ID, "old-hash", "other-hash")
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	found, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", found.PasswordHash)
+	assert.Nil(t, found.PasswordChangedAt)
+}
+
 func TestUserRepository_Delete(t *testing.T) {
 	db, cleanup := testhelper.SetupTestDB(t)
 	defer cleanup()
@@ -189,3 +220,55 @@ func TestUserRepository_Count(t *testing.T) {
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, count, 5)
 }
+
+func TestUserRepository_UpdateKeepingActiveAdmin(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	repo := persistence.NewUserRepository(db)
+	ctx := context.Background()
+
+	// テストデータ作成(有効な管理者は1人、無効な管理者が1人)
+	newUser := func(username string, status entity.UserStatus) *entity.User {
+		user := &entity.User{
+			Username:     username,
+			Email:        username + "@example.com",
+			PasswordHash: "hashedpassword",
+			Role:         entity.RoleAdmin,
+			Status:       status,
+		}
+		require.NoError(t, repo.Create(ctx, user))
+		return user
+	}
+	admin := newUser("admin", entity.StatusActive)
+	inactive := newUser("inactive", entity.StatusInactive)
+
+	// 最後の有効な管理者は降格できない
+	admin.Role = entity.RoleEditor
+	updated, err := repo.UpdateKeepingActiveAdmin(ctx, admin)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	// 最後の有効な管理者は無効化できない
+	admin.Role = entity.RoleAdmin
+	admin.Status = entity.StatusInactive
+	updated, err = repo.UpdateKeepingActiveAdmin(ctx, admin)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	found, err := repo.FindByID(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleAdmin, found.Role)
+	assert.Equal(t, entity.StatusActive, found.Status)
+
+	// 他に有効な管理者がいれば無効化できる
+	inactive.Status = entity.StatusActive
+	require.NoError(t, repo.Update(ctx, inactive))
+	updated, err = repo.UpdateKeepingActiveAdmin(ctx, admin)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	found, err = repo.FindByID(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusInactive, found.Status)
+}
@@ -0,0 +1,166 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// commentLinkRel コメント中のリンクに付けるrel属性(検索エンジンの評価を渡さず、遷移先に参照元を渡さない)
+const commentLinkRel = "nofollow ugc noopener noreferrer"
+
+// commentRenderer 読者のコメント用に機能を制限したMarkdownRendererの実装
+type commentRenderer struct {
+	md goldmark.Markdown
+}
+
+// NewCommentRenderer コメント用のMarkdownRendererを作成
+// 生のHTML・画像・見出し・Mermaidは出力せず、リンクはhttp(s)とmailtoのみnofollow付きで出力する
+func NewCommentRenderer() MarkdownRenderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.Strikethrough, // 取り消し線
+			extension.Linkify,       // 自動リンク化
+		),
+		goldmark.WithRendererOptions(
+			html.WithHardWraps(), // 改行を<br>に変換
+			html.WithXHTML(),     // XHTML互換
+			// html.WithUnsafe()は使用しない（XSS対策）
+			renderer.WithNodeRenderers(
+				util.Prioritized(&commentNodeRenderer{Config: html.NewConfig()}, 100),
+			),
+		),
+	)
+
+	return &commentRenderer{md: md}
+}
+
+// Render コメントのMarkdownをHTMLにレンダリング
+func (r *commentRenderer) Render(source string) (string, error) {
+	if source == "" {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(source), &buf); err != nil {
+		return "", fmt.Errorf("failed to render comment: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// commentNodeRenderer コメントで許可しない要素を置き換えるgoldmarkのNodeRenderer
+type commentNodeRenderer struct {
+	html.Config
+}
+
+// SetOption goldmarkのHTMLレンダラーのオプション(XHTMLなど)を受け取る
+func (r *commentNodeRenderer) SetOption(name renderer.OptionName, value interface{}) {
+	r.Config.SetOption(name, value)
+}
+
+// RegisterFuncs 置き換えるノードのレンダリング関数を登録
+func (r *commentNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHeading, r.renderHeading)
+	reg.Register(ast.KindImage, r.renderImage)
+	reg.Register(ast.KindLink, r.renderLink)
+	reg.Register(ast.KindAutoLink, r.renderAutoLink)
+	reg.Register(ast.KindRawHTML, r.renderNothing)
+	reg.Register(ast.KindHTMLBlock, r.renderNothing)
+}
+
+// renderHeading 見出しは段落として出力(ページの見出し構造を崩さないため)
+func (r *commentNodeRenderer) renderHeading(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<p>")
+	} else {
+		_, _ = w.WriteString("</p>\n")
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderImage 画像は読み込まず代替テキストのみ出力(外部画像による追跡を防ぐため)
+func (r *commentNodeRenderer) renderImage(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.Write(util.EscapeHTML(altText(source, node)))
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+// renderLink 許可したスキームのリンクのみnofollow付きで出力し、それ以外はテキストのみ出力
+func (r *commentNodeRenderer) renderLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Link)
+	if !isAllowedCommentURL(n.Destination) {
+		return ast.WalkContinue, nil
+	}
+
+	if entering {
+		_, _ = w.WriteString(`<a href="`)
+		_, _ = w.Write(util.EscapeHTML(util.URLEscape(n.Destination, true)))
+		_ = w.WriteByte('"')
+		if n.Title != nil {
+			_, _ = w.WriteString(` title="`)
+			r.Writer.Write(w, n.Title)
+			_ = w.WriteByte('"')
+		}
+		_, _ = w.WriteString(` rel="` + commentLinkRel + `">`)
+	} else {
+		_, _ = w.WriteString("</a>")
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderAutoLink 自動リンクをnofollow付きで出力
+func (r *commentNodeRenderer) renderAutoLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.AutoLink)
+	label := n.Label(source)
+	destination := n.URL(source)
+	if n.AutoLinkType == ast.AutoLinkEmail && !bytes.HasPrefix(bytes.ToLower(destination), []byte("mailto:")) {
+		destination = append([]byte("mailto:"), destination...)
+	}
+
+	if !isAllowedCommentURL(destination) {
+		_, _ = w.Write(util.EscapeHTML(label))
+		return ast.WalkContinue, nil
+	}
+
+	_, _ = w.WriteString(`<a href="`)
+	_, _ = w.Write(util.EscapeHTML(util.URLEscape(destination, false)))
+	_, _ = w.WriteString(`" rel="` + commentLinkRel + `">`)
+	_, _ = w.Write(util.EscapeHTML(label))
+	_, _ = w.WriteString("</a>")
+	return ast.WalkContinue, nil
+}
+
+// renderNothing 生のHTMLは出力しない
+func (r *commentNodeRenderer) renderNothing(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	return ast.WalkSkipChildren, nil
+}
+
+// isAllowedCommentURL コメント中のリンクとして許可するURLか(http・https・mailtoのみ)
+func isAllowedCommentURL(destination []byte) bool {
+	u, err := url.Parse(string(destination))
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.Host != ""
+	case "mailto":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package renderer_test
+
+import (
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/renderer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentRenderer_Render(t *testing.T) {
+	commentRenderer := renderer.NewCommentRenderer()
+
+	tests := []struct {
+		name        string
+		source      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "basic formatting",
+			source:   "hello **bold** ~~del~~ `code`\nnext line",
+			contains: []string{"<strong>bold</strong>", "<del>del</del>", "<code>code</code>", "<br />"},
+		},
+		{
+			name:        "heading is rendered as paragraph",
+			source:      "# Title",
+			contains:    []string{"<p>Title</p>"},
+			notContains: []string{"<h1"},
+		},
+		{
+			name:        "image is rendered as alt text",
+			source:      "![tracking pixel](https://example.com/x.png)",
+			contains:    []string{"<p>tracking pixel</p>"},
+			notContains: []string{"<img", "example.com"},
+		},
+		{
+			name:     "link has nofollow",
+			source:   "[site](https://example.com)",
+			contains: []string{`<a href="https://example.com" rel="nofollow ugc noopener noreferrer">site</a>`},
+		},
+		{
+			name:        "disallowed link schemes are rendered as text",
+			source:      "[xss](javascript:alert(1)) [relative](/admin)",
+			contains:    []string{"xss", "relative"},
+			notContains: []string{"<a", "javascript"},
+		},
+		{
+			name:   "autolink has nofollow",
+			source: "see https://example.com/a and me@example.com",
+			contains: []string{
+				`<a href="https://example.com/a" rel="nofollow ugc noopener noreferrer">https://example.com/a</a>`,
+				`<a href="mailto:me@example.com" rel="nofollow ugc noopener noreferrer">me@example.com</a>`,
+			},
+		},
+		{
+			name:        "raw html is dropped",
+			source:      "<script>alert(1)</script>\n\ntext <b onclick=\"x\">bold</b>",
+			contains:    []string{"text bold"},
+			notContains: []string{"<script", "<b", "raw HTML omitted"},
+		},
+		{
+			name:     "empty content",
+			source:   "",
+			contains: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := commentRenderer.Render(tt.source)
+			require.NoError(t, err)
+
+			for _, expected := range tt.contains {
+				assert.Contains(t, result, expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, result, unexpected)
+			}
+		})
+	}
+}
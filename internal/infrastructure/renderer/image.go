@@ -0,0 +1,166 @@
+package renderer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// webpMimeType WebPのMIMEタイプ(<picture>の<source>として出力する)
+const webpMimeType = "image/webp"
+
+// ImageResolver 記事中の画像のURLから、レスポンシブ画像として出力するための情報を解決する
+type ImageResolver interface {
+	// ResolveImage 解決できない画像(外部の画像など)の場合はfalseを返す
+	ResolveImage(src string) (*ResponsiveImage, bool)
+}
+
+// ResponsiveImage レスポンシブ画像の情報
+type ResponsiveImage struct {
+	// Width, Height 原寸の幅と高さ(レイアウトのずれを防ぐためwidth・height属性に出力する)
+	Width  int
+	Height int
+	// Sources 原本を含む、幅・形式の異なる画像
+	Sources []ImageSource
+}
+
+// ImageSource 幅・形式の異なる画像
+type ImageSource struct {
+	URL      string
+	MimeType string
+	Width    int
+}
+
+// responsiveImageRenderer 画像をsrcset・sizes・loading・width・height付きで出力するgoldmarkのNodeRenderer
+type responsiveImageRenderer struct {
+	html.Config
+	resolver ImageResolver
+}
+
+// newResponsiveImageRenderer 新しいresponsiveImageRendererを作成
+func newResponsiveImageRenderer(resolver ImageResolver) renderer.NodeRenderer {
+	return &responsiveImageRenderer{
+		Config:   html.NewConfig(),
+		resolver: resolver,
+	}
+}
+
+// SetOption goldmarkのHTMLレンダラーのオプション(XHTMLなど)を受け取る
+func (r *responsiveImageRenderer) SetOption(name renderer.OptionName, value interface{}) {
+	r.Config.SetOption(name, value)
+}
+
+// RegisterFuncs 画像ノードのレンダリング関数を登録
+func (r *responsiveImageRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindImage, r.renderImage)
+}
+
+// renderImage 画像を出力
+// WebPの画像がある場合は<picture>で囲み、対応ブラウザにはWebPを配信する
+func (r *responsiveImageRenderer) renderImage(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.Image)
+
+	var resolved *ResponsiveImage
+	if !html.IsDangerousURL(n.Destination) {
+		resolved, _ = r.resolver.ResolveImage(string(n.Destination))
+	}
+
+	var webp, fallback []ImageSource
+	if resolved != nil {
+		for _, src := range resolved.Sources {
+			if src.MimeType == webpMimeType {
+				webp = append(webp, src)
+			} else {
+				fallback = append(fallback, src)
+			}
+		}
+		if len(fallback) == 0 {
+			fallback, webp = webp, nil
+		}
+	}
+
+	if len(webp) > 0 {
+		_, _ = w.WriteString("<picture>")
+		fmt.Fprintf(w, `<source type="%s" srcset="%s" sizes="%s"`, webpMimeType, srcset(webp), sizes(resolved))
+		r.closeTag(w)
+	}
+
+	_, _ = w.WriteString(`<img src="`)
+	if !html.IsDangerousURL(n.Destination) {
+		_, _ = w.Write(util.EscapeHTML(util.URLEscape(n.Destination, true)))
+	}
+	_, _ = w.WriteString(`" alt="`)
+	_, _ = w.Write(util.EscapeHTML(altText(source, n)))
+	_ = w.WriteByte('"')
+	if n.Title != nil {
+		_, _ = w.WriteString(` title="`)
+		r.Writer.Write(w, n.Title)
+		_ = w.WriteByte('"')
+	}
+	if resolved != nil {
+		if len(fallback) > 1 || len(webp) > 0 {
+			fmt.Fprintf(w, ` srcset="%s" sizes="%s"`, srcset(fallback), sizes(resolved))
+		}
+		fmt.Fprintf(w, ` width="%d" height="%d" loading="lazy" decoding="async"`, resolved.Width, resolved.Height)
+	}
+	if n.Attributes() != nil {
+		html.RenderAttributes(w, n, html.ImageAttributeFilter)
+	}
+	r.closeTag(w)
+
+	if len(webp) > 0 {
+		_, _ = w.WriteString("</picture>")
+	}
+
+	return ast.WalkSkipChildren, nil
+}
+
+// closeTag 空要素のタグを閉じる
+func (r *responsiveImageRenderer) closeTag(w util.BufWriter) {
+	if r.XHTML {
+		_, _ = w.WriteString(" />")
+	} else {
+		_, _ = w.WriteString(">")
+	}
+}
+
+// srcset 幅記述子付きのsrcset属性値を作成
+func srcset(sources []ImageSource) string {
+	candidates := make([]string, len(sources))
+	for i, src := range sources {
+		candidates[i] = string(util.EscapeHTML(util.URLEscape([]byte(src.URL), true))) + " " + strconv.Itoa(src.Width) + "w"
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// sizes 原寸を超えて拡大しないsizes属性値を作成
+func sizes(img *ResponsiveImage) string {
+	return fmt.Sprintf("(max-width: %dpx) 100vw, %dpx", img.Width, img.Width)
+}
+
+// altText 画像ノードの子要素から代替テキストを取り出す
+func altText(source []byte, n ast.Node) []byte {
+	var buf []byte
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			buf = append(buf, t.Value(source)...)
+			if t.SoftLineBreak() {
+				buf = append(buf, ' ')
+			}
+		case *ast.String:
+			buf = append(buf, t.Value...)
+		default:
+			buf = append(buf, altText(source, c)...)
+		}
+	}
+	return buf
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
 )
 
 // mermaidBlockRe Mermaidコードブロックマッチ用の正規表現（パフォーマンス最適化のため事前コンパイル）
@@ -29,7 +31,19 @@ type markdownRenderer struct {
 }
 
 // NewMarkdownRenderer 新しいMarkdownRendererを作成
-func NewMarkdownRenderer(mermaidRenderer MermaidRenderer) MarkdownRenderer {
+// imageResolverを指定すると、解決できた画像をsrcset・width・height付きのレスポンシブ画像として出力する
+func NewMarkdownRenderer(mermaidRenderer MermaidRenderer, imageResolver ImageResolver) MarkdownRenderer {
+	rendererOptions := []renderer.Option{
+		html.WithHardWraps(), // 改行を<br>に変換
+		html.WithXHTML(),     // XHTML互換
+		// html.WithUnsafe()は使用しない（XSS対策）
+	}
+	if imageResolver != nil {
+		rendererOptions = append(rendererOptions, renderer.WithNodeRenderers(
+			util.Prioritized(newResponsiveImageRenderer(imageResolver), 100),
+		))
+	}
+
 	md := goldmark.New(
 		goldmark.WithExtensions(
 			extension.GFM,           // GitHub Flavored Markdown
@@ -41,11 +55,7 @@ func NewMarkdownRenderer(mermaidRenderer MermaidRenderer) MarkdownRenderer {
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(), // 見出しに自動ID付与
 		),
-		goldmark.WithRendererOptions(
-			html.WithHardWraps(), // 改行を<br>に変換
-			html.WithXHTML(),     // XHTML互換
-			// html.WithUnsafe()は使用しない（XSS対策）
-		),
+		goldmark.WithRendererOptions(rendererOptions...),
 	)
 
 	return &markdownRenderer{
@@ -1,6 +1,7 @@
 package renderer_test
 
 import (
+	"strings"
 	"testing"
 
 	"my-blog-engine/internal/infrastructure/renderer"
@@ -11,7 +12,7 @@ import (
 
 func TestMarkdownRenderer_Render(t *testing.T) {
 	mermaidRenderer := renderer.NewMockMermaidRenderer()
-	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer)
+	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer, nil)
 
 	tests := []struct {
 		name     string
@@ -69,7 +70,7 @@ func TestMarkdownRenderer_Render(t *testing.T) {
 
 func TestMarkdownRenderer_RenderWithMermaid(t *testing.T) {
 	mermaidRenderer := renderer.NewMockMermaidRenderer()
-	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer)
+	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer, nil)
 
 	source := "# Diagram\n\n```mermaid\ngraph TD\n  A-->B\n```\n\nEnd."
 
@@ -83,3 +84,68 @@ func TestMarkdownRenderer_RenderWithMermaid(t *testing.T) {
 	assert.Contains(t, result, "<p>")
 	assert.Contains(t, result, "End")
 }
+
+// stubImageResolver テスト用のImageResolver
+type stubImageResolver map[string]*renderer.ResponsiveImage
+
+func (r stubImageResolver) ResolveImage(src string) (*renderer.ResponsiveImage, bool) {
+	img, ok := r[src]
+	return img, ok
+}
+
+func TestMarkdownRenderer_RenderResponsiveImage(t *testing.T) {
+	resolver := stubImageResolver{
+		"/media/a.jpg": {
+			Width:  1600,
+			Height: 900,
+			Sources: []renderer.ImageSource{
+				{URL: "/media/a-320w.jpg", MimeType: "image/jpeg", Width: 320},
+				{URL: "/media/a-320w.webp", MimeType: "image/webp", Width: 320},
+				{URL: "/media/a-1600w.webp", MimeType: "image/webp", Width: 1600},
+				{URL: "/media/a.jpg", MimeType: "image/jpeg", Width: 1600},
+			},
+		},
+		"/media/b.gif": {
+			Width:   100,
+			Height:  50,
+			Sources: []renderer.ImageSource{{URL: "/media/b.gif", MimeType: "image/gif", Width: 100}},
+		},
+	}
+	mdRenderer := renderer.NewMarkdownRenderer(renderer.NewMockMermaidRenderer(), resolver)
+
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:   "with webp variants",
+			source: `![A & B](/media/a.jpg "title")`,
+			expected: `<p><picture><source type="image/webp" srcset="/media/a-320w.webp 320w, /media/a-1600w.webp 1600w" sizes="(max-width: 1600px) 100vw, 1600px" />` +
+				`<img src="/media/a.jpg" alt="A &amp; B" title="title" srcset="/media/a-320w.jpg 320w, /media/a.jpg 1600w" sizes="(max-width: 1600px) 100vw, 1600px" width="1600" height="900" loading="lazy" decoding="async" /></picture></p>`,
+		},
+		{
+			name:     "without variants",
+			source:   "![anim](/media/b.gif)",
+			expected: `<p><img src="/media/b.gif" alt="anim" width="100" height="50" loading="lazy" decoding="async" /></p>`,
+		},
+		{
+			name:     "external image",
+			source:   "![ext](https://example.com/c.png)",
+			expected: `<p><img src="https://example.com/c.png" alt="ext" /></p>`,
+		},
+		{
+			name:     "dangerous url",
+			source:   "![x](javascript:alert(1))",
+			expected: `<p><img src="" alt="x" /></p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := mdRenderer.Render(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, strings.TrimSpace(result))
+		})
+	}
+}
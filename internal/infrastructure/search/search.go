@@ -0,0 +1,162 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// maxTerms 1回の検索で使用する最大語数
+const maxTerms = 10
+
+// Terms 検索クエリを空白区切りの検索語に分割する
+// 全文検索の演算子として解釈されないよう二重引用符は除去し、重複は取り除く
+func Terms(query string) []string {
+	seen := make(map[string]bool)
+	terms := make([]string, 0)
+	for _, field := range strings.Fields(query) {
+		term := strings.ReplaceAll(field, `"`, "")
+		if term == "" || seen[strings.ToLower(term)] {
+			continue
+		}
+		seen[strings.ToLower(term)] = true
+		terms = append(terms, term)
+		if len(terms) == maxTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// BooleanQuery 検索語をMySQLのBOOLEAN MODE用クエリに変換する
+// 各語をフレーズ検索として必須条件にする(ngramパーサーでは語の部分一致ではなく連続した文字列として一致させるため)
+func BooleanQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = `+"` + term + `"`
+	}
+	return strings.Join(parts, " ")
+}
+
+// PlainText レンダリング済みHTMLからタグを除いた本文テキストを取り出す
+// 図(svg)やスクリプト等の中身は本文として扱わない
+func PlainText(renderedHTML string) string {
+	var sb strings.Builder
+	skipUntil := ""
+	s := renderedHTML
+
+	for len(s) > 0 {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			if skipUntil == "" {
+				sb.WriteString(s)
+			}
+			break
+		}
+		if skipUntil == "" {
+			sb.WriteString(s[:lt])
+		}
+
+		gt := strings.IndexByte(s[lt:], '>')
+		if gt < 0 {
+			break
+		}
+		tag := strings.ToLower(s[lt+1 : lt+gt])
+		s = s[lt+gt+1:]
+
+		name := strings.TrimPrefix(tag, "/")
+		if i := strings.IndexFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == '/' }); i >= 0 {
+			name = name[:i]
+		}
+
+		switch {
+		case skipUntil != "":
+			if tag == "/"+skipUntil {
+				skipUntil = ""
+			}
+		case name == "svg" || name == "script" || name == "style":
+			if !strings.HasPrefix(tag, "/") && !strings.HasSuffix(tag, "/") {
+				skipUntil = name
+			}
+		default:
+			// ブロック要素の境界で語が連結しないよう空白を挟む
+			sb.WriteByte(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(html.UnescapeString(sb.String())), " ")
+}
+
+// Snippet テキストから最初に検索語が現れる付近を切り出し、検索語を<mark>で強調したHTMLを返す
+// テキストはHTMLエスケープされるため、戻り値はそのままHTMLとして出力してよい
+func Snippet(text string, terms []string, maxRunes int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	lowerTerms := make([][]rune, 0, len(terms))
+	for _, term := range terms {
+		if t := []rune(strings.ToLower(term)); len(t) > 0 {
+			lowerTerms = append(lowerTerms, t)
+		}
+	}
+
+	// matchAt 位置iから始まる最長の検索語の長さ(一致しなければ0)
+	matchAt := func(i int) int {
+		longest := 0
+		for _, t := range lowerTerms {
+			if len(t) > longest && hasRunePrefix(lower[i:], t) {
+				longest = len(t)
+			}
+		}
+		return longest
+	}
+
+	// 最初の一致位置の少し手前から切り出す(末尾付近の一致では切り出し幅を前方に広げる)
+	start := 0
+	for i := range lower {
+		if matchAt(i) > 0 {
+			start = max(min(i-maxRunes/4, len(runes)-maxRunes), 0)
+			break
+		}
+	}
+	end := min(start+maxRunes, len(runes))
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("…")
+	}
+	for i := start; i < end; {
+		if n := matchAt(i); n > 0 {
+			sb.WriteString("<mark>")
+			sb.WriteString(html.EscapeString(string(runes[i : i+n])))
+			sb.WriteString("</mark>")
+			i += n
+			// 強調中の語は途中で切らない
+			end = max(end, i)
+			continue
+		}
+		sb.WriteString(html.EscapeString(string(runes[i])))
+		i++
+	}
+	if end < len(runes) {
+		sb.WriteString("…")
+	}
+
+	return sb.String()
+}
+
+// hasRunePrefix sがprefixで始まるかを判定
+func hasRunePrefix(s, prefix []rune) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if s[i] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/search"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "whitespace separated",
+			query:    "  Go　言語  ",
+			expected: []string{"Go", "言語"},
+		},
+		{
+			name:     "quotes removed",
+			query:    `"東京" タワー"`,
+			expected: []string{"東京", "タワー"},
+		},
+		{
+			name:     "duplicates removed",
+			query:    "go Go GO",
+			expected: []string{"go"},
+		},
+		{
+			name:     "empty",
+			query:    ` "" `,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, search.Terms(tt.query))
+		})
+	}
+}
+
+func TestBooleanQuery(t *testing.T) {
+	assert.Equal(t, `+"東京" +"タワー"`, search.BooleanQuery([]string{"東京", "タワー"}))
+	// 演算子はフレーズ内ではそのまま文字列として扱われる
+	assert.Equal(t, `+"-go*"`, search.BooleanQuery([]string{"-go*"}))
+}
+
+func TestPlainText(t *testing.T) {
+	rendered := `<h1 id="title">見出し</h1>
+<p>本文 &amp; <code>&lt;code&gt;</code></p>
+<p><svg viewBox="0 0 10 10"><text>図のラベル</text></svg></p><p>末尾</p>`
+
+	assert.Equal(t, "見出し 本文 & <code> 末尾", search.PlainText(rendered))
+}
+
+func TestSnippet(t *testing.T) {
+	text := strings.Repeat("あ", 100) + "東京タワーは" + strings.Repeat("い", 100)
+
+	snippet := search.Snippet(text, []string{"東京"}, 40)
+
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Contains(t, snippet, "<mark>東京</mark>タワーは")
+}
+
+func TestSnippet_EscapesHTML(t *testing.T) {
+	snippet := search.Snippet(`<script>alert("x")</script> Go`, []string{"go"}, 100)
+
+	assert.Equal(t, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <mark>Go</mark>", snippet)
+}
+
+func TestSnippet_NoMatch(t *testing.T) {
+	snippet := search.Snippet("短い本文", []string{"該当なし"}, 100)
+
+	assert.Equal(t, "短い本文", snippet)
+}
@@ -0,0 +1,87 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// MaxURLs 1つのサイトマップに含められるURLの上限(sitemaps.orgの仕様)
+const MaxURLs = 50000
+
+// ContentType サイトマップのContent-Type
+const ContentType = "application/xml; charset=utf-8"
+
+// namespace サイトマップのXML名前空間
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL サイトマップに掲載するURL
+type URL struct {
+	Loc string
+	// LastMod 最終更新日時(ゼロ値の場合は出力しない)
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []urlElement `xml:"url"`
+}
+
+type urlElement struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []urlElement `xml:"sitemap"`
+}
+
+// Render URLの一覧をサイトマップ(urlset)として出力
+func Render(urls []URL) ([]byte, error) {
+	if len(urls) > MaxURLs {
+		return nil, fmt.Errorf("too many urls for a sitemap: %d", len(urls))
+	}
+
+	doc := urlSet{
+		XMLNS: namespace,
+		URLs:  toElements(urls),
+	}
+	return marshal(doc)
+}
+
+// RenderIndex 分割したサイトマップの一覧をサイトマップインデックスとして出力
+func RenderIndex(sitemaps []URL) ([]byte, error) {
+	if len(sitemaps) > MaxURLs {
+		return nil, fmt.Errorf("too many sitemaps for a sitemap index: %d", len(sitemaps))
+	}
+
+	doc := sitemapIndex{
+		XMLNS:    namespace,
+		Sitemaps: toElements(sitemaps),
+	}
+	return marshal(doc)
+}
+
+// toElements URLをXML要素に変換(日時はW3C Datetime形式)
+func toElements(urls []URL) []urlElement {
+	elements := make([]urlElement, len(urls))
+	for i, u := range urls {
+		elements[i] = urlElement{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			elements[i].LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	return elements
+}
+
+// marshal XML宣言付きで出力
+func marshal(v interface{}) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sitemap: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,62 @@
+package sitemap_test
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/infrastructure/sitemap"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	data, err := sitemap.Render([]sitemap.URL{
+		{Loc: "https://example.com/"},
+		{Loc: "https://example.com/posts/a&b", LastMod: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+		URLs    []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"url"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+
+	require.Len(t, doc.URLs, 2)
+	assert.Equal(t, "https://example.com/", doc.URLs[0].Loc)
+	assert.Empty(t, doc.URLs[0].LastMod)
+	assert.Equal(t, "https://example.com/posts/a&b", doc.URLs[1].Loc)
+	assert.Equal(t, "2025-01-02T03:04:05Z", doc.URLs[1].LastMod)
+	assert.Contains(t, string(data), "a&amp;b")
+}
+
+func TestRender_TooManyURLs(t *testing.T) {
+	_, err := sitemap.Render(make([]sitemap.URL, sitemap.MaxURLs+1))
+	assert.Error(t, err)
+}
+
+func TestRenderIndex(t *testing.T) {
+	data, err := sitemap.RenderIndex([]sitemap.URL{
+		{Loc: "https://example.com/sitemaps/1.xml", LastMod: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Loc: "https://example.com/sitemaps/2.xml"},
+	})
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName  xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+		Sitemaps []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"sitemap"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+
+	require.Len(t, doc.Sitemaps, 2)
+	assert.Equal(t, "https://example.com/sitemaps/1.xml", doc.Sitemaps[0].Loc)
+	assert.Equal(t, "2025-01-02T00:00:00Z", doc.Sitemaps[0].LastMod)
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// localStorage ローカルファイルシステムに保存するStorageの実装
+type localStorage struct {
+	root *os.Root
+}
+
+// NewLocalStorage ディレクトリdirを保存先とするStorageを作成(ディレクトリが存在しない場合は作成)
+// ファイル操作はos.Rootを通して行い、シンボリックリンク等でdirの外にアクセスできないようにする
+func NewLocalStorage(dir string) (Storage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage directory: %w", err)
+	}
+
+	return &localStorage{root: root}, nil
+}
+
+// Put ファイルを保存(同じキーが存在する場合は上書き)
+// 書き込み途中のファイルが読み出されないよう、一時ファイルに書き込んでからリネームする
+func (s *localStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	if dir := path.Dir(key); dir != "." {
+		if err := s.root.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	tmp := key + ".tmp"
+	f, err := s.root.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		_ = s.root.Remove(tmp)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = s.root.Remove(tmp)
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	if err := s.root.Rename(tmp, key); err != nil {
+		_ = s.root.Remove(tmp)
+		return fmt.Errorf("failed to rename file: %w", err)
+	}
+
+	return nil
+}
+
+// Open ファイルを読み出す(呼び出し側でCloseすること)
+func (s *localStorage) Open(ctx context.Context, key string) (*Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	f, err := s.root.Open(key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		_ = f.Close()
+		return nil, ErrNotFound
+	}
+
+	return &Object{
+		ReadSeekCloser: f,
+		Size:           info.Size(),
+		ModTime:        info.ModTime(),
+	}, nil
+}
+
+// Delete ファイルを削除(存在しない場合はErrNotFound)
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	if err := s.root.Remove(key); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/infrastructure/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_PutOpenDelete(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(filepath.Join(dir, "uploads"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := "2025/01/image.png"
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("hello")))
+
+	obj, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	require.NoError(t, obj.Close())
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, int64(5), obj.Size)
+	assert.False(t, obj.ModTime.IsZero())
+
+	// 上書き
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("world!")))
+	obj, err = store.Open(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), obj.Size)
+	require.NoError(t, obj.Close())
+
+	// 一時ファイルは残らない
+	entries, err := os.ReadDir(filepath.Join(dir, "uploads", "2025", "01"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Open(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, key), storage.ErrNotFound)
+}
+
+func TestLocalStorage_OpenDirectory(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "2025/01/image.png", strings.NewReader("x")))
+
+	_, err = store.Open(ctx, "2025/01")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(filepath.Join(dir, "uploads"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o600))
+
+	ctx := context.Background()
+	for _, key := range []string{"", "/etc/passwd", "../secret.txt", "a/../../secret.txt", "a//b", "a\\b", "./a"} {
+		t.Run(key, func(t *testing.T) {
+			assert.ErrorIs(t, store.Put(ctx, key, strings.NewReader("x")), storage.ErrInvalidKey)
+			_, err := store.Open(ctx, key)
+			assert.ErrorIs(t, err, storage.ErrInvalidKey)
+			assert.ErrorIs(t, store.Delete(ctx, key), storage.ErrInvalidKey)
+		})
+	}
+}
+
+func TestLocalStorage_SymlinkEscape(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "uploads")
+	store, err := storage.NewLocalStorage(root)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "secret.txt"), filepath.Join(root, "link.png")))
+
+	// 保存先の外を指すシンボリックリンクは辿らない
+	_, err = store.Open(context.Background(), "link.png")
+	assert.Error(t, err)
+}
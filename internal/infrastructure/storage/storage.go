@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotFound 指定したキーのファイルが存在しない
+var ErrNotFound = errors.New("storage object not found")
+
+// ErrInvalidKey キーの形式が不正(空、絶対パス、親ディレクトリへの参照など)
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage アップロードファイルの保存先のインターフェース
+// キーは"/"区切りの相対パス(例: 2025/01/xxxx.png)
+type Storage interface {
+	// Put ファイルを保存(同じキーが存在する場合は上書き)
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Open ファイルを読み出す(呼び出し側でCloseすること)
+	Open(ctx context.Context, key string) (*Object, error)
+
+	// Delete ファイルを削除(存在しない場合はErrNotFound)
+	Delete(ctx context.Context, key string) error
+}
+
+// Object ストレージから読み出したファイル
+type Object struct {
+	io.ReadSeekCloser
+	Size    int64
+	ModTime time.Time
+}
+
+// ValidateKey キーが保存先の外を指さない正規化された相対パスか検証
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") ||
+		path.Clean(key) != key || key == "." || key == ".." || strings.HasPrefix(key, "../") {
+		return ErrInvalidKey
+	}
+	return nil
+}
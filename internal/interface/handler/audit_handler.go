@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// AuditHandler 監査ログの管理ハンドラー
+type AuditHandler struct {
+	auditUseCase usecase.AuditUseCase
+}
+
+// NewAuditHandler 新しいAuditHandlerを作成
+func NewAuditHandler(auditUseCase usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+	}
+}
+
+// AuditEventResponse 監査ログのレスポンス
+type AuditEventResponse struct {
+	ID         int64                  `json:"id"`
+	ActorID    *int64                 `json:"actorId"`
+	ActorName  string                 `json:"actorName"`
+	Action     entity.AuditAction     `json:"action"`
+	TargetType entity.AuditTargetType `json:"targetType"`
+	TargetID   *int64                 `json:"targetId"`
+	Before     map[string]any         `json:"before"`
+	After      map[string]any         `json:"after"`
+	IPAddress  string                 `json:"ipAddress"`
+	UserAgent  string                 `json:"userAgent"`
+	CreatedAt  time.Time              `json:"createdAt"`
+}
+
+// List 監査ログ一覧ハンドラー(新しい順、cursorに前のページのnextCursorを指定して続きを取得)
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	filters := repository.AuditEventFilters{
+		Action:     entity.AuditAction(query.Get("action")),
+		TargetType: entity.AuditTargetType(query.Get("targetType")),
+	}
+	if actorID := query.Get("actorId"); actorID != "" {
+		id, err := strconv.ParseInt(actorID, 10, 64)
+		if err != nil {
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid actor ID")
+			return
+		}
+		filters.ActorID = &id
+	}
+	if targetID := query.Get("targetId"); targetID != "" {
+		id, err := strconv.ParseInt(targetID, 10, 64)
+		if err != nil {
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid target ID")
+			return
+		}
+		filters.TargetID = &id
+	}
+	if from := query.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid from: must be RFC3339")
+			return
+		}
+		filters.From = &t
+	}
+	if to := query.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid to: must be RFC3339")
+			return
+		}
+		filters.To = &t
+	}
+
+	events, next, err := h.auditUseCase.List(r.Context(), filters, query.Get("cursor"), limit)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid cursor") {
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list audit events")
+		return
+	}
+
+	items := make([]*AuditEventResponse, len(events))
+	for i, event := range events {
+		items[i] = &AuditEventResponse{
+			ID:         event.ID,
+			ActorID:    event.ActorID,
+			ActorName:  event.ActorName,
+			Action:     event.Action,
+			TargetType: event.TargetType,
+			TargetID:   event.TargetID,
+			Before:     event.Before,
+			After:      event.After,
+			IPAddress:  event.IPAddress,
+			UserAgent:  event.UserAgent,
+			CreatedAt:  event.CreatedAt,
+		}
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"events":     items,
+		"nextCursor": next,
+		"limit":      limit,
+	})
+}
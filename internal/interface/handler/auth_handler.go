@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"my-blog-engine/internal/interface/middleware"
@@ -12,13 +15,15 @@ import (
 
 // AuthHandler 認証ハンドラー
 type AuthHandler struct {
-	authUseCase usecase.AuthUseCase
+	authUseCase          usecase.AuthUseCase
+	passwordResetUseCase usecase.PasswordResetUseCase
 }
 
 // NewAuthHandler 新しいAuthHandlerを作成
-func NewAuthHandler(authUseCase usecase.AuthUseCase) *AuthHandler {
+func NewAuthHandler(authUseCase usecase.AuthUseCase, passwordResetUseCase usecase.PasswordResetUseCase) *AuthHandler {
 	return &AuthHandler{
-		authUseCase: authUseCase,
+		authUseCase:          authUseCase,
+		passwordResetUseCase: passwordResetUseCase,
 	}
 }
 
@@ -33,6 +38,12 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// MFAChallengeRequest ログインの2段階目のリクエスト
+type MFAChallengeRequest struct {
+	MFAToken string `json:"mfaToken"`
+	Code     string `json:"code"`
+}
+
 // Login ログインハンドラー
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
@@ -50,6 +61,9 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	// ログイン処理
 	response, err := h.authUseCase.Login(r.Context(), req.Username, req.Password)
 	if err != nil {
+		if writeLoginLockedError(w, err) {
+			return
+		}
 		if strings.Contains(err.Error(), "invalid credentials") {
 			presenter.JSONError(w, http.StatusUnauthorized, "Invalid username or password")
 		} else if strings.Contains(err.Error(), "inactive") {
@@ -63,22 +77,30 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	presenter.JSONResponse(w, http.StatusOK, response)
 }
 
-// Logout ログアウトハンドラー
-func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	// Authorizationヘッダーからトークンを取得
+// bearerToken Authorizationヘッダーからトークンを取得
+func bearerToken(w http.ResponseWriter, r *http.Request) (string, bool) {
 	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
 		presenter.JSONError(w, http.StatusUnauthorized, "Authorization header required")
-		return
+		return "", false
 	}
 
 	parts := strings.Split(authHeader, " ")
 	if len(parts) != 2 || parts[0] != "Bearer" {
 		presenter.JSONError(w, http.StatusUnauthorized, "Invalid authorization header format")
-		return
+		return "", false
 	}
 
-	token := parts[1]
+	return parts[1], true
+}
+
+// Logout ログアウトハンドラー
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	// Authorizationヘッダーからトークンを取得
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
 
 	// ログアウト処理
 	if err := h.authUseCase.Logout(r.Context(), token); err != nil {
@@ -107,6 +129,10 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "revoked") {
 			presenter.JSONError(w, http.StatusUnauthorized, "Invalid or revoked refresh token")
+		} else if strings.Contains(err.Error(), "two-factor authentication is required") {
+			presenter.JSONError(w, http.StatusForbidden, "Two-factor authentication is required; log in again to enroll")
+		} else if strings.Contains(err.Error(), "inactive") {
+			presenter.JSONError(w, http.StatusForbidden, "User account is inactive")
 		} else {
 			presenter.JSONError(w, http.StatusInternalServerError, "Token refresh failed")
 		}
@@ -126,3 +152,293 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 
 	presenter.JSONResponse(w, http.StatusOK, user)
 }
+
+// ForgotPassword パスワード再設定の申請ハンドラー
+// メールアドレスの登録有無にかかわらず同じレスポンスを返す
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req usecase.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.passwordResetUseCase.Request(r.Context(), &req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusAccepted, presenter.SuccessResponse{
+		Success: true,
+		Message: "If the email address is registered, a password reset email has been sent",
+	})
+}
+
+// ResetPassword パスワード再設定ハンドラー
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req usecase.PasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.passwordResetUseCase.Reset(r.Context(), &req); err != nil {
+		if writePasswordPolicyError(w, err) {
+			return
+		}
+
+		switch {
+		case strings.Contains(err.Error(), "not found"),
+			strings.Contains(err.Error(), "already been used"),
+			strings.Contains(err.Error(), "has expired"),
+			strings.Contains(err.Error(), "inactive"):
+			// トークンが無効な理由は区別せずに返す
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid or expired reset token")
+		case strings.Contains(err.Error(), "is required"):
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		default:
+			presenter.JSONError(w, http.StatusInternalServerError, "Password reset failed")
+		}
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Password reset successfully")
+}
+
+// ChangePassword ログイン中のユーザー本人のパスワード変更ハンドラー
+// 変更前のセッションは全て失効するため、このクライアント用の新しいトークンを返す
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	var req usecase.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.authUseCase.ChangePassword(r.Context(), token, &req)
+	if err != nil {
+		if writePasswordPolicyError(w, err) || writeLoginLockedError(w, err) {
+			return
+		}
+
+		switch {
+		case strings.Contains(err.Error(), "invalid current password"):
+			presenter.JSONError(w, http.StatusBadRequest, "Current password is incorrect")
+		case strings.Contains(err.Error(), "is required"):
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeSessionError(w, err, "Password change failed")
+		}
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// writeMFAChallengeError ログインの2段階目のエラーをHTTPステータスに変換して返す
+func writeMFAChallengeError(w http.ResponseWriter, err error, fallback string) {
+	if writeLoginLockedError(w, err) {
+		return
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "invalid mfa token"),
+		strings.Contains(err.Error(), "mfa token is required"):
+		presenter.JSONError(w, http.StatusUnauthorized, "Invalid or expired mfa token")
+	case strings.Contains(err.Error(), "inactive"):
+		presenter.JSONError(w, http.StatusForbidden, "User account is inactive")
+	default:
+		writeMFAError(w, err, fallback)
+	}
+}
+
+// writeLoginLockedError ロック中のエラーであれば429とRetry-Afterを書き込んでtrueを返す
+func writeLoginLockedError(w http.ResponseWriter, err error) bool {
+	var lockedErr *usecase.LoginLockedError
+	if !errors.As(err, &lockedErr) {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+	presenter.JSONError(w, http.StatusTooManyRequests, "Too many failed login attempts. Please try again later")
+	return true
+}
+
+// VerifyMFA ログインの2段階目(ワンタイムコードまたはリカバリーコードの検証)ハンドラー
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req MFAChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.authUseCase.VerifyMFA(r.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		writeMFAChallengeError(w, err, "Login failed")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// BeginMFAEnrollment ログイン時の二要素認証の登録開始ハンドラー
+func (h *AuthHandler) BeginMFAEnrollment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req MFAChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	setup, err := h.authUseCase.BeginMFAEnrollment(r.Context(), req.MFAToken)
+	if err != nil {
+		writeMFAChallengeError(w, err, "Failed to set up two-factor authentication")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, setup)
+}
+
+// CompleteMFAEnrollment ログイン時の二要素認証の登録完了ハンドラー
+func (h *AuthHandler) CompleteMFAEnrollment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req MFAChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.authUseCase.CompleteMFAEnrollment(r.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		writeMFAChallengeError(w, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// writeSessionError ユースケースのエラーをHTTPステータスに変換して返す
+func writeSessionError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		presenter.JSONError(w, http.StatusNotFound, err.Error())
+	case strings.Contains(err.Error(), "is required"):
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+	case strings.Contains(err.Error(), "invalid"),
+		strings.Contains(err.Error(), "revoked"),
+		strings.Contains(err.Error(), "inactive"):
+		presenter.JSONError(w, http.StatusUnauthorized, "Invalid or expired token")
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// ListSessions 自分の有効なセッション一覧ハンドラー
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.authUseCase.ListSessions(r.Context(), token)
+	if err != nil {
+		writeSessionError(w, err, "Failed to list sessions")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession 自分のセッションを失効させるハンドラー
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authUseCase.RevokeSession(r.Context(), token, r.URL.Query().Get("id")); err != nil {
+		writeSessionError(w, err, "Failed to revoke session")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Session revoked successfully")
+}
+
+// RevokeOtherSessions 現在のセッション以外の自分のセッションを全て失効させるハンドラー
+func (h *AuthHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := h.authUseCase.RevokeOtherSessions(r.Context(), token)
+	if err != nil {
+		writeSessionError(w, err, "Failed to revoke sessions")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"revoked": count,
+	})
+}
+
+// RevokeUserSessions ユーザーの全てのセッションを失効させるハンドラー(管理者による強制ログアウト)
+func (h *AuthHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	count, err := h.authUseCase.RevokeUserSessions(r.Context(), id)
+	if err != nil {
+		writeSessionError(w, err, "Failed to revoke sessions")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"revoked": count,
+	})
+}
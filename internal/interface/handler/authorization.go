@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// authorize 認証済みのユーザーが操作できるかを確認し、権限がない場合は403を返す
+// リソースを伴わない操作ではresourceはnil
+func authorize(w http.ResponseWriter, r *http.Request, authorizer usecase.Authorizer, action usecase.Action, resource *usecase.Resource) bool {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return false
+	}
+
+	if err := authorizer.Authorize(user, action, resource); err != nil {
+		presenter.JSONError(w, http.StatusForbidden, "Forbidden: insufficient permissions")
+		return false
+	}
+
+	return true
+}
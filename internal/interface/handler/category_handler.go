@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"my-blog-engine/internal/interface/presenter"
 	"my-blog-engine/internal/usecase"
@@ -12,17 +13,23 @@ import (
 // CategoryHandler カテゴリハンドラー
 type CategoryHandler struct {
 	categoryUseCase usecase.CategoryUseCase
+	authorizer      usecase.Authorizer
 }
 
 // NewCategoryHandler 新しいCategoryHandlerを作成
-func NewCategoryHandler(categoryUseCase usecase.CategoryUseCase) *CategoryHandler {
+func NewCategoryHandler(categoryUseCase usecase.CategoryUseCase, authorizer usecase.Authorizer) *CategoryHandler {
 	return &CategoryHandler{
 		categoryUseCase: categoryUseCase,
+		authorizer:      authorizer,
 	}
 }
 
 // Create カテゴリ作成ハンドラー
 func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, usecase.ActionCategoryCreate, nil) {
+		return
+	}
+
 	var req usecase.CreateCategoryRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
@@ -40,6 +47,10 @@ func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 // Update カテゴリ更新ハンドラー
 func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, usecase.ActionCategoryUpdate, nil) {
+		return
+	}
+
 	idStr := r.URL.Query().Get("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -64,6 +75,10 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 // Delete カテゴリ削除ハンドラー
 func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, usecase.ActionCategoryDelete, nil) {
+		return
+	}
+
 	idStr := r.URL.Query().Get("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -72,6 +87,10 @@ func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.categoryUseCase.Delete(r.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Category not found")
+			return
+		}
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to delete category")
 		return
 	}
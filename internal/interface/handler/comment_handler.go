@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// CommentHandler コメント管理ハンドラー
+type CommentHandler struct {
+	commentUseCase usecase.CommentUseCase
+}
+
+// NewCommentHandler 新しいCommentHandlerを作成
+func NewCommentHandler(commentUseCase usecase.CommentUseCase) *CommentHandler {
+	return &CommentHandler{
+		commentUseCase: commentUseCase,
+	}
+}
+
+// CommentResponse コメントのレスポンス
+type CommentResponse struct {
+	ID           int64                `json:"id"`
+	PostID       int64                `json:"postId"`
+	PostTitle    string               `json:"postTitle,omitempty"`
+	PostSlug     string               `json:"postSlug,omitempty"`
+	ParentID     *int64               `json:"parentId"`
+	AuthorName   string               `json:"authorName"`
+	AuthorEmail  string               `json:"authorEmail"`
+	AuthorURL    string               `json:"authorUrl"`
+	Content      string               `json:"content"`
+	RenderedHTML string               `json:"renderedHtml"`
+	Status       entity.CommentStatus `json:"status"`
+	IPAddress    string               `json:"ipAddress"`
+	UserAgent    string               `json:"userAgent"`
+	CreatedAt    time.Time            `json:"createdAt"`
+	UpdatedAt    time.Time            `json:"updatedAt"`
+}
+
+// newCommentResponse コメントをレスポンスに変換
+func newCommentResponse(comment *entity.Comment) *CommentResponse {
+	response := &CommentResponse{
+		ID:           comment.ID,
+		PostID:       comment.PostID,
+		ParentID:     comment.ParentID,
+		AuthorName:   comment.AuthorName,
+		AuthorEmail:  comment.AuthorEmail,
+		AuthorURL:    comment.AuthorURL,
+		Content:      comment.Content,
+		RenderedHTML: comment.RenderedHTML,
+		Status:       comment.Status,
+		IPAddress:    comment.IPAddress,
+		UserAgent:    comment.UserAgent,
+		CreatedAt:    comment.CreatedAt,
+		UpdatedAt:    comment.UpdatedAt,
+	}
+	if comment.Post != nil {
+		response.PostTitle = comment.Post.Title
+		response.PostSlug = comment.Post.Slug
+	}
+	return response
+}
+
+// List コメント一覧ハンドラー(モデレーションキュー)
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	filters := repository.CommentFilters{
+		Status: entity.CommentStatus(query.Get("status")),
+	}
+	if postIDStr := query.Get("postId"); postIDStr != "" {
+		postID, err := strconv.ParseInt(postIDStr, 10, 64)
+		if err != nil {
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+			return
+		}
+		filters.PostID = &postID
+	}
+
+	comments, count, err := h.commentUseCase.List(r.Context(), filters, limit, offset)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid comment status") {
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list comments")
+		return
+	}
+
+	items := make([]*CommentResponse, len(comments))
+	for i, comment := range comments {
+		items[i] = newCommentResponse(comment)
+	}
+
+	response := map[string]interface{}{
+		"comments": items,
+		"total":    count,
+		"limit":    limit,
+		"offset":   offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// Moderate コメント一括モデレーションハンドラー
+func (h *CommentHandler) Moderate(w http.ResponseWriter, r *http.Request) {
+	var req usecase.ModerateCommentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	updated, err := h.commentUseCase.Moderate(r.Context(), &req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "invalid comment status"),
+			strings.Contains(err.Error(), "comment ids are required"),
+			strings.Contains(err.Error(), "too many comments"):
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		default:
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to moderate comments")
+		}
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"updated": updated,
+		"status":  req.Status,
+	})
+}
+
+// Delete コメント削除ハンドラー
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := r.URL.Query().Get("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	if err := h.commentUseCase.Delete(r.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to delete comment")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Comment deleted successfully")
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/feed"
+	"my-blog-engine/internal/usecase"
+)
+
+// feedItemLimit フィードに含める記事の最大件数
+const feedItemLimit = 20
+
+// defaultFeedCacheTTL フィードキャッシュのデフォルト有効期間
+const defaultFeedCacheTTL = 5 * time.Minute
+
+// FeedConfig フィード出力の設定
+type FeedConfig struct {
+	// SiteURL サイトの絶対URL(例: https://blog.example.com)
+	SiteURL         string
+	SiteTitle       string
+	SiteDescription string
+	// CacheTTL 生成したフィードを再利用する期間
+	CacheTTL time.Duration
+}
+
+// FeedHandler RSS/Atom/JSON Feedのハンドラー
+type FeedHandler struct {
+	postUseCase     usecase.PostUseCase
+	categoryUseCase usecase.CategoryUseCase
+	tagUseCase      usecase.TagUseCase
+	config          FeedConfig
+	cache           *responseCache
+}
+
+// NewFeedHandler FeedHandlerのコンストラクタ
+func NewFeedHandler(
+	postUseCase usecase.PostUseCase,
+	categoryUseCase usecase.CategoryUseCase,
+	tagUseCase usecase.TagUseCase,
+	config FeedConfig,
+) *FeedHandler {
+	config.SiteURL = strings.TrimRight(config.SiteURL, "/")
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaultFeedCacheTTL
+	}
+
+	return &FeedHandler{
+		postUseCase:     postUseCase,
+		categoryUseCase: categoryUseCase,
+		tagUseCase:      tagUseCase,
+		config:          config,
+		cache:           newResponseCache(config.CacheTTL),
+	}
+}
+
+// feedSource フィードの対象範囲
+type feedSource struct {
+	// basePath 対象の一覧ページのパス(サイト全体の場合は空文字列)
+	basePath string
+	title    string
+	list     postLister
+}
+
+// Site サイト全体のフィード
+func (h *FeedHandler) Site(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context) (*feedSource, error) {
+		return &feedSource{
+			title: h.config.SiteTitle,
+			list:  h.postUseCase.ListPublished,
+		}, nil
+	})
+}
+
+// Category カテゴリ別のフィード
+func (h *FeedHandler) Category(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	h.serve(w, r, func(ctx context.Context) (*feedSource, error) {
+		category, err := h.categoryUseCase.GetBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		return &feedSource{
+			basePath: "/category/" + url.PathEscape(slug),
+			title:    h.config.SiteTitle + " - カテゴリ: " + category.Name,
+			list: func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
+				return h.postUseCase.ListByCategory(ctx, slug, limit, offset)
+			},
+		}, nil
+	})
+}
+
+// Tag タグ別のフィード
+func (h *FeedHandler) Tag(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	h.serve(w, r, func(ctx context.Context) (*feedSource, error) {
+		tag, err := h.tagUseCase.GetBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		return &feedSource{
+			basePath: "/tag/" + url.PathEscape(slug),
+			title:    h.config.SiteTitle + " - タグ: " + tag.Name,
+			list: func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
+				return h.postUseCase.ListByTag(ctx, slug, limit, offset)
+			},
+		}, nil
+	})
+}
+
+// Author 著者別のフィード
+func (h *FeedHandler) Author(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	h.serve(w, r, func(ctx context.Context) (*feedSource, error) {
+		return &feedSource{
+			basePath: "/author/" + url.PathEscape(username),
+			title:    h.config.SiteTitle + " - 著者: " + username,
+			list: func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
+				return h.postUseCase.ListByAuthor(ctx, username, limit, offset)
+			},
+		}, nil
+	})
+}
+
+// serve フィードを返す共通処理
+// キャッシュが有効な間はデータベースにアクセスせず、条件付きGET(If-None-Match/If-Modified-Since)には304を返す
+func (h *FeedHandler) serve(w http.ResponseWriter, r *http.Request, resolve func(ctx context.Context) (*feedSource, error)) {
+	format, err := feed.ParseFormat(r.PathValue("format"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	key := r.URL.Path
+	entry := h.cache.get(key)
+	if entry == nil {
+		body, lastModified, err := h.generate(r.Context(), format, resolve)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				http.NotFound(w, r)
+				return
+			}
+			log.Printf("Feed generation error: %v", err)
+			http.Error(w, "Failed to generate feed", http.StatusInternalServerError)
+			return
+		}
+		entry = h.cache.put(key, body, lastModified)
+	}
+
+	h.cache.serve(w, r, format.ContentType(), entry)
+}
+
+// generate 記事を取得してフィードを生成し、本文と最終更新日時を返す
+func (h *FeedHandler) generate(ctx context.Context, format feed.Format, resolve func(ctx context.Context) (*feedSource, error)) ([]byte, time.Time, error) {
+	source, err := resolve(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	posts, _, err := source.list(ctx, feedItemLimit, 0)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	link := h.config.SiteURL + source.basePath
+	if source.basePath == "" {
+		link += "/"
+	}
+
+	f := &feed.Feed{
+		Title:       source.title,
+		Description: h.config.SiteDescription,
+		Link:        link,
+		FeedURL:     h.config.SiteURL + source.basePath + "/feed/" + string(format),
+		Items:       make([]*feed.Item, len(posts)),
+	}
+
+	var lastModified time.Time
+	for i, post := range posts {
+		f.Items[i] = h.newFeedItem(post)
+		if post.UpdatedAt.After(lastModified) {
+			lastModified = post.UpdatedAt
+		}
+	}
+	f.Updated = lastModified
+	if f.Updated.IsZero() {
+		f.Updated = time.Now()
+	}
+
+	body, err := feed.Render(f, format)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return body, lastModified, nil
+}
+
+// newFeedItem 記事をフィードの項目に変換
+func (h *FeedHandler) newFeedItem(post *entity.Post) *feed.Item {
+	link := h.config.SiteURL + "/posts/" + url.PathEscape(post.Slug)
+	item := &feed.Item{
+		ID:          link,
+		Title:       post.Title,
+		Link:        link,
+		ContentHTML: post.RenderedHTML,
+		Summary:     excerpt(post.RenderedHTML),
+		Updated:     post.UpdatedAt,
+	}
+	if post.PublishedAt != nil {
+		item.Published = *post.PublishedAt
+	} else {
+		item.Published = post.CreatedAt
+	}
+	if post.Author != nil {
+		item.Author = post.Author.Username
+	}
+	if post.Category != nil {
+		item.Categories = append(item.Categories, post.Category.Name)
+	}
+	for _, tag := range post.Tags {
+		item.Categories = append(item.Categories, tag.Name)
+	}
+	return item
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/interface/middleware"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// InvitationHandler 招待ハンドラー
+type InvitationHandler struct {
+	invitationUseCase usecase.InvitationUseCase
+}
+
+// NewInvitationHandler 新しいInvitationHandlerを作成
+func NewInvitationHandler(invitationUseCase usecase.InvitationUseCase) *InvitationHandler {
+	return &InvitationHandler{
+		invitationUseCase: invitationUseCase,
+	}
+}
+
+// InvitationResponse 招待のレスポンス(トークンのハッシュは含めない)
+type InvitationResponse struct {
+	ID         int64           `json:"id"`
+	Email      string          `json:"email"`
+	Role       entity.UserRole `json:"role"`
+	Status     string          `json:"status"`
+	InvitedBy  *int64          `json:"invitedBy,omitempty"`
+	Inviter    string          `json:"inviter,omitempty"`
+	UserID     *int64          `json:"userId,omitempty"`
+	ExpiresAt  time.Time       `json:"expiresAt"`
+	AcceptedAt *time.Time      `json:"acceptedAt,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// newInvitationResponse 招待をレスポンスに変換
+func newInvitationResponse(invitation *entity.Invitation, now time.Time) *InvitationResponse {
+	status := "pending"
+	switch {
+	case invitation.IsAccepted():
+		status = "accepted"
+	case invitation.IsExpired(now):
+		status = "expired"
+	}
+
+	res := &InvitationResponse{
+		ID:         invitation.ID,
+		Email:      invitation.Email,
+		Role:       invitation.Role,
+		Status:     status,
+		InvitedBy:  invitation.InvitedBy,
+		UserID:     invitation.UserID,
+		ExpiresAt:  invitation.ExpiresAt,
+		AcceptedAt: invitation.AcceptedAt,
+		CreatedAt:  invitation.CreatedAt,
+	}
+	if invitation.Inviter != nil {
+		res.Inviter = invitation.Inviter.Username
+	}
+	return res
+}
+
+// writeInvitationError ユースケースのエラーをHTTPステータスに変換して返す
+func writeInvitationError(w http.ResponseWriter, err error, fallback string) {
+	if writePasswordPolicyError(w, err) {
+		return
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "invitation not found"):
+		presenter.JSONError(w, http.StatusNotFound, "Invitation not found")
+	case strings.Contains(err.Error(), "already been used"),
+		strings.Contains(err.Error(), "has expired"):
+		presenter.JSONError(w, http.StatusGone, err.Error())
+	case strings.Contains(err.Error(), "already exists"):
+		presenter.JSONError(w, http.StatusConflict, err.Error())
+	case strings.Contains(err.Error(), "failed to send invitation"):
+		presenter.JSONError(w, http.StatusBadGateway, "Failed to send invitation")
+	case strings.Contains(err.Error(), "is required"),
+		strings.Contains(err.Error(), "invalid"):
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// List 招待一覧ハンドラー
+func (h *InvitationHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	invitations, count, err := h.invitationUseCase.List(r.Context(), limit, offset)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list invitations")
+		return
+	}
+
+	now := time.Now()
+	items := make([]*InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		items[i] = newInvitationResponse(invitation, now)
+	}
+
+	response := map[string]interface{}{
+		"invitations": items,
+		"total":       count,
+		"limit":       limit,
+		"offset":      offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// Create 招待作成ハンドラー(招待トークンはメールでのみ送り、レスポンスには含めない)
+func (h *InvitationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		presenter.JSONError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req usecase.CreateInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	invitation, err := h.invitationUseCase.Create(r.Context(), user.ID, &req)
+	if err != nil {
+		writeInvitationError(w, err, "Failed to create invitation")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusCreated, newInvitationResponse(invitation, time.Now()))
+}
+
+// Revoke 招待取り消しハンドラー
+func (h *InvitationHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.invitationUseCase.Revoke(r.Context(), id); err != nil {
+		writeInvitationError(w, err, "Failed to revoke invitation")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Invitation revoked successfully")
+}
+
+// Accept 招待受諾ハンドラー(認証不要)
+func (h *InvitationHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req usecase.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.invitationUseCase.Accept(r.Context(), &req)
+	if err != nil {
+		writeInvitationError(w, err, "Failed to accept invitation")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusCreated, newUserResponse(user))
+}
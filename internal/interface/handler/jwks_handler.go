@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/interface/presenter"
+)
+
+// jwksCacheMaxAge JWKSのキャッシュ期間(秒)
+// 鍵を追加してから署名に使い始めるまで、この期間以上あける
+const jwksCacheMaxAge = "300"
+
+// JWKSHandler JWTの検証用公開鍵を公開するハンドラー
+type JWKSHandler struct {
+	jwtManager auth.JWTManager
+}
+
+// NewJWKSHandler 新しいJWKSHandlerを作成
+func NewJWKSHandler(jwtManager auth.JWTManager) *JWKSHandler {
+	return &JWKSHandler{
+		jwtManager: jwtManager,
+	}
+}
+
+// Keys JWKS(JSON Web Key Set)を返す
+func (h *JWKSHandler) Keys(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age="+jwksCacheMaxAge)
+	presenter.JSONResponse(w, http.StatusOK, h.jwtManager.JWKS())
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// LoginProtectionHandler ログインの総当たり対策の管理ハンドラー
+type LoginProtectionHandler struct {
+	loginProtection usecase.LoginProtectionUseCase
+}
+
+// NewLoginProtectionHandler 新しいLoginProtectionHandlerを作成
+func NewLoginProtectionHandler(loginProtection usecase.LoginProtectionUseCase) *LoginProtectionHandler {
+	return &LoginProtectionHandler{
+		loginProtection: loginProtection,
+	}
+}
+
+// LoginLockoutResponse ログインのロックのレスポンス
+type LoginLockoutResponse struct {
+	Scope         entity.LoginThrottleScope `json:"scope"`
+	Key           string                    `json:"key"`
+	FailureCount  int                       `json:"failureCount"`
+	LockedUntil   *time.Time                `json:"lockedUntil"`
+	LastFailureAt time.Time                 `json:"lastFailureAt"`
+}
+
+// LoginAttemptResponse ログイン試行のレスポンス
+type LoginAttemptResponse struct {
+	ID            int64                     `json:"id"`
+	Username      string                    `json:"username"`
+	UserID        *int64                    `json:"userId,omitempty"`
+	IPAddress     string                    `json:"ipAddress"`
+	UserAgent     string                    `json:"userAgent"`
+	Succeeded     bool                      `json:"succeeded"`
+	FailureReason entity.LoginFailureReason `json:"failureReason,omitempty"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+}
+
+// ListLockouts ロック中のユーザー名・IPアドレス一覧ハンドラー
+func (h *LoginProtectionHandler) ListLockouts(w http.ResponseWriter, r *http.Request) {
+	lockouts, err := h.loginProtection.ListLockouts(r.Context())
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list login lockouts")
+		return
+	}
+
+	items := make([]*LoginLockoutResponse, len(lockouts))
+	for i, lockout := range lockouts {
+		items[i] = &LoginLockoutResponse{
+			Scope:         lockout.Scope,
+			Key:           lockout.Key,
+			FailureCount:  lockout.FailureCount,
+			LockedUntil:   lockout.LockedUntil,
+			LastFailureAt: lockout.LastFailureAt,
+		}
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"lockouts": items,
+	})
+}
+
+// Unlock ロック解除ハンドラー(scopeはusernameまたはip)
+func (h *LoginProtectionHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	scope := entity.LoginThrottleScope(r.URL.Query().Get("scope"))
+	key := r.URL.Query().Get("key")
+
+	if err := h.loginProtection.Unlock(r.Context(), scope, key); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			presenter.JSONError(w, http.StatusNotFound, "Login lockout not found")
+		case strings.Contains(err.Error(), "invalid"),
+			strings.Contains(err.Error(), "is required"):
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		default:
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to unlock login")
+		}
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Login unlocked successfully")
+}
+
+// ListAttempts ログイン試行の監査ログ一覧ハンドラー
+func (h *LoginProtectionHandler) ListAttempts(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	filters := repository.LoginAttemptFilters{
+		Username:  r.URL.Query().Get("username"),
+		IPAddress: r.URL.Query().Get("ip"),
+	}
+	if userID := r.URL.Query().Get("userId"); userID != "" {
+		id, err := strconv.ParseInt(userID, 10, 64)
+		if err != nil {
+			presenter.JSONError(w, http.StatusBadRequest, "Invalid user ID")
+			return
+		}
+		filters.UserID = &id
+	}
+
+	attempts, count, err := h.loginProtection.ListAttempts(r.Context(), filters, limit, offset)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list login attempts")
+		return
+	}
+
+	items := make([]*LoginAttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		items[i] = &LoginAttemptResponse{
+			ID:            attempt.ID,
+			Username:      attempt.Username,
+			UserID:        attempt.UserID,
+			IPAddress:     attempt.IPAddress,
+			UserAgent:     attempt.UserAgent,
+			Succeeded:     attempt.Succeeded,
+			FailureReason: attempt.FailureReason,
+			CreatedAt:     attempt.CreatedAt,
+		}
+	}
+
+	response := map[string]interface{}{
+		"attempts": items,
+		"total":    count,
+		"limit":    limit,
+		"offset":   offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/interface/middleware"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// multipartOverhead multipartのヘッダー・フォーム項目分としてファイルサイズ上限に加える余裕
+const multipartOverhead = 1 << 20
+
+// multipartMemory multipartの解析時にメモリに保持する最大サイズ(超過分は一時ファイル)
+const multipartMemory = 8 << 20
+
+// mediaCacheControl 公開配信するメディアのキャッシュ指定
+// キーはアップロードごとに一意で内容が変わらないため、長期間キャッシュさせる
+const mediaCacheControl = "public, max-age=31536000, immutable"
+
+// MediaHandler メディアハンドラー
+type MediaHandler struct {
+	mediaUseCase  usecase.MediaUseCase
+	authorizer    usecase.Authorizer
+	maxUploadSize int64
+}
+
+// NewMediaHandler 新しいMediaHandlerを作成
+func NewMediaHandler(mediaUseCase usecase.MediaUseCase, authorizer usecase.Authorizer, maxUploadSize int64) *MediaHandler {
+	if maxUploadSize <= 0 {
+		maxUploadSize = usecase.DefaultMaxUploadSize
+	}
+
+	return &MediaHandler{
+		mediaUseCase:  mediaUseCase,
+		authorizer:    authorizer,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// MediaResponse メディアのレスポンス
+type MediaResponse struct {
+	ID           int64  `json:"id"`
+	URL          string `json:"url"`
+	OriginalName string `json:"originalName"`
+	MimeType     string `json:"mimeType"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	AltText      string `json:"altText"`
+	OwnerID      *int64 `json:"ownerId"`
+	OwnerName    string `json:"ownerName,omitempty"`
+	// Variants アップロード時に生成した縮小・WebP版の画像
+	Variants  []*MediaVariantResponse `json:"variants"`
+	CreatedAt time.Time               `json:"createdAt"`
+	UpdatedAt time.Time               `json:"updatedAt"`
+}
+
+// MediaVariantResponse メディアのバリアントのレスポンス
+type MediaVariantResponse struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+// newMediaResponse メディアをレスポンスに変換
+func newMediaResponse(media *entity.Media) *MediaResponse {
+	response := &MediaResponse{
+		ID:           media.ID,
+		URL:          usecase.MediaURL(media.StorageKey),
+		OriginalName: media.OriginalName,
+		MimeType:     media.MimeType,
+		Size:         media.Size,
+		Width:        media.Width,
+		Height:       media.Height,
+		AltText:      media.AltText,
+		OwnerID:      media.OwnerID,
+		Variants:     make([]*MediaVariantResponse, len(media.Variants)),
+		CreatedAt:    media.CreatedAt,
+		UpdatedAt:    media.UpdatedAt,
+	}
+	for i, variant := range media.Variants {
+		response.Variants[i] = &MediaVariantResponse{
+			URL:      usecase.MediaURL(variant.StorageKey),
+			MimeType: variant.MimeType,
+			Size:     variant.Size,
+			Width:    variant.Width,
+			Height:   variant.Height,
+		}
+	}
+	if media.Owner != nil {
+		response.OwnerName = media.Owner.Username
+	}
+	return response
+}
+
+// Upload メディアアップロードハンドラー(multipart/form-dataのfile・altText)
+func (h *MediaHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		presenter.JSONError(w, http.StatusUnauthorized, "User not found")
+		return
+	}
+
+	if !authorize(w, r, h.authorizer, usecase.ActionMediaUpload, nil) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			presenter.JSONError(w, http.StatusRequestEntityTooLarge, "File too large")
+			return
+		}
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+	defer func() {
+		if err := r.MultipartForm.RemoveAll(); err != nil {
+			log.Printf("Failed to remove multipart files: %v", err)
+		}
+	}()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "File is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	media, err := h.mediaUseCase.Upload(r.Context(), &usecase.UploadMediaRequest{
+		OwnerID:  user.ID,
+		Filename: header.Filename,
+		AltText:  r.FormValue("altText"),
+		File:     file,
+	})
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "file too large"):
+			presenter.JSONError(w, http.StatusRequestEntityTooLarge, err.Error())
+		case strings.Contains(err.Error(), "unsupported media type"):
+			presenter.JSONError(w, http.StatusUnsupportedMediaType, err.Error())
+		case strings.Contains(err.Error(), "invalid image"),
+			strings.Contains(err.Error(), "file is required"),
+			strings.Contains(err.Error(), "alt text is too long"):
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		default:
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to upload media")
+		}
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusCreated, newMediaResponse(media))
+}
+
+// List メディア一覧ハンドラー
+func (h *MediaHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	media, count, err := h.mediaUseCase.List(r.Context(), limit, offset)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list media")
+		return
+	}
+
+	items := make([]*MediaResponse, len(media))
+	for i, m := range media {
+		items[i] = newMediaResponse(m)
+	}
+
+	response := map[string]interface{}{
+		"media":  items,
+		"total":  count,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// Update メディア更新ハンドラー(代替テキストのみ変更可能)
+func (h *MediaHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := r.URL.Query().Get("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	if !h.authorizeMedia(w, r, usecase.ActionMediaUpdate, id) {
+		return
+	}
+
+	var req usecase.UpdateMediaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	media, err := h.mediaUseCase.Update(r.Context(), id, &req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			presenter.JSONError(w, http.StatusNotFound, "Media not found")
+		case strings.Contains(err.Error(), "alt text is too long"):
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		default:
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to update media")
+		}
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, newMediaResponse(media))
+}
+
+// Delete メディア削除ハンドラー
+func (h *MediaHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := r.URL.Query().Get("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	if !h.authorizeMedia(w, r, usecase.ActionMediaDelete, id) {
+		return
+	}
+
+	if err := h.mediaUseCase.Delete(r.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Media not found")
+			return
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to delete media")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Media deleted successfully")
+}
+
+// authorizeMedia メディアに対する操作の権限を所有者から確認し、権限がない場合はエラーレスポンスを返す
+func (h *MediaHandler) authorizeMedia(w http.ResponseWriter, r *http.Request, action usecase.Action, id int64) bool {
+	media, err := h.mediaUseCase.GetByID(r.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Media not found")
+			return false
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to find media")
+		return false
+	}
+
+	return authorize(w, r, h.authorizer, action, usecase.MediaResource(media))
+}
+
+// Serve メディアファイルの公開配信
+func (h *MediaHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	obj, contentType, err := h.mediaUseCase.Open(r.Context(), r.PathValue("key"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.NotFound(w, r)
+			return
+		}
+		log.Printf("Media serve error: %v", err)
+		http.Error(w, "Failed to read media", http.StatusInternalServerError)
+		return
+	}
+	defer func() { _ = obj.Close() }()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", mediaCacheControl)
+	http.ServeContent(w, r, "", obj.ModTime, obj)
+}
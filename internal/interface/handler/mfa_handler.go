@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/interface/middleware"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// MFAHandler 二要素認証ハンドラー
+type MFAHandler struct {
+	mfaUseCase usecase.MFAUseCase
+}
+
+// NewMFAHandler 新しいMFAHandlerを作成
+func NewMFAHandler(mfaUseCase usecase.MFAUseCase) *MFAHandler {
+	return &MFAHandler{
+		mfaUseCase: mfaUseCase,
+	}
+}
+
+// MFAPolicyResponse 二要素認証ポリシーのレスポンス
+type MFAPolicyResponse struct {
+	Role      entity.UserRole `json:"role"`
+	Required  bool            `json:"required"`
+	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
+}
+
+// newMFAPolicyResponse ポリシーをレスポンスに変換
+func newMFAPolicyResponse(policy *entity.MFAPolicy) *MFAPolicyResponse {
+	res := &MFAPolicyResponse{Role: policy.Role, Required: policy.Required}
+	if !policy.UpdatedAt.IsZero() {
+		res.UpdatedAt = &policy.UpdatedAt
+	}
+	return res
+}
+
+// writeMFAError ユースケースのエラーをHTTPステータスに変換して返す
+func writeMFAError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid verification code"):
+		presenter.JSONError(w, http.StatusUnauthorized, "Invalid verification code")
+	case strings.Contains(err.Error(), "already enabled"),
+		strings.Contains(err.Error(), "is not enabled"),
+		strings.Contains(err.Error(), "has not been started"),
+		strings.Contains(err.Error(), "is required for role"):
+		presenter.JSONError(w, http.StatusConflict, err.Error())
+	case strings.Contains(err.Error(), "is required"),
+		strings.Contains(err.Error(), "invalid"):
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// currentUser コンテキストから認証済みのユーザーを取得
+func currentUser(w http.ResponseWriter, r *http.Request) (*entity.User, bool) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		presenter.JSONError(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+	return user, true
+}
+
+// decodeMFACode リクエストボディからコードを取得
+func decodeMFACode(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req usecase.MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return "", false
+	}
+	return req.Code, true
+}
+
+// Status 二要素認証の状態ハンドラー
+func (h *MFAHandler) Status(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.mfaUseCase.Status(r.Context(), user)
+	if err != nil {
+		writeMFAError(w, err, "Failed to get two-factor authentication status")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, status)
+}
+
+// Setup TOTPシークレットの発行ハンドラー
+func (h *MFAHandler) Setup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	setup, err := h.mfaUseCase.Setup(r.Context(), user)
+	if err != nil {
+		writeMFAError(w, err, "Failed to set up two-factor authentication")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, setup)
+}
+
+// Confirm 二要素認証の登録完了ハンドラー(リカバリーコードはこのレスポンスでのみ返す)
+func (h *MFAHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	code, ok := decodeMFACode(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := h.mfaUseCase.Confirm(r.Context(), user, code)
+	if err != nil {
+		writeMFAError(w, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"recoveryCodes": codes,
+	})
+}
+
+// Disable 二要素認証の無効化ハンドラー
+func (h *MFAHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	code, ok := decodeMFACode(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.mfaUseCase.Disable(r.Context(), user, code); err != nil {
+		writeMFAError(w, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Two-factor authentication disabled successfully")
+}
+
+// RegenerateRecoveryCodes リカバリーコードの再発行ハンドラー
+func (h *MFAHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	code, ok := decodeMFACode(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := h.mfaUseCase.RegenerateRecoveryCodes(r.Context(), user, code)
+	if err != nil {
+		writeMFAError(w, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"recoveryCodes": codes,
+	})
+}
+
+// ResetUser ユーザーの二要素認証を解除するハンドラー(管理者用)
+func (h *MFAHandler) ResetUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.mfaUseCase.Reset(r.Context(), id); err != nil {
+		writeMFAError(w, err, "Failed to reset two-factor authentication")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Two-factor authentication reset successfully")
+}
+
+// ListPolicies 二要素認証ポリシー一覧ハンドラー
+func (h *MFAHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.mfaUseCase.ListPolicies(r.Context())
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list mfa policies")
+		return
+	}
+
+	items := make([]*MFAPolicyResponse, len(policies))
+	for i, policy := range policies {
+		items[i] = newMFAPolicyResponse(policy)
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"policies": items,
+	})
+}
+
+// SetPolicy 二要素認証ポリシー変更ハンドラー
+func (h *MFAHandler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	var req usecase.SetMFAPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	policy, err := h.mfaUseCase.SetPolicy(r.Context(), &req)
+	if err != nil {
+		writeMFAError(w, err, "Failed to update mfa policy")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, newMFAPolicyResponse(policy))
+}
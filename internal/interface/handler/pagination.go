@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+)
+
+// paginationWindow 現在のページの前後に表示するページ番号の数
+const paginationWindow = 2
+
+// Pagination テンプレート用のページネーション
+type Pagination struct {
+	Current    int
+	TotalPages int
+	PrevURL    string
+	NextURL    string
+	Pages      []PageLink
+}
+
+// PageLink ページ番号リンク(Gapがtrueの場合は省略記号)
+type PageLink struct {
+	Number  int
+	URL     string
+	Current bool
+	Gap     bool
+}
+
+// newPagination ページネーションを生成
+// basePathは1ページ目のURLで、2ページ目以降は basePath + "/page/{n}" となる
+func newPagination(basePath string, current, total, pageSize int) *Pagination {
+	totalPages := max((total+pageSize-1)/pageSize, 1)
+
+	p := &Pagination{
+		Current:    current,
+		TotalPages: totalPages,
+	}
+	if current > 1 {
+		p.PrevURL = pageURL(basePath, current-1)
+	}
+	if current < totalPages {
+		p.NextURL = pageURL(basePath, current+1)
+	}
+
+	// 先頭・末尾と現在ページの前後のみを表示し、間は省略する
+	last := 0
+	for n := 1; n <= totalPages; n++ {
+		if n != 1 && n != totalPages && (n < current-paginationWindow || n > current+paginationWindow) {
+			continue
+		}
+		if last != 0 && n-last > 1 {
+			p.Pages = append(p.Pages, PageLink{Gap: true})
+		}
+		p.Pages = append(p.Pages, PageLink{
+			Number:  n,
+			URL:     pageURL(basePath, n),
+			Current: n == current,
+		})
+		last = n
+	}
+
+	return p
+}
+
+// pageURL 指定ページのURLを生成
+func pageURL(basePath string, page int) string {
+	if page <= 1 {
+		return basePath
+	}
+	return strings.TrimSuffix(basePath, "/") + "/page/" + strconv.Itoa(page)
+}
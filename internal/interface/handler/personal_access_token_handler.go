@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// PersonalAccessTokenHandler パーソナルアクセストークンハンドラー
+type PersonalAccessTokenHandler struct {
+	accessTokenUseCase usecase.PersonalAccessTokenUseCase
+}
+
+// NewPersonalAccessTokenHandler 新しいPersonalAccessTokenHandlerを作成
+func NewPersonalAccessTokenHandler(accessTokenUseCase usecase.PersonalAccessTokenUseCase) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		accessTokenUseCase: accessTokenUseCase,
+	}
+}
+
+// PersonalAccessTokenResponse パーソナルアクセストークンのレスポンス(トークン自体は発行時のみ含める)
+type PersonalAccessTokenResponse struct {
+	ID         int64               `json:"id"`
+	Name       string              `json:"name"`
+	Token      string              `json:"token,omitempty"`
+	Prefix     string              `json:"prefix"`
+	Scopes     []entity.TokenScope `json:"scopes"`
+	Status     string              `json:"status"`
+	ExpiresAt  *time.Time          `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time          `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+// newPersonalAccessTokenResponse トークンをレスポンスに変換
+func newPersonalAccessTokenResponse(token *entity.PersonalAccessToken, now time.Time) *PersonalAccessTokenResponse {
+	status := "active"
+	switch {
+	case token.IsRevoked():
+		status = "revoked"
+	case token.IsExpired(now):
+		status = "expired"
+	}
+
+	return &PersonalAccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.TokenPrefix,
+		Scopes:     token.Scopes,
+		Status:     status,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+// writePersonalAccessTokenError ユースケースのエラーをHTTPステータスに変換して返す
+func writePersonalAccessTokenError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "personal access token not found"):
+		presenter.JSONError(w, http.StatusNotFound, "Personal access token not found")
+	case strings.Contains(err.Error(), "is required"),
+		strings.Contains(err.Error(), "must be at most"),
+		strings.Contains(err.Error(), "invalid"):
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// List トークン一覧ハンドラー
+func (h *PersonalAccessTokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.accessTokenUseCase.List(r.Context(), user.ID)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list personal access tokens")
+		return
+	}
+
+	now := time.Now()
+	items := make([]*PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		items[i] = newPersonalAccessTokenResponse(token, now)
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"tokens": items,
+	})
+}
+
+// Create トークン発行ハンドラー(トークンはこのレスポンスでのみ返す)
+func (h *PersonalAccessTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req usecase.CreatePersonalAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	created, err := h.accessTokenUseCase.Create(r.Context(), user, &req)
+	if err != nil {
+		writePersonalAccessTokenError(w, err, "Failed to create personal access token")
+		return
+	}
+
+	res := newPersonalAccessTokenResponse(created.AccessToken, time.Now())
+	res.Token = created.Token
+	presenter.JSONResponse(w, http.StatusCreated, res)
+}
+
+// Revoke トークン失効ハンドラー
+func (h *PersonalAccessTokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid token ID")
+		return
+	}
+
+	if err := h.accessTokenUseCase.Revoke(r.Context(), user.ID, id); err != nil {
+		writePersonalAccessTokenError(w, err, "Failed to revoke personal access token")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Personal access token revoked successfully")
+}
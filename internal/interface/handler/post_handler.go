@@ -2,8 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/interface/middleware"
@@ -14,12 +17,86 @@ import (
 // PostHandler 記事ハンドラー
 type PostHandler struct {
 	postUseCase usecase.PostUseCase
+	authorizer  usecase.Authorizer
 }
 
 // NewPostHandler 新しいPostHandlerを作成
-func NewPostHandler(postUseCase usecase.PostUseCase) *PostHandler {
+func NewPostHandler(postUseCase usecase.PostUseCase, authorizer usecase.Authorizer) *PostHandler {
 	return &PostHandler{
 		postUseCase: postUseCase,
+		authorizer:  authorizer,
+	}
+}
+
+// PostShareResponse 記事の共有先のレスポンス
+type PostShareResponse struct {
+	UserID    int64           `json:"userId"`
+	Username  string          `json:"username"`
+	Role      entity.UserRole `json:"role"`
+	SharedBy  *int64          `json:"sharedBy"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// SharePostRequest 記事の共有リクエスト
+type SharePostRequest struct {
+	PostID int64 `json:"postId"`
+	UserID int64 `json:"userId"`
+}
+
+// authorizePost 記事に対する操作の権限を確認し、権限がない場合はエラーレスポンスを返す
+func (h *PostHandler) authorizePost(w http.ResponseWriter, r *http.Request, action usecase.Action, postID int64) (*entity.Post, *entity.User, bool) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+
+	post, err := h.postUseCase.AuthorizePost(r.Context(), user, action, postID)
+	if err != nil {
+		writePostAuthorizationError(w, err)
+		return nil, nil, false
+	}
+
+	return post, user, true
+}
+
+// writePostAuthorizationError 記事の権限確認のエラーをレスポンスに変換
+func writePostAuthorizationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrForbidden):
+		presenter.JSONError(w, http.StatusForbidden, "Forbidden: insufficient permissions for this post")
+	case strings.Contains(err.Error(), "not found"):
+		presenter.JSONError(w, http.StatusNotFound, "Post not found")
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to check post permissions")
+	}
+}
+
+// changesPublication 記事の公開状態を公開・予約に変更するリクエストかどうかを判定
+// (レビュー中・差し戻しへの変更はユースケースで不正なステータスとして扱う)
+func changesPublication(status *string, publishAt *time.Time) bool {
+	if publishAt != nil {
+		return true
+	}
+	if status == nil {
+		return false
+	}
+	target := entity.PostStatus(*status)
+	return target == entity.StatusPublished || target == entity.StatusScheduled
+}
+
+// writePostReviewError 記事のレビュー操作のエラーをレスポンスに変換
+func writePostReviewError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidTransition):
+		presenter.JSONError(w, http.StatusConflict, err.Error())
+	case strings.Contains(err.Error(), "failed to find reviewer") && strings.Contains(err.Error(), "not found"):
+		presenter.JSONError(w, http.StatusNotFound, "Reviewer not found")
+	case strings.Contains(err.Error(), "invalid"),
+		strings.Contains(err.Error(), "is required"),
+		strings.Contains(err.Error(), "at most"):
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, message)
 	}
 }
 
@@ -39,8 +116,24 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	req.AuthorID = user.ID
 
+	if err := h.authorizer.Authorize(user, usecase.ActionPostCreate, nil); err != nil {
+		presenter.JSONError(w, http.StatusForbidden, "Forbidden: insufficient permissions")
+		return
+	}
+	// 作成する記事は自分の記事として公開の権限を判定する
+	if changesPublication(&req.Status, req.PublishAt) {
+		if err := h.authorizer.Authorize(user, usecase.ActionPostPublish, &usecase.Resource{OwnerID: user.ID}); err != nil {
+			presenter.JSONError(w, http.StatusForbidden, "Forbidden: insufficient permissions to publish posts")
+			return
+		}
+	}
+
 	post, err := h.postUseCase.Create(r.Context(), &req)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid publish schedule") || strings.Contains(err.Error(), "invalid status") {
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to create post")
 		return
 	}
@@ -63,8 +156,29 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	existing, user, ok := h.authorizePost(w, r, usecase.ActionPostUpdate, id)
+	if !ok {
+		return
+	}
+	if changesPublication(req.Status, req.PublishAt) {
+		if err := h.authorizer.Authorize(user, usecase.ActionPostPublish, usecase.PostResource(existing, false)); err != nil {
+			presenter.JSONError(w, http.StatusForbidden, "Forbidden: insufficient permissions to publish posts")
+			return
+		}
+	}
+
+	req.EditorID = user.ID
+
 	post, err := h.postUseCase.Update(r.Context(), id, &req)
 	if err != nil {
+		if errors.Is(err, usecase.ErrPostInReview) {
+			presenter.JSONError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if strings.Contains(err.Error(), "invalid publish schedule") || strings.Contains(err.Error(), "invalid status") {
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to update post")
 		return
 	}
@@ -81,6 +195,10 @@ func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostDelete, id); !ok {
+		return
+	}
+
 	if err := h.postUseCase.Delete(r.Context(), id); err != nil {
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to delete post")
 		return
@@ -136,8 +254,29 @@ func (h *PostHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 	presenter.JSONResponse(w, http.StatusOK, post)
 }
 
-// List 記事一覧ハンドラー
+// Get 管理用の記事取得ハンドラー(下書きを含む、閲覧権限が必要)
+func (h *PostHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	post, _, ok := h.authorizePost(w, r, usecase.ActionPostRead, id)
+	if !ok {
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, post)
+}
+
+// List 記事一覧ハンドラー(ユーザーが閲覧できる記事のみ)
 func (h *PostHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
 
@@ -148,7 +287,7 @@ func (h *PostHandler) List(w http.ResponseWriter, r *http.Request) {
 		limit = 100
 	}
 
-	posts, count, err := h.postUseCase.List(r.Context(), limit, offset)
+	posts, count, err := h.postUseCase.ListForUser(r.Context(), user, limit, offset)
 	if err != nil {
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list posts")
 		return
@@ -192,6 +331,57 @@ func (h *PostHandler) ListPublished(w http.ResponseWriter, r *http.Request) {
 	presenter.JSONResponse(w, http.StatusOK, response)
 }
 
+// Search 公開記事検索ハンドラー
+func (h *PostHandler) Search(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	req := &usecase.SearchPostsRequest{
+		Query:        r.URL.Query().Get("q"),
+		CategorySlug: r.URL.Query().Get("category"),
+		TagSlug:      r.URL.Query().Get("tag"),
+		Limit:        limit,
+		Offset:       offset,
+	}
+
+	results, count, err := h.postUseCase.Search(r.Context(), req)
+	if err != nil {
+		if strings.Contains(err.Error(), "search query") {
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Category or tag not found")
+		} else {
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to search posts")
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"query":   req.Query,
+		"results": results,
+		"total":   count,
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
 // Publish 記事公開ハンドラー
 func (h *PostHandler) Publish(w http.ResponseWriter, r *http.Request) {
 	idStr := r.URL.Query().Get("id")
@@ -201,7 +391,15 @@ func (h *PostHandler) Publish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostPublish, id); !ok {
+		return
+	}
+
 	if err := h.postUseCase.Publish(r.Context(), id); err != nil {
+		if errors.Is(err, entity.ErrInvalidTransition) {
+			presenter.JSONError(w, http.StatusConflict, err.Error())
+			return
+		}
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to publish post")
 		return
 	}
@@ -218,6 +416,10 @@ func (h *PostHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostPublish, id); !ok {
+		return
+	}
+
 	if err := h.postUseCase.Unpublish(r.Context(), id); err != nil {
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to unpublish post")
 		return
@@ -225,3 +427,377 @@ func (h *PostHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
 
 	presenter.JSONSuccess(w, nil, "Post unpublished successfully")
 }
+
+// ListRevisions 記事リビジョン一覧ハンドラー
+func (h *PostHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	postID, err := strconv.ParseInt(r.URL.Query().Get("postId"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostRead, postID); !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	revisions, count, err := h.postUseCase.ListRevisions(r.Context(), postID, limit, offset)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list revisions")
+		return
+	}
+
+	response := map[string]interface{}{
+		"revisions": revisions,
+		"total":     count,
+		"limit":     limit,
+		"offset":    offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// DiffRevisions リビジョン差分ハンドラー
+// format=text を指定するとunified diffをテキストで返す
+func (h *PostHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	fromID, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid from revision ID")
+		return
+	}
+
+	toID, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid to revision ID")
+		return
+	}
+
+	// 差分を計算する前に、リビジョンの記事の閲覧権限を確認する
+	from, err := h.postUseCase.GetRevision(r.Context(), fromID)
+	if err != nil {
+		writeRevisionLookupError(w, err)
+		return
+	}
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostRead, from.PostID); !ok {
+		return
+	}
+
+	revisionDiff, err := h.postUseCase.DiffRevisions(r.Context(), fromID, toID)
+	if err != nil {
+		if strings.Contains(err.Error(), "different posts") {
+			presenter.JSONError(w, http.StatusBadRequest, "Revisions belong to different posts")
+		} else if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Revision not found")
+		} else {
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to diff revisions")
+		}
+		return
+	}
+
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/x-diff; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(revisionDiff.Diff))
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, revisionDiff)
+}
+
+// RestoreRevision リビジョン復元ハンドラー
+func (h *PostHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid revision ID")
+		return
+	}
+
+	revision, err := h.postUseCase.GetRevision(r.Context(), revisionID)
+	if err != nil {
+		writeRevisionLookupError(w, err)
+		return
+	}
+	_, user, ok := h.authorizePost(w, r, usecase.ActionPostUpdate, revision.PostID)
+	if !ok {
+		return
+	}
+
+	post, err := h.postUseCase.RestoreRevision(r.Context(), revisionID, user.ID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrPostInReview) {
+			presenter.JSONError(w, http.StatusConflict, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Revision not found")
+		} else {
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to restore revision")
+		}
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, post)
+}
+
+// writeRevisionLookupError リビジョン取得のエラーをレスポンスに変換
+func writeRevisionLookupError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "not found") {
+		presenter.JSONError(w, http.StatusNotFound, "Revision not found")
+		return
+	}
+	presenter.JSONError(w, http.StatusInternalServerError, "Failed to find revision")
+}
+
+// ListShares 記事の共有先一覧ハンドラー
+func (h *PostHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.ParseInt(r.URL.Query().Get("postId"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostRead, postID); !ok {
+		return
+	}
+
+	shares, err := h.postUseCase.ListShares(r.Context(), postID)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list post shares")
+		return
+	}
+
+	items := make([]*PostShareResponse, len(shares))
+	for i, share := range shares {
+		items[i] = &PostShareResponse{
+			UserID:    share.UserID,
+			SharedBy:  share.SharedBy,
+			CreatedAt: share.CreatedAt,
+		}
+		if share.User != nil {
+			items[i].Username = share.User.Username
+			items[i].Role = share.User.Role
+		}
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"shares": items,
+	})
+}
+
+// Share 記事の共有ハンドラー(共有先のユーザーは下書きを閲覧できる)
+func (h *PostHandler) Share(w http.ResponseWriter, r *http.Request) {
+	var req SharePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	_, user, ok := h.authorizePost(w, r, usecase.ActionPostShare, req.PostID)
+	if !ok {
+		return
+	}
+
+	if err := h.postUseCase.Share(r.Context(), req.PostID, req.UserID, user.ID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to share post")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Post shared successfully")
+}
+
+// Unshare 記事の共有解除ハンドラー
+func (h *PostHandler) Unshare(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.ParseInt(r.URL.Query().Get("postId"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	userID, err := strconv.ParseInt(r.URL.Query().Get("userId"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostShare, postID); !ok {
+		return
+	}
+
+	if err := h.postUseCase.Unshare(r.Context(), postID, userID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Post share not found")
+			return
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to unshare post")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Post unshared successfully")
+}
+
+// SubmitForReview 記事のレビューへの提出ハンドラー
+func (h *PostHandler) SubmitForReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	var req usecase.ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostSubmit, id); !ok {
+		return
+	}
+
+	post, err := h.postUseCase.SubmitForReview(r.Context(), id, req.ReviewerID)
+	if err != nil {
+		writePostReviewError(w, err, "Failed to submit post for review")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, post)
+}
+
+// AssignReviewer レビュー中の記事のレビュー担当者変更ハンドラー
+func (h *PostHandler) AssignReviewer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	var req usecase.ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostSubmit, id); !ok {
+		return
+	}
+
+	post, err := h.postUseCase.AssignReviewer(r.Context(), id, req.ReviewerID)
+	if err != nil {
+		writePostReviewError(w, err, "Failed to assign reviewer")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, post)
+}
+
+// ApproveReview 記事のレビューの承認ハンドラー(担当のレビュー担当者のみ)
+func (h *PostHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	var req usecase.ApproveReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostReview, id); !ok {
+		return
+	}
+
+	post, err := h.postUseCase.Approve(r.Context(), id, &req)
+	if err != nil {
+		writePostReviewError(w, err, "Failed to approve post")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, post)
+}
+
+// RejectReview 記事のレビューの差し戻しハンドラー(担当のレビュー担当者のみ)
+func (h *PostHandler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	var req usecase.RejectReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if _, _, ok := h.authorizePost(w, r, usecase.ActionPostReview, id); !ok {
+		return
+	}
+
+	post, err := h.postUseCase.Reject(r.Context(), id, &req)
+	if err != nil {
+		writePostReviewError(w, err, "Failed to reject post")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, post)
+}
+
+// ReviewQueue ログインユーザーのレビュー待ちの記事一覧ハンドラー
+func (h *PostHandler) ReviewQueue(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	posts, count, err := h.postUseCase.ListReviewQueue(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list review queue")
+		return
+	}
+
+	response := map[string]interface{}{
+		"posts":  posts,
+		"total":  count,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// PostPreviewHandler 記事のプレビューURLの管理ハンドラー
+type PostPreviewHandler struct {
+	previewUseCase usecase.PostPreviewUseCase
+	postUseCase    usecase.PostUseCase
+}
+
+// NewPostPreviewHandler 新しいPostPreviewHandlerを作成
+func NewPostPreviewHandler(previewUseCase usecase.PostPreviewUseCase, postUseCase usecase.PostUseCase) *PostPreviewHandler {
+	return &PostPreviewHandler{
+		previewUseCase: previewUseCase,
+		postUseCase:    postUseCase,
+	}
+}
+
+// authorizePreview 記事のプレビューURLを扱う権限を確認し、記事IDを返す
+func (h *PostPreviewHandler) authorizePreview(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return 0, false
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return 0, false
+	}
+	if _, err := h.postUseCase.AuthorizePost(r.Context(), user, usecase.ActionPostPreview, id); err != nil {
+		writePostAuthorizationError(w, err)
+		return 0, false
+	}
+
+	return id, true
+}
+
+// CreateLink プレビューURLの発行ハンドラー(リクエストボディを省略した場合は既定の有効期間)
+func (h *PostPreviewHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
+	var req usecase.CreatePreviewLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	id, ok := h.authorizePreview(w, r)
+	if !ok {
+		return
+	}
+
+	link, err := h.previewUseCase.CreateLink(r.Context(), id, &req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "invalid"):
+			presenter.JSONError(w, http.StatusBadRequest, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			presenter.JSONError(w, http.StatusNotFound, "Post not found")
+		default:
+			presenter.JSONError(w, http.StatusInternalServerError, "Failed to create preview link")
+		}
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusCreated, link)
+}
+
+// RevokeLinks 記事の発行済みのプレビューURLをすべて無効にするハンドラー
+func (h *PostPreviewHandler) RevokeLinks(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.authorizePreview(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.previewUseCase.RevokeLinks(r.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Post not found")
+			return
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to revoke preview links")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Preview links revoked successfully")
+}
@@ -1,87 +1,587 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/search"
+	"my-blog-engine/internal/interface/middleware"
 	"my-blog-engine/internal/usecase"
 )
 
+// publicPageSize 公開記事一覧の1ページあたりの件数
+const publicPageSize = 10
+
+// searchPageSize 検索結果ページの1ページあたりの件数
+const searchPageSize = 10
+
+// excerptLength 記事一覧に表示する抜粋の最大文字数
+const excerptLength = 200
+
+// maxCommentFormSize コメント投稿フォームの最大サイズ
+const maxCommentFormSize = 64 << 10
+
 // PublicHandler 公開ページのハンドラー
 type PublicHandler struct {
 	postUseCase     usecase.PostUseCase
 	categoryUseCase usecase.CategoryUseCase
-	templates       *template.Template
+	tagUseCase      usecase.TagUseCase
+	commentUseCase  usecase.CommentUseCase
+	previewUseCase  usecase.PostPreviewUseCase
+	templates       map[string]*template.Template
 }
 
 // NewPublicHandler PublicHandlerのコンストラクタ
 func NewPublicHandler(
 	postUseCase usecase.PostUseCase,
 	categoryUseCase usecase.CategoryUseCase,
+	tagUseCase usecase.TagUseCase,
+	commentUseCase usecase.CommentUseCase,
+	previewUseCase usecase.PostPreviewUseCase,
 ) *PublicHandler {
-	// テンプレートファイルを個別にパース
-	tmpl, err := template.ParseFiles("templates/home.html")
-	if err != nil {
-		log.Printf("Warning: Failed to parse templates: %v", err)
-		tmpl = template.New("fallback")
+	// テンプレートファイルをページごとに個別にパース
+	// (各ページが同名の"title"/"content"ブロックを定義するため、1つのテンプレートセットにまとめられない)
+	templates := make(map[string]*template.Template)
+	for _, page := range []string{"home.html", "post.html", "archive.html", "search.html", "error.html"} {
+		templates[page] = parsePage(page)
 	}
 
 	return &PublicHandler{
 		postUseCase:     postUseCase,
 		categoryUseCase: categoryUseCase,
-		templates:       tmpl,
+		tagUseCase:      tagUseCase,
+		commentUseCase:  commentUseCase,
+		previewUseCase:  previewUseCase,
+		templates:       templates,
+	}
+}
+
+// parsePage レイアウト・部品テンプレートとページテンプレートをパース(失敗時は空のテンプレートで代替)
+func parsePage(page string) *template.Template {
+	partials, err := filepath.Glob("templates/partials/*.html")
+	if err != nil {
+		log.Printf("Warning: Failed to find partial templates: %v", err)
+	}
+
+	files := append([]string{"templates/layout/base.html"}, partials...)
+	files = append(files, filepath.Join("templates/public", page))
+
+	tmpl, err := template.ParseFiles(files...)
+	if err != nil {
+		log.Printf("Warning: Failed to parse templates: %v", err)
+		return template.New("fallback")
+	}
+	return tmpl
+}
+
+// render ページテンプレートを実行してレスポンスに書き込む
+// 実行途中のエラーで不完全なHTMLを返さないよう、バッファに書き出してから送信する
+func (h *PublicHandler) render(w http.ResponseWriter, status int, page string, data interface{}) {
+	var buf bytes.Buffer
+	if err := h.templates[page].ExecuteTemplate(&buf, "base.html", data); err != nil {
+		log.Printf("Template execution error: %v", err)
+		if page != "error.html" {
+			h.renderError(w, http.StatusInternalServerError)
+		} else {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+// renderError エラーページを表示
+func (h *PublicHandler) renderError(w http.ResponseWriter, status int) {
+	data := map[string]interface{}{
+		"Status": status,
+	}
+	switch status {
+	case http.StatusNotFound:
+		data["Heading"] = "ページが見つかりません"
+		data["Message"] = "お探しのページは移動または削除された可能性があります。"
+	default:
+		data["Heading"] = "エラーが発生しました"
+		data["Message"] = "しばらく時間をおいてから再度アクセスしてください。"
 	}
+	h.render(w, status, "error.html", data)
+}
+
+// renderUseCaseError ユースケースのエラーに応じたエラーページを表示
+func (h *PublicHandler) renderUseCaseError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "not found") {
+		h.renderError(w, http.StatusNotFound)
+		return
+	}
+	log.Printf("Public page error: %v", err)
+	h.renderError(w, http.StatusInternalServerError)
 }
 
 // PostView テンプレート用の投稿ビュー
 type PostView struct {
 	*entity.Post
 	SafeHTML template.HTML
+	Excerpt  string
+}
+
+// newPostView 記事をテンプレート用に変換
+// RenderedHTMLをtemplate.HTMLに変換することは安全です。
+// なぜなら、RenderedHTMLはmarkdownレンダラー（goldmark）によって
+// すでにサニタイズされており、HTMLエスケープとプレースホルダーベースの
+// SVG挿入によってXSS攻撃から保護されているためです。
+func newPostView(post *entity.Post) PostView {
+	return PostView{
+		Post:     post,
+		SafeHTML: template.HTML(post.RenderedHTML),
+		Excerpt:  excerpt(post.RenderedHTML),
+	}
+}
+
+// excerpt レンダリング済みHTMLから一覧表示用の抜粋を作成
+func excerpt(renderedHTML string) string {
+	text := search.PlainText(renderedHTML)
+	if utf8.RuneCountInString(text) <= excerptLength {
+		return text
+	}
+	return string([]rune(text)[:excerptLength]) + "…"
+}
+
+// ArchiveLink テンプレート用の月別アーカイブリンク
+type ArchiveLink struct {
+	Label string
+	URL   string
+	Count int
+}
+
+// sidebar サイドバーの表示データを取得
+func (h *PublicHandler) sidebar(ctx context.Context) (map[string]interface{}, error) {
+	categories, err := h.categoryUseCase.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	monthly, err := h.postUseCase.MonthlyArchives(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archives := make([]ArchiveLink, len(monthly))
+	for i, m := range monthly {
+		archives[i] = ArchiveLink{
+			Label: fmt.Sprintf("%d年%d月", m.Year, m.Month),
+			URL:   fmt.Sprintf("/archive/%04d/%02d", m.Year, m.Month),
+			Count: m.Count,
+		}
+	}
+
+	return map[string]interface{}{
+		"Categories": categories,
+		"Archives":   archives,
+	}, nil
+}
+
+// postLister 記事一覧の取得関数
+type postLister func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error)
+
+// renderPostList 記事一覧ページの共通処理(ページ番号はパスパラメータ{page}から取得)
+func (h *PublicHandler) renderPostList(w http.ResponseWriter, r *http.Request, page, basePath string, data map[string]interface{}, list postLister) {
+	current := 1
+	if raw := r.PathValue("page"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			h.renderError(w, http.StatusNotFound)
+			return
+		}
+		// 1ページ目は正規URLへ転送
+		if n == 1 {
+			http.Redirect(w, r, basePath, http.StatusMovedPermanently)
+			return
+		}
+		current = n
+	}
+
+	ctx := r.Context()
+	posts, total, err := list(ctx, publicPageSize, (current-1)*publicPageSize)
+	if err != nil {
+		h.renderUseCaseError(w, err)
+		return
+	}
+
+	// 範囲外のページ
+	if current > 1 && len(posts) == 0 {
+		h.renderError(w, http.StatusNotFound)
+		return
+	}
+
+	sidebar, err := h.sidebar(ctx)
+	if err != nil {
+		h.renderUseCaseError(w, err)
+		return
+	}
+
+	views := make([]PostView, len(posts))
+	for i, post := range posts {
+		views[i] = newPostView(post)
+	}
+
+	data["Posts"] = views
+	data["Total"] = total
+	data["Pagination"] = newPagination(basePath, current, total, publicPageSize)
+	data["Sidebar"] = sidebar
+
+	h.render(w, http.StatusOK, page, data)
 }
 
 // Home ホームページ表示
 func (h *PublicHandler) Home(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	h.renderPostList(w, r, "home.html", "/", map[string]interface{}{}, h.postUseCase.ListPublished)
+}
 
-	// 公開投稿一覧取得
-	posts, _, err := h.postUseCase.ListPublished(ctx, 10, 0)
+// Post 記事ページ表示
+func (h *PublicHandler) Post(w http.ResponseWriter, r *http.Request) {
+	post, err := h.postUseCase.GetBySlug(r.Context(), r.PathValue("slug"))
 	if err != nil {
-		http.Error(w, "Failed to fetch posts", http.StatusInternalServerError)
+		h.renderUseCaseError(w, err)
 		return
 	}
 
-	// カテゴリ一覧取得
-	categories, err := h.categoryUseCase.List(ctx)
+	// 公開記事のみ表示（下書き・公開予約中は存在しないものとして扱う）
+	if !post.IsPublished() {
+		h.renderError(w, http.StatusNotFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CommentSubmitted": r.URL.Query().Get("comment") == "submitted",
+	}
+	// 返信リンク(?reply={id})から開いた場合は返信先を指定したフォームを表示
+	if reply := r.URL.Query().Get("reply"); reply != "" {
+		if _, err := strconv.ParseInt(reply, 10, 64); err == nil {
+			data["CommentForm"] = CommentForm{ParentID: reply}
+		}
+	}
+	h.renderPost(w, r, http.StatusOK, post, data)
+}
+
+// renderPost 記事ページを公開中のコメントとあわせて表示
+func (h *PublicHandler) renderPost(w http.ResponseWriter, r *http.Request, status int, post *entity.Post, data map[string]interface{}) {
+	threads, count, err := h.commentUseCase.ListThreads(r.Context(), post.ID)
 	if err != nil {
-		http.Error(w, "Failed to fetch categories", http.StatusInternalServerError)
+		h.renderUseCaseError(w, err)
 		return
 	}
 
-	// 投稿をテンプレート用に変換
-	// RenderedHTMLをtemplate.HTMLに変換することは安全です。
-	// なぜなら、RenderedHTMLはmarkdownレンダラー（goldmark）によって
-	// すでにサニタイズされており、HTMLエスケープとプレースホルダーベースの
-	// SVG挿入によってXSS攻撃から保護されているためです。
-	postViews := make([]PostView, len(posts))
-	for i, post := range posts {
-		postViews[i] = PostView{
-			Post:     post,
-			SafeHTML: template.HTML(post.RenderedHTML),
+	data["Post"] = newPostView(post)
+	data["Comments"] = newCommentViews(threads)
+	data["CommentCount"] = count
+	if _, ok := data["CommentForm"]; !ok {
+		data["CommentForm"] = CommentForm{}
+	}
+	h.render(w, status, "post.html", data)
+}
+
+// Preview 署名付きプレビューURLで未公開の記事を表示
+// 検索エンジンにインデックスされず、キャッシュやリファラーからURLが漏れないようにする
+func (h *PublicHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	post, expiresAt, err := h.previewUseCase.Resolve(r.Context(), r.PathValue("token"))
+	if err != nil {
+		h.renderUseCaseError(w, err)
+		return
+	}
+
+	// コメントはプレビューでは表示・投稿しない
+	h.render(w, http.StatusOK, "post.html", map[string]interface{}{
+		"Post":             newPostView(post),
+		"Preview":          true,
+		"PreviewExpiresAt": expiresAt,
+	})
+}
+
+// CommentView テンプレート用のコメントビュー
+type CommentView struct {
+	*entity.Comment
+	SafeHTML template.HTML
+	Replies  []CommentView
+}
+
+// newCommentViews コメントのスレッドをテンプレート用に変換
+// RenderedHTMLをtemplate.HTMLに変換することは安全です。
+// コメント用のレンダラーは生のHTMLを出力せず、リンクもhttp(s)とmailtoに限定しているためです。
+func newCommentViews(threads []*usecase.CommentThread) []CommentView {
+	views := make([]CommentView, len(threads))
+	for i, thread := range threads {
+		views[i] = CommentView{
+			Comment:  thread.Comment,
+			SafeHTML: template.HTML(thread.RenderedHTML),
+			Replies:  newCommentViews(thread.Replies),
+		}
+	}
+	return views
+}
+
+// CommentForm テンプレート用のコメントフォームの入力値
+type CommentForm struct {
+	ParentID string
+	Name     string
+	Email    string
+	URL      string
+	Content  string
+}
+
+// commentErrorMessages コメント投稿時の入力エラーと表示するメッセージ
+var commentErrorMessages = []struct {
+	err     string
+	message string
+}{
+	{"name is required", "お名前を入力してください。"},
+	{"name is too long", "お名前は100文字以内で入力してください。"},
+	{"comment is required", "コメントを入力してください。"},
+	{"comment is too long", "コメントは5000文字以内で入力してください。"},
+	{"invalid email address", "メールアドレスの形式が正しくありません。"},
+	{"invalid website url", "ウェブサイトにはhttp://またはhttps://で始まるURLを入力してください。"},
+	{"parent comment not found", "返信先のコメントが見つかりません。"},
+}
+
+// Comment コメント投稿(承認後に記事ページに表示される)
+func (h *PublicHandler) Comment(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	post, err := h.postUseCase.GetBySlug(r.Context(), slug)
+	if err != nil {
+		h.renderUseCaseError(w, err)
+		return
+	}
+	if !post.IsPublished() {
+		h.renderError(w, http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCommentFormSize)
+	if err := r.ParseForm(); err != nil {
+		h.renderError(w, http.StatusBadRequest)
+		return
+	}
+
+	form := CommentForm{
+		ParentID: r.PostFormValue("parentId"),
+		Name:     r.PostFormValue("name"),
+		Email:    r.PostFormValue("email"),
+		URL:      r.PostFormValue("url"),
+		Content:  r.PostFormValue("content"),
+	}
+
+	req := &usecase.SubmitCommentRequest{
+		PostID:      post.ID,
+		AuthorName:  form.Name,
+		AuthorEmail: form.Email,
+		AuthorURL:   form.URL,
+		Content:     form.Content,
+		Honeypot:    r.PostFormValue("website"),
+		IPAddress:   middleware.ClientIP(r),
+		UserAgent:   r.UserAgent(),
+	}
+	if form.ParentID != "" {
+		parentID, err := strconv.ParseInt(form.ParentID, 10, 64)
+		if err != nil {
+			h.renderError(w, http.StatusBadRequest)
+			return
+		}
+		req.ParentID = &parentID
+	}
+
+	if _, err := h.commentUseCase.Submit(r.Context(), req); err != nil {
+		for _, e := range commentErrorMessages {
+			if strings.Contains(err.Error(), e.err) {
+				data := map[string]interface{}{
+					"CommentError": e.message,
+					"CommentForm":  form,
+				}
+				h.renderPost(w, r, http.StatusBadRequest, post, data)
+				return
+			}
 		}
+		h.renderUseCaseError(w, err)
+		return
 	}
 
+	// 再読み込みによる二重投稿を防ぐため、記事ページへ転送する
+	http.Redirect(w, r, "/posts/"+url.PathEscape(slug)+"?comment=submitted#comments", http.StatusSeeOther)
+}
+
+// Category カテゴリ別記事一覧ページ表示
+func (h *PublicHandler) Category(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	category, err := h.categoryUseCase.GetBySlug(r.Context(), slug)
+	if err != nil {
+		h.renderUseCaseError(w, err)
+		return
+	}
+
+	basePath := "/category/" + url.PathEscape(slug)
 	data := map[string]interface{}{
-		"Title":      "Blog Home",
-		"Posts":      postViews,
-		"Categories": categories,
+		"Heading":     "カテゴリ: " + category.Name,
+		"Description": category.Description,
+		"FeedPath":    basePath,
 	}
+	h.renderPostList(w, r, "archive.html", basePath, data,
+		func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
+			return h.postUseCase.ListByCategory(ctx, slug, limit, offset)
+		})
+}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := h.templates.ExecuteTemplate(w, "home.html", data); err != nil {
-		log.Printf("Template execution error: %v", err)
-		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+// Tag タグ別記事一覧ページ表示
+func (h *PublicHandler) Tag(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	tag, err := h.tagUseCase.GetBySlug(r.Context(), slug)
+	if err != nil {
+		h.renderUseCaseError(w, err)
+		return
 	}
+
+	basePath := "/tag/" + url.PathEscape(slug)
+	data := map[string]interface{}{
+		"Heading":  "タグ: " + tag.Name,
+		"FeedPath": basePath,
+	}
+	h.renderPostList(w, r, "archive.html", basePath, data,
+		func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
+			return h.postUseCase.ListByTag(ctx, slug, limit, offset)
+		})
+}
+
+// Author 著者別記事一覧ページ表示
+func (h *PublicHandler) Author(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+
+	basePath := "/author/" + url.PathEscape(username)
+	data := map[string]interface{}{
+		"Heading":  "著者: " + username,
+		"FeedPath": basePath,
+	}
+	h.renderPostList(w, r, "archive.html", basePath, data,
+		func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
+			return h.postUseCase.ListByAuthor(ctx, username, limit, offset)
+		})
+}
+
+// Archive 年別・月別記事一覧ページ表示
+func (h *PublicHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil || len(r.PathValue("year")) != 4 {
+		h.renderError(w, http.StatusNotFound)
+		return
+	}
+
+	month := 0
+	heading := fmt.Sprintf("%d年", year)
+	basePath := fmt.Sprintf("/archive/%04d", year)
+	if raw := r.PathValue("month"); raw != "" {
+		month, err = strconv.Atoi(raw)
+		if err != nil || len(raw) != 2 || month < 1 || month > 12 {
+			h.renderError(w, http.StatusNotFound)
+			return
+		}
+		heading = fmt.Sprintf("%d年%d月", year, month)
+		basePath = fmt.Sprintf("/archive/%04d/%02d", year, month)
+	}
+
+	data := map[string]interface{}{
+		"Heading": heading + "の記事",
+	}
+	h.renderPostList(w, r, "archive.html", basePath, data,
+		func(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
+			return h.postUseCase.ListByMonth(ctx, year, month, limit, offset)
+		})
+}
+
+// NotFound 存在しないページへのアクセス
+func (h *PublicHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	h.renderError(w, http.StatusNotFound)
+}
+
+// SearchResultView テンプレート用の検索結果ビュー
+type SearchResultView struct {
+	*usecase.SearchResult
+	// Snippetは検索語以外をエスケープ済みのHTML
+	Snippet template.HTML
+}
+
+// Search 検索結果ページ表示
+func (h *PublicHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	data := map[string]interface{}{
+		"Query": query,
+	}
+
+	if query == "" {
+		h.render(w, http.StatusOK, "search.html", data)
+		return
+	}
+
+	results, total, err := h.postUseCase.Search(r.Context(), &usecase.SearchPostsRequest{
+		Query:  query,
+		Limit:  searchPageSize,
+		Offset: (page - 1) * searchPageSize,
+	})
+	if err != nil {
+		if !strings.Contains(err.Error(), "search query") {
+			h.renderUseCaseError(w, err)
+			return
+		}
+		data["Error"] = "検索キーワードは100文字以内で入力してください。"
+		h.render(w, http.StatusBadRequest, "search.html", data)
+		return
+	}
+
+	// Snippetをtemplate.HTMLに変換することは安全です。
+	// 抜粋はタグを除去した本文テキストをHTMLエスケープした上で、
+	// 検索語を<mark>で囲んだものだけを含むためです。
+	views := make([]SearchResultView, len(results))
+	for i, result := range results {
+		views[i] = SearchResultView{
+			SearchResult: result,
+			Snippet:      template.HTML(result.Snippet),
+		}
+	}
+
+	data["Results"] = views
+	data["Total"] = total
+	if page > 1 {
+		data["PrevURL"] = searchPageURL(query, page-1)
+	}
+	if page*searchPageSize < total {
+		data["NextURL"] = searchPageURL(query, page+1)
+	}
+
+	h.render(w, http.StatusOK, "search.html", data)
+}
+
+// searchPageURL 検索結果ページのURLを生成
+func searchPageURL(query string, page int) string {
+	values := url.Values{}
+	values.Set("q", query)
+	values.Set("page", strconv.Itoa(page))
+	return "/search?" + values.Encode()
 }
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// responseCache 生成コストの高いレスポンス(フィード・サイトマップ)を一定期間メモリに保持するキャッシュ
+type responseCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+// cachedResponse 生成済みのレスポンス
+type cachedResponse struct {
+	body         []byte
+	etag         string
+	lastModified time.Time
+	expiresAt    time.Time
+}
+
+// newResponseCache responseCacheのコンストラクタ
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]*cachedResponse),
+	}
+}
+
+// get 有効期限内のキャッシュを取得
+func (c *responseCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return entry
+}
+
+// put 生成したレスポンスをキャッシュに保存
+// 記事の非公開化などで内容が変わったのに最終更新日時が進まない場合は、
+// If-Modified-Sinceのみを送るクライアントが変更を取りこぼさないよう生成時刻を最終更新日時とする
+func (c *responseCache) put(key string, body []byte, lastModified time.Time) *cachedResponse {
+	sum := sha256.Sum256(body)
+	entry := &cachedResponse{
+		body:         body,
+		etag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		lastModified: lastModified.UTC().Truncate(time.Second),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if prev, ok := c.entries[key]; ok {
+		if prev.etag == entry.etag {
+			entry.lastModified = prev.lastModified
+		} else if !entry.lastModified.After(prev.lastModified) {
+			entry.lastModified = now.UTC().Truncate(time.Second)
+		}
+	}
+	entry.expiresAt = now.Add(c.ttl)
+
+	// 期限切れのエントリを削除
+	for k, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry
+
+	return entry
+}
+
+// serve キャッシュしたレスポンスを返す
+// 条件付きGET(If-None-Match/If-Modified-Since)には304を返す
+func (c *responseCache) serve(w http.ResponseWriter, r *http.Request, contentType string, entry *cachedResponse) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", entry.etag)
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(c.ttl.Seconds())))
+	http.ServeContent(w, r, "", entry.lastModified, bytes.NewReader(entry.body))
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// ReviewNoteHandler 記事のレビューノートハンドラー(記事を編集できるユーザーと担当のレビュー担当者のみ)
+type ReviewNoteHandler struct {
+	noteUseCase usecase.ReviewNoteUseCase
+	postUseCase usecase.PostUseCase
+}
+
+// NewReviewNoteHandler 新しいReviewNoteHandlerを作成
+func NewReviewNoteHandler(noteUseCase usecase.ReviewNoteUseCase, postUseCase usecase.PostUseCase) *ReviewNoteHandler {
+	return &ReviewNoteHandler{
+		noteUseCase: noteUseCase,
+		postUseCase: postUseCase,
+	}
+}
+
+// ReviewNoteResponse レビューノートのレスポンス
+type ReviewNoteResponse struct {
+	ID         int64                 `json:"id"`
+	PostID     int64                 `json:"postId"`
+	ParentID   *int64                `json:"parentId,omitempty"`
+	AuthorID   *int64                `json:"authorId"`
+	AuthorName string                `json:"authorName"`
+	Body       string                `json:"body"`
+	StartLine  *int                  `json:"startLine,omitempty"`
+	EndLine    *int                  `json:"endLine,omitempty"`
+	Quote      string                `json:"quote,omitempty"`
+	Outdated   bool                  `json:"outdated"`
+	Resolved   bool                  `json:"resolved"`
+	ResolvedAt *time.Time            `json:"resolvedAt,omitempty"`
+	ResolvedBy *int64                `json:"resolvedBy,omitempty"`
+	CreatedAt  time.Time             `json:"createdAt"`
+	UpdatedAt  time.Time             `json:"updatedAt"`
+	Replies    []*ReviewNoteResponse `json:"replies,omitempty"`
+}
+
+// UpdateReviewNoteRequest レビューノートの本文の変更リクエスト
+type UpdateReviewNoteRequest struct {
+	Body string `json:"body"`
+}
+
+// newReviewNoteResponse レビューノートをレスポンスに変換
+func newReviewNoteResponse(note *entity.ReviewNote) *ReviewNoteResponse {
+	response := &ReviewNoteResponse{
+		ID:         note.ID,
+		PostID:     note.PostID,
+		ParentID:   note.ParentID,
+		AuthorID:   note.AuthorID,
+		Body:       note.Body,
+		StartLine:  note.StartLine,
+		EndLine:    note.EndLine,
+		Quote:      note.Quote,
+		Outdated:   note.Outdated,
+		Resolved:   note.IsResolved(),
+		ResolvedAt: note.ResolvedAt,
+		ResolvedBy: note.ResolvedBy,
+		CreatedAt:  note.CreatedAt,
+		UpdatedAt:  note.UpdatedAt,
+	}
+	if note.Author != nil {
+		response.AuthorName = note.Author.Username
+	}
+	return response
+}
+
+// authorizeNote レビューノートを取得し、記事のレビューノートを扱う権限を確認する
+func (h *ReviewNoteHandler) authorizeNote(w http.ResponseWriter, r *http.Request) (*entity.ReviewNote, *entity.User, bool) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid review note ID")
+		return nil, nil, false
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+
+	note, err := h.noteUseCase.GetByID(r.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Review note not found")
+			return nil, nil, false
+		}
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to find review note")
+		return nil, nil, false
+	}
+
+	if _, err := h.postUseCase.AuthorizePost(r.Context(), user, usecase.ActionPostNote, note.PostID); err != nil {
+		writePostAuthorizationError(w, err)
+		return nil, nil, false
+	}
+
+	return note, user, true
+}
+
+// writeReviewNoteError レビューノートの操作のエラーをレスポンスに変換
+func writeReviewNoteError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrForbidden):
+		presenter.JSONError(w, http.StatusForbidden, "Forbidden: insufficient permissions for this review note")
+	case strings.Contains(err.Error(), "not found") && !strings.Contains(err.Error(), "invalid"):
+		presenter.JSONError(w, http.StatusNotFound, "Review note not found")
+	case strings.Contains(err.Error(), "invalid"),
+		strings.Contains(err.Error(), "is required"),
+		strings.Contains(err.Error(), "at most"):
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// List 記事のレビューノートのスレッド一覧ハンドラー(resolved=trueで解決済みのスレッドも含める)
+func (h *ReviewNoteHandler) List(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.ParseInt(r.URL.Query().Get("postId"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.postUseCase.AuthorizePost(r.Context(), user, usecase.ActionPostNote, postID); err != nil {
+		writePostAuthorizationError(w, err)
+		return
+	}
+
+	includeResolved := r.URL.Query().Get("resolved") == "true"
+	threads, err := h.noteUseCase.ListThreads(r.Context(), postID, includeResolved)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list review notes")
+		return
+	}
+
+	items := make([]*ReviewNoteResponse, len(threads))
+	for i, thread := range threads {
+		items[i] = newReviewNoteResponse(thread.ReviewNote)
+		items[i].Replies = make([]*ReviewNoteResponse, len(thread.Replies))
+		for j, reply := range thread.Replies {
+			items[i].Replies[j] = newReviewNoteResponse(reply)
+		}
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"threads": items,
+	})
+}
+
+// Create レビューノート作成ハンドラー(parentIdを指定した場合はスレッドへの返信)
+func (h *ReviewNoteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req usecase.CreateReviewNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.postUseCase.AuthorizePost(r.Context(), user, usecase.ActionPostNote, req.PostID); err != nil {
+		writePostAuthorizationError(w, err)
+		return
+	}
+
+	req.AuthorID = user.ID
+	note, err := h.noteUseCase.Create(r.Context(), &req)
+	if err != nil {
+		writeReviewNoteError(w, err, "Failed to create review note")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusCreated, newReviewNoteResponse(note))
+}
+
+// Update レビューノートの本文の変更ハンドラー(書いたユーザーのみ)
+func (h *ReviewNoteHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateReviewNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	note, user, ok := h.authorizeNote(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.noteUseCase.UpdateBody(r.Context(), note.ID, user, req.Body)
+	if err != nil {
+		writeReviewNoteError(w, err, "Failed to update review note")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, newReviewNoteResponse(updated))
+}
+
+// Delete レビューノート削除ハンドラー(書いたユーザーと管理者のみ)
+func (h *ReviewNoteHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	note, user, ok := h.authorizeNote(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.noteUseCase.Delete(r.Context(), note.ID, user); err != nil {
+		writeReviewNoteError(w, err, "Failed to delete review note")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Review note deleted successfully")
+}
+
+// Resolve スレッドを解決済みにするハンドラー
+func (h *ReviewNoteHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	note, user, ok := h.authorizeNote(w, r)
+	if !ok {
+		return
+	}
+
+	resolved, err := h.noteUseCase.Resolve(r.Context(), note.ID, user.ID)
+	if err != nil {
+		writeReviewNoteError(w, err, "Failed to resolve review note")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, newReviewNoteResponse(resolved))
+}
+
+// Reopen スレッドを未解決に戻すハンドラー
+func (h *ReviewNoteHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	note, _, ok := h.authorizeNote(w, r)
+	if !ok {
+		return
+	}
+
+	reopened, err := h.noteUseCase.Reopen(r.Context(), note.ID)
+	if err != nil {
+		writeReviewNoteError(w, err, "Failed to reopen review note")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, newReviewNoteResponse(reopened))
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/infrastructure/sitemap"
+	"my-blog-engine/internal/usecase"
+)
+
+// defaultSitemapCacheTTL サイトマップキャッシュのデフォルト有効期間
+const defaultSitemapCacheTTL = time.Hour
+
+// SitemapConfig サイトマップ・robots.txtの設定
+type SitemapConfig struct {
+	// SiteURL サイトの絶対URL(例: https://blog.example.com)
+	SiteURL string
+	// CacheTTL 生成したサイトマップを再利用する期間
+	CacheTTL time.Duration
+	// RobotsDisallow robots.txtでクロールを禁止するパス
+	RobotsDisallow []string
+}
+
+// SitemapHandler sitemap.xml・robots.txtのハンドラー
+type SitemapHandler struct {
+	sitemapUseCase usecase.SitemapUseCase
+	config         SitemapConfig
+	cache          *responseCache
+}
+
+// NewSitemapHandler SitemapHandlerのコンストラクタ
+func NewSitemapHandler(sitemapUseCase usecase.SitemapUseCase, config SitemapConfig) *SitemapHandler {
+	config.SiteURL = strings.TrimRight(config.SiteURL, "/")
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaultSitemapCacheTTL
+	}
+
+	return &SitemapHandler{
+		sitemapUseCase: sitemapUseCase,
+		config:         config,
+		cache:          newResponseCache(config.CacheTTL),
+	}
+}
+
+// Sitemap サイトマップ
+// 掲載ページ数が上限(50,000件)を超える場合は、分割したサイトマップ(/sitemaps/{n}.xml)のインデックスを返す
+func (h *SitemapHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(ctx context.Context) ([]byte, time.Time, error) {
+		total, err := h.sitemapUseCase.Count(ctx)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		if total <= sitemap.MaxURLs {
+			return h.renderURLSet(ctx, 0)
+		}
+
+		pages := (total + sitemap.MaxURLs - 1) / sitemap.MaxURLs
+		sitemaps := make([]sitemap.URL, pages)
+		for i := range sitemaps {
+			sitemaps[i] = sitemap.URL{Loc: fmt.Sprintf("%s/sitemaps/%d.xml", h.config.SiteURL, i+1)}
+		}
+
+		body, err := sitemap.RenderIndex(sitemaps)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return body, time.Time{}, nil
+	})
+}
+
+// SitemapPage 分割したサイトマップ(ページ番号は1始まり)
+func (h *SitemapHandler) SitemapPage(w http.ResponseWriter, r *http.Request) {
+	raw, ok := strings.CutSuffix(r.PathValue("file"), ".xml")
+	page, err := strconv.Atoi(raw)
+	if !ok || err != nil || page < 1 || strconv.Itoa(page) != raw {
+		http.NotFound(w, r)
+		return
+	}
+
+	h.serve(w, r, func(ctx context.Context) ([]byte, time.Time, error) {
+		total, err := h.sitemapUseCase.Count(ctx)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		// 分割不要な場合は/sitemap.xmlのみ
+		offset := (page - 1) * sitemap.MaxURLs
+		if total <= sitemap.MaxURLs || offset >= total {
+			return nil, time.Time{}, fmt.Errorf("sitemap page not found")
+		}
+
+		return h.renderURLSet(ctx, offset)
+	})
+}
+
+// renderURLSet offset番目から最大50,000件のページをサイトマップとして出力し、本文と最終更新日時を返す
+func (h *SitemapHandler) renderURLSet(ctx context.Context, offset int) ([]byte, time.Time, error) {
+	entries, err := h.sitemapUseCase.List(ctx, sitemap.MaxURLs, offset)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var lastModified time.Time
+	urls := make([]sitemap.URL, len(entries))
+	for i, entry := range entries {
+		urls[i] = sitemap.URL{
+			Loc:     h.config.SiteURL + sitemapPath(entry),
+			LastMod: entry.LastModified,
+		}
+		if entry.LastModified.After(lastModified) {
+			lastModified = entry.LastModified
+		}
+	}
+
+	body, err := sitemap.Render(urls)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return body, lastModified, nil
+}
+
+// sitemapPath サイトマップのエントリに対応するページのパス
+func sitemapPath(entry *usecase.SitemapEntry) string {
+	switch entry.Kind {
+	case usecase.SitemapEntryCategory:
+		return "/category/" + url.PathEscape(entry.Slug)
+	case usecase.SitemapEntryTag:
+		return "/tag/" + url.PathEscape(entry.Slug)
+	case usecase.SitemapEntryPost:
+		return "/posts/" + url.PathEscape(entry.Slug)
+	default:
+		return "/"
+	}
+}
+
+// serve サイトマップを返す共通処理
+func (h *SitemapHandler) serve(w http.ResponseWriter, r *http.Request, generate func(ctx context.Context) ([]byte, time.Time, error)) {
+	key := r.URL.Path
+	entry := h.cache.get(key)
+	if entry == nil {
+		body, lastModified, err := generate(r.Context())
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				http.NotFound(w, r)
+				return
+			}
+			log.Printf("Sitemap generation error: %v", err)
+			http.Error(w, "Failed to generate sitemap", http.StatusInternalServerError)
+			return
+		}
+		entry = h.cache.put(key, body, lastModified)
+	}
+
+	h.cache.serve(w, r, sitemap.ContentType, entry)
+}
+
+// Robots robots.txt
+func (h *SitemapHandler) Robots(w http.ResponseWriter, r *http.Request) {
+	var sb strings.Builder
+	sb.WriteString("User-agent: *\n")
+	if len(h.config.RobotsDisallow) == 0 {
+		// 空のDisallowはすべてのクロールを許可する
+		sb.WriteString("Disallow:\n")
+	}
+	for _, path := range h.config.RobotsDisallow {
+		fmt.Fprintf(&sb, "Disallow: %s\n", path)
+	}
+	fmt.Fprintf(&sb, "\nSitemap: %s/sitemap.xml\n", h.config.SiteURL)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(sb.String())); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"my-blog-engine/internal/interface/presenter"
 	"my-blog-engine/internal/usecase"
@@ -12,17 +13,23 @@ import (
 // TagHandler タグハンドラー
 type TagHandler struct {
 	tagUseCase usecase.TagUseCase
+	authorizer usecase.Authorizer
 }
 
 // NewTagHandler 新しいTagHandlerを作成
-func NewTagHandler(tagUseCase usecase.TagUseCase) *TagHandler {
+func NewTagHandler(tagUseCase usecase.TagUseCase, authorizer usecase.Authorizer) *TagHandler {
 	return &TagHandler{
 		tagUseCase: tagUseCase,
+		authorizer: authorizer,
 	}
 }
 
 // Create タグ作成ハンドラー
 func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, usecase.ActionTagCreate, nil) {
+		return
+	}
+
 	var req usecase.CreateTagRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
@@ -40,6 +47,10 @@ func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 // Update タグ更新ハンドラー
 func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, usecase.ActionTagUpdate, nil) {
+		return
+	}
+
 	idStr := r.URL.Query().Get("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -64,6 +75,10 @@ func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 // Delete タグ削除ハンドラー
 func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, h.authorizer, usecase.ActionTagDelete, nil) {
+		return
+	}
+
 	idStr := r.URL.Query().Get("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
@@ -72,6 +87,10 @@ func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.tagUseCase.Delete(r.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			presenter.JSONError(w, http.StatusNotFound, "Tag not found")
+			return
+		}
 		presenter.JSONError(w, http.StatusInternalServerError, "Failed to delete tag")
 		return
 	}
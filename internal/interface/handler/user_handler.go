@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/interface/presenter"
+	"my-blog-engine/internal/usecase"
+)
+
+// UserHandler ユーザー管理ハンドラー
+type UserHandler struct {
+	userUseCase usecase.UserUseCase
+}
+
+// NewUserHandler 新しいUserHandlerを作成
+func NewUserHandler(userUseCase usecase.UserUseCase) *UserHandler {
+	return &UserHandler{
+		userUseCase: userUseCase,
+	}
+}
+
+// UserResponse ユーザーのレスポンス(パスワードハッシュは含めない)
+type UserResponse struct {
+	ID        int64             `json:"id"`
+	Username  string            `json:"username"`
+	Email     string            `json:"email"`
+	Role      entity.UserRole   `json:"role"`
+	Status    entity.UserStatus `json:"status"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// newUserResponse ユーザーをレスポンスに変換
+func newUserResponse(user *entity.User) *UserResponse {
+	return &UserResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Role:      user.Role,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// PasswordPolicyErrorResponse パスワードポリシー違反のエラーレスポンス(違反の種類を全て含む)
+type PasswordPolicyErrorResponse struct {
+	presenter.ErrorResponse
+	Violations []usecase.PasswordViolation `json:"violations"`
+}
+
+// writePasswordPolicyError パスワードポリシー違反であれば400と違反の一覧を返す
+func writePasswordPolicyError(w http.ResponseWriter, err error) bool {
+	var policyErr *usecase.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+
+	presenter.JSONResponse(w, http.StatusBadRequest, PasswordPolicyErrorResponse{
+		ErrorResponse: presenter.ErrorResponse{
+			Error:   http.StatusText(http.StatusBadRequest),
+			Message: "Password does not meet the password policy",
+			Code:    http.StatusBadRequest,
+		},
+		Violations: policyErr.Violations,
+	})
+	return true
+}
+
+// writeUserError ユースケースのエラーをHTTPステータスに変換して返す
+func writeUserError(w http.ResponseWriter, err error, fallback string) {
+	if writePasswordPolicyError(w, err) {
+		return
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		presenter.JSONError(w, http.StatusNotFound, "User not found")
+	case strings.Contains(err.Error(), "already exists"),
+		strings.Contains(err.Error(), "last active admin"):
+		presenter.JSONError(w, http.StatusConflict, err.Error())
+	case strings.Contains(err.Error(), "is required"),
+		strings.Contains(err.Error(), "invalid"):
+		presenter.JSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		presenter.JSONError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// parseUserID クエリパラメータidからユーザーIDを取得
+func parseUserID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid user ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List ユーザー一覧ハンドラー
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	users, count, err := h.userUseCase.List(r.Context(), limit, offset)
+	if err != nil {
+		presenter.JSONError(w, http.StatusInternalServerError, "Failed to list users")
+		return
+	}
+
+	items := make([]*UserResponse, len(users))
+	for i, user := range users {
+		items[i] = newUserResponse(user)
+	}
+
+	response := map[string]interface{}{
+		"users":  items,
+		"total":  count,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, response)
+}
+
+// Create ユーザー作成ハンドラー
+func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req usecase.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.userUseCase.Create(r.Context(), &req)
+	if err != nil {
+		writeUserError(w, err, "Failed to create user")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusCreated, newUserResponse(user))
+}
+
+// Update ユーザー更新ハンドラー(ロール・ステータスの変更)
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req usecase.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.userUseCase.Update(r.Context(), id, &req)
+	if err != nil {
+		writeUserError(w, err, "Failed to update user")
+		return
+	}
+
+	presenter.JSONResponse(w, http.StatusOK, newUserResponse(user))
+}
+
+// ResetPassword パスワード再設定ハンドラー
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		presenter.JSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req usecase.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presenter.JSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.userUseCase.ResetPassword(r.Context(), id, &req); err != nil {
+		writeUserError(w, err, "Failed to reset password")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "Password reset successfully")
+}
+
+// Deactivate ユーザー無効化ハンドラー
+func (h *UserHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.userUseCase.Deactivate(r.Context(), id); err != nil {
+		writeUserError(w, err, "Failed to deactivate user")
+		return
+	}
+
+	presenter.JSONSuccess(w, nil, "User deactivated successfully")
+}
@@ -15,21 +15,26 @@ type contextKey string
 const (
 	// UserContextKeyユーザーコンテキストキー
 	UserContextKey contextKey = "user"
+	// scopeContextKey パーソナルアクセストークンに必要なスコープのコンテキストキー
+	scopeContextKey contextKey = "scope"
 )
 
 // AuthMiddleware 認証ミドルウェア
 type AuthMiddleware struct {
-	authUseCase usecase.AuthUseCase
+	authUseCase        usecase.AuthUseCase
+	accessTokenUseCase usecase.PersonalAccessTokenUseCase
 }
 
 // NewAuthMiddleware 新しいAuthMiddlewareを作成
-func NewAuthMiddleware(authUseCase usecase.AuthUseCase) *AuthMiddleware {
+func NewAuthMiddleware(authUseCase usecase.AuthUseCase, accessTokenUseCase usecase.PersonalAccessTokenUseCase) *AuthMiddleware {
 	return &AuthMiddleware{
-		authUseCase: authUseCase,
+		authUseCase:        authUseCase,
+		accessTokenUseCase: accessTokenUseCase,
 	}
 }
 
 // Authenticate 認証を行うミドルウェア
+// JWTのほか、AcceptAccessTokenで必要なスコープを指定したエンドポイントではパーソナルアクセストークンも受け付ける
 func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Authorizationヘッダーを取得
@@ -48,6 +53,11 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 
 		token := parts[1]
 
+		if usecase.IsPersonalAccessToken(token) {
+			m.authenticateAccessToken(w, r, token, next)
+			return
+		}
+
 		// トークン検証
 		user, err := m.authUseCase.ValidateToken(r.Context(), token)
 		if err != nil {
@@ -56,11 +66,54 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 		}
 
 		// ユーザーをコンテキストに追加
-		ctx := context.WithValue(r.Context(), UserContextKey, user)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(withUser(r.Context(), user)))
 	})
 }
 
+// authenticateAccessToken パーソナルアクセストークンで認証を行う
+// スコープの指定がないエンドポイント(トークンの発行やユーザー管理など)では使用できない
+func (m *AuthMiddleware) authenticateAccessToken(w http.ResponseWriter, r *http.Request, token string, next http.Handler) {
+	scope, ok := r.Context().Value(scopeContextKey).(entity.TokenScope)
+	if !ok {
+		http.Error(w, "Forbidden: personal access tokens are not allowed", http.StatusForbidden)
+		return
+	}
+
+	user, err := m.accessTokenUseCase.Authenticate(r.Context(), token, scope)
+	if err != nil {
+		if strings.Contains(err.Error(), "insufficient scope") {
+			http.Error(w, "Forbidden: insufficient scope", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(withUser(r.Context(), user)))
+}
+
+// withUser 認証したユーザーをコンテキストに設定(監査ログの操作したユーザーとしても記録する)
+func withUser(ctx context.Context, user *entity.User) context.Context {
+	ctx = context.WithValue(ctx, UserContextKey, user)
+	return usecase.WithActor(ctx, user)
+}
+
+// AcceptAccessToken パーソナルアクセストークンを受け付けるエンドポイントに必要なスコープを指定するミドルウェア
+// GET・HEADはread、それ以外のメソッドはwriteのスコープが必要(Authenticateの外側に適用する)
+func (m *AuthMiddleware) AcceptAccessToken(read, write entity.TokenScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := write
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				scope = read
+			}
+
+			ctx := context.WithValue(r.Context(), scopeContextKey, scope)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 // RequireRole 指定されたロールを必要とするミドルウェア
 func (m *AuthMiddleware) RequireRole(roles ...entity.UserRole) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
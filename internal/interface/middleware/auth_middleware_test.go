@@ -2,6 +2,7 @@ package middleware_test
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/mailer"
 	"my-blog-engine/internal/infrastructure/persistence"
 	"my-blog-engine/internal/interface/middleware"
 	"my-blog-engine/internal/usecase"
@@ -21,6 +23,13 @@ import (
 func setupAuthMiddleware(t *testing.T) (*middleware.AuthMiddleware, *entity.User, string, func()) {
 	t.Helper()
 
+	authMiddleware, user, token, _, cleanup := setupAuthMiddlewareWithAccessTokens(t)
+	return authMiddleware, user, token, cleanup
+}
+
+func setupAuthMiddlewareWithAccessTokens(t *testing.T) (*middleware.AuthMiddleware, *entity.User, string, usecase.PersonalAccessTokenUseCase, func()) {
+	t.Helper()
+
 	db, cleanup := testhelper.SetupTestDB(t)
 
 	userRepo := persistence.NewUserRepository(db)
@@ -33,16 +42,27 @@ func setupAuthMiddleware(t *testing.T) (*middleware.AuthMiddleware, *entity.User
 		RefreshExpiry: 168 * time.Hour,
 	})
 	require.NoError(t, err)
+	secretBox, err := auth.NewSecretBox("test-secret-key-min-32-chars-long")
+	require.NoError(t, err)
+	auditUseCase := usecase.NewAuditUseCase(persistence.NewAuditEventRepository(db), usecase.AuditConfig{})
+	mfaUseCase := usecase.NewMFAUseCase(persistence.NewMFARepository(db), persistence.NewMFAPolicyRepository(db), secretBox, auditUseCase, "Test Blog")
 
 	authUseCase := usecase.NewAuthUseCase(
 		userRepo,
 		tokenRepo,
+		persistence.NewRefreshTokenRepository(db),
+		persistence.NewSessionRepository(db),
 		jwtManager,
 		passwordHasher,
+		usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil),
+		mfaUseCase,
+		usecase.NewLoginProtectionUseCase(persistence.NewLoginThrottleRepository(db), persistence.NewLoginAttemptRepository(db), mailer.NewLogMailer(slog.Default()), auditUseCase, usecase.LoginProtectionConfig{}),
+		auditUseCase,
 		15*time.Minute,
 	)
 
-	authMiddleware := middleware.NewAuthMiddleware(authUseCase)
+	accessTokenUseCase := usecase.NewPersonalAccessTokenUseCase(persistence.NewPersonalAccessTokenRepository(db), userRepo, auditUseCase)
+	authMiddleware := middleware.NewAuthMiddleware(authUseCase, accessTokenUseCase)
 
 	// テストユーザー作成
 	ctx := context.Background()
@@ -63,7 +83,7 @@ func setupAuthMiddleware(t *testing.T) (*middleware.AuthMiddleware, *entity.User
 	response, err := authUseCase.Login(ctx, "testuser", "password123")
 	require.NoError(t, err)
 
-	return authMiddleware, user, response.AccessToken, cleanup
+	return authMiddleware, user, response.AccessToken, accessTokenUseCase, cleanup
 }
 
 func TestAuthMiddleware_Authenticate_Success(t *testing.T) {
@@ -168,3 +188,59 @@ func TestAuthMiddleware_RequireRole_Forbidden(t *testing.T) {
 
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
+
+func TestAuthMiddleware_AccessToken(t *testing.T) {
+	authMiddleware, user, _, accessTokenUseCase, cleanup := setupAuthMiddlewareWithAccessTokens(t)
+	defer cleanup()
+
+	created, err := accessTokenUseCase.Create(context.Background(), user, &usecase.CreatePersonalAccessTokenRequest{
+		Name:   "deploy",
+		Scopes: []entity.TokenScope{entity.ScopePostsWrite},
+	})
+	require.NoError(t, err)
+
+	reached := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		contextUser, ok := middleware.GetUserFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, user.ID, contextUser.ID)
+		w.WriteHeader(http.StatusOK)
+	})
+	scoped := authMiddleware.AcceptAccessToken(entity.ScopePostsRead, entity.ScopePostsWrite)(authMiddleware.Authenticate(next))
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		method  string
+		token   string
+		status  int
+	}{
+		{name: "write with write scope", handler: scoped, method: http.MethodPost, token: created.Token, status: http.StatusOK},
+		{name: "read without read scope", handler: scoped, method: http.MethodGet, token: created.Token, status: http.StatusForbidden},
+		{name: "endpoint without scope", handler: authMiddleware.Authenticate(next), method: http.MethodPost, token: created.Token, status: http.StatusForbidden},
+		{name: "unknown token", handler: scoped, method: http.MethodPost, token: usecase.PersonalAccessTokenPrefix + "unknown", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached = false
+			req := httptest.NewRequest(tt.method, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.status == http.StatusOK, reached)
+		})
+	}
+
+	// 失効したトークンは使用できない
+	require.NoError(t, accessTokenUseCase.Revoke(context.Background(), user.ID, created.AccessToken.ID))
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+created.Token)
+	rec := httptest.NewRecorder()
+	scoped.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"my-blog-engine/internal/usecase"
+)
+
+// ClientInfo リクエスト元のIPアドレスとUser-Agentをコンテキストに設定するミドルウェア
+// (ログインセッションと監査ログに記録する)
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := usecase.WithClientInfo(r.Context(), usecase.ClientInfo{
+			UserAgent: r.UserAgent(),
+			IPAddress: ClientIP(r),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
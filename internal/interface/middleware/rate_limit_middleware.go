@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -38,7 +39,7 @@ func NewRateLimiter(rate, burst int) *RateLimiter {
 // Limit レート制限を適用するミドルウェア
 func (rl *RateLimiter) Limit(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip := r.RemoteAddr
+		ip := ClientIP(r)
 
 		if !rl.allow(ip) {
 			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
@@ -49,6 +50,15 @@ func (rl *RateLimiter) Limit(next http.Handler) http.Handler {
 	})
 }
 
+// ClientIP リクエスト元のIPアドレスを取得(RemoteAddrからポート番号を除く)
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // allow リクエストを許可するかチェック
 func (rl *RateLimiter) allow(ip string) bool {
 	rl.mu.Lock()
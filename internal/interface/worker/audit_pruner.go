@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"my-blog-engine/internal/usecase"
+)
+
+// defaultAuditPruneInterval 実行間隔が不正な場合に使用する既定値
+const defaultAuditPruneInterval = time.Hour
+
+// AuditPruner 保持期間を過ぎた監査ログを定期的に削除するワーカー
+type AuditPruner struct {
+	auditUseCase usecase.AuditUseCase
+	interval     time.Duration
+}
+
+// NewAuditPruner 新しいAuditPrunerを作成
+func NewAuditPruner(auditUseCase usecase.AuditUseCase, interval time.Duration) *AuditPruner {
+	if interval <= 0 {
+		interval = defaultAuditPruneInterval
+	}
+	return &AuditPruner{
+		auditUseCase: auditUseCase,
+		interval:     interval,
+	}
+}
+
+// Run ctxがキャンセルされるまで一定間隔で監査ログを削除する
+// 起動直後にも1回実行し、停止中に保持期間を過ぎた記録も削除する
+func (p *AuditPruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.prune(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.prune(ctx)
+		}
+	}
+}
+
+// prune 保持期間を過ぎた監査ログを削除
+func (p *AuditPruner) prune(ctx context.Context) {
+	deleted, err := p.auditUseCase.Prune(ctx, time.Now())
+	if deleted > 0 {
+		slog.Info("Pruned audit events", "count", deleted)
+	}
+	if err != nil && ctx.Err() == nil {
+		slog.Error("Failed to prune audit events", "error", err)
+	}
+}
@@ -0,0 +1,49 @@
+package worker_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/interface/worker"
+	"my-blog-engine/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubAuditUseCase Pruneの呼び出し回数のみを記録するスタブ
+type stubAuditUseCase struct {
+	usecase.AuditUseCase
+	calls atomic.Int32
+}
+
+func (s *stubAuditUseCase) Prune(_ context.Context, _ time.Time) (int, error) {
+	s.calls.Add(1)
+	return 0, nil
+}
+
+func TestAuditPruner_Run(t *testing.T) {
+	stub := &stubAuditUseCase{}
+	pruner := worker.NewAuditPruner(stub, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pruner.Run(ctx)
+	}()
+
+	// 起動直後と一定間隔で実行される
+	assert.Eventually(t, func() bool {
+		return stub.calls.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	// キャンセルで停止する
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pruner did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"my-blog-engine/internal/usecase"
+)
+
+// defaultScheduledPublishInterval 実行間隔が不正な場合に使用する既定値
+const defaultScheduledPublishInterval = 30 * time.Second
+
+// ScheduledPublisher 公開予定日時を過ぎた予約記事を定期的に公開するワーカー
+type ScheduledPublisher struct {
+	postUseCase usecase.PostUseCase
+	interval    time.Duration
+}
+
+// NewScheduledPublisher 新しいScheduledPublisherを作成
+func NewScheduledPublisher(postUseCase usecase.PostUseCase, interval time.Duration) *ScheduledPublisher {
+	if interval <= 0 {
+		interval = defaultScheduledPublishInterval
+	}
+	return &ScheduledPublisher{
+		postUseCase: postUseCase,
+		interval:    interval,
+	}
+}
+
+// Run ctxがキャンセルされるまで一定間隔で予約記事を公開する
+// 起動直後にも1回実行し、停止中に期限を迎えた記事を取りこぼさないようにする
+func (p *ScheduledPublisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.publishDue(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.publishDue(ctx)
+		}
+	}
+}
+
+// publishDue 期限を迎えた予約記事を公開
+func (p *ScheduledPublisher) publishDue(ctx context.Context) {
+	ids, err := p.postUseCase.PublishDue(ctx, time.Now())
+	if len(ids) > 0 {
+		slog.Info("Published scheduled posts", "count", len(ids), "post_ids", ids)
+	}
+	if err != nil && ctx.Err() == nil {
+		slog.Error("Failed to publish scheduled posts", "error", err)
+	}
+}
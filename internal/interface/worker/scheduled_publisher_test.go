@@ -0,0 +1,49 @@
+package worker_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/interface/worker"
+	"my-blog-engine/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubPostUseCase PublishDueの呼び出し回数のみを記録するスタブ
+type stubPostUseCase struct {
+	usecase.PostUseCase
+	calls atomic.Int32
+}
+
+func (s *stubPostUseCase) PublishDue(_ context.Context, _ time.Time) ([]int64, error) {
+	s.calls.Add(1)
+	return nil, nil
+}
+
+func TestScheduledPublisher_Run(t *testing.T) {
+	stub := &stubPostUseCase{}
+	publisher := worker.NewScheduledPublisher(stub, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		publisher.Run(ctx)
+	}()
+
+	// 起動直後と一定間隔で実行される
+	assert.Eventually(t, func() bool {
+		return stub.calls.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	// キャンセルで停止する
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publisher did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+)
+
+// DefaultAuditRetention 監査ログの保持期間の既定値
+const DefaultAuditRetention = 365 * 24 * time.Hour
+
+// auditPruneBatchSize 保持期間を過ぎた監査ログを1回のクエリで削除する最大件数
+const auditPruneBatchSize = 1000
+
+// AuditUseCase 管理操作の監査ログユースケースのインターフェース
+type AuditUseCase interface {
+	// Record 監査ログを記録(記録に失敗しても操作自体は失敗させず、エラーログに残す)
+	// 操作したユーザーとクライアントの情報はコンテキストから取得する
+	Record(ctx context.Context, entry *AuditEntry)
+	// List 監査ログを新しい順に取得し、続きがある場合は次のページのカーソルを返す
+	List(ctx context.Context, filters repository.AuditEventFilters, cursor string, limit int) ([]*entity.AuditEvent, string, error)
+	// Prune 保持期間を過ぎた監査ログを削除し、削除した件数を返す
+	Prune(ctx context.Context, now time.Time) (int, error)
+}
+
+// AuditEntry 監査ログに記録する操作
+type AuditEntry struct {
+	Action     entity.AuditAction
+	TargetType entity.AuditTargetType
+	TargetID   int64
+	// Before・After 変更前後のスナップショット(両方ある場合は変更された項目のみ記録する)
+	Before map[string]any
+	After  map[string]any
+	// Actor 操作したユーザー(nilの場合はコンテキストのユーザー)
+	Actor *entity.User
+}
+
+// AuditConfig 監査ログの設定
+type AuditConfig struct {
+	// Retention 保持期間(0以下の場合は削除しない)
+	Retention time.Duration
+}
+
+// actorKey 操作したユーザーのコンテキストキー
+type actorKey struct{}
+
+// WithActor 操作したユーザーをコンテキストに設定(監査ログに記録する)
+func WithActor(ctx context.Context, user *entity.User) context.Context {
+	return context.WithValue(ctx, actorKey{}, user)
+}
+
+// actorFromContext コンテキストから操作したユーザーを取得
+func actorFromContext(ctx context.Context) *entity.User {
+	user, _ := ctx.Value(actorKey{}).(*entity.User)
+	return user
+}
+
+// auditUseCase AuditUseCaseの実装
+type auditUseCase struct {
+	auditRepo repository.AuditEventRepository
+	retention time.Duration
+}
+
+// NewAuditUseCase 新しいAuditUseCaseを作成
+func NewAuditUseCase(auditRepo repository.AuditEventRepository, config AuditConfig) AuditUseCase {
+	return &auditUseCase{
+		auditRepo: auditRepo,
+		retention: config.Retention,
+	}
+}
+
+// Record 監査ログを記録
+func (u *auditUseCase) Record(ctx context.Context, entry *AuditEntry) {
+	client := clientInfoFromContext(ctx)
+	event := &entity.AuditEvent{
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		IPAddress:  client.IPAddress,
+		UserAgent:  truncateRunes(client.UserAgent, maxUserAgentLength),
+	}
+	if entry.TargetID != 0 {
+		event.TargetID = &entry.TargetID
+	}
+
+	actor := entry.Actor
+	if actor == nil {
+		actor = actorFromContext(ctx)
+	}
+	if actor != nil {
+		event.ActorID = &actor.ID
+		event.ActorName = actor.Username
+	}
+
+	event.Before, event.After = auditChanges(entry.Before, entry.After)
+
+	// 操作の完了後に記録するため、リクエストがキャンセルされても記録する
+	if err := u.auditRepo.Create(context.WithoutCancel(ctx), event); err != nil {
+		slog.ErrorContext(ctx, "Failed to record audit event", "action", entry.Action, "target_type", entry.TargetType, "target_id", entry.TargetID, "error", err)
+	}
+}
+
+// auditChanges 変更前後の両方がある場合は値が変わった項目のみに絞り込む
+func auditChanges(before, after map[string]any) (map[string]any, map[string]any) {
+	if before == nil || after == nil {
+		return before, after
+	}
+
+	changedBefore := make(map[string]any)
+	changedAfter := make(map[string]any)
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !sameAuditValue(old, value) {
+			changedBefore[key] = before[key]
+			changedAfter[key] = value
+		}
+	}
+	for key, old := range before {
+		if _, ok := after[key]; !ok {
+			changedBefore[key] = old
+			changedAfter[key] = nil
+		}
+	}
+
+	return changedBefore, changedAfter
+}
+
+// sameAuditValue スナップショットの値が同じかどうかをJSON表現で比較
+func sameAuditValue(a, b any) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
+
+// List 監査ログを新しい順に取得し、続きがある場合は次のページのカーソルを返す
+// カーソルは前のページの最後の監査ログのIDで、記録が追加されてもページがずれない
+func (u *auditUseCase) List(ctx context.Context, filters repository.AuditEventFilters, cursor string, limit int) ([]*entity.AuditEvent, string, error) {
+	if limit < 1 {
+		return nil, "", fmt.Errorf("invalid limit: must be at least 1")
+	}
+
+	var beforeID int64
+	if cursor != "" {
+		id, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, "", fmt.Errorf("invalid cursor")
+		}
+		beforeID = id
+	}
+
+	// 続きがあるかを判定するため1件多く取得する
+	events, err := u.auditRepo.List(ctx, filters, beforeID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(events) > limit {
+		events = events[:limit]
+		next = strconv.FormatInt(events[len(events)-1].ID, 10)
+	}
+
+	return events, next, nil
+}
+
+// Prune 保持期間を過ぎた監査ログを削除し、削除した件数を返す
+func (u *auditUseCase) Prune(ctx context.Context, now time.Time) (int, error) {
+	if u.retention <= 0 {
+		return 0, nil
+	}
+
+	before := now.Add(-u.retention)
+	total := 0
+	for {
+		deleted, err := u.auditRepo.DeleteBefore(ctx, before, auditPruneBatchSize)
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+		if deleted < auditPruneBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// postAuditSnapshot 監査ログに記録する記事の状態(本文はリビジョンに記録されるため長さのみ)
+func postAuditSnapshot(post *entity.Post) map[string]any {
+	tagIDs := make([]int64, len(post.Tags))
+	for i, tag := range post.Tags {
+		tagIDs[i] = tag.ID
+	}
+	return map[string]any{
+		"title":         post.Title,
+		"slug":          post.Slug,
+		"status":        post.Status,
+		"authorId":      post.AuthorID,
+		"categoryId":    post.CategoryID,
+		"tagIds":        tagIDs,
+		"contentLength": len(post.Content),
+		"publishedAt":   post.PublishedAt,
+		"scheduledAt":   post.ScheduledAt,
+		"reviewerId":    post.ReviewerID,
+	}
+}
+
+// categoryAuditSnapshot 監査ログに記録するカテゴリの状態
+func categoryAuditSnapshot(category *entity.Category) map[string]any {
+	return map[string]any{
+		"name":        category.Name,
+		"slug":        category.Slug,
+		"description": category.Description,
+	}
+}
+
+// tagAuditSnapshot 監査ログに記録するタグの状態
+func tagAuditSnapshot(tag *entity.Tag) map[string]any {
+	return map[string]any{
+		"name": tag.Name,
+		"slug": tag.Slug,
+	}
+}
+
+// commentAuditSnapshot 監査ログに記録するコメントの状態(本文は長さのみ)
+func commentAuditSnapshot(comment *entity.Comment) map[string]any {
+	return map[string]any{
+		"postId":        comment.PostID,
+		"parentId":      comment.ParentID,
+		"authorName":    comment.AuthorName,
+		"status":        comment.Status,
+		"contentLength": len(comment.Content),
+	}
+}
+
+// mediaAuditSnapshot 監査ログに記録するメディアの状態
+func mediaAuditSnapshot(media *entity.Media) map[string]any {
+	return map[string]any{
+		"storageKey":   media.StorageKey,
+		"originalName": media.OriginalName,
+		"mimeType":     media.MimeType,
+		"size":         media.Size,
+		"altText":      media.AltText,
+		"ownerId":      media.OwnerID,
+	}
+}
+
+// userAuditSnapshot 監査ログに記録するユーザーの状態(パスワードハッシュは含めない)
+func userAuditSnapshot(user *entity.User) map[string]any {
+	return map[string]any{
+		"username": user.Username,
+		"email":    user.Email,
+		"role":     user.Role,
+		"status":   user.Status,
+	}
+}
@@ -0,0 +1,224 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/infrastructure/renderer"
+	"my-blog-engine/internal/infrastructure/storage"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+// newTestAudit テスト用のAuditUseCaseを作成(保持期間の既定値で削除する)
+func newTestAudit(db *bun.DB) usecase.AuditUseCase {
+	return usecase.NewAuditUseCase(persistence.NewAuditEventRepository(db), usecase.AuditConfig{
+		Retention: usecase.DefaultAuditRetention,
+	})
+}
+
+func TestAuditUseCase_Record(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	admin := &entity.User{
+		Username:     "admin",
+		Email:        "admin@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleAdmin,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(context.Background(), admin))
+
+	ctx := usecase.WithActor(context.Background(), admin)
+	ctx = usecase.WithClientInfo(ctx, usecase.ClientInfo{UserAgent: "test-agent", IPAddress: "192.0.2.1"})
+
+	audit := newTestAudit(db)
+	categoryUseCase := usecase.NewCategoryUseCase(persistence.NewCategoryRepository(db), audit)
+
+	category, err := categoryUseCase.Create(ctx, &usecase.CreateCategoryRequest{Name: "Tech", Slug: "tech", Description: "Technology"})
+	require.NoError(t, err)
+	newName := "Technology"
+	_, err = categoryUseCase.Update(ctx, category.ID, &usecase.UpdateCategoryRequest{Name: &newName})
+	require.NoError(t, err)
+	require.NoError(t, categoryUseCase.Delete(ctx, category.ID))
+
+	events, _, err := audit.List(ctx, repository.AuditEventFilters{TargetType: entity.AuditTargetCategory}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	deleted, updated, created := events[0], events[1], events[2]
+	assert.Equal(t, entity.AuditCategoryCreate, created.Action)
+	assert.Nil(t, created.Before)
+	assert.Equal(t, "Tech", created.After["name"])
+	require.NotNil(t, created.ActorID)
+	assert.Equal(t, admin.ID, *created.ActorID)
+	assert.Equal(t, "admin", created.ActorName)
+	assert.Equal(t, "192.0.2.1", created.IPAddress)
+	assert.Equal(t, "test-agent", created.UserAgent)
+	require.NotNil(t, created.TargetID)
+	assert.Equal(t, category.ID, *created.TargetID)
+
+	// 更新は変更された項目のみ記録する
+	assert.Equal(t, entity.AuditCategoryUpdate, updated.Action)
+	assert.Equal(t, map[string]any{"name": "Tech"}, updated.Before)
+	assert.Equal(t, map[string]any{"name": "Technology"}, updated.After)
+
+	assert.Equal(t, entity.AuditCategoryDelete, deleted.Action)
+	assert.Equal(t, "Technology", deleted.Before["name"])
+	assert.Nil(t, deleted.After)
+}
+
+func TestAuditUseCase_Record_CommentsAndMedia(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	admin := &entity.User{
+		Username:     "admin",
+		Email:        "admin@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleAdmin,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, persistence.NewUserRepository(db).Create(context.Background(), admin))
+
+	post := &entity.Post{
+		Title:    "Published",
+		Slug:     "published",
+		Content:  "content",
+		Status:   entity.StatusPublished,
+		AuthorID: admin.ID,
+	}
+	postRepo := persistence.NewPostRepository(db)
+	require.NoError(t, postRepo.Create(context.Background(), post))
+
+	ctx := usecase.WithActor(context.Background(), admin)
+	audit := newTestAudit(db)
+
+	// コメントのモデレーション・削除
+	commentUseCase := usecase.NewCommentUseCase(persistence.NewCommentRepository(db), postRepo, renderer.NewCommentRenderer(), audit)
+	comment := submitComment(t, commentUseCase, post.ID, nil, "nice")
+	_, err := commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{IDs: []int64{comment.ID}, Status: entity.CommentApproved})
+	require.NoError(t, err)
+	require.NoError(t, commentUseCase.Delete(ctx, comment.ID))
+
+	events, _, err := audit.List(ctx, repository.AuditEventFilters{TargetType: entity.AuditTargetComment}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, entity.AuditCommentDelete, events[0].Action)
+	assert.Equal(t, "reader", events[0].Before["authorName"])
+	assert.Equal(t, entity.AuditCommentModerate, events[1].Action)
+	assert.Equal(t, map[string]any{"status": "pending"}, events[1].Before)
+	assert.Equal(t, map[string]any{"status": "approved"}, events[1].After)
+	require.NotNil(t, events[1].ActorID)
+	assert.Equal(t, admin.ID, *events[1].ActorID)
+
+	// メディアのアップロード・更新・削除
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mediaUseCase := usecase.NewMediaUseCase(persistence.NewMediaRepository(db), store, nil, audit, 0)
+	media, err := mediaUseCase.Upload(ctx, &usecase.UploadMediaRequest{
+		OwnerID:  admin.ID,
+		Filename: "image.png",
+		AltText:  "before",
+		File:     bytes.NewReader(testPNG(t, 2, 2)),
+	})
+	require.NoError(t, err)
+	altText := "after"
+	_, err = mediaUseCase.Update(ctx, media.ID, &usecase.UpdateMediaRequest{AltText: &altText})
+	require.NoError(t, err)
+	require.NoError(t, mediaUseCase.Delete(ctx, media.ID))
+
+	events, _, err = audit.List(ctx, repository.AuditEventFilters{TargetType: entity.AuditTargetMedia}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	deleted, updated, uploaded := events[0], events[1], events[2]
+	assert.Equal(t, entity.AuditMediaUpload, uploaded.Action)
+	assert.Equal(t, "image.png", uploaded.After["originalName"])
+	require.NotNil(t, uploaded.TargetID)
+	assert.Equal(t, media.ID, *uploaded.TargetID)
+	assert.Equal(t, entity.AuditMediaUpdate, updated.Action)
+	assert.Equal(t, map[string]any{"altText": "before"}, updated.Before)
+	assert.Equal(t, map[string]any{"altText": "after"}, updated.After)
+	assert.Equal(t, entity.AuditMediaDelete, deleted.Action)
+	assert.Nil(t, deleted.After)
+}
+
+func TestAuditUseCase_List(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	audit := newTestAudit(db)
+	for i := int64(1); i <= 5; i++ {
+		audit.Record(ctx, &usecase.AuditEntry{
+			Action:     entity.AuditPostPublish,
+			TargetType: entity.AuditTargetPost,
+			TargetID:   i,
+		})
+	}
+
+	// カーソルで続きを取得し、最後のページではカーソルを返さない
+	var targets []int64
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		events, next, err := audit.List(ctx, repository.AuditEventFilters{}, cursor, 2)
+		require.NoError(t, err)
+		for _, event := range events {
+			assert.Nil(t, event.ActorID)
+			targets = append(targets, *event.TargetID)
+		}
+		cursor = next
+	}
+	assert.Equal(t, []int64{5, 4, 3, 2, 1}, targets)
+	assert.Empty(t, cursor)
+
+	_, _, err := audit.List(ctx, repository.AuditEventFilters{}, "abc", 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid cursor")
+
+	for _, limit := range []int{0, -1} {
+		_, _, err = audit.List(ctx, repository.AuditEventFilters{}, "", limit)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid limit")
+	}
+}
+
+func TestAuditUseCase_Prune(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := persistence.NewAuditEventRepository(db)
+
+	now := time.Now().Truncate(time.Second)
+	for _, createdAt := range []time.Time{now.Add(-48 * time.Hour), now.Add(-36 * time.Hour), now.Add(-time.Hour)} {
+		require.NoError(t, repo.Create(ctx, &entity.AuditEvent{
+			Action:     entity.AuditPostPublish,
+			TargetType: entity.AuditTargetPost,
+			CreatedAt:  createdAt,
+		}))
+	}
+
+	// 保持期間が0の場合は削除しない
+	deleted, err := usecase.NewAuditUseCase(repo, usecase.AuditConfig{}).Prune(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = usecase.NewAuditUseCase(repo, usecase.AuditConfig{Retention: 24 * time.Hour}).Prune(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	events, err := repo.List(ctx, repository.AuditEventFilters{}, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
@@ -5,11 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/domain/repository"
 	"my-blog-engine/internal/infrastructure/auth"
+
+	"github.com/google/uuid"
 )
 
 // AuthUseCase 認証ユースケースのインターフェース
@@ -18,58 +21,159 @@ type AuthUseCase interface {
 	Logout(ctx context.Context, token string) error
 	RefreshToken(ctx context.Context, refreshToken string) (*RefreshTokenResponse, error)
 	ValidateToken(ctx context.Context, token string) (*entity.User, error)
+	// VerifyMFA ログインの2段階目としてワンタイムコード(またはリカバリーコード)を検証してトークンを発行
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResponse, error)
+	// BeginMFAEnrollment ログイン時に二要素認証の登録を求められたユーザーのTOTPシークレットを発行
+	BeginMFAEnrollment(ctx context.Context, mfaToken string) (*MFASetupResponse, error)
+	// CompleteMFAEnrollment 確認コードを検証して二要素認証を有効にし、トークンを発行
+	CompleteMFAEnrollment(ctx context.Context, mfaToken, code string) (*MFAEnrollmentResponse, error)
+	// ListSessions トークンのユーザーの有効なセッション一覧を取得
+	ListSessions(ctx context.Context, token string) ([]*SessionResponse, error)
+	// RevokeSession トークンのユーザーのセッションを失効させる
+	RevokeSession(ctx context.Context, token, sessionID string) error
+	// RevokeOtherSessions トークンのセッション以外のユーザーのセッションを全て失効させる
+	RevokeOtherSessions(ctx context.Context, token string) (int, error)
+	// RevokeUserSessions ユーザーの全てのセッションを失効させる(管理者による強制ログアウト)
+	RevokeUserSessions(ctx context.Context, userID int64) (int, error)
+	// ChangePassword 現在のパスワードを確認して自分のパスワードを変更し、新しいセッションのトークンを発行
+	ChangePassword(ctx context.Context, token string, req *ChangePasswordRequest) (*LoginResponse, error)
+}
+
+// ChangePasswordRequest パスワード変更リクエスト
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// sessionTouchInterval セッションの最終利用日時を更新する間隔(リクエストごとの書き込みを避ける)
+const sessionTouchInterval = time.Minute
+
+// maxUserAgentLength セッションに保存するUser-Agentの最大長
+const maxUserAgentLength = 255
+
+// ClientInfo ログインしたクライアントの情報(セッション一覧で端末を識別するために保存する)
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// clientInfoKey ClientInfoのコンテキストキー
+type clientInfoKey struct{}
+
+// WithClientInfo クライアントの情報をコンテキストに設定
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// clientInfoFromContext コンテキストからクライアントの情報を取得
+func clientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+// SessionResponse セッションのレスポンス
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	// Current リクエストに使用したトークンのセッションかどうか
+	Current bool `json:"current"`
 }
 
 // LoginResponse ログインレスポンス
+// 二要素認証が必要な場合はトークンの代わりにMFATokenを返し、MFARequiredまたはMFAEnrollmentRequiredを設定する
 type LoginResponse struct {
-	AccessToken  string       `json:"accessToken"`
-	RefreshToken string       `json:"refreshToken"`
-	ExpiresIn    int64        `json:"expiresIn"`
-	User         *entity.User `json:"user"`
+	AccessToken           string       `json:"accessToken,omitempty"`
+	RefreshToken          string       `json:"refreshToken,omitempty"`
+	ExpiresIn             int64        `json:"expiresIn,omitempty"`
+	MFARequired           bool         `json:"mfaRequired,omitempty"`
+	MFAEnrollmentRequired bool         `json:"mfaEnrollmentRequired,omitempty"`
+	MFAToken              string       `json:"mfaToken,omitempty"`
+	User                  *entity.User `json:"user"`
+}
+
+// MFAEnrollmentResponse ログイン時の二要素認証の登録完了レスポンス
+type MFAEnrollmentResponse struct {
+	*LoginResponse
+	// RecoveryCodes リカバリーコード(この時だけ表示する)
+	RecoveryCodes []string `json:"recoveryCodes"`
 }
 
 // RefreshTokenResponse リフレッシュトークンレスポンス
+// リフレッシュのたびに新しいリフレッシュトークンを返し、使用したトークンは使えなくなる
 type RefreshTokenResponse struct {
-	AccessToken string `json:"accessToken"`
-	ExpiresIn   int64  `json:"expiresIn"`
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"`
 }
 
 // authUseCase AuthUseCaseの実装
 type authUseCase struct {
-	userRepo       repository.UserRepository
-	tokenRepo      repository.TokenRepository
-	jwtManager     auth.JWTManager
-	passwordHasher auth.PasswordHasher
-	accessExpiry   time.Duration
+	userRepo         repository.UserRepository
+	tokenRepo        repository.TokenRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	sessionRepo      repository.SessionRepository
+	jwtManager       auth.JWTManager
+	passwordHasher   auth.PasswordHasher
+	passwordPolicy   PasswordPolicy
+	mfaUseCase       MFAUseCase
+	loginProtection  LoginProtectionUseCase
+	audit            AuditUseCase
+	accessExpiry     time.Duration
 }
 
 // NewAuthUseCase 新しいAuthUseCaseを作成
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
 	tokenRepo repository.TokenRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
 	jwtManager auth.JWTManager,
 	passwordHasher auth.PasswordHasher,
+	passwordPolicy PasswordPolicy,
+	mfaUseCase MFAUseCase,
+	loginProtection LoginProtectionUseCase,
+	audit AuditUseCase,
 	accessExpiry time.Duration,
 ) AuthUseCase {
 	return &authUseCase{
-		userRepo:       userRepo,
-		tokenRepo:      tokenRepo,
-		jwtManager:     jwtManager,
-		passwordHasher: passwordHasher,
-		accessExpiry:   accessExpiry,
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRepo:      sessionRepo,
+		jwtManager:       jwtManager,
+		passwordHasher:   passwordHasher,
+		passwordPolicy:   passwordPolicy,
+		mfaUseCase:       mfaUseCase,
+		loginProtection:  loginProtection,
+		audit:            audit,
+		accessExpiry:     accessExpiry,
 	}
 }
 
 // Login ユーザーログイン
+// 二要素認証が有効なユーザー、またはポリシーで必須のロールのユーザーにはトークンを発行せず、2段階目用のMFATokenを返す
+// 失敗が続いたユーザー名・IPアドレスは一時的にロックし、ロック中はパスワードを検証せずにLoginLockedErrorを返す
 func (u *authUseCase) Login(ctx context.Context, username, password string) (*LoginResponse, error) {
 	if username == "" || password == "" {
 		return nil, fmt.Errorf("username and password are required")
 	}
 
+	if err := u.loginProtection.Check(ctx, username); err != nil {
+		var lockedErr *LoginLockedError
+		if errors.As(err, &lockedErr) {
+			u.loginProtection.RecordFailure(ctx, username, nil, entity.LoginFailureLocked)
+		}
+		return nil, err
+	}
+
 	// ユーザー検索
 	user, err := u.userRepo.FindByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			u.loginProtection.RecordFailure(ctx, username, nil, entity.LoginFailureInvalidCredentials)
 			return nil, fmt.Errorf("invalid credentials")
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
@@ -77,25 +181,116 @@ func (u *authUseCase) Login(ctx context.Context, username, password string) (*Lo
 
 	// ステータスチェック
 	if !user.IsActive() {
+		u.loginProtection.RecordFailure(ctx, username, user, entity.LoginFailureInactive)
 		return nil, fmt.Errorf("user account is inactive")
 	}
 
 	// パスワード検証
 	if err := u.passwordHasher.Verify(user.PasswordHash, password); err != nil {
+		u.loginProtection.RecordFailure(ctx, username, user, entity.LoginFailureInvalidCredentials)
 		return nil, fmt.Errorf("invalid credentials")
 	}
+	u.rehashPassword(ctx, user, password)
+
+	// 二要素認証チェック
+	enabled, err := u.mfaUseCase.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if enabled {
+		return u.mfaChallenge(ctx, username, user, auth.PurposeMFA)
+	}
+
+	required, err := u.mfaUseCase.IsRequired(ctx, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if required {
+		return u.mfaChallenge(ctx, username, user, auth.PurposeMFAEnrollment)
+	}
+
+	return u.issueTokens(ctx, user)
+}
+
+// rehashPassword 古いアルゴリズム・パラメータのハッシュを現在の設定でハッシュ化し直す
+// (平文のパスワードが手元にあるログイン成功時のみ可能。失敗してもログインは続ける)
+func (u *authUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	if !u.passwordHasher.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := u.passwordHasher.Hash(password)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to rehash password", "user_id", user.ID, "error", err)
+		return
+	}
+
+	if _, err := u.userRepo.UpdatePasswordHash(ctx, user.ID, user.PasswordHash, passwordHash); err != nil {
+		slog.ErrorContext(ctx, "Failed to rehash password", "user_id", user.ID, "error", err)
+		return
+	}
+	user.PasswordHash = passwordHash
+}
+
+// mfaChallenge 2段階目用のMFATokenを返す
+// ログインはまだ完了していないため、失敗回数はリセットせずに二要素認証の途中として記録する
+func (u *authUseCase) mfaChallenge(ctx context.Context, username string, user *entity.User, purpose auth.TokenPurpose) (*LoginResponse, error) {
+	mfaToken, err := u.jwtManager.GenerateMFAToken(user, purpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mfa token: %w", err)
+	}
+	u.loginProtection.RecordFailure(ctx, username, user, entity.LoginFailureMFAPending)
+
+	user.PasswordHash = ""
+
+	return &LoginResponse{
+		MFARequired:           purpose == auth.PurposeMFA,
+		MFAEnrollmentRequired: purpose == auth.PurposeMFAEnrollment,
+		MFAToken:              mfaToken,
+		User:                  user,
+	}, nil
+}
+
+// issueTokens アクセストークンとリフレッシュトークンを発行
+// ログインごとに新しいセッションを作成し、リフレッシュトークンはセッションと同じIDのファミリーとして登録する
+func (u *authUseCase) issueTokens(ctx context.Context, user *entity.User) (*LoginResponse, error) {
+	info := clientInfoFromContext(ctx)
+	session := &entity.Session{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		UserAgent: truncateRunes(info.UserAgent, maxUserAgentLength),
+		IPAddress: info.IPAddress,
+	}
 
 	// トークン生成
-	accessToken, err := u.jwtManager.GenerateAccessToken(user)
+	accessToken, err := u.jwtManager.GenerateAccessToken(user, session.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := u.jwtManager.GenerateRefreshToken(user)
+	refreshToken, record, err := u.newRefreshToken(user, session.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
+	session.ExpiresAt = record.ExpiresAt
+	if err := u.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	if err := u.refreshTokenRepo.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	// 二要素認証を含めてログインが完了した時点で記録し、ユーザー名の失敗回数をリセットする
+	u.loginProtection.RecordSuccess(ctx, user.Username, user)
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthLogin,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+		After:      map[string]any{"sessionId": session.ID},
+		Actor:      user,
+	})
+
 	// パスワードハッシュをレスポンスから除外
 	user.PasswordHash = ""
 
@@ -107,7 +302,28 @@ func (u *authUseCase) Login(ctx context.Context, username, password string) (*Lo
 	}, nil
 }
 
+// newRefreshToken 指定したセッション(ファミリー)のリフレッシュトークンを生成
+func (u *authUseCase) newRefreshToken(user *entity.User, sessionID string) (string, *entity.RefreshToken, error) {
+	token, err := u.jwtManager.GenerateRefreshToken(user, sessionID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	claims, err := u.jwtManager.ValidateToken(token)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return token, &entity.RefreshToken{
+		JTI:       claims.ID,
+		FamilyID:  sessionID,
+		UserID:    user.ID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
 // Logout ユーザーログアウト
+// トークンをブラックリストに追加し、トークンのセッションを失効させる
 func (u *authUseCase) Logout(ctx context.Context, token string) error {
 	if token == "" {
 		return fmt.Errorf("token is required")
@@ -124,10 +340,25 @@ func (u *authUseCase) Logout(ctx context.Context, token string) error {
 		return fmt.Errorf("failed to blacklist token: %w", err)
 	}
 
+	if claims.SessionID != "" {
+		if _, err := u.sessionRepo.Revoke(ctx, claims.UserID, claims.SessionID); err != nil {
+			return err
+		}
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthLogout,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   claims.UserID,
+		Before:     map[string]any{"sessionId": claims.SessionID},
+		Actor:      &entity.User{ID: claims.UserID, Username: claims.Username},
+	})
+
 	return nil
 }
 
 // RefreshToken トークンをリフレッシュ
+// 使用したリフレッシュトークンは同じファミリーの新しいトークンと交換し、ブラックリストに追加する
 func (u *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*RefreshTokenResponse, error) {
 	if refreshToken == "" {
 		return nil, fmt.Errorf("refresh token is required")
@@ -138,6 +369,35 @@ func (u *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*R
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
+	// アクセストークン・二要素認証の途中で発行したトークンはリフレッシュに使用できない
+	if claims.Type != auth.TokenTypeRefresh {
+		return nil, fmt.Errorf("invalid refresh token: unexpected token type")
+	}
+
+	// 発行済みのリフレッシュトークンかどうかを確認
+	record, err := u.refreshTokenRepo.FindByJTI(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invalid refresh token: token is not registered")
+		}
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	// ログアウトなどで失効したセッションのトークンは使用できない
+	session, err := u.sessionRepo.FindByID(ctx, record.FamilyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("token has been revoked")
+		}
+		return nil, err
+	}
+	if session.IsRevoked() {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+
+	if !record.IsUsable() {
+		return nil, u.revokeReusedFamily(ctx, record)
+	}
 
 	// ブラックリストチェック
 	exists, err := u.tokenRepo.Exists(ctx, claims.ID)
@@ -159,52 +419,421 @@ func (u *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*R
 		return nil, fmt.Errorf("user account is inactive")
 	}
 
+	// パスワード変更前に発行されたトークンは無効
+	if user.IsTokenRevoked(issuedAt(claims)) {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+
+	// ポリシーで二要素認証が必須になったロールは、登録するまでリフレッシュできない
+	if err := u.ensureMFAPolicy(ctx, user); err != nil {
+		return nil, err
+	}
+
 	// 新しいアクセストークン生成
-	accessToken, err := u.jwtManager.GenerateAccessToken(user)
+	accessToken, err := u.jwtManager.GenerateAccessToken(user, session.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
+	// リフレッシュトークンのローテーション
+	newRefreshToken, next, err := u.newRefreshToken(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := u.refreshTokenRepo.Rotate(ctx, record.JTI, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		// 同じトークンが同時に使われ、先に交換された
+		return nil, u.revokeReusedFamily(ctx, record)
+	}
+
+	// 交換済みのトークンはrefresh_tokensで拒否できるため、ブラックリストへの追加に失敗しても処理を続ける
+	if err := u.tokenRepo.Add(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		slog.ErrorContext(ctx, "Failed to blacklist rotated refresh token", "error", err)
+	}
+	if err := u.sessionRepo.Touch(ctx, session.ID, time.Now(), next.ExpiresAt); err != nil {
+		slog.ErrorContext(ctx, "Failed to update session", "error", err)
+	}
+
 	return &RefreshTokenResponse{
-		AccessToken: accessToken,
-		ExpiresIn:   int64(u.accessExpiry.Seconds()),
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    int64(u.accessExpiry.Seconds()),
 	}, nil
 }
 
+// revokeReusedFamily 交換済み・失効済みのリフレッシュトークンが再利用された場合にファミリー全体を失効させる
+// トークンが盗まれた可能性があるため、正規の利用者が持つ最新のトークンも含めて使えなくする
+func (u *authUseCase) revokeReusedFamily(ctx context.Context, record *entity.RefreshToken) error {
+	slog.WarnContext(ctx, "Refresh token reuse detected; revoking token family",
+		"event", "refresh_token_reuse",
+		"user_id", record.UserID,
+		"family_id", record.FamilyID,
+		"jti", record.JTI,
+	)
+
+	if err := u.refreshTokenRepo.RevokeFamily(ctx, record.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	// 同じセッションで発行済みのアクセストークンも使えなくする
+	if _, err := u.sessionRepo.Revoke(ctx, record.UserID, record.FamilyID); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("refresh token reuse detected: token has been revoked")
+}
+
 // ValidateToken トークンを検証してユーザーを返す
 func (u *authUseCase) ValidateToken(ctx context.Context, token string) (*entity.User, error) {
+	user, _, err := u.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// パスワードハッシュを除外
+	user.PasswordHash = ""
+
+	return user, nil
+}
+
+// authenticate アクセストークンを検証してユーザーとクレームを返す
+func (u *authUseCase) authenticate(ctx context.Context, token string) (*entity.User, *auth.Claims, error) {
 	if token == "" {
-		return nil, fmt.Errorf("token is required")
+		return nil, nil, fmt.Errorf("token is required")
 	}
 
 	// トークン検証
 	claims, err := u.jwtManager.ValidateToken(token)
 	if err != nil {
-		return nil, fmt.Errorf("invalid token: %w", err)
+		return nil, nil, fmt.Errorf("invalid token: %w", err)
+	}
+	// リフレッシュトークン・二要素認証の途中で発行したトークンはアクセストークンとして使用できない
+	if claims.Type != auth.TokenTypeAccess {
+		return nil, nil, fmt.Errorf("invalid token: unexpected token type")
 	}
 
 	// ブラックリストチェック
 	exists, err := u.tokenRepo.Exists(ctx, claims.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check token blacklist: %w", err)
+		return nil, nil, fmt.Errorf("failed to check token blacklist: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("token has been revoked")
+		return nil, nil, fmt.Errorf("token has been revoked")
 	}
 
 	// ユーザー検索
 	user, err := u.userRepo.FindByID(ctx, claims.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find user: %w", err)
+		return nil, nil, fmt.Errorf("failed to find user: %w", err)
 	}
 
 	// ステータスチェック
 	if !user.IsActive() {
-		return nil, fmt.Errorf("user account is inactive")
+		return nil, nil, fmt.Errorf("user account is inactive")
 	}
 
-	// パスワードハッシュを除外
-	user.PasswordHash = ""
+	// パスワード変更前に発行されたトークンは無効
+	if user.IsTokenRevoked(issuedAt(claims)) {
+		return nil, nil, fmt.Errorf("token has been revoked")
+	}
 
-	return user, nil
+	// セッションチェック(セッション導入前に発行したトークンはSessionIDを持たない)
+	if claims.SessionID != "" {
+		if err := u.checkSession(ctx, claims.SessionID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return user, claims, nil
+}
+
+// checkSession セッションが失効していないことを確認し、最終利用日時を更新する
+func (u *authUseCase) checkSession(ctx context.Context, sessionID string) error {
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("token has been revoked")
+		}
+		return err
+	}
+	if session.IsRevoked() {
+		return fmt.Errorf("token has been revoked")
+	}
+
+	now := time.Now()
+	if now.Sub(session.LastUsedAt) >= sessionTouchInterval {
+		if err := u.sessionRepo.Touch(ctx, session.ID, now, time.Time{}); err != nil {
+			slog.ErrorContext(ctx, "Failed to update session", "error", err)
+		}
+	}
+
+	return nil
+}
+
+// ListSessions トークンのユーザーの有効なセッション一覧を取得
+func (u *authUseCase) ListSessions(ctx context.Context, token string) ([]*SessionResponse, error) {
+	user, claims, err := u.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := u.sessionRepo.ListActiveByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*SessionResponse, len(sessions))
+	for i, session := range sessions {
+		items[i] = &SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			Current:    session.ID == claims.SessionID,
+		}
+	}
+
+	return items, nil
+}
+
+// RevokeSession トークンのユーザーのセッションを失効させる(現在のセッションを指定した場合はログアウトと同じ)
+func (u *authUseCase) RevokeSession(ctx context.Context, token, sessionID string) error {
+	user, _, err := u.authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+	if sessionID == "" {
+		return fmt.Errorf("session id is required")
+	}
+
+	revoked, err := u.sessionRepo.Revoke(ctx, user.ID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions トークンのセッション以外のユーザーのセッションを全て失効させる
+func (u *authUseCase) RevokeOtherSessions(ctx context.Context, token string) (int, error) {
+	user, claims, err := u.authenticate(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+
+	return u.sessionRepo.RevokeByUserID(ctx, user.ID, claims.SessionID)
+}
+
+// ChangePassword 現在のパスワードを確認して自分のパスワードを変更し、新しいセッションのトークンを発行
+// パスワードの更新と同じトランザクションで変更前のセッション(このリクエストのセッションを含む)を全て失効させる
+// 現在のパスワードの誤りはログインの失敗と同じく数え、ロック中は確認しない
+func (u *authUseCase) ChangePassword(ctx context.Context, token string, req *ChangePasswordRequest) (*LoginResponse, error) {
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return nil, fmt.Errorf("current password and new password are required")
+	}
+
+	user, _, err := u.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.checkLoginLock(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := u.passwordHasher.Verify(user.PasswordHash, req.CurrentPassword); err != nil {
+		u.loginProtection.RecordFailure(ctx, user.Username, user, entity.LoginFailureInvalidCredentials)
+		return nil, fmt.Errorf("invalid current password")
+	}
+
+	if err := u.passwordPolicy.Validate(ctx, req.NewPassword, user.Username, user.Email); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := u.passwordHasher.Hash(req.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.ChangePassword(passwordHash, time.Now())
+
+	if err := u.userRepo.UpdatePassword(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// パスワードハッシュは記録しない
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthPasswordChange,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+		Actor:      user,
+	})
+
+	return u.issueTokens(ctx, user)
+}
+
+// RevokeUserSessions ユーザーの全てのセッションを失効させる(管理者による強制ログアウト)
+func (u *authUseCase) RevokeUserSessions(ctx context.Context, userID int64) (int, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("user not found")
+		}
+		return 0, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	revoked, err := u.sessionRepo.RevokeByUserID(ctx, userID, "")
+	if err != nil {
+		return 0, err
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditUserRevokeSession,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   userID,
+		After:      map[string]any{"revokedSessions": revoked},
+	})
+
+	return revoked, nil
+}
+
+// VerifyMFA ログインの2段階目としてワンタイムコード(またはリカバリーコード)を検証してトークンを発行
+// コードの誤りはパスワードの誤りと同じくユーザー名の失敗回数に数え、ロック中はコードを検証しない
+// 1つのMFATokenで誤ったコードが続いた場合はトークンをブラックリストに追加し、ログインからやり直させる
+func (u *authUseCase) VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResponse, error) {
+	user, claims, err := u.validateMFAToken(ctx, mfaToken, auth.PurposeMFA)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.checkLoginLock(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := u.mfaUseCase.Verify(ctx, user, code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			u.loginProtection.RecordFailure(ctx, user.Username, user, entity.LoginFailureInvalidMFACode)
+			if u.loginProtection.RecordMFATokenFailure(ctx, claims.ID) {
+				if err := u.tokenRepo.Add(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+					slog.ErrorContext(ctx, "Failed to blacklist mfa token", "error", err)
+				}
+			}
+		}
+		return nil, err
+	}
+
+	return u.issueTokens(ctx, user)
+}
+
+// checkLoginLock ユーザー名またはIPアドレスがロックされていればLoginLockedErrorを返す(ロック中の試行も記録する)
+func (u *authUseCase) checkLoginLock(ctx context.Context, user *entity.User) error {
+	err := u.loginProtection.Check(ctx, user.Username)
+	var lockedErr *LoginLockedError
+	if errors.As(err, &lockedErr) {
+		u.loginProtection.RecordFailure(ctx, user.Username, user, entity.LoginFailureLocked)
+	}
+	return err
+}
+
+// BeginMFAEnrollment ログイン時に二要素認証の登録を求められたユーザーのTOTPシークレットを発行
+func (u *authUseCase) BeginMFAEnrollment(ctx context.Context, mfaToken string) (*MFASetupResponse, error) {
+	user, _, err := u.validateMFAToken(ctx, mfaToken, auth.PurposeMFAEnrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.mfaUseCase.Setup(ctx, user)
+}
+
+// CompleteMFAEnrollment 確認コードを検証して二要素認証を有効にし、トークンを発行
+func (u *authUseCase) CompleteMFAEnrollment(ctx context.Context, mfaToken, code string) (*MFAEnrollmentResponse, error) {
+	user, _, err := u.validateMFAToken(ctx, mfaToken, auth.PurposeMFAEnrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	// ロック中のユーザーにはトークンを発行しない
+	if err := u.checkLoginLock(ctx, user); err != nil {
+		return nil, err
+	}
+
+	codes, err := u.mfaUseCase.Confirm(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	login, err := u.issueTokens(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollmentResponse{LoginResponse: login, RecoveryCodes: codes}, nil
+}
+
+// validateMFAToken 2段階目用のMFATokenを検証してユーザーとクレームを返す
+func (u *authUseCase) validateMFAToken(ctx context.Context, mfaToken string, purpose auth.TokenPurpose) (*entity.User, *auth.Claims, error) {
+	if mfaToken == "" {
+		return nil, nil, fmt.Errorf("mfa token is required")
+	}
+
+	claims, err := u.jwtManager.ValidateToken(mfaToken)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid mfa token: %w", err)
+	}
+	if claims.Purpose != purpose {
+		return nil, nil, fmt.Errorf("invalid mfa token: unexpected token purpose")
+	}
+
+	// 誤ったコードが続いたトークンはブラックリストに追加されている
+	exists, err := u.tokenRepo.Exists(ctx, claims.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check token blacklist: %w", err)
+	}
+	if exists {
+		return nil, nil, fmt.Errorf("invalid mfa token: token has been revoked")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if !user.IsActive() {
+		return nil, nil, fmt.Errorf("user account is inactive")
+	}
+	if user.IsTokenRevoked(issuedAt(claims)) {
+		return nil, nil, fmt.Errorf("invalid mfa token: token has been revoked")
+	}
+
+	return user, claims, nil
+}
+
+// ensureMFAPolicy ポリシーで二要素認証が必須のロールで未登録の場合はエラー
+func (u *authUseCase) ensureMFAPolicy(ctx context.Context, user *entity.User) error {
+	required, err := u.mfaUseCase.IsRequired(ctx, user.Role)
+	if err != nil {
+		return fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if !required {
+		return nil
+	}
+
+	enabled, err := u.mfaUseCase.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if !enabled {
+		return fmt.Errorf("two-factor authentication is required: log in again to enroll")
+	}
+	return nil
+}
+
+// issuedAt トークンの発行日時を取得(発行日時のないトークンはゼロ値として扱う)
+func issuedAt(claims *auth.Claims) time.Time {
+	if claims.IssuedAt == nil {
+		return time.Time{}
+	}
+	return claims.IssuedAt.Time
 }
@@ -2,10 +2,12 @@ package usecase_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
 	"my-blog-engine/internal/infrastructure/auth"
 	"my-blog-engine/internal/infrastructure/persistence"
 	"my-blog-engine/internal/usecase"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
 )
 
 func setupAuthUseCase(t *testing.T) (usecase.AuthUseCase, func()) {
@@ -34,14 +37,39 @@ func setupAuthUseCase(t *testing.T) (usecase.AuthUseCase, func()) {
 	authUseCase := usecase.NewAuthUseCase(
 		userRepo,
 		tokenRepo,
+		persistence.NewRefreshTokenRepository(db),
+		persistence.NewSessionRepository(db),
 		jwtManager,
 		passwordHasher,
+		usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil),
+		newTestMFAUseCase(t, db),
+		newTestLoginProtection(t, db),
+		newTestAudit(db),
 		15*time.Minute,
 	)
 
 	return authUseCase, cleanup
 }
 
+// newTestMFAUseCase テスト用のMFAUseCaseを作成
+func newTestMFAUseCase(t *testing.T, db *bun.DB) usecase.MFAUseCase {
+	t.Helper()
+
+	secretBox, err := auth.NewSecretBox("test-secret-key-min-32-chars-long")
+	require.NoError(t, err)
+
+	return usecase.NewMFAUseCase(persistence.NewMFARepository(db), persistence.NewMFAPolicyRepository(db), secretBox, newTestAudit(db), "Test Blog")
+}
+
+// newTestLoginProtection テスト用のLoginProtectionUseCaseを作成(ロックの通知メールは破棄する)
+func newTestLoginProtection(t *testing.T, db *bun.DB) usecase.LoginProtectionUseCase {
+	t.Helper()
+
+	return usecase.NewLoginProtectionUseCase(persistence.NewLoginThrottleRepository(db), persistence.NewLoginAttemptRepository(db), &fakeMailer{}, newTestAudit(db), usecase.LoginProtectionConfig{
+		SiteTitle: "Test Blog",
+	})
+}
+
 func TestAuthUseCase_Login(t *testing.T) {
 	ctx := context.Background()
 
@@ -77,8 +105,14 @@ func TestAuthUseCase_Login(t *testing.T) {
 	authUseCase := usecase.NewAuthUseCase(
 		userRepo,
 		tokenRepo,
+		persistence.NewRefreshTokenRepository(db),
+		persistence.NewSessionRepository(db),
 		jwtManager,
 		passwordHasher,
+		usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil),
+		newTestMFAUseCase(t, db),
+		newTestLoginProtection(t, db),
+		newTestAudit(db),
 		15*time.Minute,
 	)
 
@@ -91,6 +125,72 @@ func TestAuthUseCase_Login(t *testing.T) {
 	assert.Empty(t, response.User.PasswordHash) // パスワードハッシュ除外確認
 }
 
+func TestAuthUseCase_Login_RehashPassword(t *testing.T) {
+	ctx := context.Background()
+
+	db, dbCleanup := testhelper.SetupTestDB(t)
+	defer dbCleanup()
+
+	userRepo := persistence.NewUserRepository(db)
+
+	// 以前のbcryptのハッシュで登録されたユーザー
+	bcryptHash, err := auth.NewPasswordHasher().Hash("password123")
+	require.NoError(t, err)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: bcryptHash,
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	passwordHasher, err := auth.NewArgon2Hasher(auth.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1})
+	require.NoError(t, err)
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	authUseCase := usecase.NewAuthUseCase(
+		userRepo,
+		persistence.NewTokenRepository(db),
+		persistence.NewRefreshTokenRepository(db),
+		persistence.NewSessionRepository(db),
+		jwtManager,
+		passwordHasher,
+		usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil),
+		newTestMFAUseCase(t, db),
+		newTestLoginProtection(t, db),
+		newTestAudit(db),
+		15*time.Minute,
+	)
+
+	// 失敗したログインではハッシュ化し直さない
+	_, err = authUseCase.Login(ctx, "testuser", "wrong-password")
+	require.Error(t, err)
+	found, err := userRepo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, bcryptHash, found.PasswordHash)
+
+	// 成功したログインで現在の設定のArgon2idのハッシュに置き換える
+	_, err = authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+	found, err = userRepo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(found.PasswordHash, "$argon2id$"))
+	assert.False(t, passwordHasher.NeedsRehash(found.PasswordHash))
+	assert.Nil(t, found.PasswordChangedAt)
+
+	// 置き換えた後も同じパスワードでログインできる
+	response, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.AccessToken)
+}
+
 func TestAuthUseCase_Login_InvalidCredentials(t *testing.T) {
 	authUseCase, cleanup := setupAuthUseCase(t)
 	defer cleanup()
@@ -137,8 +237,14 @@ func TestAuthUseCase_Logout(t *testing.T) {
 	authUseCase := usecase.NewAuthUseCase(
 		userRepo,
 		tokenRepo,
+		persistence.NewRefreshTokenRepository(db),
+		persistence.NewSessionRepository(db),
 		jwtManager,
 		passwordHasher,
+		usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil),
+		newTestMFAUseCase(t, db),
+		newTestLoginProtection(t, db),
+		newTestAudit(db),
 		15*time.Minute,
 	)
 
@@ -187,8 +293,14 @@ func TestAuthUseCase_ValidateToken(t *testing.T) {
 	authUseCase := usecase.NewAuthUseCase(
 		userRepo,
 		tokenRepo,
+		persistence.NewRefreshTokenRepository(db),
+		persistence.NewSessionRepository(db),
 		jwtManager,
 		passwordHasher,
+		usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil),
+		newTestMFAUseCase(t, db),
+		newTestLoginProtection(t, db),
+		newTestAudit(db),
 		15*time.Minute,
 	)
 
@@ -202,3 +314,256 @@ func TestAuthUseCase_ValidateToken(t *testing.T) {
 	assert.Equal(t, user.Role, validatedUser.Role)
 	assert.Empty(t, validatedUser.PasswordHash)
 }
+
+func setupRefreshTokenTest(t *testing.T) (usecase.AuthUseCase, repository.RefreshTokenRepository, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	refreshTokenRepo := persistence.NewRefreshTokenRepository(db)
+	passwordHasher := auth.NewPasswordHasher()
+
+	hash, err := passwordHasher.Hash("password123")
+	require.NoError(t, err)
+	require.NoError(t, userRepo.Create(context.Background(), &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: hash,
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}))
+
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	authUseCase := usecase.NewAuthUseCase(
+		userRepo,
+		persistence.NewTokenRepository(db),
+		refreshTokenRepo,
+		persistence.NewSessionRepository(db),
+		jwtManager,
+		passwordHasher,
+		usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil),
+		newTestMFAUseCase(t, db),
+		newTestLoginProtection(t, db),
+		newTestAudit(db),
+		15*time.Minute,
+	)
+
+	return authUseCase, refreshTokenRepo, cleanup
+}
+
+func TestAuthUseCase_RefreshToken_Rotation(t *testing.T) {
+	authUseCase, _, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	login, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+
+	first, err := authUseCase.RefreshToken(ctx, login.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.AccessToken)
+	assert.NotEmpty(t, first.RefreshToken)
+	assert.NotEqual(t, login.RefreshToken, first.RefreshToken)
+
+	// 交換後のトークンで続けてリフレッシュできる
+	second, err := authUseCase.RefreshToken(ctx, first.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	// アクセストークンはリフレッシュトークンとして使用できない
+	_, err = authUseCase.RefreshToken(ctx, login.AccessToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid refresh token")
+}
+
+func TestAuthUseCase_TokenTypes(t *testing.T) {
+	authUseCase, _, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	login, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+
+	// リフレッシュトークンはアクセストークンとして使用できない
+	_, err = authUseCase.ValidateToken(ctx, login.RefreshToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected token type")
+	_, err = authUseCase.ListSessions(ctx, login.RefreshToken)
+	require.Error(t, err)
+
+	// アクセストークンはリフレッシュトークンとして使用できない
+	_, err = authUseCase.RefreshToken(ctx, login.AccessToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected token type")
+
+	// 種類が一致すれば使用できる
+	_, err = authUseCase.ValidateToken(ctx, login.AccessToken)
+	require.NoError(t, err)
+	_, err = authUseCase.RefreshToken(ctx, login.RefreshToken)
+	require.NoError(t, err)
+}
+
+func TestAuthUseCase_ChangePassword(t *testing.T) {
+	authUseCase, _, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	login, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+	other, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+
+	// 現在のパスワードが誤っている場合は変更しない
+	_, err = authUseCase.ChangePassword(ctx, login.AccessToken, &usecase.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password"})
+	assert.ErrorContains(t, err, "invalid current password")
+
+	// パスワードポリシーを満たさない場合は変更しない
+	_, err = authUseCase.ChangePassword(ctx, login.AccessToken, &usecase.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "short"})
+	var policyErr *usecase.PasswordPolicyError
+	assert.ErrorAs(t, err, &policyErr)
+
+	response, err := authUseCase.ChangePassword(ctx, login.AccessToken, &usecase.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "new-password"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.AccessToken)
+	assert.Empty(t, response.User.PasswordHash)
+
+	// 変更前のセッションは全て失効し、新しいセッションだけが残る
+	for _, token := range []string{login.AccessToken, other.AccessToken} {
+		_, err = authUseCase.ValidateToken(ctx, token)
+		assert.ErrorContains(t, err, "revoked")
+	}
+	_, err = authUseCase.RefreshToken(ctx, other.RefreshToken)
+	assert.Error(t, err)
+	sessions, err := authUseCase.ListSessions(ctx, response.AccessToken)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.True(t, sessions[0].Current)
+
+	_, err = authUseCase.Login(ctx, "testuser", "password123")
+	assert.ErrorContains(t, err, "invalid credentials")
+	_, err = authUseCase.Login(ctx, "testuser", "new-password")
+	assert.NoError(t, err)
+}
+
+func TestAuthUseCase_RefreshToken_ReuseDetection(t *testing.T) {
+	authUseCase, refreshTokenRepo, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	login, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+	other, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+
+	rotated, err := authUseCase.RefreshToken(ctx, login.RefreshToken)
+	require.NoError(t, err)
+
+	// 交換済みのトークンを再利用するとファミリー全体が失効する
+	_, err = authUseCase.RefreshToken(ctx, login.RefreshToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reuse detected")
+
+	_, err = authUseCase.RefreshToken(ctx, rotated.RefreshToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoked")
+
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{SecretKey: "test-secret-key-min-32-chars-long"})
+	require.NoError(t, err)
+	parsed, err := jwtManager.ValidateToken(rotated.RefreshToken)
+	require.NoError(t, err)
+	record, err := refreshTokenRepo.FindByJTI(ctx, parsed.ID)
+	require.NoError(t, err)
+	assert.True(t, record.IsRevoked())
+
+	// 別のログイン(ファミリー)には影響しない
+	_, err = authUseCase.RefreshToken(ctx, other.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestAuthUseCase_Sessions(t *testing.T) {
+	authUseCase, _, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	laptop, err := authUseCase.Login(usecase.WithClientInfo(ctx, usecase.ClientInfo{UserAgent: "Laptop", IPAddress: "192.0.2.1"}), "testuser", "password123")
+	require.NoError(t, err)
+	phone, err := authUseCase.Login(usecase.WithClientInfo(ctx, usecase.ClientInfo{UserAgent: "Phone", IPAddress: "192.0.2.2"}), "testuser", "password123")
+	require.NoError(t, err)
+
+	sessions, err := authUseCase.ListSessions(ctx, laptop.AccessToken)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	var current *usecase.SessionResponse
+	for _, session := range sessions {
+		if session.Current {
+			current = session
+		}
+	}
+	require.NotNil(t, current)
+	assert.Equal(t, "Laptop", current.UserAgent)
+	assert.Equal(t, "192.0.2.1", current.IPAddress)
+
+	// 他のセッションを失効させると、そのセッションのトークンは使えなくなる
+	count, err := authUseCase.RevokeOtherSessions(ctx, laptop.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, err = authUseCase.ValidateToken(ctx, phone.AccessToken)
+	assert.Error(t, err)
+	_, err = authUseCase.RefreshToken(ctx, phone.RefreshToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoked")
+
+	_, err = authUseCase.ValidateToken(ctx, laptop.AccessToken)
+	require.NoError(t, err)
+
+	err = authUseCase.RevokeSession(ctx, laptop.AccessToken, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestAuthUseCase_Logout_RevokesSession(t *testing.T) {
+	authUseCase, _, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	login, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+
+	require.NoError(t, authUseCase.Logout(ctx, login.AccessToken))
+
+	// ログアウトしたセッションのリフレッシュトークンは使えない
+	_, err = authUseCase.RefreshToken(ctx, login.RefreshToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoked")
+}
+
+func TestAuthUseCase_RevokeUserSessions(t *testing.T) {
+	authUseCase, _, cleanup := setupRefreshTokenTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	login, err := authUseCase.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+	user, err := authUseCase.ValidateToken(ctx, login.AccessToken)
+	require.NoError(t, err)
+
+	count, err := authUseCase.RevokeUserSessions(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, err = authUseCase.ValidateToken(ctx, login.AccessToken)
+	assert.Error(t, err)
+
+	_, err = authUseCase.RevokeUserSessions(ctx, 99999)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
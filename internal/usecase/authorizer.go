@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"my-blog-engine/internal/domain/entity"
+)
+
+// ErrForbidden 操作の権限がない場合のエラー
+var ErrForbidden = errors.New("forbidden")
+
+// Action 権限を判定する操作
+type Action string
+
+const (
+	ActionPostCreate Action = "post.create"
+	ActionPostRead   Action = "post.read"
+	ActionPostUpdate Action = "post.update"
+	ActionPostDelete Action = "post.delete"
+	// ActionPostPublish 公開・予約・非公開への切り替え
+	ActionPostPublish Action = "post.publish"
+	// ActionPostShare 下書きを閲覧できるユーザーの追加・削除
+	ActionPostShare Action = "post.share"
+	// ActionPostSubmit レビューへの提出・レビュー担当者の変更
+	ActionPostSubmit Action = "post.submit"
+	// ActionPostReview レビューの承認・差し戻し
+	ActionPostReview Action = "post.review"
+	// ActionPostNote レビューノートの閲覧・作成・解決
+	ActionPostNote Action = "post.note"
+	// ActionPostPreview アカウントを持たない相手に渡すプレビューURLの発行・失効
+	ActionPostPreview Action = "post.preview"
+
+	ActionCategoryCreate Action = "category.create"
+	ActionCategoryUpdate Action = "category.update"
+	ActionCategoryDelete Action = "category.delete"
+
+	ActionTagCreate Action = "tag.create"
+	ActionTagUpdate Action = "tag.update"
+	ActionTagDelete Action = "tag.delete"
+
+	ActionMediaUpload Action = "media.upload"
+	ActionMediaUpdate Action = "media.update"
+	ActionMediaDelete Action = "media.delete"
+)
+
+// Resource 権限の判定対象となるリソースの属性
+type Resource struct {
+	// OwnerID 作成者のユーザーID(作成者がいない場合は0)
+	OwnerID int64
+	// Published 公開済みまたは公開予約済みかどうか
+	Published bool
+	// Shared 判定対象のユーザーに共有されているかどうか
+	Shared bool
+	// ReviewerID レビュー担当者のユーザーID(担当者がいない場合は0)
+	ReviewerID int64
+}
+
+// PostResource 記事を権限の判定対象に変換
+func PostResource(post *entity.Post, shared bool) *Resource {
+	resource := &Resource{
+		OwnerID:   post.AuthorID,
+		Published: post.IsPublished() || post.IsScheduled(),
+		Shared:    shared,
+	}
+	if post.ReviewerID != nil {
+		resource.ReviewerID = *post.ReviewerID
+	}
+	return resource
+}
+
+// MediaResource メディアを権限の判定対象に変換
+func MediaResource(media *entity.Media) *Resource {
+	resource := &Resource{}
+	if media.OwnerID != nil {
+		resource.OwnerID = *media.OwnerID
+	}
+	return resource
+}
+
+// Authorizer ロールとリソースの所有者から操作の可否を判定するポリシーのインターフェース
+type Authorizer interface {
+	// Can 操作が許可されているかどうかを判定(リソースを伴わない操作ではresourceはnil)
+	Can(user *entity.User, action Action, resource *Resource) bool
+	// Authorize 操作が許可されていない場合はErrForbiddenをラップしたエラーを返す
+	Authorize(user *entity.User, action Action, resource *Resource) error
+}
+
+// authorizer Authorizerの実装
+type authorizer struct{}
+
+// NewAuthorizer 新しいAuthorizerを作成
+//
+//   - admin: すべての操作
+//   - editor: 記事の作成、自分の記事の編集・削除・公開・共有・レビューへの提出、担当する記事のレビュー、すべての記事の閲覧・プレビューURLの発行、カテゴリ・タグの管理、自分のメディアの管理
+//   - contributor: 記事の作成、自分の下書きの編集・削除・共有・レビューへの提出、自分のメディアの管理(公開はできない)
+//   - viewer: 共有された記事の閲覧
+//
+// レビューノートは記事を編集できるユーザーと担当のレビュー担当者だけが扱える
+func NewAuthorizer() Authorizer {
+	return &authorizer{}
+}
+
+// Can 操作が許可されているかどうかを判定
+func (a *authorizer) Can(user *entity.User, action Action, resource *Resource) bool {
+	if user == nil || !user.IsActive() {
+		return false
+	}
+	if user.Role == entity.RoleAdmin {
+		return true
+	}
+
+	editor := user.Role == entity.RoleEditor
+	contributor := user.Role == entity.RoleContributor
+	owner := resource != nil && resource.OwnerID != 0 && resource.OwnerID == user.ID
+
+	switch action {
+	case ActionPostCreate, ActionMediaUpload:
+		return editor || contributor
+	case ActionPostRead:
+		return resource != nil && (editor || owner || resource.Shared)
+	case ActionPostUpdate, ActionPostDelete:
+		return owner && (editor || (contributor && !resource.Published))
+	case ActionPostPublish:
+		return owner && editor
+	case ActionPostShare, ActionPostSubmit, ActionMediaUpdate, ActionMediaDelete:
+		return owner && (editor || contributor)
+	case ActionPostReview:
+		return editor && resource != nil && resource.ReviewerID != 0 && resource.ReviewerID == user.ID
+	case ActionPostNote:
+		return a.Can(user, ActionPostUpdate, resource) || a.Can(user, ActionPostReview, resource)
+	case ActionPostPreview:
+		return editor && resource != nil
+	case ActionCategoryCreate, ActionCategoryUpdate, ActionCategoryDelete,
+		ActionTagCreate, ActionTagUpdate, ActionTagDelete:
+		return editor
+	}
+
+	return false
+}
+
+// Authorize 操作が許可されていない場合はErrForbiddenをラップしたエラーを返す
+func (a *authorizer) Authorize(user *entity.User, action Action, resource *Resource) error {
+	if !a.Can(user, action, resource) {
+		return fmt.Errorf("%w: %s", ErrForbidden, action)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizer_Can(t *testing.T) {
+	authorizer := usecase.NewAuthorizer()
+
+	admin := &entity.User{ID: 1, Role: entity.RoleAdmin, Status: entity.StatusActive}
+	editor := &entity.User{ID: 2, Role: entity.RoleEditor, Status: entity.StatusActive}
+	contributor := &entity.User{ID: 3, Role: entity.RoleContributor, Status: entity.StatusActive}
+	viewer := &entity.User{ID: 4, Role: entity.RoleViewer, Status: entity.StatusActive}
+	inactive := &entity.User{ID: 5, Role: entity.RoleAdmin, Status: entity.StatusInactive}
+
+	editorsDraft := &usecase.Resource{OwnerID: editor.ID}
+	contributorsDraft := &usecase.Resource{OwnerID: contributor.ID}
+	contributorsPublished := &usecase.Resource{OwnerID: contributor.ID, Published: true}
+	sharedDraft := &usecase.Resource{OwnerID: editor.ID, Shared: true}
+	orphanMedia := &usecase.Resource{}
+	reviewedByEditor := &usecase.Resource{OwnerID: contributor.ID, ReviewerID: editor.ID}
+	reviewedByOther := &usecase.Resource{OwnerID: contributor.ID, ReviewerID: admin.ID}
+
+	tests := []struct {
+		name     string
+		user     *entity.User
+		action   usecase.Action
+		resource *usecase.Resource
+		expected bool
+	}{
+		{"anonymous cannot read", nil, usecase.ActionPostRead, sharedDraft, false},
+		{"inactive admin cannot delete", inactive, usecase.ActionPostDelete, editorsDraft, false},
+		{"admin can delete others' posts", admin, usecase.ActionPostDelete, editorsDraft, true},
+		{"admin can delete categories", admin, usecase.ActionCategoryDelete, nil, true},
+		{"admin can delete orphan media", admin, usecase.ActionMediaDelete, orphanMedia, true},
+
+		{"editor can create posts", editor, usecase.ActionPostCreate, nil, true},
+		{"editor can update own post", editor, usecase.ActionPostUpdate, editorsDraft, true},
+		{"editor can publish own post", editor, usecase.ActionPostPublish, editorsDraft, true},
+		{"editor cannot update others' posts", editor, usecase.ActionPostUpdate, contributorsDraft, false},
+		{"editor cannot publish others' posts", editor, usecase.ActionPostPublish, contributorsDraft, false},
+		{"editor can read others' posts", editor, usecase.ActionPostRead, contributorsDraft, true},
+		{"editor can delete categories", editor, usecase.ActionCategoryDelete, nil, true},
+		{"editor can update tags", editor, usecase.ActionTagUpdate, nil, true},
+		{"editor cannot delete orphan media", editor, usecase.ActionMediaDelete, orphanMedia, false},
+		{"editor can review assigned post", editor, usecase.ActionPostReview, reviewedByEditor, true},
+		{"editor cannot review post assigned to others", editor, usecase.ActionPostReview, reviewedByOther, false},
+		{"reviewer can add notes to assigned post", editor, usecase.ActionPostNote, reviewedByEditor, true},
+		{"editor cannot add notes to others' posts", editor, usecase.ActionPostNote, reviewedByOther, false},
+		{"editor can create preview links for others' drafts", editor, usecase.ActionPostPreview, contributorsDraft, true},
+
+		{"contributor can create posts", contributor, usecase.ActionPostCreate, nil, true},
+		{"contributor can update own draft", contributor, usecase.ActionPostUpdate, contributorsDraft, true},
+		{"contributor can delete own draft", contributor, usecase.ActionPostDelete, contributorsDraft, true},
+		{"contributor cannot update own published post", contributor, usecase.ActionPostUpdate, contributorsPublished, false},
+		{"contributor cannot publish own draft", contributor, usecase.ActionPostPublish, contributorsDraft, false},
+		{"contributor can share own draft", contributor, usecase.ActionPostShare, contributorsDraft, true},
+		{"contributor can submit own draft", contributor, usecase.ActionPostSubmit, contributorsDraft, true},
+		{"contributor cannot submit others' drafts", contributor, usecase.ActionPostSubmit, editorsDraft, false},
+		{"contributor can add notes to own draft", contributor, usecase.ActionPostNote, contributorsDraft, true},
+		{"contributor cannot add notes to own published post", contributor, usecase.ActionPostNote, contributorsPublished, false},
+		{"contributor cannot create preview links", contributor, usecase.ActionPostPreview, contributorsDraft, false},
+		{"contributor cannot review", contributor, usecase.ActionPostReview, &usecase.Resource{OwnerID: editor.ID, ReviewerID: contributor.ID}, false},
+		{"contributor cannot read others' drafts", contributor, usecase.ActionPostRead, editorsDraft, false},
+		{"contributor cannot create categories", contributor, usecase.ActionCategoryCreate, nil, false},
+		{"contributor can upload media", contributor, usecase.ActionMediaUpload, nil, true},
+
+		{"viewer can read shared draft", viewer, usecase.ActionPostRead, sharedDraft, true},
+		{"viewer cannot update shared draft", viewer, usecase.ActionPostUpdate, sharedDraft, false},
+		{"viewer cannot read notes on shared draft", viewer, usecase.ActionPostNote, sharedDraft, false},
+		{"viewer cannot read unshared draft", viewer, usecase.ActionPostRead, editorsDraft, false},
+		{"viewer cannot create posts", viewer, usecase.ActionPostCreate, nil, false},
+		{"unknown action is denied", editor, usecase.Action("post.archive"), editorsDraft, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, authorizer.Can(tt.user, tt.action, tt.resource))
+		})
+	}
+}
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	authorizer := usecase.NewAuthorizer()
+	viewer := &entity.User{ID: 1, Role: entity.RoleViewer, Status: entity.StatusActive}
+
+	err := authorizer.Authorize(viewer, usecase.ActionPostCreate, nil)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	assert.Contains(t, err.Error(), "post.create")
+
+	assert.NoError(t, authorizer.Authorize(viewer, usecase.ActionPostRead, &usecase.Resource{OwnerID: 2, Shared: true}))
+}
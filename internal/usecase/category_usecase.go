@@ -35,12 +35,14 @@ type UpdateCategoryRequest struct {
 // categoryUseCase CategoryUseCaseの実装
 type categoryUseCase struct {
 	categoryRepo repository.CategoryRepository
+	audit        AuditUseCase
 }
 
 // NewCategoryUseCase 新しいCategoryUseCaseを作成
-func NewCategoryUseCase(categoryRepo repository.CategoryRepository) CategoryUseCase {
+func NewCategoryUseCase(categoryRepo repository.CategoryRepository, audit AuditUseCase) CategoryUseCase {
 	return &categoryUseCase{
 		categoryRepo: categoryRepo,
+		audit:        audit,
 	}
 }
 
@@ -60,6 +62,13 @@ func (u *categoryUseCase) Create(ctx context.Context, req *CreateCategoryRequest
 		return nil, fmt.Errorf("failed to create category: %w", err)
 	}
 
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditCategoryCreate,
+		TargetType: entity.AuditTargetCategory,
+		TargetID:   category.ID,
+		After:      categoryAuditSnapshot(category),
+	})
+
 	return category, nil
 }
 
@@ -69,6 +78,7 @@ func (u *categoryUseCase) Update(ctx context.Context, id int64, req *UpdateCateg
 	if err != nil {
 		return nil, fmt.Errorf("failed to find category: %w", err)
 	}
+	before := categoryAuditSnapshot(category)
 
 	if req.Name != nil {
 		category.Name = *req.Name
@@ -84,14 +94,35 @@ func (u *categoryUseCase) Update(ctx context.Context, id int64, req *UpdateCateg
 		return nil, fmt.Errorf("failed to update category: %w", err)
 	}
 
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditCategoryUpdate,
+		TargetType: entity.AuditTargetCategory,
+		TargetID:   category.ID,
+		Before:     before,
+		After:      categoryAuditSnapshot(category),
+	})
+
 	return category, nil
 }
 
 // Delete カテゴリを削除
 func (u *categoryUseCase) Delete(ctx context.Context, id int64) error {
+	category, err := u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find category: %w", err)
+	}
+
 	if err := u.categoryRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditCategoryDelete,
+		TargetType: entity.AuditTargetCategory,
+		TargetID:   id,
+		Before:     categoryAuditSnapshot(category),
+	})
+
 	return nil
 }
 
@@ -17,7 +17,7 @@ func TestCategoryUseCase_Create(t *testing.T) {
 	defer cleanup()
 
 	categoryRepo := persistence.NewCategoryRepository(db)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -39,7 +39,7 @@ func TestCategoryUseCase_Update(t *testing.T) {
 	defer cleanup()
 
 	categoryRepo := persistence.NewCategoryRepository(db)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -69,7 +69,7 @@ func TestCategoryUseCase_Delete(t *testing.T) {
 	defer cleanup()
 
 	categoryRepo := persistence.NewCategoryRepository(db)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -95,7 +95,7 @@ func TestCategoryUseCase_GetBySlug(t *testing.T) {
 	defer cleanup()
 
 	categoryRepo := persistence.NewCategoryRepository(db)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -119,7 +119,7 @@ func TestCategoryUseCase_List(t *testing.T) {
 	defer cleanup()
 
 	categoryRepo := persistence.NewCategoryRepository(db)
-	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
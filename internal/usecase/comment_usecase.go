@@ -0,0 +1,331 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/renderer"
+)
+
+const (
+	// maxCommentAuthorNameLength コメント投稿者名の最大文字数
+	maxCommentAuthorNameLength = 100
+	// maxCommentFieldLength メールアドレス・URLの最大文字数
+	maxCommentFieldLength = 255
+	// maxCommentContentLength コメント本文の最大文字数
+	maxCommentContentLength = 5000
+	// maxModerateComments 一括モデレーションで一度に変更できる最大件数
+	maxModerateComments = 100
+)
+
+// CommentUseCase コメントユースケースのインターフェース
+type CommentUseCase interface {
+	// Submit 読者のコメントを承認待ちとして受け付ける
+	Submit(ctx context.Context, req *SubmitCommentRequest) (*entity.Comment, error)
+	// ListThreads 記事の公開中のコメントをスレッドとして取得し、公開中のコメント数とあわせて返す
+	ListThreads(ctx context.Context, postID int64) ([]*CommentThread, int, error)
+	// List モデレーション用にコメント一覧を新しい順に取得
+	List(ctx context.Context, filters repository.CommentFilters, limit, offset int) ([]*entity.Comment, int, error)
+	// Moderate 複数のコメントのステータスを一括で変更し、変更した件数を返す
+	Moderate(ctx context.Context, req *ModerateCommentsRequest) (int, error)
+	// Delete コメントを完全に削除(返信も削除される)
+	Delete(ctx context.Context, id int64) error
+}
+
+// SubmitCommentRequest コメント投稿リクエスト
+type SubmitCommentRequest struct {
+	PostID      int64
+	ParentID    *int64
+	AuthorName  string
+	AuthorEmail string
+	AuthorURL   string
+	Content     string
+	// Honeypot 画面には表示しない入力欄の値(ボットが入力した場合は保存しない)
+	Honeypot  string
+	IPAddress string
+	UserAgent string
+}
+
+// ModerateCommentsRequest コメントの一括モデレーションリクエスト
+type ModerateCommentsRequest struct {
+	IDs    []int64              `json:"ids"`
+	Status entity.CommentStatus `json:"status"`
+}
+
+// CommentThread 返信をまとめたコメントのスレッド
+type CommentThread struct {
+	*entity.Comment
+	Replies []*CommentThread
+}
+
+// commentUseCase CommentUseCaseの実装
+type commentUseCase struct {
+	commentRepo     repository.CommentRepository
+	postRepo        repository.PostRepository
+	commentRenderer renderer.MarkdownRenderer
+	audit           AuditUseCase
+}
+
+// NewCommentUseCase 新しいCommentUseCaseを作成
+func NewCommentUseCase(
+	commentRepo repository.CommentRepository,
+	postRepo repository.PostRepository,
+	commentRenderer renderer.MarkdownRenderer,
+	audit AuditUseCase,
+) CommentUseCase {
+	return &commentUseCase{
+		commentRepo:     commentRepo,
+		postRepo:        postRepo,
+		commentRenderer: commentRenderer,
+		audit:           audit,
+	}
+}
+
+// Submit 読者のコメントを承認待ちとして受け付ける
+// ハニーポットが入力されている場合は保存せず、通常の投稿と同じ結果を返す(ボットに検知させないため)
+func (u *commentUseCase) Submit(ctx context.Context, req *SubmitCommentRequest) (*entity.Comment, error) {
+	comment := &entity.Comment{
+		PostID:      req.PostID,
+		ParentID:    req.ParentID,
+		AuthorName:  strings.TrimSpace(req.AuthorName),
+		AuthorEmail: strings.TrimSpace(req.AuthorEmail),
+		AuthorURL:   strings.TrimSpace(req.AuthorURL),
+		Content:     strings.TrimSpace(req.Content),
+		Status:      entity.CommentPending,
+		IPAddress:   truncateRunes(req.IPAddress, 45),
+		UserAgent:   truncateRunes(req.UserAgent, maxCommentFieldLength),
+	}
+
+	if err := validateComment(comment); err != nil {
+		return nil, err
+	}
+
+	if req.Honeypot != "" {
+		return comment, nil
+	}
+
+	post, err := u.postRepo.FindByID(ctx, req.PostID)
+	if err != nil {
+		return nil, err
+	}
+	// 公開中の記事以外にはコメントできない
+	if !post.IsPublished() {
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if req.ParentID != nil {
+		parent, err := u.commentRepo.FindByID(ctx, *req.ParentID)
+		if err != nil || parent.PostID != post.ID || !parent.IsApproved() {
+			return nil, fmt.Errorf("parent comment not found")
+		}
+	}
+
+	renderedHTML, err := u.commentRenderer.Render(comment.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render comment: %w", err)
+	}
+	comment.RenderedHTML = renderedHTML
+
+	if err := u.commentRepo.Create(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// validateComment コメントの入力内容を検証
+func validateComment(comment *entity.Comment) error {
+	if comment.AuthorName == "" {
+		return fmt.Errorf("name is required")
+	}
+	if utf8.RuneCountInString(comment.AuthorName) > maxCommentAuthorNameLength {
+		return fmt.Errorf("name is too long")
+	}
+	if comment.Content == "" {
+		return fmt.Errorf("comment is required")
+	}
+	if utf8.RuneCountInString(comment.Content) > maxCommentContentLength {
+		return fmt.Errorf("comment is too long")
+	}
+
+	if comment.AuthorEmail != "" {
+		addr, err := mail.ParseAddress(comment.AuthorEmail)
+		if err != nil || addr.Address != comment.AuthorEmail || len(comment.AuthorEmail) > maxCommentFieldLength {
+			return fmt.Errorf("invalid email address")
+		}
+	}
+
+	if comment.AuthorURL != "" {
+		u, err := url.Parse(comment.AuthorURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(comment.AuthorURL) > maxCommentFieldLength {
+			return fmt.Errorf("invalid website url")
+		}
+	}
+
+	return nil
+}
+
+// truncateRunes 文字列を最大文字数で切り詰める
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit])
+}
+
+// ListThreads 記事の公開中のコメントをスレッドとして取得し、公開中のコメント数とあわせて返す
+// 公開中の返信がある削除済みのコメントは、スレッドを保つため本文を除いて残す
+func (u *commentUseCase) ListThreads(ctx context.Context, postID int64) ([]*CommentThread, int, error) {
+	comments, err := u.commentRepo.ListByPost(ctx, postID, []entity.CommentStatus{entity.CommentApproved, entity.CommentDeleted})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	threads := make(map[int64]*CommentThread, len(comments))
+	for _, comment := range comments {
+		if comment.IsDeleted() {
+			comment = &entity.Comment{
+				ID:        comment.ID,
+				PostID:    comment.PostID,
+				ParentID:  comment.ParentID,
+				Status:    comment.Status,
+				CreatedAt: comment.CreatedAt,
+			}
+		}
+		threads[comment.ID] = &CommentThread{Comment: comment}
+	}
+
+	roots := make([]*CommentThread, 0)
+	for _, comment := range comments {
+		thread := threads[comment.ID]
+		if comment.ParentID == nil {
+			roots = append(roots, thread)
+			continue
+		}
+		// 返信先が公開されていない返信は表示しない
+		if parent, ok := threads[*comment.ParentID]; ok {
+			parent.Replies = append(parent.Replies, thread)
+		}
+	}
+
+	roots = pruneDeletedThreads(roots)
+	return roots, countApproved(roots), nil
+}
+
+// pruneDeletedThreads 公開中の返信がない削除済みのコメントを取り除く
+func pruneDeletedThreads(threads []*CommentThread) []*CommentThread {
+	kept := make([]*CommentThread, 0, len(threads))
+	for _, thread := range threads {
+		thread.Replies = pruneDeletedThreads(thread.Replies)
+		if !thread.IsDeleted() || len(thread.Replies) > 0 {
+			kept = append(kept, thread)
+		}
+	}
+	return kept
+}
+
+// countApproved スレッド中の公開中のコメント数を数える
+func countApproved(threads []*CommentThread) int {
+	count := 0
+	for _, thread := range threads {
+		if thread.IsApproved() {
+			count++
+		}
+		count += countApproved(thread.Replies)
+	}
+	return count
+}
+
+// List モデレーション用にコメント一覧を新しい順に取得
+func (u *commentUseCase) List(ctx context.Context, filters repository.CommentFilters, limit, offset int) ([]*entity.Comment, int, error) {
+	if filters.Status != "" && !filters.Status.IsValid() {
+		return nil, 0, fmt.Errorf("invalid comment status: %s", filters.Status)
+	}
+
+	comments, err := u.commentRepo.List(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	count, err := u.commentRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	return comments, count, nil
+}
+
+// Moderate 複数のコメントのステータスを一括で変更し、変更した件数を返す
+func (u *commentUseCase) Moderate(ctx context.Context, req *ModerateCommentsRequest) (int, error) {
+	if !req.Status.IsValid() {
+		return 0, fmt.Errorf("invalid comment status: %s", req.Status)
+	}
+	if len(req.IDs) == 0 {
+		return 0, fmt.Errorf("comment ids are required")
+	}
+	if len(req.IDs) > maxModerateComments {
+		return 0, fmt.Errorf("too many comments: maximum is %d", maxModerateComments)
+	}
+
+	// 監査ログに変更前のステータスを記録するため、変更前のコメントを取得する(存在しないIDは無視する)
+	comments := make([]*entity.Comment, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		comment, err := u.commentRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return 0, err
+		}
+		comments = append(comments, comment)
+	}
+
+	updated, err := u.commentRepo.UpdateStatus(ctx, req.IDs, req.Status)
+	if err != nil {
+		return 0, fmt.Errorf("failed to moderate comments: %w", err)
+	}
+
+	for _, comment := range comments {
+		if comment.Status == req.Status {
+			continue
+		}
+		u.audit.Record(ctx, &AuditEntry{
+			Action:     entity.AuditCommentModerate,
+			TargetType: entity.AuditTargetComment,
+			TargetID:   comment.ID,
+			Before:     map[string]any{"status": comment.Status},
+			After:      map[string]any{"status": req.Status},
+		})
+	}
+
+	return updated, nil
+}
+
+// Delete コメントを完全に削除(返信も削除される)
+func (u *commentUseCase) Delete(ctx context.Context, id int64) error {
+	comment, err := u.commentRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.commentRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditCommentDelete,
+		TargetType: entity.AuditTargetComment,
+		TargetID:   id,
+		Before:     commentAuditSnapshot(comment),
+	})
+
+	return nil
+}
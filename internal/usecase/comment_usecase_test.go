@@ -0,0 +1,260 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/infrastructure/renderer"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCommentUseCase(t *testing.T) (usecase.CommentUseCase, *entity.Post, *entity.Post, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	commentRepo := persistence.NewCommentRepository(db)
+
+	commentUseCase := usecase.NewCommentUseCase(commentRepo, postRepo, renderer.NewCommentRenderer(), newTestAudit(db))
+
+	// テストユーザー・記事作成
+	user := &entity.User{
+		Username:     "testauthor",
+		Email:        "author@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	published := &entity.Post{
+		Title:    "Published",
+		Slug:     "published",
+		Content:  "content",
+		Status:   entity.StatusPublished,
+		AuthorID: user.ID,
+	}
+	require.NoError(t, postRepo.Create(ctx, published))
+
+	draft := &entity.Post{
+		Title:    "Draft",
+		Slug:     "draft",
+		Content:  "content",
+		Status:   entity.StatusDraft,
+		AuthorID: user.ID,
+	}
+	require.NoError(t, postRepo.Create(ctx, draft))
+
+	return commentUseCase, published, draft, cleanup
+}
+
+// submitComment テスト用にコメントを投稿
+func submitComment(t *testing.T, commentUseCase usecase.CommentUseCase, postID int64, parentID *int64, content string) *entity.Comment {
+	t.Helper()
+
+	comment, err := commentUseCase.Submit(context.Background(), &usecase.SubmitCommentRequest{
+		PostID:     postID,
+		ParentID:   parentID,
+		AuthorName: "reader",
+		Content:    content,
+	})
+	require.NoError(t, err)
+	return comment
+}
+
+func TestCommentUseCase_Submit(t *testing.T) {
+	commentUseCase, post, _, cleanup := setupCommentUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	comment, err := commentUseCase.Submit(ctx, &usecase.SubmitCommentRequest{
+		PostID:      post.ID,
+		AuthorName:  " reader ",
+		AuthorEmail: "reader@example.com",
+		AuthorURL:   "https://reader.example.com",
+		Content:     "**nice** <script>alert(1)</script>",
+		IPAddress:   "192.0.2.1",
+		UserAgent:   "test-agent",
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, comment.ID)
+	assert.Equal(t, "reader", comment.AuthorName)
+	// 承認待ちとして受け付ける
+	assert.Equal(t, entity.CommentPending, comment.Status)
+	assert.Contains(t, comment.RenderedHTML, "<strong>nice</strong>")
+	assert.NotContains(t, comment.RenderedHTML, "<script>")
+
+	// 承認されるまで公開されない
+	threads, count, err := commentUseCase.ListThreads(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Empty(t, threads)
+	assert.Zero(t, count)
+
+	pending, total, err := commentUseCase.List(ctx, repository.CommentFilters{Status: entity.CommentPending}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "192.0.2.1", pending[0].IPAddress)
+}
+
+func TestCommentUseCase_Submit_Honeypot(t *testing.T) {
+	commentUseCase, post, _, cleanup := setupCommentUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	comment, err := commentUseCase.Submit(ctx, &usecase.SubmitCommentRequest{
+		PostID:     post.ID,
+		AuthorName: "bot",
+		Content:    "buy now",
+		Honeypot:   "https://spam.example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.CommentPending, comment.Status)
+
+	// 保存されない
+	_, total, err := commentUseCase.List(ctx, repository.CommentFilters{}, 10, 0)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestCommentUseCase_Submit_Invalid(t *testing.T) {
+	commentUseCase, post, draft, cleanup := setupCommentUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	pending := submitComment(t, commentUseCase, post.ID, nil, "pending")
+	missing := int64(99999)
+
+	tests := []struct {
+		name    string
+		req     usecase.SubmitCommentRequest
+		wantErr string
+	}{
+		{
+			name:    "name is required",
+			req:     usecase.SubmitCommentRequest{PostID: post.ID, AuthorName: " ", Content: "hi"},
+			wantErr: "name is required",
+		},
+		{
+			name:    "comment is required",
+			req:     usecase.SubmitCommentRequest{PostID: post.ID, AuthorName: "reader"},
+			wantErr: "comment is required",
+		},
+		{
+			name:    "comment is too long",
+			req:     usecase.SubmitCommentRequest{PostID: post.ID, AuthorName: "reader", Content: strings.Repeat("あ", 5001)},
+			wantErr: "comment is too long",
+		},
+		{
+			name:    "invalid email",
+			req:     usecase.SubmitCommentRequest{PostID: post.ID, AuthorName: "reader", AuthorEmail: "Reader <reader@example.com>", Content: "hi"},
+			wantErr: "invalid email address",
+		},
+		{
+			name:    "invalid url",
+			req:     usecase.SubmitCommentRequest{PostID: post.ID, AuthorName: "reader", AuthorURL: "javascript:alert(1)", Content: "hi"},
+			wantErr: "invalid website url",
+		},
+		{
+			name:    "draft post",
+			req:     usecase.SubmitCommentRequest{PostID: draft.ID, AuthorName: "reader", Content: "hi"},
+			wantErr: "not found",
+		},
+		{
+			name:    "missing parent",
+			req:     usecase.SubmitCommentRequest{PostID: post.ID, ParentID: &missing, AuthorName: "reader", Content: "hi"},
+			wantErr: "parent comment not found",
+		},
+		{
+			name:    "parent is not approved",
+			req:     usecase.SubmitCommentRequest{PostID: post.ID, ParentID: &pending.ID, AuthorName: "reader", Content: "hi"},
+			wantErr: "parent comment not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := commentUseCase.Submit(ctx, &tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCommentUseCase_ListThreads(t *testing.T) {
+	commentUseCase, post, _, cleanup := setupCommentUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	root := submitComment(t, commentUseCase, post.ID, nil, "root")
+	_, err := commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{IDs: []int64{root.ID}, Status: entity.CommentApproved})
+	require.NoError(t, err)
+
+	reply := submitComment(t, commentUseCase, post.ID, &root.ID, "reply")
+	other := submitComment(t, commentUseCase, post.ID, nil, "other")
+	spam := submitComment(t, commentUseCase, post.ID, nil, "spam")
+
+	// 一括モデレーション
+	updated, err := commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{IDs: []int64{reply.ID, other.ID}, Status: entity.CommentApproved})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	_, err = commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{IDs: []int64{spam.ID}, Status: entity.CommentSpam})
+	require.NoError(t, err)
+
+	threads, count, err := commentUseCase.ListThreads(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, threads, 2)
+	assert.Equal(t, "root", threads[0].Content)
+	require.Len(t, threads[0].Replies, 1)
+	assert.Equal(t, "reply", threads[0].Replies[0].Content)
+	assert.Equal(t, "other", threads[1].Content)
+
+	// 返信のある削除済みコメントは本文を除いて残り、返信のないものは表示しない
+	_, err = commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{IDs: []int64{root.ID, other.ID}, Status: entity.CommentDeleted})
+	require.NoError(t, err)
+
+	threads, count, err = commentUseCase.ListThreads(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, threads, 1)
+	assert.True(t, threads[0].IsDeleted())
+	assert.Empty(t, threads[0].Content)
+	assert.Empty(t, threads[0].AuthorName)
+	require.Len(t, threads[0].Replies, 1)
+	assert.Equal(t, "reply", threads[0].Replies[0].Content)
+}
+
+func TestCommentUseCase_Moderate_Invalid(t *testing.T) {
+	commentUseCase, _, _, cleanup := setupCommentUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, err := commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{IDs: []int64{1}, Status: "published"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid comment status")
+
+	_, err = commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{Status: entity.CommentApproved})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "comment ids are required")
+
+	_, err = commentUseCase.Moderate(ctx, &usecase.ModerateCommentsRequest{IDs: make([]int64, 101), Status: entity.CommentApproved})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too many comments")
+
+	_, _, err = commentUseCase.List(ctx, repository.CommentFilters{Status: "unknown"}, 10, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid comment status")
+}
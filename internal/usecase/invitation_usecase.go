@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/mailer"
+)
+
+// DefaultInvitationTTL 招待の既定の有効期間
+const DefaultInvitationTTL = 72 * time.Hour
+
+// InvitationUseCase 招待ユースケースのインターフェース
+type InvitationUseCase interface {
+	// Create 招待を作成してメールで送信
+	Create(ctx context.Context, inviterID int64, req *CreateInvitationRequest) (*entity.Invitation, error)
+	// Accept 招待を受諾してユーザーを作成
+	Accept(ctx context.Context, req *AcceptInvitationRequest) (*entity.User, error)
+	// List 招待一覧を新しい順に取得
+	List(ctx context.Context, limit, offset int) ([]*entity.Invitation, int, error)
+	// Revoke 未受諾の招待を取り消す
+	Revoke(ctx context.Context, id int64) error
+}
+
+// CreateInvitationRequest 招待作成リクエスト
+type CreateInvitationRequest struct {
+	Email string          `json:"email"`
+	Role  entity.UserRole `json:"role"`
+}
+
+// AcceptInvitationRequest 招待受諾リクエスト
+type AcceptInvitationRequest struct {
+	Token    string `json:"token"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// InvitationConfig 招待メールの設定
+type InvitationConfig struct {
+	SiteURL   string
+	SiteTitle string
+	// TTL 招待の有効期間(0以下の場合はDefaultInvitationTTL)
+	TTL time.Duration
+}
+
+// invitationUseCase InvitationUseCaseの実装
+type invitationUseCase struct {
+	invitationRepo repository.InvitationRepository
+	userRepo       repository.UserRepository
+	passwordHasher auth.PasswordHasher
+	passwordPolicy PasswordPolicy
+	mailer         mailer.Mailer
+	audit          AuditUseCase
+	config         InvitationConfig
+}
+
+// NewInvitationUseCase 新しいInvitationUseCaseを作成
+func NewInvitationUseCase(
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	passwordHasher auth.PasswordHasher,
+	passwordPolicy PasswordPolicy,
+	mailer mailer.Mailer,
+	audit AuditUseCase,
+	config InvitationConfig,
+) InvitationUseCase {
+	if config.TTL <= 0 {
+		config.TTL = DefaultInvitationTTL
+	}
+	config.SiteURL = strings.TrimRight(config.SiteURL, "/")
+
+	return &invitationUseCase{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		passwordHasher: passwordHasher,
+		passwordPolicy: passwordPolicy,
+		mailer:         mailer,
+		audit:          audit,
+		config:         config,
+	}
+}
+
+// Create 招待を作成してメールで送信(ロールの指定がない場合はeditor)
+// 同じメールアドレスへの未受諾の招待は取り消し、新しい招待のみを有効にする
+func (u *invitationUseCase) Create(ctx context.Context, inviterID int64, req *CreateInvitationRequest) (*entity.Invitation, error) {
+	email := strings.TrimSpace(req.Email)
+	role := req.Role
+	if role == "" {
+		role = entity.RoleEditor
+	}
+
+	if err := validateUserEmail(email); err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("invalid role: %s", role)
+	}
+	if _, err := u.userRepo.FindByEmail(ctx, email); err == nil {
+		return nil, fmt.Errorf("email already exists")
+	}
+
+	if err := u.invitationRepo.DeletePendingByEmail(ctx, email); err != nil {
+		return nil, fmt.Errorf("failed to revoke previous invitations: %w", err)
+	}
+
+	token, tokenHash, err := auth.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &entity.Invitation{
+		Email:     email,
+		Role:      role,
+		TokenHash: tokenHash,
+		InvitedBy: &inviterID,
+		ExpiresAt: time.Now().Add(u.config.TTL).Truncate(time.Second),
+	}
+	if err := u.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	if err := u.mailer.Send(ctx, u.invitationMessage(invitation, token)); err != nil {
+		// 届かなかった招待は残さない
+		if delErr := u.invitationRepo.Delete(ctx, invitation.ID); delErr != nil {
+			return nil, fmt.Errorf("failed to send invitation: %w (cleanup failed: %v)", err, delErr)
+		}
+		return nil, fmt.Errorf("failed to send invitation: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditInvitationCreate,
+		TargetType: entity.AuditTargetInvitation,
+		TargetID:   invitation.ID,
+		After:      map[string]any{"email": invitation.Email, "role": invitation.Role, "expiresAt": invitation.ExpiresAt},
+	})
+
+	return invitation, nil
+}
+
+// invitationMessage 招待メールを作成
+func (u *invitationUseCase) invitationMessage(invitation *entity.Invitation, token string) *mailer.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "%sに%sとして招待されました。\n\n", u.config.SiteTitle, invitation.Role)
+	fmt.Fprintf(&body, "%s までに、以下の招待トークンを使ってユーザー名とパスワードを設定してください。\n\n",
+		invitation.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&body, "招待トークン: %s\n\n", token)
+	fmt.Fprintf(&body, "設定方法: POST %s/api/invitations/accept\n", u.config.SiteURL)
+	body.WriteString("(JSON: {\"token\": \"招待トークン\", \"username\": \"ユーザー名\", \"password\": \"パスワード\"})\n\n")
+	body.WriteString("招待トークンは一度だけ使用できます。心当たりがない場合は、このメールを破棄してください。\n")
+
+	return &mailer.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("[%s] 招待のお知らせ", u.config.SiteTitle),
+		Body:    body.String(),
+	}
+}
+
+// Accept 招待を受諾して、招待されたメールアドレス・ロールの有効なユーザーを作成
+func (u *invitationUseCase) Accept(ctx context.Context, req *AcceptInvitationRequest) (*entity.User, error) {
+	if req.Token == "" {
+		return nil, fmt.Errorf("invitation token is required")
+	}
+
+	invitation, err := u.invitationRepo.FindByTokenHash(ctx, auth.HashToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+	if invitation.IsAccepted() {
+		return nil, fmt.Errorf("invitation has already been used")
+	}
+	if invitation.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("invitation has expired")
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if err := validateUsername(username); err != nil {
+		return nil, err
+	}
+	if err := u.passwordPolicy.Validate(ctx, req.Password, username, invitation.Email); err != nil {
+		return nil, err
+	}
+	if _, err := u.userRepo.FindByUsername(ctx, username); err == nil {
+		return nil, fmt.Errorf("username already exists")
+	}
+	if _, err := u.userRepo.FindByEmail(ctx, invitation.Email); err == nil {
+		return nil, fmt.Errorf("email already exists")
+	}
+
+	passwordHash, err := u.passwordHasher.Hash(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &entity.User{
+		Username:     username,
+		Email:        invitation.Email,
+		PasswordHash: passwordHash,
+		Role:         invitation.Role,
+		Status:       entity.StatusActive,
+	}
+	if err := u.invitationRepo.Accept(ctx, invitation, user); err != nil {
+		return nil, err
+	}
+
+	// 受諾したユーザー自身の操作として記録する
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditInvitationAccept,
+		TargetType: entity.AuditTargetInvitation,
+		TargetID:   invitation.ID,
+		After:      userAuditSnapshot(user),
+		Actor:      user,
+	})
+
+	return user, nil
+}
+
+// List 招待一覧を新しい順に取得
+func (u *invitationUseCase) List(ctx context.Context, limit, offset int) ([]*entity.Invitation, int, error) {
+	invitations, err := u.invitationRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	count, err := u.invitationRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	return invitations, count, nil
+}
+
+// Revoke 未受諾の招待を取り消す
+func (u *invitationUseCase) Revoke(ctx context.Context, id int64) error {
+	invitation, err := u.invitationRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if invitation.IsAccepted() {
+		return fmt.Errorf("invitation has already been used")
+	}
+
+	if err := u.invitationRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditInvitationRevoke,
+		TargetType: entity.AuditTargetInvitation,
+		TargetID:   id,
+		Before:     map[string]any{"email": invitation.Email, "role": invitation.Role},
+	})
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/mailer"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMailer 送信したメールを記録するテスト用のMailer
+type fakeMailer struct {
+	mu       sync.Mutex
+	messages []*mailer.Message
+	err      error
+	// delay 送信にかかる時間(応答の遅いSMTPサーバーの再現)
+	delay time.Duration
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	time.Sleep(m.delay)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// sent 送信したメールがn件になるまで待って返す(バックグラウンドで送信するメール用)
+func (m *fakeMailer) sent(t *testing.T, n int) []*mailer.Message {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.messages) >= n
+	}, 5*time.Second, 5*time.Millisecond)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*mailer.Message(nil), m.messages...)
+}
+
+// invitationTokenPattern 招待メールの本文から招待トークンを取り出す
+var invitationTokenPattern = regexp.MustCompile(`招待トークン: (\S+)`)
+
+// lastInvitationToken 最後に送信した招待メールの招待トークンを取得
+func (m *fakeMailer) lastInvitationToken(t *testing.T) string {
+	t.Helper()
+
+	require.NotEmpty(t, m.messages)
+	match := invitationTokenPattern.FindStringSubmatch(m.messages[len(m.messages)-1].Body)
+	require.Len(t, match, 2)
+	return match[1]
+}
+
+func setupInvitationUseCase(t *testing.T) (usecase.InvitationUseCase, repository.InvitationRepository, *fakeMailer, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	invitationRepo := persistence.NewInvitationRepository(db)
+	m := &fakeMailer{}
+
+	admin := &entity.User{
+		Username:     "admin",
+		Email:        "admin@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleAdmin,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), admin))
+
+	invitationUseCase := usecase.NewInvitationUseCase(invitationRepo, userRepo, auth.NewPasswordHasher(), usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), m, newTestAudit(db), usecase.InvitationConfig{
+		SiteURL:   "https://blog.example.com/",
+		SiteTitle: "Test Blog",
+	})
+
+	return invitationUseCase, invitationRepo, m, admin, cleanup
+}
+
+func TestInvitationUseCase_CreateAndAccept(t *testing.T) {
+	invitationUseCase, invitationRepo, m, admin, cleanup := setupInvitationUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	invitation, err := invitationUseCase.Create(ctx, admin.ID, &usecase.CreateInvitationRequest{
+		Email: "editor@example.com",
+		Role:  entity.RoleEditor,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, invitation.ID)
+	assert.WithinDuration(t, time.Now().Add(usecase.DefaultInvitationTTL), invitation.ExpiresAt, time.Minute)
+
+	// メールでトークンを送り、データベースにはハッシュのみを保存する
+	require.Len(t, m.messages, 1)
+	assert.Equal(t, "editor@example.com", m.messages[0].To)
+	assert.Contains(t, m.messages[0].Subject, "Test Blog")
+	assert.Contains(t, m.messages[0].Body, "https://blog.example.com/api/invitations/accept")
+	token := m.lastInvitationToken(t)
+	assert.NotEqual(t, token, invitation.TokenHash)
+	assert.Equal(t, auth.HashToken(token), invitation.TokenHash)
+
+	user, err := invitationUseCase.Accept(ctx, &usecase.AcceptInvitationRequest{
+		Token:    token,
+		Username: "editor",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, user.ID)
+	assert.Equal(t, "editor@example.com", user.Email)
+	assert.Equal(t, entity.RoleEditor, user.Role)
+	assert.True(t, user.IsActive())
+
+	found, err := invitationRepo.FindByID(ctx, invitation.ID)
+	require.NoError(t, err)
+	assert.True(t, found.IsAccepted())
+
+	// トークンは一度だけ使用できる
+	_, err = invitationUseCase.Accept(ctx, &usecase.AcceptInvitationRequest{
+		Token:    token,
+		Username: "editor2",
+		Password: "password123",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already been used")
+
+	// 登録済みのメールアドレスは招待できない
+	_, err = invitationUseCase.Create(ctx, admin.ID, &usecase.CreateInvitationRequest{Email: "editor@example.com"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "email already exists")
+}
+
+func TestInvitationUseCase_Create_Invalid(t *testing.T) {
+	invitationUseCase, _, m, admin, cleanup := setupInvitationUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, err := invitationUseCase.Create(ctx, admin.ID, &usecase.CreateInvitationRequest{Email: "not-an-email"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid email address")
+
+	_, err = invitationUseCase.Create(ctx, admin.ID, &usecase.CreateInvitationRequest{Email: "editor@example.com", Role: "owner"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid role")
+
+	// 送信に失敗した招待は残さない
+	m.err = errors.New("smtp unavailable")
+	_, err = invitationUseCase.Create(ctx, admin.ID, &usecase.CreateInvitationRequest{Email: "editor@example.com"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send invitation")
+
+	_, total, err := invitationUseCase.List(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestInvitationUseCase_Create_ReplacesPending(t *testing.T) {
+	invitationUseCase, _, m, admin, cleanup := setupInvitationUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	req := &usecase.CreateInvitationRequest{Email: "editor@example.com"}
+
+	_, err := invitationUseCase.Create(ctx, admin.ID, req)
+	require.NoError(t, err)
+	oldToken := m.lastInvitationToken(t)
+
+	invitation, err := invitationUseCase.Create(ctx, admin.ID, req)
+	require.NoError(t, err)
+	// ロールの指定がない場合はeditor
+	assert.Equal(t, entity.RoleEditor, invitation.Role)
+
+	invitations, total, err := invitationUseCase.List(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, invitation.ID, invitations[0].ID)
+
+	_, err = invitationUseCase.Accept(ctx, &usecase.AcceptInvitationRequest{
+		Token:    oldToken,
+		Username: "editor",
+		Password: "password123",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestInvitationUseCase_Accept_Invalid(t *testing.T) {
+	invitationUseCase, invitationRepo, m, admin, cleanup := setupInvitationUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// 期限切れの招待
+	token, tokenHash, err := auth.GenerateToken()
+	require.NoError(t, err)
+	require.NoError(t, invitationRepo.Create(ctx, &entity.Invitation{
+		Email:     "expired@example.com",
+		Role:      entity.RoleEditor,
+		TokenHash: tokenHash,
+		InvitedBy: &admin.ID,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+	_, err = invitationUseCase.Accept(ctx, &usecase.AcceptInvitationRequest{Token: token, Username: "expired", Password: "password123"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expired")
+
+	_, err = invitationUseCase.Create(ctx, admin.ID, &usecase.CreateInvitationRequest{Email: "editor@example.com"})
+	require.NoError(t, err)
+	valid := m.lastInvitationToken(t)
+
+	tests := []struct {
+		name    string
+		req     usecase.AcceptInvitationRequest
+		wantErr string
+	}{
+		{
+			name:    "token is required",
+			req:     usecase.AcceptInvitationRequest{Username: "editor", Password: "password123"},
+			wantErr: "invitation token is required",
+		},
+		{
+			name:    "unknown token",
+			req:     usecase.AcceptInvitationRequest{Token: "unknown", Username: "editor", Password: "password123"},
+			wantErr: "not found",
+		},
+		{
+			name:    "invalid username",
+			req:     usecase.AcceptInvitationRequest{Token: valid, Username: "a b", Password: "password123"},
+			wantErr: "invalid username",
+		},
+		{
+			name:    "short password",
+			req:     usecase.AcceptInvitationRequest{Token: valid, Username: "editor", Password: "short"},
+			wantErr: "password must be at least",
+		},
+		{
+			name:    "duplicate username",
+			req:     usecase.AcceptInvitationRequest{Token: valid, Username: "admin", Password: "password123"},
+			wantErr: "username already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := invitationUseCase.Accept(ctx, &tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestInvitationUseCase_Revoke(t *testing.T) {
+	invitationUseCase, _, m, admin, cleanup := setupInvitationUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	invitation, err := invitationUseCase.Create(ctx, admin.ID, &usecase.CreateInvitationRequest{Email: "editor@example.com"})
+	require.NoError(t, err)
+	token := m.lastInvitationToken(t)
+
+	require.NoError(t, invitationUseCase.Revoke(ctx, invitation.ID))
+
+	_, err = invitationUseCase.Accept(ctx, &usecase.AcceptInvitationRequest{Token: token, Username: "editor", Password: "password123"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	err = invitationUseCase.Revoke(ctx, invitation.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/mailer"
+)
+
+const (
+	// DefaultLoginMaxFailures ユーザー名ごとのロックまでの既定の連続失敗回数
+	DefaultLoginMaxFailures = 5
+	// DefaultLoginIPMaxFailures IPアドレスごとのロックまでの既定の連続失敗回数
+	DefaultLoginIPMaxFailures = 20
+	// DefaultLoginLockoutBase 最初のロックの既定の期間(以降は失敗のたびに2倍にする)
+	DefaultLoginLockoutBase = time.Minute
+	// DefaultLoginLockoutMax ロックの既定の最大期間
+	DefaultLoginLockoutMax = time.Hour
+	// DefaultLoginFailureWindow 失敗回数をリセットするまでの既定の期間(最後の失敗からこの期間が過ぎるとリセット)
+	DefaultLoginFailureWindow = 24 * time.Hour
+)
+
+// maxThrottleKeyLength 失敗状況のキーとして保存するユーザー名の最大長
+const maxThrottleKeyLength = 255
+
+// maxMFATokenFailures 1つのMFATokenで誤ったコードを入力できる回数(超えるとそのトークンは使えない)
+const maxMFATokenFailures = 3
+
+// LoginLockedError ログインがロックされている場合のエラー
+type LoginLockedError struct {
+	// RetryAfter ロックが解除されるまでの時間
+	RetryAfter time.Duration
+}
+
+// Error エラーメッセージを返す
+func (e *LoginLockedError) Error() string {
+	return "too many failed login attempts: login is temporarily locked"
+}
+
+// LoginProtectionUseCase ログインの総当たり対策ユースケースのインターフェース
+type LoginProtectionUseCase interface {
+	// Check ユーザー名またはIPアドレスがロックされていればLoginLockedErrorを返す
+	Check(ctx context.Context, username string) error
+	// RecordFailure ログインの失敗を記録し、失敗回数に応じてロックする
+	RecordFailure(ctx context.Context, username string, user *entity.User, reason entity.LoginFailureReason)
+	// RecordSuccess ログインの成功を記録し、ユーザー名の失敗回数をリセットする
+	RecordSuccess(ctx context.Context, username string, user *entity.User)
+	// RecordMFATokenFailure MFATokenでのコードの誤りを数え、上限に達した場合はtrueを返す
+	RecordMFATokenFailure(ctx context.Context, tokenID string) bool
+	// ListLockouts ロック中のユーザー名・IPアドレスの一覧を取得
+	ListLockouts(ctx context.Context) ([]*entity.LoginThrottle, error)
+	// Unlock ユーザー名またはIPアドレスのロックを解除する
+	Unlock(ctx context.Context, scope entity.LoginThrottleScope, key string) error
+	// ListAttempts ログイン試行の監査ログを新しい順に取得
+	ListAttempts(ctx context.Context, filters repository.LoginAttemptFilters, limit, offset int) ([]*entity.LoginAttempt, int, error)
+}
+
+// LoginProtectionConfig ログインの総当たり対策の設定
+type LoginProtectionConfig struct {
+	SiteTitle string
+	// MaxFailures ユーザー名ごとのロックまでの連続失敗回数(0以下の場合はDefaultLoginMaxFailures)
+	MaxFailures int
+	// IPMaxFailures IPアドレスごとのロックまでの連続失敗回数(0以下の場合はDefaultLoginIPMaxFailures)
+	IPMaxFailures int
+	// LockoutBase 最初のロックの期間(0以下の場合はDefaultLoginLockoutBase)
+	LockoutBase time.Duration
+	// LockoutMax ロックの最大期間(0以下の場合はDefaultLoginLockoutMax)
+	LockoutMax time.Duration
+	// FailureWindow 失敗回数をリセットするまでの期間(0以下の場合はDefaultLoginFailureWindow)
+	FailureWindow time.Duration
+}
+
+// loginProtectionUseCase LoginProtectionUseCaseの実装
+type loginProtectionUseCase struct {
+	throttleRepo repository.LoginThrottleRepository
+	attemptRepo  repository.LoginAttemptRepository
+	mailer       mailer.Mailer
+	audit        AuditUseCase
+	config       LoginProtectionConfig
+}
+
+// NewLoginProtectionUseCase 新しいLoginProtectionUseCaseを作成
+func NewLoginProtectionUseCase(
+	throttleRepo repository.LoginThrottleRepository,
+	attemptRepo repository.LoginAttemptRepository,
+	mailer mailer.Mailer,
+	audit AuditUseCase,
+	config LoginProtectionConfig,
+) LoginProtectionUseCase {
+	if config.MaxFailures <= 0 {
+		config.MaxFailures = DefaultLoginMaxFailures
+	}
+	if config.IPMaxFailures <= 0 {
+		config.IPMaxFailures = DefaultLoginIPMaxFailures
+	}
+	if config.LockoutBase <= 0 {
+		config.LockoutBase = DefaultLoginLockoutBase
+	}
+	if config.LockoutMax <= 0 {
+		config.LockoutMax = DefaultLoginLockoutMax
+	}
+	if config.FailureWindow <= 0 {
+		config.FailureWindow = DefaultLoginFailureWindow
+	}
+
+	return &loginProtectionUseCase{
+		throttleRepo: throttleRepo,
+		attemptRepo:  attemptRepo,
+		mailer:       mailer,
+		audit:        audit,
+		config:       config,
+	}
+}
+
+// usernameThrottleKey 失敗状況のキーにするユーザー名(大文字・小文字を区別せずに数える)
+func usernameThrottleKey(username string) string {
+	return truncateRunes(strings.ToLower(strings.TrimSpace(username)), maxThrottleKeyLength)
+}
+
+// throttleKeys ユーザー名とクライアントのIPアドレスの失敗状況のキー(IPアドレスが不明な場合は含めない)
+func throttleKeys(ctx context.Context, username string) map[entity.LoginThrottleScope]string {
+	keys := map[entity.LoginThrottleScope]string{
+		entity.LoginThrottleUsername: usernameThrottleKey(username),
+	}
+	if ip := clientInfoFromContext(ctx).IPAddress; ip != "" {
+		keys[entity.LoginThrottleIP] = ip
+	}
+	return keys
+}
+
+// Check ユーザー名またはIPアドレスがロックされていればLoginLockedErrorを返す
+func (u *loginProtectionUseCase) Check(ctx context.Context, username string) error {
+	now := time.Now()
+	var retryAfter time.Duration
+
+	for scope, key := range throttleKeys(ctx, username) {
+		throttle, err := u.throttleRepo.Find(ctx, scope, key)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return err
+		}
+		if throttle.IsLocked(now) {
+			retryAfter = max(retryAfter, throttle.LockedUntil.Sub(now))
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure ログインの失敗を記録し、失敗回数に応じてロックする
+// ロックの期間は上限の回数に達した時点からLockoutBase、以降は失敗のたびに2倍(LockoutMaxまで)
+// 認証情報・二要素認証のコードの誤り以外(ロック中の試行など)は監査ログにのみ記録し、失敗回数に含めない
+func (u *loginProtectionUseCase) RecordFailure(ctx context.Context, username string, user *entity.User, reason entity.LoginFailureReason) {
+	u.recordAttempt(ctx, username, user, false, reason)
+	if !reason.CountsTowardLockout() {
+		return
+	}
+
+	now := time.Now()
+	for scope, key := range throttleKeys(ctx, username) {
+		throttle, err := u.throttleRepo.RecordFailure(ctx, scope, key, now, now.Add(-u.config.FailureWindow))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to record login failure", "error", err)
+			continue
+		}
+
+		limit := u.config.MaxFailures
+		if scope == entity.LoginThrottleIP {
+			limit = u.config.IPMaxFailures
+		}
+		if throttle.FailureCount < limit {
+			continue
+		}
+
+		until := now.Add(u.lockoutDuration(throttle.FailureCount - limit))
+		if err := u.throttleRepo.Lock(ctx, scope, key, until); err != nil {
+			slog.ErrorContext(ctx, "Failed to lock login", "error", err)
+			continue
+		}
+
+		slog.WarnContext(ctx, "Security event",
+			"event", "login_locked",
+			"scope", scope,
+			"key", key,
+			"failure_count", throttle.FailureCount,
+			"locked_until", until,
+		)
+
+		// ユーザー名のロックは最初にロックしたときのみ本人に通知する
+		// ログインの応答がSMTPサーバーの応答時間に左右されないよう、メールはバックグラウンドで送信する
+		if scope == entity.LoginThrottleUsername && user != nil && throttle.FailureCount == limit {
+			sendMailInBackground(ctx, u.mailer, u.lockoutMessage(ctx, user, until), "login_lockout")
+		}
+	}
+}
+
+// lockoutDuration 上限の回数を超えた回数からロックの期間を計算
+func (u *loginProtectionUseCase) lockoutDuration(exceeded int) time.Duration {
+	duration := u.config.LockoutBase
+	for i := 0; i < exceeded && duration < u.config.LockoutMax; i++ {
+		duration *= 2
+	}
+	return min(duration, u.config.LockoutMax)
+}
+
+// lockoutMessage ログインのロックを通知するメールを作成
+func (u *loginProtectionUseCase) lockoutMessage(ctx context.Context, user *entity.User, until time.Time) *mailer.Message {
+	info := clientInfoFromContext(ctx)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%sさん\n\n%sのアカウントで、ログインの失敗が%d回続いたため、ログインを一時的にロックしました。\n\n",
+		user.Username, u.config.SiteTitle, u.config.MaxFailures)
+	fmt.Fprintf(&body, "ロック解除予定: %s\n", until.UTC().Format("2006-01-02 15:04 MST"))
+	if info.IPAddress != "" {
+		fmt.Fprintf(&body, "最後に失敗したIPアドレス: %s\n", info.IPAddress)
+	}
+	body.WriteString("\n心当たりがない場合は、第三者がパスワードを推測しようとしている可能性があります。パスワードの変更と二要素認証の有効化をご検討ください。\n")
+
+	return &mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("[%s] ログインを一時的にロックしました", u.config.SiteTitle),
+		Body:    body.String(),
+	}
+}
+
+// RecordSuccess ログインの成功を記録し、ユーザー名の失敗回数をリセットする
+// IPアドレスの失敗回数は、攻撃者が自分のアカウントへのログインでリセットできないよう残す
+func (u *loginProtectionUseCase) RecordSuccess(ctx context.Context, username string, user *entity.User) {
+	u.recordAttempt(ctx, username, user, true, "")
+
+	if _, err := u.throttleRepo.Reset(ctx, entity.LoginThrottleUsername, usernameThrottleKey(username)); err != nil {
+		slog.ErrorContext(ctx, "Failed to reset login throttle", "error", err)
+	}
+}
+
+// RecordMFATokenFailure MFATokenでのコードの誤りを数え、maxMFATokenFailures回に達した場合はtrueを返す
+// 上限に達したトークンは呼び出し側で使えなくするため、失敗状況は削除する
+func (u *loginProtectionUseCase) RecordMFATokenFailure(ctx context.Context, tokenID string) bool {
+	now := time.Now()
+	throttle, err := u.throttleRepo.RecordFailure(ctx, entity.LoginThrottleMFAToken, tokenID, now, now.Add(-u.config.FailureWindow))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record mfa token failure", "error", err)
+		return false
+	}
+	if throttle.FailureCount < maxMFATokenFailures {
+		return false
+	}
+
+	if _, err := u.throttleRepo.Reset(ctx, entity.LoginThrottleMFAToken, tokenID); err != nil {
+		slog.ErrorContext(ctx, "Failed to reset mfa token failures", "error", err)
+	}
+	return true
+}
+
+// recordAttempt ログイン試行を監査ログに記録(記録に失敗してもログインは続ける)
+func (u *loginProtectionUseCase) recordAttempt(ctx context.Context, username string, user *entity.User, succeeded bool, reason entity.LoginFailureReason) {
+	info := clientInfoFromContext(ctx)
+	attempt := &entity.LoginAttempt{
+		Username:      truncateRunes(username, maxThrottleKeyLength),
+		IPAddress:     info.IPAddress,
+		UserAgent:     truncateRunes(info.UserAgent, maxUserAgentLength),
+		Succeeded:     succeeded,
+		FailureReason: reason,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+	}
+
+	if err := u.attemptRepo.Create(ctx, attempt); err != nil {
+		slog.ErrorContext(ctx, "Failed to record login attempt", "error", err)
+	}
+}
+
+// ListLockouts ロック中のユーザー名・IPアドレスの一覧を取得
+func (u *loginProtectionUseCase) ListLockouts(ctx context.Context) ([]*entity.LoginThrottle, error) {
+	return u.throttleRepo.ListLocked(ctx, time.Now())
+}
+
+// Unlock ユーザー名またはIPアドレスのロックを解除する(失敗回数もリセットする)
+func (u *loginProtectionUseCase) Unlock(ctx context.Context, scope entity.LoginThrottleScope, key string) error {
+	if !scope.IsValid() {
+		return fmt.Errorf("invalid scope: %s", scope)
+	}
+	if scope == entity.LoginThrottleUsername {
+		key = usernameThrottleKey(key)
+	}
+	if key == "" {
+		return fmt.Errorf("key is required")
+	}
+
+	reset, err := u.throttleRepo.Reset(ctx, scope, key)
+	if err != nil {
+		return err
+	}
+	if !reset {
+		return fmt.Errorf("login lockout not found")
+	}
+
+	// 失敗状況はIDではなく単位とキーで識別する
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthUnlock,
+		TargetType: entity.AuditTargetLoginThrottle,
+		Before:     map[string]any{"scope": scope, "key": key},
+	})
+
+	return nil
+}
+
+// ListAttempts ログイン試行の監査ログを新しい順に取得
+func (u *loginProtectionUseCase) ListAttempts(ctx context.Context, filters repository.LoginAttemptFilters, limit, offset int) ([]*entity.LoginAttempt, int, error) {
+	attempts, err := u.attemptRepo.List(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := u.attemptRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return attempts, count, nil
+}
@@ -0,0 +1,212 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLoginProtectionUseCase(t *testing.T) (usecase.LoginProtectionUseCase, usecase.AuthUseCase, *fakeMailer, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	passwordHasher := auth.NewPasswordHasher()
+	hash, err := passwordHasher.Hash("password123")
+	require.NoError(t, err)
+
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: hash,
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), user))
+
+	m := &fakeMailer{}
+	loginProtection := usecase.NewLoginProtectionUseCase(persistence.NewLoginThrottleRepository(db), persistence.NewLoginAttemptRepository(db), m, newTestAudit(db), usecase.LoginProtectionConfig{
+		SiteTitle:     "Test Blog",
+		MaxFailures:   3,
+		IPMaxFailures: 5,
+		LockoutBase:   time.Minute,
+		LockoutMax:    time.Hour,
+	})
+
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+	authUseCase := usecase.NewAuthUseCase(userRepo, persistence.NewTokenRepository(db), persistence.NewRefreshTokenRepository(db), persistence.NewSessionRepository(db), jwtManager, passwordHasher, usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), newTestMFAUseCase(t, db), loginProtection, newTestAudit(db), 15*time.Minute)
+
+	return loginProtection, authUseCase, m, user, cleanup
+}
+
+func TestLoginProtectionUseCase_LockUsername(t *testing.T) {
+	loginProtection, authUseCase, m, user, cleanup := setupLoginProtectionUseCase(t)
+	defer cleanup()
+
+	ctx := usecase.WithClientInfo(context.Background(), usecase.ClientInfo{UserAgent: "test-agent", IPAddress: "192.0.2.1"})
+
+	for i := 0; i < 3; i++ {
+		_, err := authUseCase.Login(ctx, "Editor", "wrong-password")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid credentials")
+	}
+
+	// 上限に達したら正しいパスワードでもログインできない
+	_, err := authUseCase.Login(ctx, "editor", "password123")
+	var lockedErr *usecase.LoginLockedError
+	require.True(t, errors.As(err, &lockedErr))
+	assert.InDelta(t, time.Minute.Seconds(), lockedErr.RetryAfter.Seconds(), 5)
+
+	// 本人にロックを通知する
+	messages := m.sent(t, 1)
+	require.Len(t, messages, 1)
+	assert.Equal(t, user.Email, messages[0].To)
+	assert.Contains(t, messages[0].Subject, "ロック")
+	assert.Contains(t, messages[0].Body, "192.0.2.1")
+
+	lockouts, err := loginProtection.ListLockouts(ctx)
+	require.NoError(t, err)
+	require.Len(t, lockouts, 1)
+	assert.Equal(t, entity.LoginThrottleUsername, lockouts[0].Scope)
+	assert.Equal(t, "editor", lockouts[0].Key)
+	assert.Equal(t, 3, lockouts[0].FailureCount)
+
+	// 監査ログにすべての試行を記録する
+	attempts, total, err := loginProtection.ListAttempts(ctx, repository.LoginAttemptFilters{UserID: &user.ID}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, attempts, 3)
+	assert.Equal(t, entity.LoginFailureInvalidCredentials, attempts[0].FailureReason)
+	assert.Equal(t, "test-agent", attempts[0].UserAgent)
+
+	attempts, _, err = loginProtection.ListAttempts(ctx, repository.LoginAttemptFilters{Username: "editor"}, 10, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, attempts)
+	assert.Equal(t, entity.LoginFailureLocked, attempts[0].FailureReason)
+
+	// 管理者がロックを解除すればログインできる
+	err = loginProtection.Unlock(ctx, entity.LoginThrottleUsername, "EDITOR")
+	require.NoError(t, err)
+	response, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.AccessToken)
+
+	err = loginProtection.Unlock(ctx, entity.LoginThrottleUsername, "editor")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestLoginProtectionUseCase_LockUsername_SlowMailer(t *testing.T) {
+	_, authUseCase, m, user, cleanup := setupLoginProtectionUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	m.delay = 500 * time.Millisecond
+
+	// ロックした試行もメールの送信を待たずに応答する
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		_, err := authUseCase.Login(ctx, "editor", "wrong-password")
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), m.delay)
+	}
+
+	// メールは応答の後に送信される
+	messages := m.sent(t, 1)
+	assert.Equal(t, user.Email, messages[0].To)
+}
+
+func TestLoginProtectionUseCase_UnknownUsername(t *testing.T) {
+	_, authUseCase, m, _, cleanup := setupLoginProtectionUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err := authUseCase.Login(ctx, "nobody", "wrong-password")
+		require.Error(t, err)
+	}
+
+	// 存在しないユーザー名も同じようにロックする(ユーザー名の存在を推測させない)
+	_, err := authUseCase.Login(ctx, "nobody", "wrong-password")
+	var lockedErr *usecase.LoginLockedError
+	assert.True(t, errors.As(err, &lockedErr))
+	assert.Empty(t, m.messages)
+}
+
+func TestLoginProtectionUseCase_SuccessResetsFailures(t *testing.T) {
+	_, authUseCase, _, _, cleanup := setupLoginProtectionUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := authUseCase.Login(ctx, "editor", "wrong-password")
+		require.Error(t, err)
+	}
+	_, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+
+	// 成功したら失敗回数を数え直す
+	for i := 0; i < 2; i++ {
+		_, err := authUseCase.Login(ctx, "editor", "wrong-password")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid credentials")
+	}
+	_, err = authUseCase.Login(ctx, "editor", "password123")
+	assert.NoError(t, err)
+}
+
+func TestLoginProtectionUseCase_LockIPAddress(t *testing.T) {
+	loginProtection, authUseCase, _, _, cleanup := setupLoginProtectionUseCase(t)
+	defer cleanup()
+
+	ctx := usecase.WithClientInfo(context.Background(), usecase.ClientInfo{IPAddress: "192.0.2.1"})
+	for _, username := range []string{"user1", "user2", "user3", "user4", "user5"} {
+		_, err := authUseCase.Login(ctx, username, "wrong-password")
+		require.Error(t, err)
+	}
+
+	// 同じIPアドレスからは別のユーザー名でもログインできない
+	_, err := authUseCase.Login(ctx, "editor", "password123")
+	var lockedErr *usecase.LoginLockedError
+	require.True(t, errors.As(err, &lockedErr))
+
+	// 別のIPアドレスからはログインできる
+	other := usecase.WithClientInfo(context.Background(), usecase.ClientInfo{IPAddress: "192.0.2.2"})
+	_, err = authUseCase.Login(other, "editor", "password123")
+	require.NoError(t, err)
+
+	require.NoError(t, loginProtection.Unlock(ctx, entity.LoginThrottleIP, "192.0.2.1"))
+	_, err = authUseCase.Login(ctx, "editor", "password123")
+	assert.NoError(t, err)
+}
+
+func TestLoginProtectionUseCase_Unlock_Invalid(t *testing.T) {
+	loginProtection, _, _, _, cleanup := setupLoginProtectionUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	err := loginProtection.Unlock(ctx, entity.LoginThrottleScope("email"), "editor")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid scope")
+
+	err = loginProtection.Unlock(ctx, entity.LoginThrottleUsername, "  ")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key is required")
+}
@@ -0,0 +1,516 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // GIFのデコーダーを登録
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/imaging"
+	"my-blog-engine/internal/infrastructure/renderer"
+	"my-blog-engine/internal/infrastructure/storage"
+
+	"github.com/google/uuid"
+	_ "golang.org/x/image/webp" // WebPのデコーダーを登録
+)
+
+// DefaultMaxUploadSize アップロード可能なファイルサイズのデフォルト上限(10MB)
+const DefaultMaxUploadSize int64 = 10 << 20
+
+// maxImagePixels 受け付ける画像の最大画素数(展開後に巨大になる画像によるメモリ枯渇を防ぐ)
+const maxImagePixels = 50_000_000
+
+// maxAltTextLength 代替テキストの最大文字数
+const maxAltTextLength = 500
+
+// maxOriginalNameLength 元のファイル名として保存する最大文字数
+const maxOriginalNameLength = 255
+
+// mediaURLPrefix メディアを公開配信するURLのパス
+const mediaURLPrefix = "/media/"
+
+// mediaVariantWidths アップロード時に生成する縮小画像の幅(原本より小さいもののみ生成)
+var mediaVariantWidths = []int{320, 640, 1280}
+
+// variantJPEGQuality 縮小画像をJPEGで保存する際の品質
+const variantJPEGQuality = 82
+
+// orientedJPEGQuality EXIFの向きを反映するためにJPEGの原本を再圧縮する際の品質
+const orientedJPEGQuality = 92
+
+// mediaType アップロードを許可する画像形式
+type mediaType struct {
+	// format image.DecodeConfigが返す形式名
+	format    string
+	extension string
+}
+
+// allowedMediaTypes アップロードを許可するMIMEタイプ(ファイルの内容から判定)
+// SVGはスクリプトを埋め込めるため許可しない
+var allowedMediaTypes = map[string]mediaType{
+	"image/jpeg": {format: "jpeg", extension: ".jpg"},
+	"image/png":  {format: "png", extension: ".png"},
+	"image/gif":  {format: "gif", extension: ".gif"},
+	"image/webp": {format: "webp", extension: ".webp"},
+}
+
+// MediaUseCase メディアユースケースのインターフェース
+type MediaUseCase interface {
+	Upload(ctx context.Context, req *UploadMediaRequest) (*entity.Media, error)
+	Update(ctx context.Context, id int64, req *UpdateMediaRequest) (*entity.Media, error)
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Media, error)
+	List(ctx context.Context, limit, offset int) ([]*entity.Media, int, error)
+	// Open 公開配信用にファイルを開き、Content-Typeとあわせて返す(呼び出し側でCloseすること)
+	Open(ctx context.Context, key string) (*storage.Object, string, error)
+}
+
+// UploadMediaRequest メディアアップロードリクエスト
+type UploadMediaRequest struct {
+	OwnerID  int64
+	Filename string
+	AltText  string
+	File     io.Reader
+}
+
+// UpdateMediaRequest メディア更新リクエスト
+type UpdateMediaRequest struct {
+	AltText *string `json:"altText"`
+}
+
+// mediaUseCase MediaUseCaseの実装
+type mediaUseCase struct {
+	mediaRepo     repository.MediaRepository
+	storage       storage.Storage
+	webpEncoder   imaging.WebPEncoder
+	audit         AuditUseCase
+	maxUploadSize int64
+}
+
+// NewMediaUseCase 新しいMediaUseCaseを作成(maxUploadSizeが0以下の場合はDefaultMaxUploadSize)
+// webpEncoderがnilの場合、WebPのバリアントは生成しない
+func NewMediaUseCase(
+	mediaRepo repository.MediaRepository,
+	store storage.Storage,
+	webpEncoder imaging.WebPEncoder,
+	audit AuditUseCase,
+	maxUploadSize int64,
+) MediaUseCase {
+	if maxUploadSize <= 0 {
+		maxUploadSize = DefaultMaxUploadSize
+	}
+
+	return &mediaUseCase{
+		mediaRepo:     mediaRepo,
+		storage:       store,
+		webpEncoder:   webpEncoder,
+		audit:         audit,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// MediaURL ストレージキーから公開配信用のURLのパスを作成
+func MediaURL(key string) string {
+	return mediaURLPrefix + key
+}
+
+// Upload 画像をストレージに保存してメディアを登録
+// 形式はファイル名や申告されたContent-Typeではなく内容から判定する
+func (u *mediaUseCase) Upload(ctx context.Context, req *UploadMediaRequest) (*entity.Media, error) {
+	if req.File == nil {
+		return nil, fmt.Errorf("file is required")
+	}
+	if utf8.RuneCountInString(req.AltText) > maxAltTextLength {
+		return nil, fmt.Errorf("alt text is too long")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(req.File, u.maxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file is required")
+	}
+	if int64(len(data)) > u.maxUploadSize {
+		return nil, fmt.Errorf("file too large: maximum size is %d bytes", u.maxUploadSize)
+	}
+
+	mimeType := http.DetectContentType(data)
+	allowed, ok := allowedMediaTypes[mimeType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported media type: %s", mimeType)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+	if format != allowed.format {
+		return nil, fmt.Errorf("invalid image: content does not match %s", mimeType)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("invalid image: dimensions %dx%d are not allowed", config.Width, config.Height)
+	}
+
+	// 位置情報などのメタデータを取り除き、バリアント生成用にデコードする
+	original, img, err := prepareOriginal(data, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	width, height := config.Width, config.Height
+	if img != nil {
+		width, height = img.Bounds().Dx(), img.Bounds().Dy()
+	}
+
+	base := fmt.Sprintf("%s/%s", time.Now().UTC().Format("2006/01"), uuid.NewString())
+	key := base + allowed.extension
+
+	// 途中で失敗した場合は保存済みのファイルを残さない
+	var storedKeys []string
+	cleanup := func() {
+		for _, storedKey := range storedKeys {
+			_ = u.storage.Delete(ctx, storedKey)
+		}
+	}
+
+	if err := u.storage.Put(ctx, key, bytes.NewReader(original)); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	storedKeys = append(storedKeys, key)
+
+	variants, err := u.createVariants(ctx, base, img, mimeType, &storedKeys)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to create image variants: %w", err)
+	}
+
+	media := &entity.Media{
+		StorageKey:   key,
+		OriginalName: originalName(req.Filename),
+		MimeType:     mimeType,
+		Size:         int64(len(original)),
+		Width:        width,
+		Height:       height,
+		AltText:      strings.TrimSpace(req.AltText),
+		Variants:     variants,
+	}
+	if req.OwnerID != 0 {
+		media.OwnerID = &req.OwnerID
+	}
+
+	if err := u.mediaRepo.Create(ctx, media); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to create media: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditMediaUpload,
+		TargetType: entity.AuditTargetMedia,
+		TargetID:   media.ID,
+		After:      mediaAuditSnapshot(media),
+	})
+
+	return u.mediaRepo.FindByID(ctx, media.ID)
+}
+
+// prepareOriginal 保存する原本からEXIF(位置情報など)のメタデータを取り除き、バリアント生成用にデコードした画像とあわせて返す
+// JPEGはEXIFの向きを反映する必要がある場合のみ再圧縮し、それ以外は画像データをそのまま保つ
+// GIFはアニメーションを保つためそのまま保存し、バリアントも生成しない(画像はnil)
+func prepareOriginal(data []byte, mimeType string) ([]byte, image.Image, error) {
+	var stripped []byte
+	var err error
+	switch mimeType {
+	case "image/jpeg":
+		if orientation := imaging.JPEGOrientation(data); orientation != 1 {
+			return reorientJPEG(data, orientation)
+		}
+		stripped, err = imaging.StripJPEGMetadata(data)
+	case "image/png":
+		stripped, err = imaging.StripPNGMetadata(data)
+	case "image/webp":
+		stripped, err = imaging.StripWebPMetadata(data)
+	default:
+		return data, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid image: %w", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid image: %w", err)
+	}
+
+	return stripped, img, nil
+}
+
+// reorientJPEG EXIFの向きを反映したJPEGに再圧縮する(EXIFは含めない)
+func reorientJPEG(data []byte, orientation int) ([]byte, image.Image, error) {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid image: %w", err)
+	}
+	img = imaging.ApplyOrientation(img, orientation)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: orientedJPEGQuality}); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	return buf.Bytes(), img, nil
+}
+
+// createVariants 幅別の縮小画像と、WebPエンコーダーがある場合はWebP版を生成して保存する
+// 縮小画像は透過がなければJPEG、あればPNGで保存する。保存したキーはstoredKeysに追加する
+func (u *mediaUseCase) createVariants(ctx context.Context, base string, img image.Image, mimeType string, storedKeys *[]string) ([]*entity.MediaVariant, error) {
+	if img == nil {
+		return nil, nil
+	}
+
+	variants := make([]*entity.MediaVariant, 0)
+	put := func(resized image.Image, variantMimeType string, data []byte) error {
+		bounds := resized.Bounds()
+		key := fmt.Sprintf("%s-%dw%s", base, bounds.Dx(), allowedMediaTypes[variantMimeType].extension)
+		if err := u.storage.Put(ctx, key, bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("failed to store variant: %w", err)
+		}
+		*storedKeys = append(*storedKeys, key)
+
+		variants = append(variants, &entity.MediaVariant{
+			StorageKey: key,
+			MimeType:   variantMimeType,
+			Size:       int64(len(data)),
+			Width:      bounds.Dx(),
+			Height:     bounds.Dy(),
+		})
+		return nil
+	}
+
+	for _, width := range mediaVariantWidths {
+		if width >= img.Bounds().Dx() {
+			break
+		}
+		resized := imaging.Resize(img, width)
+
+		variantMimeType, data, err := encodeVariant(resized)
+		if err != nil {
+			return nil, err
+		}
+		if err := put(resized, variantMimeType, data); err != nil {
+			return nil, err
+		}
+
+		if u.webpEncoder != nil {
+			data, err := u.webpEncoder.EncodeWebP(ctx, resized)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode webp: %w", err)
+			}
+			if err := put(resized, "image/webp", data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 原寸のWebP版(原本がWebPの場合は不要)
+	if u.webpEncoder != nil && mimeType != "image/webp" {
+		data, err := u.webpEncoder.EncodeWebP(ctx, img)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode webp: %w", err)
+		}
+		if err := put(img, "image/webp", data); err != nil {
+			return nil, err
+		}
+	}
+
+	return variants, nil
+}
+
+// encodeVariant 縮小画像をエンコードし、MIMEタイプとあわせて返す
+func encodeVariant(img image.Image) (string, []byte, error) {
+	var buf bytes.Buffer
+	if imaging.HasAlpha(img) {
+		if err := png.Encode(&buf, img); err != nil {
+			return "", nil, fmt.Errorf("failed to encode png: %w", err)
+		}
+		return "image/png", buf.Bytes(), nil
+	}
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: variantJPEGQuality}); err != nil {
+		return "", nil, fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+	return "image/jpeg", buf.Bytes(), nil
+}
+
+// originalName アップロードされたファイル名からディレクトリ部分を除き、長さを制限する
+func originalName(filename string) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	if utf8.RuneCountInString(name) > maxOriginalNameLength {
+		name = string([]rune(name)[:maxOriginalNameLength])
+	}
+	return name
+}
+
+// Update メディアの代替テキストを更新
+func (u *mediaUseCase) Update(ctx context.Context, id int64, req *UpdateMediaRequest) (*entity.Media, error) {
+	media, err := u.mediaRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := mediaAuditSnapshot(media)
+
+	if req.AltText != nil {
+		if utf8.RuneCountInString(*req.AltText) > maxAltTextLength {
+			return nil, fmt.Errorf("alt text is too long")
+		}
+		media.AltText = strings.TrimSpace(*req.AltText)
+	}
+
+	if err := u.mediaRepo.Update(ctx, media); err != nil {
+		return nil, fmt.Errorf("failed to update media: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditMediaUpdate,
+		TargetType: entity.AuditTargetMedia,
+		TargetID:   media.ID,
+		Before:     before,
+		After:      mediaAuditSnapshot(media),
+	})
+
+	return media, nil
+}
+
+// Delete メディアを削除(ストレージ上の原本・バリアントのファイルも削除)
+func (u *mediaUseCase) Delete(ctx context.Context, id int64) error {
+	media, err := u.mediaRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.mediaRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete media: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditMediaDelete,
+		TargetType: entity.AuditTargetMedia,
+		TargetID:   id,
+		Before:     mediaAuditSnapshot(media),
+	})
+
+	keys := []string{media.StorageKey}
+	for _, variant := range media.Variants {
+		keys = append(keys, variant.StorageKey)
+	}
+	for _, key := range keys {
+		if err := u.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to delete media file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByID IDでメディアを取得
+func (u *mediaUseCase) GetByID(ctx context.Context, id int64) (*entity.Media, error) {
+	return u.mediaRepo.FindByID(ctx, id)
+}
+
+// List メディア一覧を新しい順に取得
+func (u *mediaUseCase) List(ctx context.Context, limit, offset int) ([]*entity.Media, int, error) {
+	media, err := u.mediaRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list media: %w", err)
+	}
+
+	count, err := u.mediaRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count media: %w", err)
+	}
+
+	return media, count, nil
+}
+
+// Open 公開配信用にファイルを開き、Content-Typeとあわせて返す(呼び出し側でCloseすること)
+// 配信のたびにデータベースを参照しないよう、Content-Typeはキーの拡張子から決める
+func (u *mediaUseCase) Open(ctx context.Context, key string) (*storage.Object, string, error) {
+	contentType := ""
+	for mimeType, allowed := range allowedMediaTypes {
+		if path.Ext(key) == allowed.extension {
+			contentType = mimeType
+			break
+		}
+	}
+	if contentType == "" {
+		return nil, "", fmt.Errorf("media not found: %s", key)
+	}
+
+	obj, err := u.storage.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			return nil, "", fmt.Errorf("media not found: %w", err)
+		}
+		return nil, "", fmt.Errorf("failed to open media: %w", err)
+	}
+
+	return obj, contentType, nil
+}
+
+// mediaImageResolver メディアライブラリの画像をレスポンシブ画像として解決するrenderer.ImageResolverの実装
+type mediaImageResolver struct {
+	mediaRepo repository.MediaRepository
+}
+
+// NewMediaImageResolver 記事中の/media/の画像をバリアント付きで解決するImageResolverを作成
+func NewMediaImageResolver(mediaRepo repository.MediaRepository) renderer.ImageResolver {
+	return &mediaImageResolver{mediaRepo: mediaRepo}
+}
+
+// ResolveImage /media/{key}の画像の原寸とバリアントを取得(メディアライブラリにない画像はfalse)
+func (r *mediaImageResolver) ResolveImage(src string) (*renderer.ResponsiveImage, bool) {
+	key, ok := strings.CutPrefix(src, mediaURLPrefix)
+	if !ok || storage.ValidateKey(key) != nil {
+		return nil, false
+	}
+
+	media, err := r.mediaRepo.FindByStorageKey(context.Background(), key)
+	if err != nil {
+		return nil, false
+	}
+
+	sources := make([]renderer.ImageSource, 0, len(media.Variants)+1)
+	for _, variant := range media.Variants {
+		sources = append(sources, renderer.ImageSource{
+			URL:      MediaURL(variant.StorageKey),
+			MimeType: variant.MimeType,
+			Width:    variant.Width,
+		})
+	}
+	sources = append(sources, renderer.ImageSource{
+		URL:      MediaURL(media.StorageKey),
+		MimeType: media.MimeType,
+		Width:    media.Width,
+	})
+
+	return &renderer.ResponsiveImage{
+		Width:   media.Width,
+		Height:  media.Height,
+		Sources: sources,
+	}, true
+}
@@ -0,0 +1,324 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/imaging"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/infrastructure/renderer"
+	"my-blog-engine/internal/infrastructure/storage"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMediaUseCase(t *testing.T, maxUploadSize int64, webpEncoder imaging.WebPEncoder) (usecase.MediaUseCase, storage.Storage, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	mediaRepo := persistence.NewMediaRepository(db)
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mediaUseCase := usecase.NewMediaUseCase(mediaRepo, store, webpEncoder, newTestAudit(db), maxUploadSize)
+
+	// テストユーザー作成
+	user := &entity.User{
+		Username:     "testauthor",
+		Email:        "author@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), user))
+
+	return mediaUseCase, store, user, cleanup
+}
+
+// testPNG 指定サイズのPNG画像を生成
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// fakeWebPEncoder テスト用のWebPEncoder(エンコードした画像の幅を記録する)
+type fakeWebPEncoder struct {
+	widths []int
+}
+
+func (e *fakeWebPEncoder) EncodeWebP(ctx context.Context, img image.Image) ([]byte, error) {
+	e.widths = append(e.widths, img.Bounds().Dx())
+	return []byte("RIFF\x04\x00\x00\x00WEBP"), nil
+}
+
+// testJPEGWithOrientation EXIFのOrientationを埋め込んだJPEG画像を生成
+func testJPEGWithOrientation(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	data := buf.Bytes()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	withExif := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	withExif = binary.BigEndian.AppendUint16(withExif, uint16(len(payload)+2))
+	withExif = append(withExif, payload...)
+	return append(withExif, data[2:]...)
+}
+
+func TestMediaUseCase_Upload(t *testing.T) {
+	mediaUseCase, store, user, cleanup := setupMediaUseCase(t, 0, nil)
+	defer cleanup()
+
+	ctx := context.Background()
+	data := testPNG(t, 40, 30)
+
+	media, err := mediaUseCase.Upload(ctx, &usecase.UploadMediaRequest{
+		OwnerID:  user.ID,
+		Filename: `C:\Users\me\photo.txt`,
+		AltText:  " 写真 ",
+		File:     bytes.NewReader(data),
+	})
+	require.NoError(t, err)
+
+	// 形式はファイル名ではなく内容から判定
+	assert.Equal(t, "image/png", media.MimeType)
+	assert.True(t, strings.HasSuffix(media.StorageKey, ".png"))
+	assert.Equal(t, "photo.txt", media.OriginalName)
+	assert.Equal(t, int64(len(data)), media.Size)
+	assert.Equal(t, 40, media.Width)
+	assert.Equal(t, 30, media.Height)
+	assert.Equal(t, "写真", media.AltText)
+	require.NotNil(t, media.Owner)
+	assert.Equal(t, user.ID, media.Owner.ID)
+
+	// ストレージに保存されている
+	obj, contentType, err := mediaUseCase.Open(ctx, media.StorageKey)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	require.NoError(t, obj.Close())
+	assert.Equal(t, "image/png", contentType)
+	assert.Equal(t, data, stored)
+
+	// 削除するとファイルも消える
+	require.NoError(t, mediaUseCase.Delete(ctx, media.ID))
+	_, err = store.Open(ctx, media.StorageKey)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = mediaUseCase.GetByID(ctx, media.ID)
+	assert.Error(t, err)
+}
+
+func TestMediaUseCase_Upload_Rejected(t *testing.T) {
+	mediaUseCase, _, user, cleanup := setupMediaUseCase(t, 1024, nil)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			data:    nil,
+			wantErr: "file is required",
+		},
+		{
+			name:    "too large",
+			data:    bytes.Repeat([]byte("a"), 1025),
+			wantErr: "file too large",
+		},
+		{
+			name:    "svg",
+			data:    []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+			wantErr: "unsupported media type",
+		},
+		{
+			name:    "broken png",
+			data:    append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 32)...),
+			wantErr: "invalid image",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mediaUseCase.Upload(ctx, &usecase.UploadMediaRequest{
+				OwnerID:  user.ID,
+				Filename: "image.png",
+				File:     bytes.NewReader(tt.data),
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, total, err := mediaUseCase.List(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
+func TestMediaUseCase_Update(t *testing.T) {
+	mediaUseCase, _, user, cleanup := setupMediaUseCase(t, 0, nil)
+	defer cleanup()
+
+	ctx := context.Background()
+	media, err := mediaUseCase.Upload(ctx, &usecase.UploadMediaRequest{
+		OwnerID:  user.ID,
+		Filename: "image.png",
+		AltText:  "before",
+		File:     bytes.NewReader(testPNG(t, 2, 2)),
+	})
+	require.NoError(t, err)
+
+	// 空文字列に更新できる
+	empty := ""
+	updated, err := mediaUseCase.Update(ctx, media.ID, &usecase.UpdateMediaRequest{AltText: &empty})
+	require.NoError(t, err)
+	assert.Empty(t, updated.AltText)
+
+	found, err := mediaUseCase.GetByID(ctx, media.ID)
+	require.NoError(t, err)
+	assert.Empty(t, found.AltText)
+
+	tooLong := strings.Repeat("a", 501)
+	_, err = mediaUseCase.Update(ctx, media.ID, &usecase.UpdateMediaRequest{AltText: &tooLong})
+	assert.Error(t, err)
+}
+
+func TestMediaUseCase_Open_NotFound(t *testing.T) {
+	mediaUseCase, _, _, cleanup := setupMediaUseCase(t, 0, nil)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, key := range []string{"2025/01/missing.png", "2025/01/file.txt", "../secret.png"} {
+		_, _, err := mediaUseCase.Open(ctx, key)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	}
+}
+
+func TestMediaUseCase_Upload_Variants(t *testing.T) {
+	encoder := &fakeWebPEncoder{}
+	mediaUseCase, store, user, cleanup := setupMediaUseCase(t, 0, encoder)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// 横1000px・縦500pxで撮影し、時計回りに90度回転して表示する写真
+	media, err := mediaUseCase.Upload(ctx, &usecase.UploadMediaRequest{
+		OwnerID:  user.ID,
+		Filename: "photo.jpg",
+		File:     bytes.NewReader(testJPEGWithOrientation(t, 1000, 500, 6)),
+	})
+	require.NoError(t, err)
+
+	// 向きを反映した寸法になり、EXIFは除去される
+	assert.Equal(t, 500, media.Width)
+	assert.Equal(t, 1000, media.Height)
+
+	obj, err := store.Open(ctx, media.StorageKey)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	require.NoError(t, obj.Close())
+	assert.NotContains(t, string(stored), "Exif")
+	assert.Equal(t, int64(len(stored)), media.Size)
+
+	// 原本より小さい幅の縮小画像と、その幅・原寸のWebP版
+	require.Len(t, media.Variants, 3)
+	assert.Equal(t, []int{320, 500}, encoder.widths)
+
+	variants := make(map[string]*entity.MediaVariant)
+	for _, variant := range media.Variants {
+		variants[variant.MimeType+"/"+strings.TrimPrefix(variant.StorageKey, strings.TrimSuffix(media.StorageKey, ".jpg"))] = variant
+	}
+	require.Contains(t, variants, "image/jpeg/-320w.jpg")
+	require.Contains(t, variants, "image/webp/-320w.webp")
+	require.Contains(t, variants, "image/webp/-500w.webp")
+	assert.Equal(t, 640, variants["image/jpeg/-320w.jpg"].Height)
+
+	for _, variant := range media.Variants {
+		obj, _, err := mediaUseCase.Open(ctx, variant.StorageKey)
+		require.NoError(t, err)
+		require.NoError(t, obj.Close())
+	}
+}
+
+func TestMediaImageResolver(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	mediaRepo := persistence.NewMediaRepository(db)
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mediaUseCase := usecase.NewMediaUseCase(mediaRepo, store, &fakeWebPEncoder{}, newTestAudit(db), 0)
+	media, err := mediaUseCase.Upload(ctx, &usecase.UploadMediaRequest{
+		Filename: "photo.jpg",
+		File:     bytes.NewReader(testJPEGWithOrientation(t, 800, 600, 1)),
+	})
+	require.NoError(t, err)
+
+	resolver := usecase.NewMediaImageResolver(mediaRepo)
+
+	resolved, ok := resolver.ResolveImage(usecase.MediaURL(media.StorageKey))
+	require.True(t, ok)
+	assert.Equal(t, 800, resolved.Width)
+	assert.Equal(t, 600, resolved.Height)
+	// 320w・640wのJPEGとWebP、原寸のWebP、原本
+	require.Len(t, resolved.Sources, 6)
+	assert.Equal(t, usecase.MediaURL(media.StorageKey), resolved.Sources[5].URL)
+
+	for _, src := range []string{"https://example.com/image.jpg", "/media/2025/01/missing.jpg", "/media/../secret.jpg"} {
+		_, ok := resolver.ResolveImage(src)
+		assert.False(t, ok, src)
+	}
+
+	// 記事中の画像はバリアント付きのレスポンシブ画像として出力される
+	mdRenderer := renderer.NewMarkdownRenderer(renderer.NewMockMermaidRenderer(), resolver)
+	html, err := mdRenderer.Render("![写真](" + usecase.MediaURL(media.StorageKey) + ")")
+	require.NoError(t, err)
+	assert.Contains(t, html, `<picture><source type="image/webp"`)
+	assert.Contains(t, html, `width="800" height="600" loading="lazy"`)
+	assert.Contains(t, html, strings.TrimSuffix(media.StorageKey, ".jpg")+"-320w.jpg 320w")
+}
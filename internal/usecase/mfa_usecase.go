@@ -0,0 +1,412 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+)
+
+// recoveryCodeCount 一度に発行するリカバリーコードの数
+const recoveryCodeCount = 10
+
+// ErrInvalidMFACode ワンタイムコード・リカバリーコードが一致しない場合のエラー
+var ErrInvalidMFACode = errors.New("invalid verification code")
+
+// MFAUseCase 二要素認証ユースケースのインターフェース
+type MFAUseCase interface {
+	// Status ユーザーの二要素認証の状態を取得
+	Status(ctx context.Context, user *entity.User) (*MFAStatus, error)
+	// Setup TOTPシークレットを発行(確認コードを入力するまで有効にならない)
+	Setup(ctx context.Context, user *entity.User) (*MFASetupResponse, error)
+	// Confirm 確認コードを検証して二要素認証を有効にし、リカバリーコードを返す
+	Confirm(ctx context.Context, user *entity.User, code string) ([]string, error)
+	// Verify ワンタイムコードまたはリカバリーコードを検証
+	Verify(ctx context.Context, user *entity.User, code string) error
+	// Disable コードを検証して二要素認証を無効にする
+	Disable(ctx context.Context, user *entity.User, code string) error
+	// RegenerateRecoveryCodes コードを検証してリカバリーコードを再発行
+	RegenerateRecoveryCodes(ctx context.Context, user *entity.User, code string) ([]string, error)
+	// Reset 管理者がユーザーの二要素認証を解除(端末とリカバリーコードを紛失した場合)
+	Reset(ctx context.Context, userID int64) error
+	// IsEnabled ユーザーの二要素認証が有効かどうかを取得
+	IsEnabled(ctx context.Context, userID int64) (bool, error)
+	// IsRequired ロールで二要素認証が必須かどうかを取得
+	IsRequired(ctx context.Context, role entity.UserRole) (bool, error)
+	// ListPolicies 全ロールのポリシーを取得
+	ListPolicies(ctx context.Context) ([]*entity.MFAPolicy, error)
+	// SetPolicy ロールのポリシーを変更
+	SetPolicy(ctx context.Context, req *SetMFAPolicyRequest) (*entity.MFAPolicy, error)
+}
+
+// MFAStatus 二要素認証の状態
+type MFAStatus struct {
+	Enabled bool `json:"enabled"`
+	// Required ロールのポリシーで必須かどうか
+	Required               bool `json:"required"`
+	RemainingRecoveryCodes int  `json:"remainingRecoveryCodes"`
+}
+
+// MFASetupResponse TOTPシークレットの発行レスポンス
+type MFASetupResponse struct {
+	Secret string `json:"secret"`
+	// OTPAuthURI 認証アプリに登録するURI(QRコードの内容)
+	OTPAuthURI string `json:"otpauthUri"`
+}
+
+// MFACodeRequest ワンタイムコード(またはリカバリーコード)を含むリクエスト
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+// SetMFAPolicyRequest ポリシー変更リクエスト
+type SetMFAPolicyRequest struct {
+	Role     entity.UserRole `json:"role"`
+	Required bool            `json:"required"`
+}
+
+// mfaUseCase MFAUseCaseの実装
+type mfaUseCase struct {
+	mfaRepo    repository.MFARepository
+	policyRepo repository.MFAPolicyRepository
+	secretBox  auth.SecretBox
+	audit      AuditUseCase
+	issuer     string
+}
+
+// NewMFAUseCase 新しいMFAUseCaseを作成(issuerは認証アプリに表示するサービス名)
+func NewMFAUseCase(
+	mfaRepo repository.MFARepository,
+	policyRepo repository.MFAPolicyRepository,
+	secretBox auth.SecretBox,
+	audit AuditUseCase,
+	issuer string,
+) MFAUseCase {
+	return &mfaUseCase{
+		mfaRepo:    mfaRepo,
+		policyRepo: policyRepo,
+		secretBox:  secretBox,
+		audit:      audit,
+		issuer:     issuer,
+	}
+}
+
+// Status ユーザーの二要素認証の状態を取得
+func (u *mfaUseCase) Status(ctx context.Context, user *entity.User) (*MFAStatus, error) {
+	required, err := u.IsRequired(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	status := &MFAStatus{Required: required}
+
+	mfa, err := u.findEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return status, nil
+	}
+
+	remaining, err := u.mfaRepo.CountUnusedRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	status.Enabled = true
+	status.RemainingRecoveryCodes = remaining
+
+	return status, nil
+}
+
+// Setup TOTPシークレットを発行
+// 有効な二要素認証がある場合は、無効にしてからでないと再発行できない(コードなしで置き換えられないようにするため)
+func (u *mfaUseCase) Setup(ctx context.Context, user *entity.User) (*MFASetupResponse, error) {
+	enabled, err := u.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := u.secretBox.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+
+	if err := u.mfaRepo.SavePending(ctx, &entity.UserMFA{UserID: user.ID, Secret: encrypted}); err != nil {
+		return nil, err
+	}
+
+	return &MFASetupResponse{
+		Secret:     secret,
+		OTPAuthURI: auth.TOTPURI(u.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm 確認コードを検証して二要素認証を有効にし、リカバリーコードを返す
+func (u *mfaUseCase) Confirm(ctx context.Context, user *entity.User, code string) ([]string, error) {
+	mfa, err := u.mfaRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("two-factor authentication setup has not been started")
+		}
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return nil, fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	step, err := u.validateTOTP(mfa, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	mfa.LastUsedStep = step
+	if err := u.mfaRepo.Enable(ctx, mfa, hashes); err != nil {
+		return nil, err
+	}
+
+	// ログイン時の登録ではコンテキストにユーザーがいないため、操作したユーザーを指定する
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthMFAEnable,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+		Actor:      user,
+	})
+
+	return codes, nil
+}
+
+// Verify ワンタイムコードまたはリカバリーコードを検証(どちらも一度だけ使用できる)
+func (u *mfaUseCase) Verify(ctx context.Context, user *entity.User, code string) error {
+	mfa, err := u.findEnabled(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil {
+		return fmt.Errorf("two-factor authentication is not enabled")
+	}
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return fmt.Errorf("verification code is required")
+	}
+
+	if !isTOTPCode(code) {
+		used, err := u.mfaRepo.UseRecoveryCode(ctx, user.ID, auth.HashToken(auth.NormalizeRecoveryCode(code)))
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	step, err := u.validateTOTP(mfa, code)
+	if err != nil {
+		return err
+	}
+	used, err := u.mfaRepo.UseStep(ctx, user.ID, step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		// 同じコード(またはそれより古いコード)は再利用できない
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// Disable コードを検証して二要素認証を無効にする(ポリシーで必須のロールは無効にできない)
+func (u *mfaUseCase) Disable(ctx context.Context, user *entity.User, code string) error {
+	required, err := u.IsRequired(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return fmt.Errorf("two-factor authentication is required for role %s", user.Role)
+	}
+
+	if err := u.Verify(ctx, user, code); err != nil {
+		return err
+	}
+
+	if err := u.mfaRepo.Delete(ctx, user.ID); err != nil {
+		return err
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthMFADisable,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+		Actor:      user,
+	})
+
+	return nil
+}
+
+// RegenerateRecoveryCodes コードを検証してリカバリーコードを再発行(以前のコードは使えなくなる)
+func (u *mfaUseCase) RegenerateRecoveryCodes(ctx context.Context, user *entity.User, code string) ([]string, error) {
+	if err := u.Verify(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.mfaRepo.ReplaceRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Reset 管理者がユーザーの二要素認証を解除
+func (u *mfaUseCase) Reset(ctx context.Context, userID int64) error {
+	if err := u.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthMFAReset,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   userID,
+	})
+
+	return nil
+}
+
+// IsEnabled ユーザーの二要素認証が有効かどうかを取得
+func (u *mfaUseCase) IsEnabled(ctx context.Context, userID int64) (bool, error) {
+	mfa, err := u.findEnabled(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return mfa != nil, nil
+}
+
+// IsRequired ロールで二要素認証が必須かどうかを取得
+func (u *mfaUseCase) IsRequired(ctx context.Context, role entity.UserRole) (bool, error) {
+	return u.policyRepo.IsRequired(ctx, role)
+}
+
+// ListPolicies 全ロールのポリシーを取得(未設定のロールは任意として返す)
+func (u *mfaUseCase) ListPolicies(ctx context.Context) ([]*entity.MFAPolicy, error) {
+	saved, err := u.policyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byRole := make(map[entity.UserRole]*entity.MFAPolicy, len(saved))
+	for _, policy := range saved {
+		byRole[policy.Role] = policy
+	}
+
+	roles := []entity.UserRole{entity.RoleAdmin, entity.RoleEditor, entity.RoleContributor, entity.RoleViewer}
+	policies := make([]*entity.MFAPolicy, len(roles))
+	for i, role := range roles {
+		if policy, ok := byRole[role]; ok {
+			policies[i] = policy
+		} else {
+			policies[i] = &entity.MFAPolicy{Role: role}
+		}
+	}
+
+	return policies, nil
+}
+
+// SetPolicy ロールのポリシーを変更
+// 既にログインしているユーザーには、次回のログイン(またはトークンのリフレッシュ)から適用される
+func (u *mfaUseCase) SetPolicy(ctx context.Context, req *SetMFAPolicyRequest) (*entity.MFAPolicy, error) {
+	if !req.Role.IsValid() {
+		return nil, fmt.Errorf("invalid role: %s", req.Role)
+	}
+
+	policy := &entity.MFAPolicy{Role: req.Role, Required: req.Required, UpdatedAt: time.Now()}
+	if err := u.policyRepo.Save(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	// ポリシーはロール単位のためIDを持たない
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthMFAPolicy,
+		TargetType: entity.AuditTargetMFAPolicy,
+		After:      map[string]any{"role": policy.Role, "required": policy.Required},
+	})
+
+	return policy, nil
+}
+
+// findEnabled 有効な二要素認証設定を取得(未登録・登録途中の場合はnil)
+func (u *mfaUseCase) findEnabled(ctx context.Context, userID int64) (*entity.UserMFA, error) {
+	mfa, err := u.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !mfa.IsEnabled() {
+		return nil, nil
+	}
+	return mfa, nil
+}
+
+// validateTOTP ワンタイムコードを検証し、一致したタイムステップを返す
+func (u *mfaUseCase) validateTOTP(mfa *entity.UserMFA, code string) (int64, error) {
+	secret, err := u.secretBox.Decrypt(mfa.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	step, ok := auth.ValidateTOTP(secret, code, time.Now())
+	if !ok || step <= mfa.LastUsedStep {
+		return 0, ErrInvalidMFACode
+	}
+	return step, nil
+}
+
+// isTOTPCode ワンタイムコードの形式(6桁の数字)かどうかを判定
+func isTOTPCode(code string) bool {
+	if len(code) != auth.TOTPDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRecoveryCodes リカバリーコードと保存用のハッシュを生成
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, recoveryCodeCount)
+	hashes = make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := auth.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = code
+		hashes[i] = auth.HashToken(code)
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,340 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// totpCode 現在時刻からoffsetステップずらしたワンタイムコードを生成
+func totpCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+offset)
+	require.NoError(t, err)
+	return code
+}
+
+func setupMFAUseCase(t *testing.T) (usecase.MFAUseCase, usecase.AuthUseCase, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	passwordHasher := auth.NewPasswordHasher()
+
+	hash, err := passwordHasher.Hash("password123")
+	require.NoError(t, err)
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: hash,
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), user))
+
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	mfaUseCase := newTestMFAUseCase(t, db)
+	authUseCase := usecase.NewAuthUseCase(userRepo, persistence.NewTokenRepository(db), persistence.NewRefreshTokenRepository(db), persistence.NewSessionRepository(db), jwtManager, passwordHasher, usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), mfaUseCase, newTestLoginProtection(t, db), newTestAudit(db), 15*time.Minute)
+
+	return mfaUseCase, authUseCase, user, cleanup
+}
+
+// enableMFA ユーザーの二要素認証を有効にし、シークレットとリカバリーコードを返す
+func enableMFA(t *testing.T, mfaUseCase usecase.MFAUseCase, user *entity.User) (string, []string) {
+	t.Helper()
+
+	ctx := context.Background()
+	setup, err := mfaUseCase.Setup(ctx, user)
+	require.NoError(t, err)
+
+	codes, err := mfaUseCase.Confirm(ctx, user, totpCode(t, setup.Secret, -1))
+	require.NoError(t, err)
+	return setup.Secret, codes
+}
+
+func TestMFAUseCase_SetupAndConfirm(t *testing.T) {
+	mfaUseCase, _, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	setup, err := mfaUseCase.Setup(ctx, user)
+	require.NoError(t, err)
+	assert.Len(t, setup.Secret, 32)
+	assert.Contains(t, setup.OTPAuthURI, "otpauth://totp/Test%20Blog:editor@example.com?")
+	assert.Contains(t, setup.OTPAuthURI, "secret="+setup.Secret)
+
+	// 確認するまでは有効にならない
+	enabled, err := mfaUseCase.IsEnabled(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	_, err = mfaUseCase.Confirm(ctx, user, "000000")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid verification code")
+
+	codes, err := mfaUseCase.Confirm(ctx, user, totpCode(t, setup.Secret, 0))
+	require.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	status, err := mfaUseCase.Status(ctx, user)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, 10, status.RemainingRecoveryCodes)
+
+	// 有効な間はシークレットを再発行できない
+	_, err = mfaUseCase.Setup(ctx, user)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already enabled")
+}
+
+func TestMFAUseCase_Verify(t *testing.T) {
+	mfaUseCase, _, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	secret, codes := enableMFA(t, mfaUseCase, user)
+
+	code := totpCode(t, secret, 0)
+	require.NoError(t, mfaUseCase.Verify(ctx, user, code))
+
+	// 同じコードは再利用できない
+	err := mfaUseCase.Verify(ctx, user, code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid verification code")
+
+	// リカバリーコードは一度だけ使用できる(大文字・区切りなしでも可)
+	require.NoError(t, mfaUseCase.Verify(ctx, user, strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))))
+	assert.Error(t, mfaUseCase.Verify(ctx, user, codes[0]))
+
+	status, err := mfaUseCase.Status(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, 9, status.RemainingRecoveryCodes)
+
+	assert.Error(t, mfaUseCase.Verify(ctx, user, "aaaa-bbbb-cccc-dddd"))
+}
+
+func TestMFAUseCase_RegenerateAndDisable(t *testing.T) {
+	mfaUseCase, _, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	secret, oldCodes := enableMFA(t, mfaUseCase, user)
+
+	newCodes, err := mfaUseCase.RegenerateRecoveryCodes(ctx, user, totpCode(t, secret, 0))
+	require.NoError(t, err)
+	assert.Len(t, newCodes, 10)
+	assert.Error(t, mfaUseCase.Verify(ctx, user, oldCodes[0]))
+
+	// ポリシーで必須のロールは無効にできない
+	_, err = mfaUseCase.SetPolicy(ctx, &usecase.SetMFAPolicyRequest{Role: entity.RoleEditor, Required: true})
+	require.NoError(t, err)
+	err = mfaUseCase.Disable(ctx, user, newCodes[0])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is required for role")
+
+	_, err = mfaUseCase.SetPolicy(ctx, &usecase.SetMFAPolicyRequest{Role: entity.RoleEditor, Required: false})
+	require.NoError(t, err)
+	require.NoError(t, mfaUseCase.Disable(ctx, user, newCodes[0]))
+
+	enabled, err := mfaUseCase.IsEnabled(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+}
+
+func TestMFAUseCase_Policies(t *testing.T) {
+	mfaUseCase, _, _, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := mfaUseCase.SetPolicy(ctx, &usecase.SetMFAPolicyRequest{Role: entity.RoleAdmin, Required: true})
+	require.NoError(t, err)
+
+	policies, err := mfaUseCase.ListPolicies(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 4)
+	assert.Equal(t, entity.RoleAdmin, policies[0].Role)
+	assert.True(t, policies[0].Required)
+	assert.Equal(t, entity.RoleEditor, policies[1].Role)
+	assert.False(t, policies[1].Required)
+	assert.Equal(t, entity.RoleContributor, policies[2].Role)
+	assert.False(t, policies[2].Required)
+
+	_, err = mfaUseCase.SetPolicy(ctx, &usecase.SetMFAPolicyRequest{Role: "owner", Required: true})
+	assert.Error(t, err)
+}
+
+func TestAuthUseCase_LoginWithMFA(t *testing.T) {
+	mfaUseCase, authUseCase, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	secret, codes := enableMFA(t, mfaUseCase, user)
+
+	// 1段階目ではトークンを発行しない
+	challenge, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+	assert.True(t, challenge.MFARequired)
+	assert.NotEmpty(t, challenge.MFAToken)
+	assert.Empty(t, challenge.AccessToken)
+	assert.Empty(t, challenge.RefreshToken)
+
+	// MFATokenはアクセストークンとして使用できない
+	_, err = authUseCase.ValidateToken(ctx, challenge.MFAToken)
+	assert.Error(t, err)
+	_, err = authUseCase.RefreshToken(ctx, challenge.MFAToken)
+	assert.Error(t, err)
+
+	_, err = authUseCase.VerifyMFA(ctx, challenge.MFAToken, "000000")
+	assert.Error(t, err)
+
+	response, err := authUseCase.VerifyMFA(ctx, challenge.MFAToken, totpCode(t, secret, 0))
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.AccessToken)
+	assert.NotEmpty(t, response.RefreshToken)
+	assert.Empty(t, response.User.PasswordHash)
+
+	// リカバリーコードでもログインできる
+	response, err = authUseCase.VerifyMFA(ctx, challenge.MFAToken, codes[1])
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.AccessToken)
+}
+
+func TestAuthUseCase_VerifyMFA_LockedUser(t *testing.T) {
+	mfaUseCase, authUseCase, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	secret, _ := enableMFA(t, mfaUseCase, user)
+
+	challenge, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+	require.True(t, challenge.MFARequired)
+
+	// MFATokenの取得後にロックされた場合、正しいコードでもトークンを発行しない
+	for i := 0; i < usecase.DefaultLoginMaxFailures; i++ {
+		_, err := authUseCase.Login(ctx, "editor", "wrong-password")
+		require.Error(t, err)
+	}
+
+	response, err := authUseCase.VerifyMFA(ctx, challenge.MFAToken, totpCode(t, secret, 0))
+	var lockedErr *usecase.LoginLockedError
+	require.True(t, errors.As(err, &lockedErr))
+	assert.Nil(t, response)
+}
+
+func TestAuthUseCase_LoginWithMFA_PendingDoesNotResetFailures(t *testing.T) {
+	mfaUseCase, authUseCase, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	secret, _ := enableMFA(t, mfaUseCase, user)
+
+	for i := 0; i < usecase.DefaultLoginMaxFailures-1; i++ {
+		_, err := authUseCase.Login(ctx, "editor", "wrong-password")
+		require.Error(t, err)
+	}
+
+	// パスワードが正しくても、トークンを発行するまでは失敗回数を数え直さない
+	challenge, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+	require.True(t, challenge.MFARequired)
+
+	_, err = authUseCase.Login(ctx, "editor", "wrong-password")
+	require.Error(t, err)
+	_, err = authUseCase.VerifyMFA(ctx, challenge.MFAToken, totpCode(t, secret, 0))
+	var lockedErr *usecase.LoginLockedError
+	require.True(t, errors.As(err, &lockedErr))
+}
+
+func TestAuthUseCase_VerifyMFA_TooManyInvalidCodes(t *testing.T) {
+	mfaUseCase, authUseCase, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	secret, _ := enableMFA(t, mfaUseCase, user)
+
+	challenge, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := authUseCase.VerifyMFA(ctx, challenge.MFAToken, "000000")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid verification code")
+	}
+
+	// 誤ったコードが続いたMFATokenは正しいコードでも使えない
+	_, err = authUseCase.VerifyMFA(ctx, challenge.MFAToken, totpCode(t, secret, 0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid mfa token")
+
+	// ログインからやり直せば認証できる
+	challenge, err = authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+	response, err := authUseCase.VerifyMFA(ctx, challenge.MFAToken, totpCode(t, secret, 0))
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.AccessToken)
+}
+
+func TestAuthUseCase_LoginWithRequiredEnrollment(t *testing.T) {
+	mfaUseCase, authUseCase, user, cleanup := setupMFAUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// ポリシー適用前に発行したリフレッシュトークン
+	before, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+	require.NotEmpty(t, before.RefreshToken)
+
+	_, err = mfaUseCase.SetPolicy(ctx, &usecase.SetMFAPolicyRequest{Role: entity.RoleEditor, Required: true})
+	require.NoError(t, err)
+
+	// 未登録のままではリフレッシュできない
+	_, err = authUseCase.RefreshToken(ctx, before.RefreshToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "two-factor authentication is required")
+
+	challenge, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+	assert.True(t, challenge.MFAEnrollmentRequired)
+	assert.Empty(t, challenge.AccessToken)
+
+	// 登録用のトークンでコードの検証はできない
+	_, err = authUseCase.VerifyMFA(ctx, challenge.MFAToken, "000000")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid mfa token")
+
+	setup, err := authUseCase.BeginMFAEnrollment(ctx, challenge.MFAToken)
+	require.NoError(t, err)
+
+	enrollment, err := authUseCase.CompleteMFAEnrollment(ctx, challenge.MFAToken, totpCode(t, setup.Secret, 0))
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.AccessToken)
+	assert.Len(t, enrollment.RecoveryCodes, 10)
+
+	_, err = authUseCase.RefreshToken(ctx, enrollment.RefreshToken)
+	assert.NoError(t, err)
+
+	enabled, err := mfaUseCase.IsEnabled(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"my-blog-engine/internal/infrastructure/auth"
+)
+
+const (
+	// DefaultPasswordMinLength パスワードの既定の最小文字数
+	DefaultPasswordMinLength = 8
+	// maxPasswordBytes パスワードの最大バイト数(bcryptは72バイトを超える部分を無視するため)
+	maxPasswordBytes = 72
+	// minPersonalInfoLength パスワードに含めてはいけない個人情報とみなす最小の文字数
+	// (短いユーザー名・メールアドレスのローカル部で、無関係なパスワードまで拒否しないため)
+	minPersonalInfoLength = 3
+)
+
+// PasswordViolationCode パスワードポリシー違反の種類
+type PasswordViolationCode string
+
+const (
+	PasswordTooShort         PasswordViolationCode = "too_short"
+	PasswordTooLong          PasswordViolationCode = "too_long"
+	PasswordContainsUsername PasswordViolationCode = "contains_username"
+	PasswordContainsEmail    PasswordViolationCode = "contains_email"
+	PasswordBreached         PasswordViolationCode = "breached"
+)
+
+// PasswordViolation パスワードポリシー違反
+type PasswordViolation struct {
+	Code    PasswordViolationCode `json:"code"`
+	Message string                `json:"message"`
+}
+
+// PasswordPolicyError パスワードがポリシーを満たさない場合のエラー(違反を全て含む)
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+// Error エラーメッセージを返す
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// PasswordPolicyConfig パスワードポリシーの設定
+type PasswordPolicyConfig struct {
+	// MinLength 最小文字数(0以下の場合はDefaultPasswordMinLength)
+	MinLength int
+	// MaxBytes 最大バイト数(0以下または72を超える場合は72)
+	MaxBytes int
+}
+
+// PasswordPolicy パスワードポリシーのインターフェース
+type PasswordPolicy interface {
+	// Validate パスワードがポリシーを満たすか検証(満たさない場合はPasswordPolicyError)
+	Validate(ctx context.Context, password, username, email string) error
+}
+
+// passwordPolicy PasswordPolicyの実装
+type passwordPolicy struct {
+	config   PasswordPolicyConfig
+	breached auth.BreachedPasswordChecker
+}
+
+// NewPasswordPolicy 新しいPasswordPolicyを作成(breachedがnilの場合は漏洩パスワードを確認しない)
+func NewPasswordPolicy(config PasswordPolicyConfig, breached auth.BreachedPasswordChecker) PasswordPolicy {
+	if config.MinLength <= 0 {
+		config.MinLength = DefaultPasswordMinLength
+	}
+	if config.MaxBytes <= 0 || config.MaxBytes > maxPasswordBytes {
+		config.MaxBytes = maxPasswordBytes
+	}
+
+	return &passwordPolicy{
+		config:   config,
+		breached: breached,
+	}
+}
+
+// Validate パスワードがポリシーを満たすか検証
+// 長さ・ユーザー名・メールアドレスの違反は全てまとめて返し、漏洩パスワードの確認はそれらを満たす場合のみ行う
+func (p *passwordPolicy) Validate(ctx context.Context, password, username, email string) error {
+	var violations []PasswordViolation
+
+	if utf8.RuneCountInString(password) < p.config.MinLength {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordTooShort,
+			Message: fmt.Sprintf("password must be at least %d characters", p.config.MinLength),
+		})
+	}
+	if len(password) > p.config.MaxBytes {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordTooLong,
+			Message: fmt.Sprintf("password is too long: maximum is %d bytes", p.config.MaxBytes),
+		})
+	}
+
+	lower := strings.ToLower(password)
+	if containsPersonalInfo(lower, username) {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordContainsUsername,
+			Message: "password must not contain the username",
+		})
+	}
+	localPart, _, _ := strings.Cut(email, "@")
+	if containsPersonalInfo(lower, email) || containsPersonalInfo(lower, localPart) {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordContainsEmail,
+			Message: "password must not contain the email address",
+		})
+	}
+
+	if len(violations) == 0 && p.breached != nil {
+		breached, err := p.breached.IsBreached(password)
+		if err != nil {
+			// 一覧を読めない場合もパスワードの設定は止めない
+			slog.ErrorContext(ctx, "Failed to check breached password", "error", err)
+		} else if breached {
+			violations = append(violations, PasswordViolation{
+				Code:    PasswordBreached,
+				Message: "password has appeared in a data breach: choose a different password",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// containsPersonalInfo 小文字にしたパスワードに個人情報(大文字・小文字を区別しない)が含まれるかどうかを判定
+func containsPersonalInfo(lowerPassword, info string) bool {
+	info = strings.ToLower(strings.TrimSpace(info))
+	return utf8.RuneCountInString(info) >= minPersonalInfoLength && strings.Contains(lowerPassword, info)
+}
@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"my-blog-engine/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBreachedPasswords 指定したパスワードを漏洩パスワードとして扱うテスト用のBreachedPasswordChecker
+type fakeBreachedPasswords struct {
+	passwords []string
+	err       error
+}
+
+func (f *fakeBreachedPasswords) IsBreached(password string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	for _, p := range f.passwords {
+		if p == password {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// violationCodes パスワードポリシー違反の種類を取り出す
+func violationCodes(t *testing.T, err error) []usecase.PasswordViolationCode {
+	t.Helper()
+
+	var policyErr *usecase.PasswordPolicyError
+	require.True(t, errors.As(err, &policyErr))
+
+	codes := make([]usecase.PasswordViolationCode, len(policyErr.Violations))
+	for i, violation := range policyErr.Violations {
+		codes[i] = violation.Code
+	}
+	return codes
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{MinLength: 10}, &fakeBreachedPasswords{passwords: []string{"password1234"}})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		password string
+		want     []usecase.PasswordViolationCode
+	}{
+		{"valid", "correct-horse-battery", nil},
+		{"too short", "short-pw", []usecase.PasswordViolationCode{usecase.PasswordTooShort}},
+		{"multibyte counts characters", "パスワードは十文字以上", nil},
+		{"too long", strings.Repeat("a", 73), []usecase.PasswordViolationCode{usecase.PasswordTooLong}},
+		{"contains username", "my-Editor-password", []usecase.PasswordViolationCode{usecase.PasswordContainsUsername}},
+		{"contains email local part", "jane.doe-secret!", []usecase.PasswordViolationCode{usecase.PasswordContainsEmail}},
+		{"multiple violations", "editor", []usecase.PasswordViolationCode{usecase.PasswordTooShort, usecase.PasswordContainsUsername}},
+		{"breached", "password1234", []usecase.PasswordViolationCode{usecase.PasswordBreached}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(ctx, tt.password, "editor", "jane.doe@example.com")
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, violationCodes(t, err))
+		})
+	}
+}
+
+func TestPasswordPolicy_Defaults(t *testing.T) {
+	// 最大バイト数はbcryptの上限の72バイトを超えられない
+	policy := usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{MaxBytes: 100}, nil)
+	ctx := context.Background()
+
+	err := policy.Validate(ctx, "short", "editor", "editor@example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "password must be at least 8 characters")
+
+	err = policy.Validate(ctx, strings.Repeat("a", 73), "editor", "editor@example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum is 72 bytes")
+
+	// 短いユーザー名はパスワードに含まれていてもよい
+	assert.NoError(t, policy.Validate(ctx, "a-long-password", "al", "al@example.com"))
+}
+
+func TestPasswordPolicy_BreachedListError(t *testing.T) {
+	// 漏洩パスワードの一覧を読めない場合もパスワードの設定は止めない
+	policy := usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, &fakeBreachedPasswords{err: errors.New("disk error")})
+	assert.NoError(t, policy.Validate(context.Background(), "correct-horse-battery", "editor", "editor@example.com"))
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/mailer"
+)
+
+const (
+	// DefaultPasswordResetTTL 再設定トークンの既定の有効期間
+	DefaultPasswordResetTTL = 30 * time.Minute
+	// DefaultPasswordResetResponseTime 再設定の申請にかける既定の最小処理時間
+	DefaultPasswordResetResponseTime = time.Second
+)
+
+// PasswordResetUseCase パスワード再設定ユースケースのインターフェース
+type PasswordResetUseCase interface {
+	// Request 再設定トークンを発行してメールで送信
+	Request(ctx context.Context, req *ForgotPasswordRequest) error
+	// Reset 再設定トークンを使ってパスワードを変更
+	Reset(ctx context.Context, req *PasswordResetRequest) error
+}
+
+// ForgotPasswordRequest パスワード再設定の申請リクエスト
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// PasswordResetRequest パスワード再設定リクエスト
+type PasswordResetRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// PasswordResetConfig パスワード再設定の設定
+type PasswordResetConfig struct {
+	SiteURL   string
+	SiteTitle string
+	// TTL 再設定トークンの有効期間(0以下の場合はDefaultPasswordResetTTL)
+	TTL time.Duration
+	// ResponseTime 申請の最小処理時間(0以下の場合はDefaultPasswordResetResponseTime)
+	// 登録済みのメールアドレスかどうかを処理時間から推測されないよう、この時間まで待つ(メールは待たずに送信する)
+	ResponseTime time.Duration
+}
+
+// passwordResetUseCase PasswordResetUseCaseの実装
+type passwordResetUseCase struct {
+	resetRepo      repository.PasswordResetRepository
+	userRepo       repository.UserRepository
+	passwordHasher auth.PasswordHasher
+	passwordPolicy PasswordPolicy
+	mailer         mailer.Mailer
+	audit          AuditUseCase
+	config         PasswordResetConfig
+}
+
+// NewPasswordResetUseCase 新しいPasswordResetUseCaseを作成
+func NewPasswordResetUseCase(
+	resetRepo repository.PasswordResetRepository,
+	userRepo repository.UserRepository,
+	passwordHasher auth.PasswordHasher,
+	passwordPolicy PasswordPolicy,
+	mailer mailer.Mailer,
+	audit AuditUseCase,
+	config PasswordResetConfig,
+) PasswordResetUseCase {
+	if config.TTL <= 0 {
+		config.TTL = DefaultPasswordResetTTL
+	}
+	if config.ResponseTime <= 0 {
+		config.ResponseTime = DefaultPasswordResetResponseTime
+	}
+	config.SiteURL = strings.TrimRight(config.SiteURL, "/")
+
+	return &passwordResetUseCase{
+		resetRepo:      resetRepo,
+		userRepo:       userRepo,
+		passwordHasher: passwordHasher,
+		passwordPolicy: passwordPolicy,
+		mailer:         mailer,
+		audit:          audit,
+		config:         config,
+	}
+}
+
+// Request 有効なユーザーのメールアドレスであれば再設定トークンを発行してメールで送信
+// メールアドレスの登録有無を知られないよう、入力の形式エラー以外は常に成功として扱い、処理時間も揃える
+func (u *passwordResetUseCase) Request(ctx context.Context, req *ForgotPasswordRequest) error {
+	email := strings.TrimSpace(req.Email)
+	if err := validateUserEmail(email); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(u.config.ResponseTime)
+	defer waitUntil(ctx, deadline)
+
+	if err := u.request(ctx, email); err != nil {
+		slog.ErrorContext(ctx, "Failed to process password reset request", "error", err)
+	}
+
+	return nil
+}
+
+// request 再設定トークンを発行してメールで送信(該当するユーザーがいない場合は何もしない)
+// SMTPサーバーの応答時間で登録済みのメールアドレスかどうかを推測されないよう、メールはバックグラウンドで送信する
+func (u *passwordResetUseCase) request(ctx context.Context, email string) error {
+	user, err := u.userRepo.FindByEmail(ctx, email)
+	if err != nil || !user.IsActive() {
+		return nil
+	}
+
+	// 以前に発行したトークンは使えなくする
+	if err := u.resetRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+
+	token, tokenHash, err := auth.GenerateToken()
+	if err != nil {
+		return err
+	}
+
+	resetToken := &entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(u.config.TTL).Truncate(time.Second),
+	}
+	if err := u.resetRepo.Create(ctx, resetToken); err != nil {
+		return err
+	}
+
+	sendMailInBackground(ctx, u.mailer, u.resetMessage(user, resetToken, token), "password_reset")
+
+	return nil
+}
+
+// resetMessage パスワード再設定メールを作成
+func (u *passwordResetUseCase) resetMessage(user *entity.User, resetToken *entity.PasswordResetToken, token string) *mailer.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "%sさん\n\n%sのパスワード再設定が申請されました。\n\n", user.Username, u.config.SiteTitle)
+	fmt.Fprintf(&body, "%s までに、以下の再設定トークンを使って新しいパスワードを設定してください。\n\n",
+		resetToken.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&body, "再設定トークン: %s\n\n", token)
+	fmt.Fprintf(&body, "設定方法: POST %s/api/auth/password/reset\n", u.config.SiteURL)
+	body.WriteString("(JSON: {\"token\": \"再設定トークン\", \"password\": \"新しいパスワード\"})\n\n")
+	body.WriteString("再設定トークンは一度だけ使用できます。心当たりがない場合は、このメールを破棄してください(パスワードは変更されません)。\n")
+
+	return &mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("[%s] パスワード再設定のご案内", u.config.SiteTitle),
+		Body:    body.String(),
+	}
+}
+
+// Reset 再設定トークンを使ってパスワードを変更
+// 変更前に発行されたアクセストークン・リフレッシュトークンは全て無効になり、ユーザーのセッションも全て失効する
+func (u *passwordResetUseCase) Reset(ctx context.Context, req *PasswordResetRequest) error {
+	if req.Token == "" {
+		return fmt.Errorf("reset token is required")
+	}
+
+	resetToken, err := u.resetRepo.FindByTokenHash(ctx, auth.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if resetToken.IsUsed() {
+		return fmt.Errorf("password reset token has already been used")
+	}
+	if resetToken.IsExpired(time.Now()) {
+		return fmt.Errorf("password reset token has expired")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, resetToken.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.IsActive() {
+		return fmt.Errorf("user account is inactive")
+	}
+	if err := u.passwordPolicy.Validate(ctx, req.Password, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	passwordHash, err := u.passwordHasher.Hash(req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.ChangePassword(passwordHash, time.Now())
+
+	if err := u.resetRepo.Consume(ctx, resetToken, user); err != nil {
+		return err
+	}
+
+	// 再設定トークンを持つユーザー自身の操作として記録する
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthPasswordReset,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+		Actor:      user,
+	})
+
+	return nil
+}
+
+// sendMailInBackground メールをバックグラウンドで送信(送信の完了を待たずに戻る)
+// リクエストの終了で送信が中断されないよう、コンテキストのキャンセルは引き継がない。失敗はエラーログに残す
+func sendMailInBackground(ctx context.Context, m mailer.Mailer, msg *mailer.Message, kind string) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := m.Send(ctx, msg); err != nil {
+			slog.ErrorContext(ctx, "Failed to send mail", "kind", kind, "error", err)
+		}
+	}()
+}
+
+// waitUntil 指定日時まで待機(コンテキストがキャンセルされた場合は待たない)
+func waitUntil(ctx context.Context, deadline time.Time) {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,186 @@
+package usecase_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetTokenPattern 再設定メールの本文から再設定トークンを取り出す
+var resetTokenPattern = regexp.MustCompile(`再設定トークン: (\S+)`)
+
+// resetToken n件目の再設定メールが送信されるまで待って再設定トークンを取得
+func (m *fakeMailer) resetToken(t *testing.T, n int) string {
+	t.Helper()
+
+	messages := m.sent(t, n)
+	match := resetTokenPattern.FindStringSubmatch(messages[n-1].Body)
+	require.Len(t, match, 2)
+	return match[1]
+}
+
+func setupPasswordResetUseCase(t *testing.T) (usecase.PasswordResetUseCase, usecase.AuthUseCase, *fakeMailer, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	passwordHasher := auth.NewPasswordHasher()
+	m := &fakeMailer{}
+
+	hash, err := passwordHasher.Hash("password123")
+	require.NoError(t, err)
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: hash,
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), user))
+
+	resetUseCase := usecase.NewPasswordResetUseCase(persistence.NewPasswordResetRepository(db), userRepo, passwordHasher, usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), m, newTestAudit(db), usecase.PasswordResetConfig{
+		SiteURL:      "https://blog.example.com/",
+		SiteTitle:    "Test Blog",
+		ResponseTime: 10 * time.Millisecond,
+	})
+
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+	authUseCase := usecase.NewAuthUseCase(userRepo, persistence.NewTokenRepository(db), persistence.NewRefreshTokenRepository(db), persistence.NewSessionRepository(db), jwtManager, passwordHasher, usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), newTestMFAUseCase(t, db), newTestLoginProtection(t, db), newTestAudit(db), 15*time.Minute)
+
+	return resetUseCase, authUseCase, m, user, cleanup
+}
+
+func TestPasswordResetUseCase_RequestAndReset(t *testing.T) {
+	resetUseCase, authUseCase, m, user, cleanup := setupPasswordResetUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	login, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+
+	require.NoError(t, resetUseCase.Request(ctx, &usecase.ForgotPasswordRequest{Email: " editor@example.com "}))
+	messages := m.sent(t, 1)
+	require.Len(t, messages, 1)
+	assert.Equal(t, user.Email, messages[0].To)
+	assert.Contains(t, messages[0].Body, "https://blog.example.com/api/auth/password/reset")
+	token := m.resetToken(t, 1)
+
+	// JWTの発行日時は秒単位のため、ログインとパスワード変更の時刻をずらす
+	time.Sleep(time.Second)
+
+	require.NoError(t, resetUseCase.Reset(ctx, &usecase.PasswordResetRequest{Token: token, Password: "new-password"}))
+
+	_, err = authUseCase.Login(ctx, "editor", "password123")
+	assert.Error(t, err)
+	relogin, err := authUseCase.Login(ctx, "editor", "new-password")
+	require.NoError(t, err)
+
+	// 変更前に発行したトークンは使えない
+	_, err = authUseCase.RefreshToken(ctx, login.RefreshToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoked")
+	_, err = authUseCase.ValidateToken(ctx, login.AccessToken)
+	assert.Error(t, err)
+
+	// 変更前のログインのセッションは失効し、一覧には再設定後のログインのみ残る
+	sessions, err := authUseCase.ListSessions(ctx, relogin.AccessToken)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.True(t, sessions[0].Current)
+
+	_, err = authUseCase.RefreshToken(ctx, relogin.RefreshToken)
+	assert.NoError(t, err)
+
+	// 再設定トークンは一度だけ使用できる
+	err = resetUseCase.Reset(ctx, &usecase.PasswordResetRequest{Token: token, Password: "another-password"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already been used")
+}
+
+func TestPasswordResetUseCase_Request_UnknownEmail(t *testing.T) {
+	resetUseCase, _, m, _, cleanup := setupPasswordResetUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// 登録されていないメールアドレスでも成功として扱い、メールは送らない
+	start := time.Now()
+	require.NoError(t, resetUseCase.Request(ctx, &usecase.ForgotPasswordRequest{Email: "unknown@example.com"}))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	assert.Empty(t, m.messages)
+
+	err := resetUseCase.Request(ctx, &usecase.ForgotPasswordRequest{Email: "not an address"})
+	assert.Error(t, err)
+}
+
+func TestPasswordResetUseCase_Request_SlowMailer(t *testing.T) {
+	resetUseCase, _, m, user, cleanup := setupPasswordResetUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	m.delay = 500 * time.Millisecond
+
+	// メールの送信を待たないため、登録済みのメールアドレスでも処理時間はResponseTime程度
+	start := time.Now()
+	require.NoError(t, resetUseCase.Request(ctx, &usecase.ForgotPasswordRequest{Email: user.Email}))
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 10*time.Millisecond)
+	assert.Less(t, elapsed, m.delay)
+
+	// メールはリクエストの後に送信される
+	messages := m.sent(t, 1)
+	assert.Equal(t, user.Email, messages[0].To)
+}
+
+func TestPasswordResetUseCase_Request_ReplacesPreviousToken(t *testing.T) {
+	resetUseCase, _, m, _, cleanup := setupPasswordResetUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	require.NoError(t, resetUseCase.Request(ctx, &usecase.ForgotPasswordRequest{Email: "editor@example.com"}))
+	first := m.resetToken(t, 1)
+	require.NoError(t, resetUseCase.Request(ctx, &usecase.ForgotPasswordRequest{Email: "editor@example.com"}))
+	second := m.resetToken(t, 2)
+
+	err := resetUseCase.Reset(ctx, &usecase.PasswordResetRequest{Token: first, Password: "new-password"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	require.NoError(t, resetUseCase.Reset(ctx, &usecase.PasswordResetRequest{Token: second, Password: "new-password"}))
+}
+
+func TestPasswordResetUseCase_Reset_Invalid(t *testing.T) {
+	resetUseCase, _, m, _, cleanup := setupPasswordResetUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	require.NoError(t, resetUseCase.Request(ctx, &usecase.ForgotPasswordRequest{Email: "editor@example.com"}))
+	token := m.resetToken(t, 1)
+
+	err := resetUseCase.Reset(ctx, &usecase.PasswordResetRequest{Password: "new-password"})
+	assert.Error(t, err)
+
+	err = resetUseCase.Reset(ctx, &usecase.PasswordResetRequest{Token: token, Password: "short"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "password must be")
+
+	err = resetUseCase.Reset(ctx, &usecase.PasswordResetRequest{Token: "unknown", Password: "new-password"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+)
+
+const (
+	// PersonalAccessTokenPrefix パーソナルアクセストークンの接頭辞(JWTと見分けるため)
+	PersonalAccessTokenPrefix = "pat_"
+	// personalAccessTokenDisplayLength 一覧に表示するトークンの先頭の文字数(接頭辞を含む)
+	personalAccessTokenDisplayLength = 12
+	// maxPersonalAccessTokenNameLength トークン名の最大長
+	maxPersonalAccessTokenNameLength = 100
+)
+
+// PersonalAccessTokenUseCase パーソナルアクセストークンユースケースのインターフェース
+type PersonalAccessTokenUseCase interface {
+	// Create トークンを発行(トークン自体はこのときのみ返す)
+	Create(ctx context.Context, user *entity.User, req *CreatePersonalAccessTokenRequest) (*CreatePersonalAccessTokenResponse, error)
+	// List ユーザーの失効していないトークン一覧を取得
+	List(ctx context.Context, userID int64) ([]*entity.PersonalAccessToken, error)
+	// Revoke ユーザーのトークンを失効させる
+	Revoke(ctx context.Context, userID, id int64) error
+	// Authenticate トークンを検証し、指定されたスコープを持っていればトークンのユーザーを返す
+	Authenticate(ctx context.Context, token string, scope entity.TokenScope) (*entity.User, error)
+}
+
+// CreatePersonalAccessTokenRequest トークン発行リクエスト
+type CreatePersonalAccessTokenRequest struct {
+	Name   string              `json:"name"`
+	Scopes []entity.TokenScope `json:"scopes"`
+	// ExpiresAt 有効期限(省略した場合は無期限)
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// CreatePersonalAccessTokenResponse トークン発行レスポンス
+type CreatePersonalAccessTokenResponse struct {
+	Token       string
+	AccessToken *entity.PersonalAccessToken
+}
+
+// personalAccessTokenUseCase PersonalAccessTokenUseCaseの実装
+type personalAccessTokenUseCase struct {
+	tokenRepo repository.PersonalAccessTokenRepository
+	userRepo  repository.UserRepository
+	audit     AuditUseCase
+}
+
+// NewPersonalAccessTokenUseCase 新しいPersonalAccessTokenUseCaseを作成
+func NewPersonalAccessTokenUseCase(tokenRepo repository.PersonalAccessTokenRepository, userRepo repository.UserRepository, audit AuditUseCase) PersonalAccessTokenUseCase {
+	return &personalAccessTokenUseCase{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		audit:     audit,
+	}
+}
+
+// IsPersonalAccessToken Bearerトークンがパーソナルアクセストークンかどうかを判定
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+// Create トークンを発行
+// データベースにはハッシュと表示用の先頭の文字列のみを保存する
+func (u *personalAccessTokenUseCase) Create(ctx context.Context, user *entity.User, req *CreatePersonalAccessTokenRequest) (*CreatePersonalAccessTokenResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("token name is required")
+	}
+	if utf8.RuneCountInString(name) > maxPersonalAccessTokenNameLength {
+		return nil, fmt.Errorf("token name must be at most %d characters", maxPersonalAccessTokenNameLength)
+	}
+
+	scopes, err := normalizeTokenScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.Truncate(time.Second)
+		if !t.After(time.Now()) {
+			return nil, fmt.Errorf("invalid expiresAt: must be in the future")
+		}
+		expiresAt = &t
+	}
+
+	secret, _, err := auth.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	token := PersonalAccessTokenPrefix + secret
+
+	accessToken := &entity.PersonalAccessToken{
+		UserID:      user.ID,
+		Name:        name,
+		TokenHash:   auth.HashToken(token),
+		TokenPrefix: token[:personalAccessTokenDisplayLength],
+		Scopes:      scopes,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   time.Now().Truncate(time.Second),
+	}
+	if err := u.tokenRepo.Create(ctx, accessToken); err != nil {
+		return nil, err
+	}
+
+	// トークンの値・ハッシュは記録しない
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthTokenCreate,
+		TargetType: entity.AuditTargetPersonalAccessToken,
+		TargetID:   accessToken.ID,
+		After:      map[string]any{"name": accessToken.Name, "scopes": accessToken.Scopes, "expiresAt": accessToken.ExpiresAt},
+		Actor:      user,
+	})
+
+	return &CreatePersonalAccessTokenResponse{Token: token, AccessToken: accessToken}, nil
+}
+
+// normalizeTokenScopes スコープを検証し、重複を取り除く
+func normalizeTokenScopes(scopes []entity.TokenScope) ([]entity.TokenScope, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required")
+	}
+
+	seen := make(map[entity.TokenScope]bool, len(scopes))
+	normalized := make([]entity.TokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, fmt.Errorf("invalid scope: %s", scope)
+		}
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		normalized = append(normalized, scope)
+	}
+
+	return normalized, nil
+}
+
+// List ユーザーの失効していないトークン一覧を取得
+func (u *personalAccessTokenUseCase) List(ctx context.Context, userID int64) ([]*entity.PersonalAccessToken, error) {
+	return u.tokenRepo.ListByUserID(ctx, userID)
+}
+
+// Revoke ユーザーのトークンを失効させる
+func (u *personalAccessTokenUseCase) Revoke(ctx context.Context, userID, id int64) error {
+	revoked, err := u.tokenRepo.Revoke(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return fmt.Errorf("personal access token not found")
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditAuthTokenRevoke,
+		TargetType: entity.AuditTargetPersonalAccessToken,
+		TargetID:   id,
+	})
+
+	return nil
+}
+
+// Authenticate トークンを検証し、指定されたスコープを持っていればトークンのユーザーを返す
+// ユーザーのロールによる制限はトークンにも適用されるため、ロールの確認は呼び出し側で行う
+func (u *personalAccessTokenUseCase) Authenticate(ctx context.Context, token string, scope entity.TokenScope) (*entity.User, error) {
+	if !IsPersonalAccessToken(token) {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	accessToken, err := u.tokenRepo.FindByTokenHash(ctx, auth.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invalid token")
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if accessToken.IsRevoked() {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+	if accessToken.IsExpired(now) {
+		return nil, fmt.Errorf("token has expired")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, accessToken.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	if !user.IsActive() {
+		return nil, fmt.Errorf("user account is inactive")
+	}
+
+	if !accessToken.HasScope(scope) {
+		return nil, fmt.Errorf("insufficient scope: %s is required", scope)
+	}
+
+	if accessToken.LastUsedAt == nil || now.Sub(*accessToken.LastUsedAt) >= sessionTouchInterval {
+		if err := u.tokenRepo.Touch(ctx, accessToken.ID, now); err != nil {
+			slog.ErrorContext(ctx, "Failed to update personal access token", "error", err)
+		}
+	}
+
+	return user, nil
+}
@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPersonalAccessTokenUseCase(t *testing.T) (usecase.PersonalAccessTokenUseCase, repository.PersonalAccessTokenRepository, repository.UserRepository, *entity.User, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	tokenRepo := persistence.NewPersonalAccessTokenRepository(db)
+
+	user := &entity.User{
+		Username:     "editor",
+		Email:        "editor@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(context.Background(), user))
+
+	return usecase.NewPersonalAccessTokenUseCase(tokenRepo, userRepo, newTestAudit(db)), tokenRepo, userRepo, user, cleanup
+}
+
+func TestPersonalAccessTokenUseCase_CreateAndAuthenticate(t *testing.T) {
+	accessTokenUseCase, tokenRepo, _, user, cleanup := setupPersonalAccessTokenUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+	created, err := accessTokenUseCase.Create(ctx, user, &usecase.CreatePersonalAccessTokenRequest{
+		Name:      " deploy ",
+		Scopes:    []entity.TokenScope{entity.ScopePostsWrite, entity.ScopeMediaWrite, entity.ScopePostsWrite},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, usecase.PersonalAccessTokenPrefix))
+	assert.Equal(t, "deploy", created.AccessToken.Name)
+	assert.Equal(t, []entity.TokenScope{entity.ScopePostsWrite, entity.ScopeMediaWrite}, created.AccessToken.Scopes)
+	assert.True(t, strings.HasPrefix(created.Token, created.AccessToken.TokenPrefix))
+
+	// データベースにはトークン自体を保存しない
+	stored, err := tokenRepo.FindByTokenHash(ctx, auth.HashToken(created.Token))
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Token, stored.TokenHash)
+	assert.Nil(t, stored.LastUsedAt)
+
+	authenticated, err := accessTokenUseCase.Authenticate(ctx, created.Token, entity.ScopePostsWrite)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, authenticated.ID)
+
+	stored, err = tokenRepo.FindByTokenHash(ctx, auth.HashToken(created.Token))
+	require.NoError(t, err)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	// 許可していないスコープの操作はできない
+	_, err = accessTokenUseCase.Authenticate(ctx, created.Token, entity.ScopePostsRead)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient scope")
+
+	_, err = accessTokenUseCase.Authenticate(ctx, usecase.PersonalAccessTokenPrefix+"unknown", entity.ScopePostsWrite)
+	assert.Error(t, err)
+}
+
+func TestPersonalAccessTokenUseCase_Create_Invalid(t *testing.T) {
+	accessTokenUseCase, _, _, user, cleanup := setupPersonalAccessTokenUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		req  usecase.CreatePersonalAccessTokenRequest
+		err  string
+	}{
+		{name: "empty name", req: usecase.CreatePersonalAccessTokenRequest{Scopes: []entity.TokenScope{entity.ScopePostsRead}}, err: "token name is required"},
+		{name: "long name", req: usecase.CreatePersonalAccessTokenRequest{Name: strings.Repeat("a", 101), Scopes: []entity.TokenScope{entity.ScopePostsRead}}, err: "must be at most"},
+		{name: "no scopes", req: usecase.CreatePersonalAccessTokenRequest{Name: "deploy"}, err: "at least one scope is required"},
+		{name: "unknown scope", req: usecase.CreatePersonalAccessTokenRequest{Name: "deploy", Scopes: []entity.TokenScope{"users:write"}}, err: "invalid scope"},
+		{name: "past expiry", req: usecase.CreatePersonalAccessTokenRequest{Name: "deploy", Scopes: []entity.TokenScope{entity.ScopePostsRead}, ExpiresAt: &past}, err: "invalid expiresAt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := accessTokenUseCase.Create(ctx, user, &tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestPersonalAccessTokenUseCase_ListAndRevoke(t *testing.T) {
+	accessTokenUseCase, _, _, user, cleanup := setupPersonalAccessTokenUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	created, err := accessTokenUseCase.Create(ctx, user, &usecase.CreatePersonalAccessTokenRequest{
+		Name:   "deploy",
+		Scopes: []entity.TokenScope{entity.ScopePostsWrite},
+	})
+	require.NoError(t, err)
+
+	tokens, err := accessTokenUseCase.List(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, created.AccessToken.ID, tokens[0].ID)
+
+	// 他のユーザーのトークンは失効できない
+	err = accessTokenUseCase.Revoke(ctx, user.ID+1, created.AccessToken.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	require.NoError(t, accessTokenUseCase.Revoke(ctx, user.ID, created.AccessToken.ID))
+
+	_, err = accessTokenUseCase.Authenticate(ctx, created.Token, entity.ScopePostsWrite)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoked")
+
+	tokens, err = accessTokenUseCase.List(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestPersonalAccessTokenUseCase_Authenticate_ExpiredAndInactive(t *testing.T) {
+	accessTokenUseCase, tokenRepo, userRepo, user, cleanup := setupPersonalAccessTokenUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// 期限切れのトークンは使用できない
+	expiredAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	expired := usecase.PersonalAccessTokenPrefix + "expired"
+	require.NoError(t, tokenRepo.Create(ctx, &entity.PersonalAccessToken{
+		UserID:      user.ID,
+		Name:        "expired",
+		TokenHash:   auth.HashToken(expired),
+		TokenPrefix: expired[:12],
+		Scopes:      []entity.TokenScope{entity.ScopePostsWrite},
+		ExpiresAt:   &expiredAt,
+	}))
+	_, err := accessTokenUseCase.Authenticate(ctx, expired, entity.ScopePostsWrite)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expired")
+
+	// 無効化されたユーザーのトークンは使用できない
+	created, err := accessTokenUseCase.Create(ctx, user, &usecase.CreatePersonalAccessTokenRequest{
+		Name:   "deploy",
+		Scopes: []entity.TokenScope{entity.ScopePostsWrite},
+	})
+	require.NoError(t, err)
+	user.Status = entity.StatusInactive
+	require.NoError(t, userRepo.Update(ctx, user))
+
+	_, err = accessTokenUseCase.Authenticate(ctx, created.Token, entity.ScopePostsWrite)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inactive")
+}
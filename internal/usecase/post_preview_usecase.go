@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+)
+
+const (
+	// DefaultPreviewLinkTTL プレビューURLの有効期間の既定値
+	DefaultPreviewLinkTTL = 72 * time.Hour
+	// DefaultPreviewLinkMaxTTL プレビューURLに指定できる有効期間の上限の既定値
+	DefaultPreviewLinkMaxTTL = 30 * 24 * time.Hour
+)
+
+// PostPreviewUseCase アカウントを持たない相手に下書きを見せるプレビューURLのユースケースのインターフェース
+// 記事に対する権限(ActionPostPreview)はハンドラーで確認する
+type PostPreviewUseCase interface {
+	// CreateLink 記事の署名付きプレビューURLを発行
+	CreateLink(ctx context.Context, postID int64, req *CreatePreviewLinkRequest) (*PreviewLink, error)
+	// RevokeLinks 記事の発行済みのプレビューURLをすべて無効にする
+	RevokeLinks(ctx context.Context, postID int64) error
+	// Resolve プレビューURLのトークンを検証して記事とURLの有効期限を返す
+	Resolve(ctx context.Context, token string) (*entity.Post, time.Time, error)
+}
+
+// CreatePreviewLinkRequest プレビューURLの発行リクエスト
+type CreatePreviewLinkRequest struct {
+	// ExpiresAt 有効期限(省略した場合は既定の有効期間)
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// PreviewLink 発行したプレビューURL
+type PreviewLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// PreviewConfig プレビューURLの設定
+type PreviewConfig struct {
+	// SiteURL プレビューURLのベースURL
+	SiteURL string
+	// TTL 有効期限を省略した場合の有効期間
+	TTL time.Duration
+	// MaxTTL 指定できる有効期間の上限
+	MaxTTL time.Duration
+}
+
+// postPreviewUseCase PostPreviewUseCaseの実装
+type postPreviewUseCase struct {
+	postRepo repository.PostRepository
+	signer   auth.PreviewSigner
+	audit    AuditUseCase
+	siteURL  string
+	ttl      time.Duration
+	maxTTL   time.Duration
+}
+
+// NewPostPreviewUseCase 新しいPostPreviewUseCaseを作成
+func NewPostPreviewUseCase(postRepo repository.PostRepository, signer auth.PreviewSigner, audit AuditUseCase, config PreviewConfig) PostPreviewUseCase {
+	if config.TTL <= 0 {
+		config.TTL = DefaultPreviewLinkTTL
+	}
+	if config.MaxTTL <= 0 {
+		config.MaxTTL = DefaultPreviewLinkMaxTTL
+	}
+	return &postPreviewUseCase{
+		postRepo: postRepo,
+		signer:   signer,
+		audit:    audit,
+		siteURL:  strings.TrimRight(config.SiteURL, "/"),
+		ttl:      config.TTL,
+		maxTTL:   config.MaxTTL,
+	}
+}
+
+// CreateLink 記事の署名付きプレビューURLを発行
+// URLはデータベースに保存せず、記事のプレビューのバージョンを署名に含めることで記事ごとに失効できるようにする
+func (u *postPreviewUseCase) CreateLink(ctx context.Context, postID int64, req *CreatePreviewLinkRequest) (*PreviewLink, error) {
+	now := time.Now()
+	expiresAt := now.Add(u.ttl)
+	if req.ExpiresAt != nil {
+		switch {
+		case !req.ExpiresAt.After(now):
+			return nil, fmt.Errorf("invalid expiresAt: must be in the future")
+		case req.ExpiresAt.After(now.Add(u.maxTTL)):
+			return nil, fmt.Errorf("invalid expiresAt: must be within %s", u.maxTTL)
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	token := u.signer.Sign(auth.PreviewClaims{
+		PostID:    post.ID,
+		Version:   post.PreviewVersion,
+		ExpiresAt: expiresAt,
+	})
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostPreviewLink,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   post.ID,
+		After:      map[string]any{"expiresAt": expiresAt, "previewVersion": post.PreviewVersion},
+	})
+
+	return &PreviewLink{
+		URL:       u.siteURL + "/preview/" + url.PathEscape(token),
+		ExpiresAt: expiresAt.Truncate(time.Second),
+	}, nil
+}
+
+// RevokeLinks 記事のプレビューのバージョンを上げて発行済みのプレビューURLをすべて無効にする
+func (u *postPreviewUseCase) RevokeLinks(ctx context.Context, postID int64) error {
+	version, err := u.postRepo.IncrementPreviewVersion(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostPreviewRevoke,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   postID,
+		After:      map[string]any{"previewVersion": version},
+	})
+
+	return nil
+}
+
+// Resolve プレビューURLのトークンを検証して記事とURLの有効期限を返す
+// 署名の不一致・期限切れ・失効はいずれも"preview link not found"として扱い、理由を区別しない
+func (u *postPreviewUseCase) Resolve(ctx context.Context, token string) (*entity.Post, time.Time, error) {
+	claims, err := u.signer.Verify(token, time.Now())
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("preview link not found: %w", err)
+	}
+
+	post, err := u.postRepo.FindByID(ctx, claims.PostID)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to find post: %w", err)
+	}
+	if post.PreviewVersion != claims.Version {
+		return nil, time.Time{}, fmt.Errorf("preview link not found: revoked")
+	}
+
+	return post, claims.ExpiresAt, nil
+}
@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPreviewUseCase(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	signer, err := auth.NewPreviewSigner("preview-secret-key-min-32-chars-long")
+	require.NoError(t, err)
+	previewUseCase := usecase.NewPostPreviewUseCase(postRepo, signer, newTestAudit(db), usecase.PreviewConfig{
+		SiteURL: "https://blog.example.com/",
+		TTL:     time.Hour,
+		MaxTTL:  24 * time.Hour,
+	})
+
+	author := &entity.User{Username: "author", Email: "author@example.com", PasswordHash: "hash", Role: entity.RoleEditor, Status: entity.StatusActive}
+	require.NoError(t, userRepo.Create(ctx, author))
+	post := &entity.Post{Title: "Draft", Slug: "draft", Content: "content", Status: entity.StatusDraft, AuthorID: author.ID}
+	require.NoError(t, postRepo.Create(ctx, post))
+
+	tokenOf := func(link *usecase.PreviewLink) string {
+		require.True(t, strings.HasPrefix(link.URL, "https://blog.example.com/preview/"))
+		return strings.TrimPrefix(link.URL, "https://blog.example.com/preview/")
+	}
+
+	// 有効期限を省略した場合は既定の有効期間
+	link, err := previewUseCase.CreateLink(ctx, post.ID, &usecase.CreatePreviewLinkRequest{})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), link.ExpiresAt, 5*time.Second)
+
+	found, expiresAt, err := previewUseCase.Resolve(ctx, tokenOf(link))
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, found.ID)
+	assert.Equal(t, entity.StatusDraft, found.Status)
+	assert.WithinDuration(t, link.ExpiresAt, expiresAt, time.Second)
+
+	// 有効期限は未来かつ上限以内
+	past := time.Now().Add(-time.Minute)
+	_, err = previewUseCase.CreateLink(ctx, post.ID, &usecase.CreatePreviewLinkRequest{ExpiresAt: &past})
+	assert.ErrorContains(t, err, "invalid expiresAt")
+	tooLate := time.Now().Add(48 * time.Hour)
+	_, err = previewUseCase.CreateLink(ctx, post.ID, &usecase.CreatePreviewLinkRequest{ExpiresAt: &tooLate})
+	assert.ErrorContains(t, err, "invalid expiresAt")
+
+	_, err = previewUseCase.CreateLink(ctx, post.ID+1000, &usecase.CreatePreviewLinkRequest{})
+	assert.ErrorContains(t, err, "not found")
+
+	// 改ざん・期限切れのトークンは見つからないものとして扱う
+	_, _, err = previewUseCase.Resolve(ctx, tokenOf(link)+"x")
+	assert.ErrorContains(t, err, "preview link not found")
+	expired := signer.Sign(auth.PreviewClaims{PostID: post.ID, ExpiresAt: time.Now().Add(-time.Second)})
+	_, _, err = previewUseCase.Resolve(ctx, expired)
+	assert.ErrorContains(t, err, "preview link not found")
+
+	// 失効すると発行済みのURLはすべて無効になり、新しく発行したURLは有効
+	custom := time.Now().Add(12 * time.Hour)
+	other, err := previewUseCase.CreateLink(ctx, post.ID, &usecase.CreatePreviewLinkRequest{ExpiresAt: &custom})
+	require.NoError(t, err)
+	require.NoError(t, previewUseCase.RevokeLinks(ctx, post.ID))
+
+	for _, revoked := range []*usecase.PreviewLink{link, other} {
+		_, _, err = previewUseCase.Resolve(ctx, tokenOf(revoked))
+		assert.ErrorContains(t, err, "preview link not found")
+	}
+
+	fresh, err := previewUseCase.CreateLink(ctx, post.ID, &usecase.CreatePreviewLinkRequest{})
+	require.NoError(t, err)
+	_, _, err = previewUseCase.Resolve(ctx, tokenOf(fresh))
+	assert.NoError(t, err)
+
+	assert.ErrorContains(t, previewUseCase.RevokeLinks(ctx, post.ID+1000), "not found")
+}
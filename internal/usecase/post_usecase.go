@@ -2,13 +2,39 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/diff"
 	"my-blog-engine/internal/infrastructure/renderer"
+	"my-blog-engine/internal/infrastructure/search"
 )
 
+// revisionDiffContext リビジョン差分で表示する前後の行数
+const revisionDiffContext = 3
+
+// scheduledPublishBatchSize 予約公開で1トランザクションあたりに処理する最大件数
+const scheduledPublishBatchSize = 100
+
+// maxSearchQueryLength 検索クエリの最大文字数
+const maxSearchQueryLength = 100
+
+// searchSnippetLength 検索結果の抜粋の最大文字数
+const searchSnippetLength = 160
+
+// maxReviewCommentLength 差し戻しコメントの最大文字数
+const maxReviewCommentLength = 2000
+
+// ErrPostInReview レビュー中の記事のタイトル・スラッグ・本文を変更しようとした場合のエラー
+// (レビュアーが確認した内容と承認される内容を一致させるため、編集するには下書きに戻す必要がある)
+var ErrPostInReview = errors.New("post is in review: withdraw it to draft before editing")
+
 // PostUseCase 記事ユースケースのインターフェース
 type PostUseCase interface {
 	Create(ctx context.Context, req *CreatePostRequest) (*entity.Post, error)
@@ -20,29 +46,106 @@ type PostUseCase interface {
 	ListPublished(ctx context.Context, limit, offset int) ([]*entity.Post, int, error)
 	ListByCategory(ctx context.Context, categorySlug string, limit, offset int) ([]*entity.Post, int, error)
 	ListByTag(ctx context.Context, tagSlug string, limit, offset int) ([]*entity.Post, int, error)
+	ListByAuthor(ctx context.Context, username string, limit, offset int) ([]*entity.Post, int, error)
+	ListByMonth(ctx context.Context, year, month int, limit, offset int) ([]*entity.Post, int, error)
+	MonthlyArchives(ctx context.Context) ([]*repository.MonthlyPostCount, error)
+	Search(ctx context.Context, req *SearchPostsRequest) ([]*SearchResult, int, error)
 	Publish(ctx context.Context, id int64) error
 	Unpublish(ctx context.Context, id int64) error
+	PublishDue(ctx context.Context, now time.Time) ([]int64, error)
+	ListRevisions(ctx context.Context, postID int64, limit, offset int) ([]*entity.PostRevision, int, error)
+	DiffRevisions(ctx context.Context, fromID, toID int64) (*RevisionDiff, error)
+	RestoreRevision(ctx context.Context, revisionID, editorID int64) (*entity.Post, error)
+	GetRevision(ctx context.Context, id int64) (*entity.PostRevision, error)
+	// AuthorizePost 記事を取得し、ユーザーが操作できない場合はErrForbiddenをラップしたエラーを返す
+	AuthorizePost(ctx context.Context, user *entity.User, action Action, postID int64) (*entity.Post, error)
+	// ListForUser ユーザーが閲覧できる記事一覧を取得
+	ListForUser(ctx context.Context, user *entity.User, limit, offset int) ([]*entity.Post, int, error)
+	Share(ctx context.Context, postID, userID, sharedBy int64) error
+	Unshare(ctx context.Context, postID, userID int64) error
+	ListShares(ctx context.Context, postID int64) ([]*entity.PostShare, error)
+	// SubmitForReview レビュー担当者を指定して記事をレビューに提出
+	SubmitForReview(ctx context.Context, postID, reviewerID int64) (*entity.Post, error)
+	// AssignReviewer レビュー中の記事のレビュー担当者を変更
+	AssignReviewer(ctx context.Context, postID, reviewerID int64) (*entity.Post, error)
+	// Approve レビューを承認して記事を公開(公開日時を指定した場合は予約)
+	Approve(ctx context.Context, postID int64, req *ApproveReviewRequest) (*entity.Post, error)
+	// Reject コメントを付けてレビューを差し戻す
+	Reject(ctx context.Context, postID int64, req *RejectReviewRequest) (*entity.Post, error)
+	// ListReviewQueue レビュー担当者のレビュー待ちの記事一覧を提出日時の古い順に取得
+	ListReviewQueue(ctx context.Context, reviewerID int64, limit, offset int) ([]*entity.Post, int, error)
 }
 
 // CreatePostRequest 記事作成リクエスト
 type CreatePostRequest struct {
-	Title      string  `json:"title"`
-	Slug       string  `json:"slug"`
-	Content    string  `json:"content"`
-	Status     string  `json:"status"`
-	AuthorID   int64   `json:"authorId"`
-	CategoryID *int64  `json:"categoryId"`
-	TagIDs     []int64 `json:"tagIds"`
+	Title      string     `json:"title"`
+	Slug       string     `json:"slug"`
+	Content    string     `json:"content"`
+	Status     string     `json:"status"`
+	AuthorID   int64      `json:"authorId"`
+	CategoryID *int64     `json:"categoryId"`
+	TagIDs     []int64    `json:"tagIds"`
+	PublishAt  *time.Time `json:"publishAt"`
 }
 
 // UpdatePostRequest 記事更新リクエスト
 type UpdatePostRequest struct {
-	Title      *string `json:"title"`
-	Slug       *string `json:"slug"`
-	Content    *string `json:"content"`
-	Status     *string `json:"status"`
-	CategoryID *int64  `json:"categoryId"`
-	TagIDs     []int64 `json:"tagIds"`
+	Title      *string    `json:"title"`
+	Slug       *string    `json:"slug"`
+	Content    *string    `json:"content"`
+	Status     *string    `json:"status"`
+	CategoryID *int64     `json:"categoryId"`
+	TagIDs     []int64    `json:"tagIds"`
+	PublishAt  *time.Time `json:"publishAt"`
+	EditorID   int64      `json:"-"`
+}
+
+// ReviewRequest レビューへの提出・レビュー担当者の変更リクエスト
+type ReviewRequest struct {
+	ReviewerID int64 `json:"reviewerId"`
+}
+
+// ApproveReviewRequest レビューの承認リクエスト(publishAtを省略した場合はすぐに公開する)
+type ApproveReviewRequest struct {
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+// RejectReviewRequest レビューの差し戻しリクエスト
+type RejectReviewRequest struct {
+	Comment string `json:"comment"`
+}
+
+// SearchPostsRequest 公開記事の検索リクエスト
+type SearchPostsRequest struct {
+	Query        string
+	CategorySlug string
+	TagSlug      string
+	Limit        int
+	Offset       int
+}
+
+// SearchResult 記事検索結果
+// Snippetは検索語を<mark>で強調したHTMLで、本文はエスケープ済み
+type SearchResult struct {
+	Post    *entity.Post `json:"post"`
+	Score   float64      `json:"score"`
+	Snippet string       `json:"snippet"`
+}
+
+// RevisionDiff リビジョン間の差分
+type RevisionDiff struct {
+	PostID         int64         `json:"postId"`
+	FromRevisionID int64         `json:"fromRevisionId"`
+	ToRevisionID   int64         `json:"toRevisionId"`
+	Changes        []FieldChange `json:"changes"`
+	Diff           string        `json:"diff"`
+}
+
+// FieldChange 本文以外の項目の変更内容
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
 }
 
 // postUseCase PostUseCaseの実装
@@ -50,6 +153,12 @@ type postUseCase struct {
 	postRepo     repository.PostRepository
 	categoryRepo repository.CategoryRepository
 	tagRepo      repository.TagRepository
+	userRepo     repository.UserRepository
+	revisionRepo repository.PostRevisionRepository
+	shareRepo    repository.PostShareRepository
+	noteRepo     repository.ReviewNoteRepository
+	authorizer   Authorizer
+	audit        AuditUseCase
 	mdRenderer   renderer.MarkdownRenderer
 }
 
@@ -58,12 +167,24 @@ func NewPostUseCase(
 	postRepo repository.PostRepository,
 	categoryRepo repository.CategoryRepository,
 	tagRepo repository.TagRepository,
+	userRepo repository.UserRepository,
+	revisionRepo repository.PostRevisionRepository,
+	shareRepo repository.PostShareRepository,
+	noteRepo repository.ReviewNoteRepository,
+	authorizer Authorizer,
+	audit AuditUseCase,
 	mdRenderer renderer.MarkdownRenderer,
 ) PostUseCase {
 	return &postUseCase{
 		postRepo:     postRepo,
 		categoryRepo: categoryRepo,
 		tagRepo:      tagRepo,
+		userRepo:     userRepo,
+		revisionRepo: revisionRepo,
+		shareRepo:    shareRepo,
+		noteRepo:     noteRepo,
+		authorizer:   authorizer,
+		audit:        audit,
 		mdRenderer:   mdRenderer,
 	}
 }
@@ -86,11 +207,14 @@ func (u *postUseCase) Create(ctx context.Context, req *CreatePostRequest) (*enti
 		Slug:         req.Slug,
 		Content:      req.Content,
 		RenderedHTML: renderedHTML,
-		Status:       entity.PostStatus(req.Status),
 		AuthorID:     req.AuthorID,
 		CategoryID:   req.CategoryID,
 	}
 
+	if err := applyStatus(post, req.Status, req.PublishAt); err != nil {
+		return nil, err
+	}
+
 	if err := u.postRepo.Create(ctx, post); err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
@@ -103,16 +227,39 @@ func (u *postUseCase) Create(ctx context.Context, req *CreatePostRequest) (*enti
 	}
 
 	// 作成した記事を取得(リレーション含む)
-	return u.postRepo.FindByID(ctx, post.ID)
+	created, err := u.postRepo.FindByID(ctx, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	// 初版をリビジョンとして記録
+	if err := u.recordRevision(ctx, created, req.AuthorID); err != nil {
+		return nil, err
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostCreate,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   created.ID,
+		After:      postAuditSnapshot(created),
+	})
+
+	return created, nil
 }
 
 // Update 記事を更新
+// レビュー中の記事はタイトル・スラッグ・本文を変更できない(同じリクエストで下書きに戻す場合も含む)
 func (u *postUseCase) Update(ctx context.Context, id int64, req *UpdatePostRequest) (*entity.Post, error) {
 	// 既存の記事を取得
 	post, err := u.postRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find post: %w", err)
 	}
+	if post.IsInReview() && changesReviewedContent(post, req) {
+		return nil, ErrPostInReview
+	}
+	before := postAuditSnapshot(post)
+	oldContent := post.Content
 
 	// 更新
 	if req.Title != nil {
@@ -130,8 +277,14 @@ func (u *postUseCase) Update(ctx context.Context, id int64, req *UpdatePostReque
 		}
 		post.RenderedHTML = renderedHTML
 	}
-	if req.Status != nil {
-		post.Status = entity.PostStatus(*req.Status)
+	if req.Status != nil || req.PublishAt != nil {
+		status := ""
+		if req.Status != nil {
+			status = *req.Status
+		}
+		if err := applyStatus(post, status, req.PublishAt); err != nil {
+			return nil, err
+		}
 	}
 	if req.CategoryID != nil {
 		post.CategoryID = req.CategoryID
@@ -143,42 +296,176 @@ func (u *postUseCase) Update(ctx context.Context, id int64, req *UpdatePostReque
 
 	// タグ更新
 	if req.TagIDs != nil {
-		// 既存のタグを取得
-		existingTags, err := u.postRepo.GetTags(ctx, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get existing tags: %w", err)
+		if err := u.replaceTags(ctx, id, req.TagIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	// 更新した記事を取得
+	updated, err := u.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	// 更新後の状態をリビジョンとして記録
+	if err := u.recordRevision(ctx, updated, req.EditorID); err != nil {
+		return nil, err
+	}
+	if err := u.reanchorNotes(ctx, id, oldContent, updated.Content); err != nil {
+		return nil, err
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostUpdate,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   id,
+		Before:     before,
+		After:      postAuditSnapshot(updated),
+	})
+
+	return updated, nil
+}
+
+// changesReviewedContent リクエストがレビューの対象(タイトル・スラッグ・本文)を変更するかどうかを判定
+// 値が変わらない項目は変更とみなさない(編集画面が全項目を送信する場合のため)
+func changesReviewedContent(post *entity.Post, req *UpdatePostRequest) bool {
+	return (req.Title != nil && *req.Title != post.Title) ||
+		(req.Slug != nil && *req.Slug != post.Slug) ||
+		(req.Content != nil && *req.Content != post.Content)
+}
+
+// applyStatus リクエストのステータスと公開予約日時を記事に反映
+// publishAtを指定した場合は公開予約となり、statusは省略するかscheduledである必要がある
+// レビューへの提出・承認・差し戻しはレビューの操作でのみ行い、レビュー中の記事は下書きに戻す(取り下げる)ことだけができる
+func applyStatus(post *entity.Post, status string, publishAt *time.Time) error {
+	target := entity.PostStatus(status)
+	if publishAt != nil {
+		target = entity.StatusScheduled
+	} else if target == "" {
+		target = entity.StatusDraft
+	}
+	switch {
+	case !target.IsValid():
+		return fmt.Errorf("invalid status: %s", status)
+	case target == entity.StatusInReview || target == entity.StatusRejected:
+		return fmt.Errorf("invalid status: %s can only be set through the review workflow", target)
+	case post.IsInReview() && target != entity.StatusDraft:
+		return fmt.Errorf("invalid status: post is in review and must be approved by the reviewer")
+	case post.Status != "" && !post.CanTransitionTo(target):
+		return fmt.Errorf("invalid status: %w: %s to %s", entity.ErrInvalidTransition, post.Status, target)
+	}
+
+	if publishAt != nil {
+		if status != "" && entity.PostStatus(status) != entity.StatusScheduled {
+			return fmt.Errorf("invalid publish schedule: publishAt cannot be combined with status %q", status)
 		}
+		if !publishAt.After(time.Now()) {
+			return fmt.Errorf("invalid publish schedule: publishAt must be in the future")
+		}
+		post.Schedule(*publishAt)
+		return nil
+	}
 
-		// 既存のタグIDを抽出
-		existingTagIDs := make([]int64, len(existingTags))
-		for i, tag := range existingTags {
-			existingTagIDs[i] = tag.ID
+	switch entity.PostStatus(status) {
+	case entity.StatusPublished:
+		post.Publish()
+	case entity.StatusScheduled:
+		// 既に予約済みの記事はそのままの日時で予約を維持する
+		if post.ScheduledAt == nil {
+			return fmt.Errorf("invalid publish schedule: publishAt is required for scheduled posts")
 		}
+		post.Status = entity.StatusScheduled
+	case entity.StatusDraft, "":
+		post.Unpublish()
+	default:
+		return fmt.Errorf("invalid status: %s", status)
+	}
+
+	return nil
+}
 
-		// 既存のタグを削除
-		if len(existingTagIDs) > 0 {
-			if err := u.postRepo.RemoveTags(ctx, id, existingTagIDs); err != nil {
-				return nil, fmt.Errorf("failed to remove tags: %w", err)
-			}
+// replaceTags 記事のタグを指定したタグで置き換える
+func (u *postUseCase) replaceTags(ctx context.Context, postID int64, tagIDs []int64) error {
+	// 既存のタグを取得
+	existingTags, err := u.postRepo.GetTags(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing tags: %w", err)
+	}
+
+	// 既存のタグIDを抽出
+	existingTagIDs := make([]int64, len(existingTags))
+	for i, tag := range existingTags {
+		existingTagIDs[i] = tag.ID
+	}
+
+	// 既存のタグを削除
+	if len(existingTagIDs) > 0 {
+		if err := u.postRepo.RemoveTags(ctx, postID, existingTagIDs); err != nil {
+			return fmt.Errorf("failed to remove tags: %w", err)
 		}
+	}
 
-		// 新しいタグを追加
-		if len(req.TagIDs) > 0 {
-			if err := u.postRepo.AddTags(ctx, id, req.TagIDs); err != nil {
-				return nil, fmt.Errorf("failed to add tags: %w", err)
-			}
+	// 新しいタグを追加
+	if len(tagIDs) > 0 {
+		if err := u.postRepo.AddTags(ctx, postID, tagIDs); err != nil {
+			return fmt.Errorf("failed to add tags: %w", err)
 		}
 	}
 
-	// 更新した記事を取得
-	return u.postRepo.FindByID(ctx, id)
+	return nil
+}
+
+// recordRevision 記事の現在の状態をリビジョンとして保存
+func (u *postUseCase) recordRevision(ctx context.Context, post *entity.Post, editorID int64) error {
+	if err := u.revisionRepo.Create(ctx, entity.NewPostRevision(post, editorID)); err != nil {
+		return fmt.Errorf("failed to record post revision: %w", err)
+	}
+	return nil
+}
+
+// reanchorNotes 本文の変更に合わせてレビューノートの紐付けた行範囲を更新
+func (u *postUseCase) reanchorNotes(ctx context.Context, postID int64, oldContent, newContent string) error {
+	if oldContent == newContent {
+		return nil
+	}
+
+	notes, err := u.noteRepo.ListAnchored(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if len(notes) == 0 {
+		return nil
+	}
+
+	lineMap := diff.LineMap(diff.Lines(diff.SplitLines(oldContent), diff.SplitLines(newContent)))
+	changed := make([]*entity.ReviewNote, 0, len(notes))
+	for _, note := range notes {
+		if note.Reanchor(newContent, lineMap) {
+			changed = append(changed, note)
+		}
+	}
+
+	return u.noteRepo.UpdateAnchors(ctx, changed)
 }
 
 // Delete 記事を削除
 func (u *postUseCase) Delete(ctx context.Context, id int64) error {
+	post, err := u.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find post: %w", err)
+	}
+
 	if err := u.postRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostDelete,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   id,
+		Before:     postAuditSnapshot(post),
+	})
+
 	return nil
 }
 
@@ -215,6 +502,27 @@ func (u *postUseCase) List(ctx context.Context, limit, offset int) ([]*entity.Po
 	return posts, count, nil
 }
 
+// ListForUser ユーザーが閲覧できる記事一覧を取得
+// すべての記事を閲覧できるロールには全件、それ以外には著者の記事と共有された記事を返す
+func (u *postUseCase) ListForUser(ctx context.Context, user *entity.User, limit, offset int) ([]*entity.Post, int, error) {
+	// 著者でも共有先でもない記事を閲覧できるかで判定する
+	if u.authorizer.Can(user, ActionPostRead, &Resource{}) {
+		return u.List(ctx, limit, offset)
+	}
+
+	posts, err := u.postRepo.ListAccessible(ctx, user.ID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	count, err := u.postRepo.CountAccessible(ctx, user.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	return posts, count, nil
+}
+
 // ListPublished 公開済み記事一覧を取得
 func (u *postUseCase) ListPublished(ctx context.Context, limit, offset int) ([]*entity.Post, int, error) {
 	posts, err := u.postRepo.ListPublished(ctx, limit, offset)
@@ -242,8 +550,12 @@ func (u *postUseCase) ListByCategory(ctx context.Context, categorySlug string, l
 		return nil, 0, fmt.Errorf("failed to list posts by category: %w", err)
 	}
 
-	// TODO: カテゴリ別の記事数カウントメソッド追加
-	return posts, len(posts), nil
+	count, err := u.postRepo.CountByCategory(ctx, category.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count posts by category: %w", err)
+	}
+
+	return posts, count, nil
 }
 
 // ListByTag タグ別記事一覧を取得
@@ -258,8 +570,119 @@ func (u *postUseCase) ListByTag(ctx context.Context, tagSlug string, limit, offs
 		return nil, 0, fmt.Errorf("failed to list posts by tag: %w", err)
 	}
 
-	// TODO: タグ別の記事数カウントメソッド追加
-	return posts, len(posts), nil
+	count, err := u.postRepo.CountByTag(ctx, tag.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count posts by tag: %w", err)
+	}
+
+	return posts, count, nil
+}
+
+// ListByAuthor 著者別の公開記事一覧を取得
+func (u *postUseCase) ListByAuthor(ctx context.Context, username string, limit, offset int) ([]*entity.Post, int, error) {
+	author, err := u.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find author: %w", err)
+	}
+
+	posts, err := u.postRepo.ListByAuthor(ctx, author.ID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list posts by author: %w", err)
+	}
+
+	count, err := u.postRepo.CountByAuthor(ctx, author.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count posts by author: %w", err)
+	}
+
+	return posts, count, nil
+}
+
+// ListByMonth 年別・月別の公開記事一覧を取得(monthが0の場合は年全体)
+// 期間の区切りはUTCで判定する
+func (u *postUseCase) ListByMonth(ctx context.Context, year, month int, limit, offset int) ([]*entity.Post, int, error) {
+	if year < 1 || year > 9999 || month < 0 || month > 12 {
+		return nil, 0, fmt.Errorf("invalid archive period: %d-%d", year, month)
+	}
+
+	var from, to time.Time
+	if month == 0 {
+		from = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(1, 0, 0)
+	} else {
+		from = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(0, 1, 0)
+	}
+
+	posts, err := u.postRepo.ListPublishedBetween(ctx, from, to, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list posts by date: %w", err)
+	}
+
+	count, err := u.postRepo.CountPublishedBetween(ctx, from, to)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count posts by date: %w", err)
+	}
+
+	return posts, count, nil
+}
+
+// MonthlyArchives 月別アーカイブ(公開記事数)の一覧を取得
+func (u *postUseCase) MonthlyArchives(ctx context.Context) ([]*repository.MonthlyPostCount, error) {
+	archives, err := u.postRepo.CountPublishedByMonth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly archives: %w", err)
+	}
+	return archives, nil
+}
+
+// Search 公開記事を全文検索し、関連度の高い順に取得
+func (u *postUseCase) Search(ctx context.Context, req *SearchPostsRequest) ([]*SearchResult, int, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, 0, fmt.Errorf("search query is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, 0, fmt.Errorf("search query is too long")
+	}
+
+	filters := repository.PostSearchFilters{Status: entity.StatusPublished}
+	if req.CategorySlug != "" {
+		category, err := u.categoryRepo.FindBySlug(ctx, req.CategorySlug)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to find category: %w", err)
+		}
+		filters.CategoryID = &category.ID
+	}
+	if req.TagSlug != "" {
+		tag, err := u.tagRepo.FindBySlug(ctx, req.TagSlug)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to find tag: %w", err)
+		}
+		filters.TagID = &tag.ID
+	}
+
+	hits, err := u.postRepo.Search(ctx, query, filters, req.Limit, req.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	count, err := u.postRepo.CountSearch(ctx, query, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	terms := search.Terms(query)
+	results := make([]*SearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = &SearchResult{
+			Post:    hit.Post,
+			Score:   hit.Score,
+			Snippet: search.Snippet(search.PlainText(hit.Post.RenderedHTML), terms, searchSnippetLength),
+		}
+	}
+
+	return results, count, nil
 }
 
 // Publish 記事を公開
@@ -272,12 +695,25 @@ func (u *postUseCase) Publish(ctx context.Context, id int64) error {
 	if post.Status == entity.StatusPublished {
 		return nil // すでに公開済み
 	}
+	// レビュー中の記事は承認で、差し戻された記事は再提出して承認されてから公開する
+	if post.IsInReview() || !post.CanTransitionTo(entity.StatusPublished) {
+		return fmt.Errorf("%w: %s to %s", entity.ErrInvalidTransition, post.Status, entity.StatusPublished)
+	}
 
+	before := postAuditSnapshot(post)
 	post.Publish()
 	if err := u.postRepo.Update(ctx, post); err != nil {
 		return fmt.Errorf("failed to publish post: %w", err)
 	}
 
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostPublish,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   id,
+		Before:     before,
+		After:      postAuditSnapshot(post),
+	})
+
 	return nil
 }
 
@@ -292,10 +728,435 @@ func (u *postUseCase) Unpublish(ctx context.Context, id int64) error {
 		return nil // すでに下書き
 	}
 
+	before := postAuditSnapshot(post)
 	post.Unpublish()
 	if err := u.postRepo.Update(ctx, post); err != nil {
 		return fmt.Errorf("failed to unpublish post: %w", err)
 	}
 
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostUnpublish,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   id,
+		Before:     before,
+		After:      postAuditSnapshot(post),
+	})
+
+	return nil
+}
+
+// PublishDue 公開予定日時を過ぎた予約記事をすべて公開し、公開した記事IDを返す
+func (u *postUseCase) PublishDue(ctx context.Context, now time.Time) ([]int64, error) {
+	published := make([]int64, 0)
+	for {
+		ids, err := u.postRepo.PublishDue(ctx, now, scheduledPublishBatchSize)
+		if err != nil {
+			return published, fmt.Errorf("failed to publish due posts: %w", err)
+		}
+		published = append(published, ids...)
+
+		// 予約公開はシステムによる操作として記録する
+		for _, id := range ids {
+			u.audit.Record(ctx, &AuditEntry{
+				Action:     entity.AuditPostPublish,
+				TargetType: entity.AuditTargetPost,
+				TargetID:   id,
+				Before:     map[string]any{"status": entity.StatusScheduled},
+				After:      map[string]any{"status": entity.StatusPublished},
+			})
+		}
+
+		if len(ids) < scheduledPublishBatchSize {
+			return published, nil
+		}
+	}
+}
+
+// ListRevisions 記事のリビジョン一覧を取得
+func (u *postUseCase) ListRevisions(ctx context.Context, postID int64, limit, offset int) ([]*entity.PostRevision, int, error) {
+	revisions, err := u.revisionRepo.ListByPost(ctx, postID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list post revisions: %w", err)
+	}
+
+	count, err := u.revisionRepo.CountByPost(ctx, postID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count post revisions: %w", err)
+	}
+
+	return revisions, count, nil
+}
+
+// DiffRevisions 2つのリビジョン間の差分を取得
+func (u *postUseCase) DiffRevisions(ctx context.Context, fromID, toID int64) (*RevisionDiff, error) {
+	from, err := u.revisionRepo.FindByID(ctx, fromID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revision: %w", err)
+	}
+
+	to, err := u.revisionRepo.FindByID(ctx, toID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revision: %w", err)
+	}
+
+	if from.PostID != to.PostID {
+		return nil, fmt.Errorf("revisions belong to different posts")
+	}
+
+	changes := make([]FieldChange, 0)
+	addChange := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, FieldChange{Field: field, From: before, To: after})
+		}
+	}
+	addChange("title", from.Title, to.Title)
+	addChange("slug", from.Slug, to.Slug)
+	addChange("categoryId", formatOptionalID(from.CategoryID), formatOptionalID(to.CategoryID))
+	addChange("tagIds", formatIDs(from.TagIDs), formatIDs(to.TagIDs))
+
+	return &RevisionDiff{
+		PostID:         from.PostID,
+		FromRevisionID: from.ID,
+		ToRevisionID:   to.ID,
+		Changes:        changes,
+		Diff: diff.Unified(
+			fmt.Sprintf("revision/%d", from.ID),
+			fmt.Sprintf("revision/%d", to.ID),
+			from.Content,
+			to.Content,
+			revisionDiffContext,
+		),
+	}, nil
+}
+
+// RestoreRevision リビジョンの内容で記事を復元(復元結果も新しいリビジョンとして記録される)
+// レビュー中の記事はタイトル・スラッグ・本文が変わる復元はできない(Updateと同じく下書きに戻す必要がある)
+func (u *postUseCase) RestoreRevision(ctx context.Context, revisionID, editorID int64) (*entity.Post, error) {
+	revision, err := u.revisionRepo.FindByID(ctx, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revision: %w", err)
+	}
+
+	post, err := u.postRepo.FindByID(ctx, revision.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+	if post.IsInReview() && changesReviewedContent(post, &UpdatePostRequest{Title: &revision.Title, Slug: &revision.Slug, Content: &revision.Content}) {
+		return nil, ErrPostInReview
+	}
+	before := postAuditSnapshot(post)
+	oldContent := post.Content
+
+	renderedHTML, err := u.mdRenderer.Render(revision.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	post.Title = revision.Title
+	post.Slug = revision.Slug
+	post.Content = revision.Content
+	post.RenderedHTML = renderedHTML
+	post.CategoryID = revision.CategoryID
+
+	// リビジョン作成後に削除されたカテゴリは復元しない
+	if post.CategoryID != nil {
+		if _, err := u.categoryRepo.FindByID(ctx, *post.CategoryID); err != nil {
+			post.CategoryID = nil
+		}
+	}
+
+	if err := u.postRepo.Update(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to restore post: %w", err)
+	}
+
+	// リビジョン作成後に削除されたタグは復元しない
+	tagIDs := make([]int64, 0, len(revision.TagIDs))
+	if len(revision.TagIDs) > 0 {
+		tags, err := u.tagRepo.FindByIDs(ctx, revision.TagIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tags: %w", err)
+		}
+		for _, tag := range tags {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	if err := u.replaceTags(ctx, post.ID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	restored, err := u.postRepo.FindByID(ctx, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if err := u.recordRevision(ctx, restored, editorID); err != nil {
+		return nil, err
+	}
+	if err := u.reanchorNotes(ctx, post.ID, oldContent, restored.Content); err != nil {
+		return nil, err
+	}
+
+	after := postAuditSnapshot(restored)
+	after["revisionId"] = revisionID
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostRestore,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   post.ID,
+		Before:     before,
+		After:      after,
+	})
+
+	return restored, nil
+}
+
+// GetRevision IDでリビジョンを取得
+func (u *postUseCase) GetRevision(ctx context.Context, id int64) (*entity.PostRevision, error) {
+	revision, err := u.revisionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revision: %w", err)
+	}
+	return revision, nil
+}
+
+// AuthorizePost 記事を取得し、ユーザーが操作できない場合はErrForbiddenをラップしたエラーを返す
+func (u *postUseCase) AuthorizePost(ctx context.Context, user *entity.User, action Action, postID int64) (*entity.Post, error) {
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	shared := false
+	if user != nil && user.ID != post.AuthorID {
+		shared, err = u.shareRepo.Exists(ctx, post.ID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.authorizer.Authorize(user, action, PostResource(post, shared)); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// Share 記事をユーザーに共有し、下書きを閲覧できるようにする
+func (u *postUseCase) Share(ctx context.Context, postID, userID, sharedBy int64) error {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err := u.shareRepo.Save(ctx, &entity.PostShare{
+		PostID:   postID,
+		UserID:   userID,
+		SharedBy: &sharedBy,
+	}); err != nil {
+		return err
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostShare,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   postID,
+		After:      map[string]any{"userId": userID},
+	})
+
 	return nil
 }
+
+// Unshare 記事の共有を解除
+func (u *postUseCase) Unshare(ctx context.Context, postID, userID int64) error {
+	deleted, err := u.shareRepo.Delete(ctx, postID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("post share not found")
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostUnshare,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   postID,
+		Before:     map[string]any{"userId": userID},
+	})
+
+	return nil
+}
+
+// ListShares 記事の共有先一覧を取得
+func (u *postUseCase) ListShares(ctx context.Context, postID int64) ([]*entity.PostShare, error) {
+	shares, err := u.shareRepo.ListByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// SubmitForReview レビュー担当者を指定して記事をレビューに提出
+func (u *postUseCase) SubmitForReview(ctx context.Context, postID, reviewerID int64) (*entity.Post, error) {
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+	if err := u.validateReviewer(ctx, post, reviewerID); err != nil {
+		return nil, err
+	}
+
+	if err := post.SubmitForReview(reviewerID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.postRepo.Update(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to submit post for review: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostSubmit,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   postID,
+		After:      map[string]any{"status": post.Status, "reviewerId": reviewerID},
+	})
+
+	return post, nil
+}
+
+// AssignReviewer レビュー中の記事のレビュー担当者を変更
+func (u *postUseCase) AssignReviewer(ctx context.Context, postID, reviewerID int64) (*entity.Post, error) {
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+	if !post.IsInReview() {
+		return nil, fmt.Errorf("%w: post is not in review", entity.ErrInvalidTransition)
+	}
+	if err := u.validateReviewer(ctx, post, reviewerID); err != nil {
+		return nil, err
+	}
+
+	before := map[string]any{"reviewerId": post.ReviewerID}
+	post.ReviewerID = &reviewerID
+	if err := u.postRepo.Update(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to assign reviewer: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostAssignReviewer,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   postID,
+		Before:     before,
+		After:      map[string]any{"reviewerId": reviewerID},
+	})
+
+	return post, nil
+}
+
+// validateReviewer レビュー担当者が記事をレビューできるユーザーかどうかを確認(著者は自分の記事をレビューできない)
+func (u *postUseCase) validateReviewer(ctx context.Context, post *entity.Post, reviewerID int64) error {
+	if reviewerID == post.AuthorID {
+		return fmt.Errorf("invalid reviewer: authors cannot review their own posts")
+	}
+
+	reviewer, err := u.userRepo.FindByID(ctx, reviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to find reviewer: %w", err)
+	}
+	if !u.authorizer.Can(reviewer, ActionPostReview, &Resource{OwnerID: post.AuthorID, ReviewerID: reviewer.ID}) {
+		return fmt.Errorf("invalid reviewer: %s cannot review posts", reviewer.Username)
+	}
+
+	return nil
+}
+
+// Approve レビューを承認して記事を公開(公開日時を指定した場合は予約)
+func (u *postUseCase) Approve(ctx context.Context, postID int64, req *ApproveReviewRequest) (*entity.Post, error) {
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		return nil, fmt.Errorf("invalid publish schedule: publishAt must be in the future")
+	}
+
+	before := postAuditSnapshot(post)
+	if err := post.Approve(req.PublishAt, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.postRepo.Update(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to approve post: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostApprove,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   postID,
+		Before:     before,
+		After:      postAuditSnapshot(post),
+	})
+
+	return post, nil
+}
+
+// Reject コメントを付けてレビューを差し戻す
+func (u *postUseCase) Reject(ctx context.Context, postID int64, req *RejectReviewRequest) (*entity.Post, error) {
+	comment := strings.TrimSpace(req.Comment)
+	if comment == "" {
+		return nil, fmt.Errorf("review comment is required")
+	}
+	if utf8.RuneCountInString(comment) > maxReviewCommentLength {
+		return nil, fmt.Errorf("review comment must be at most %d characters", maxReviewCommentLength)
+	}
+
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if err := post.Reject(comment, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.postRepo.Update(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to reject post: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditPostReject,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   postID,
+		Before:     map[string]any{"status": entity.StatusInReview},
+		After:      map[string]any{"status": post.Status, "comment": comment},
+	})
+
+	return post, nil
+}
+
+// ListReviewQueue レビュー担当者のレビュー待ちの記事一覧を提出日時の古い順に取得
+func (u *postUseCase) ListReviewQueue(ctx context.Context, reviewerID int64, limit, offset int) ([]*entity.Post, int, error) {
+	posts, err := u.postRepo.ListAwaitingReview(ctx, reviewerID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list review queue: %w", err)
+	}
+
+	count, err := u.postRepo.CountAwaitingReview(ctx, reviewerID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count review queue: %w", err)
+	}
+
+	return posts, count, nil
+}
+
+// formatOptionalID 差分表示用にIDを文字列化(nilは空文字列)
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+// formatIDs 差分表示用にIDリストを文字列化
+func formatIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"my-blog-engine/internal/domain/entity"
 	"my-blog-engine/internal/infrastructure/persistence"
@@ -23,14 +24,21 @@ func setupPostUseCase(t *testing.T) (usecase.PostUseCase, *entity.User, func())
 	postRepo := persistence.NewPostRepository(db)
 	categoryRepo := persistence.NewCategoryRepository(db)
 	tagRepo := persistence.NewTagRepository(db)
+	revisionRepo := persistence.NewPostRevisionRepository(db)
 
 	mermaidRenderer := renderer.NewMockMermaidRenderer()
-	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer)
+	mdRenderer := renderer.NewMarkdownRenderer(mermaidRenderer, nil)
 
 	postUseCase := usecase.NewPostUseCase(
 		postRepo,
 		categoryRepo,
 		tagRepo,
+		userRepo,
+		revisionRepo,
+		persistence.NewPostShareRepository(db),
+		persistence.NewReviewNoteRepository(db),
+		usecase.NewAuthorizer(),
+		newTestAudit(db),
 		mdRenderer,
 	)
 
@@ -211,3 +219,557 @@ func TestPostUseCase_Unpublish(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, entity.StatusDraft, unpublished.Status)
 }
+
+func TestPostUseCase_Update_RecordsRevision(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "Original Title",
+		Slug:     "original-slug",
+		Content:  "line1\nline2\n",
+		Status:   "draft",
+		AuthorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	newContent := "line1\nline2 changed\n"
+	_, err = postUseCase.Update(ctx, post.ID, &usecase.UpdatePostRequest{
+		Content:  &newContent,
+		EditorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	// 作成時と更新時の2リビジョン(新しい順)
+	revisions, total, err := postUseCase.ListRevisions(ctx, post.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, newContent, revisions[0].Content)
+	assert.Equal(t, "line1\nline2\n", revisions[1].Content)
+	require.NotNil(t, revisions[0].EditorID)
+	assert.Equal(t, user.ID, *revisions[0].EditorID)
+}
+
+func TestPostUseCase_DiffRevisions(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "Original Title",
+		Slug:     "original-slug",
+		Content:  "line1\nline2\n",
+		Status:   "draft",
+		AuthorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	newTitle := "Updated Title"
+	newContent := "line1\nline2 changed\n"
+	_, err = postUseCase.Update(ctx, post.ID, &usecase.UpdatePostRequest{
+		Title:    &newTitle,
+		Content:  &newContent,
+		EditorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	revisions, _, err := postUseCase.ListRevisions(ctx, post.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	result, err := postUseCase.DiffRevisions(ctx, revisions[1].ID, revisions[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, result.PostID)
+	assert.Contains(t, result.Diff, "-line2\n+line2 changed\n")
+	require.Len(t, result.Changes, 1)
+	assert.Equal(t, "title", result.Changes[0].Field)
+	assert.Equal(t, "Original Title", result.Changes[0].From)
+	assert.Equal(t, newTitle, result.Changes[0].To)
+}
+
+func TestPostUseCase_RestoreRevision(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "Original Title",
+		Slug:     "original-slug",
+		Content:  "Original content",
+		Status:   "draft",
+		AuthorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	revisions, _, err := postUseCase.ListRevisions(ctx, post.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	original := revisions[0]
+
+	newContent := "Clobbered content"
+	_, err = postUseCase.Update(ctx, post.ID, &usecase.UpdatePostRequest{
+		Content:  &newContent,
+		EditorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	// 初版に復元
+	restored, err := postUseCase.RestoreRevision(ctx, original.ID, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Original content", restored.Content)
+	assert.Contains(t, restored.RenderedHTML, "Original content")
+
+	// 復元自体も新しいリビジョンになる
+	revisions, total, err := postUseCase.ListRevisions(ctx, post.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, "Original content", revisions[0].Content)
+}
+
+func TestPostUseCase_Create_Scheduled(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	publishAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:     "Scheduled Post",
+		Slug:      "scheduled-post",
+		Content:   "Scheduled content",
+		AuthorID:  user.ID,
+		PublishAt: &publishAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusScheduled, post.Status)
+	require.NotNil(t, post.ScheduledAt)
+	assert.True(t, post.ScheduledAt.Equal(publishAt))
+	assert.Nil(t, post.PublishedAt)
+
+	// 過去の日時は予約できない
+	past := time.Now().Add(-time.Hour)
+	_, err = postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:     "Past Post",
+		Slug:      "past-post",
+		Content:   "Past content",
+		AuthorID:  user.ID,
+		PublishAt: &past,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid publish schedule")
+
+	// 公開日時なしでscheduledは指定できない
+	_, err = postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "No Time Post",
+		Slug:     "no-time-post",
+		Content:  "No time content",
+		Status:   "scheduled",
+		AuthorID: user.ID,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid publish schedule")
+}
+
+func TestPostUseCase_PublishDue(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:     "Scheduled Post",
+		Slug:      "scheduled-post",
+		Content:   "Scheduled content",
+		AuthorID:  user.ID,
+		PublishAt: &publishAt,
+	})
+	require.NoError(t, err)
+
+	// 予約日時前は公開されない
+	ids, err := postUseCase.PublishDue(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// 予約日時を過ぎると公開される
+	ids, err = postUseCase.PublishDue(ctx, publishAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{post.ID}, ids)
+
+	published, err := postUseCase.GetByID(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusPublished, published.Status)
+	require.NotNil(t, published.PublishedAt)
+	assert.True(t, published.PublishedAt.Equal(publishAt))
+}
+
+func TestPostUseCase_Unpublish_CancelsSchedule(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour)
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:     "Scheduled Post",
+		Slug:      "scheduled-post",
+		Content:   "Scheduled content",
+		AuthorID:  user.ID,
+		PublishAt: &publishAt,
+	})
+	require.NoError(t, err)
+
+	err = postUseCase.Unpublish(ctx, post.ID)
+	require.NoError(t, err)
+
+	cancelled, err := postUseCase.GetByID(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusDraft, cancelled.Status)
+	assert.Nil(t, cancelled.ScheduledAt)
+}
+
+func TestPostUseCase_Search(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "全文検索の実装",
+		Slug:     "fulltext-search",
+		Content:  "# 概要\n\nMySQLのngramパーサーで<全文検索>を実装する。",
+		Status:   "published",
+		AuthorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "下書きの全文検索",
+		Slug:     "draft-search",
+		Content:  "全文検索の下書き",
+		Status:   "draft",
+		AuthorID: user.ID,
+	})
+	require.NoError(t, err)
+
+	results, total, err := postUseCase.Search(ctx, &usecase.SearchPostsRequest{
+		Query: "全文検索",
+		Limit: 10,
+	})
+	require.NoError(t, err)
+
+	// 公開記事のみが対象
+	assert.Equal(t, 1, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, "fulltext-search", results[0].Post.Slug)
+	assert.Contains(t, results[0].Snippet, "<mark>全文検索</mark>")
+	// 本文中の記号はエスケープされる
+	assert.Contains(t, results[0].Snippet, "&lt;")
+	assert.NotContains(t, results[0].Snippet, "<h1")
+
+	// 空のクエリ
+	_, _, err = postUseCase.Search(ctx, &usecase.SearchPostsRequest{Query: "  ", Limit: 10})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "search query is required")
+}
+
+func TestPostUseCase_ListByAuthor(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, status := range []string{"published", "draft"} {
+		_, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+			Title:    "Post " + status,
+			Slug:     "post-" + status,
+			Content:  "content",
+			Status:   status,
+			AuthorID: user.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	posts, total, err := postUseCase.ListByAuthor(ctx, user.Username, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "post-published", posts[0].Slug)
+
+	_, _, err = postUseCase.ListByAuthor(ctx, "nobody", 10, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestPostUseCase_ListByMonth(t *testing.T) {
+	postUseCase, user, cleanup := setupPostUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "Published Post",
+		Slug:     "published-post",
+		Content:  "content",
+		Status:   "published",
+		AuthorID: user.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, post.PublishedAt)
+
+	publishedAt := post.PublishedAt.UTC()
+
+	// 月別
+	posts, total, err := postUseCase.ListByMonth(ctx, publishedAt.Year(), int(publishedAt.Month()), 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, posts, 1)
+	assert.Equal(t, post.ID, posts[0].ID)
+
+	// 年別
+	_, total, err = postUseCase.ListByMonth(ctx, publishedAt.Year(), 0, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	// 前年には含まれない
+	_, total, err = postUseCase.ListByMonth(ctx, publishedAt.Year()-1, 0, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	// 不正な月
+	_, _, err = postUseCase.ListByMonth(ctx, publishedAt.Year(), 13, 10, 0)
+	assert.Error(t, err)
+
+	archives, err := postUseCase.MonthlyArchives(ctx)
+	require.NoError(t, err)
+	require.Len(t, archives, 1)
+	assert.Equal(t, publishedAt.Year(), archives[0].Year)
+	assert.Equal(t, int(publishedAt.Month()), archives[0].Month)
+	assert.Equal(t, 1, archives[0].Count)
+}
+
+func TestPostUseCase_AuthorizePost(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postUseCase := usecase.NewPostUseCase(
+		persistence.NewPostRepository(db),
+		persistence.NewCategoryRepository(db),
+		persistence.NewTagRepository(db),
+		userRepo,
+		persistence.NewPostRevisionRepository(db),
+		persistence.NewPostShareRepository(db),
+		persistence.NewReviewNoteRepository(db),
+		usecase.NewAuthorizer(),
+		newTestAudit(db),
+		renderer.NewMarkdownRenderer(renderer.NewMockMermaidRenderer(), nil),
+	)
+
+	newUser := func(username string, role entity.UserRole) *entity.User {
+		user := &entity.User{
+			Username:     username,
+			Email:        username + "@example.com",
+			PasswordHash: "hash",
+			Role:         role,
+			Status:       entity.StatusActive,
+		}
+		require.NoError(t, userRepo.Create(ctx, user))
+		return user
+	}
+	contributor := newUser("contributor", entity.RoleContributor)
+	editor := newUser("editor", entity.RoleEditor)
+	viewer := newUser("viewer", entity.RoleViewer)
+
+	draft, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "Draft",
+		Slug:     "draft",
+		Content:  "content",
+		Status:   "draft",
+		AuthorID: contributor.ID,
+	})
+	require.NoError(t, err)
+
+	// 著者の下書きは編集できるが公開できない
+	_, err = postUseCase.AuthorizePost(ctx, contributor, usecase.ActionPostUpdate, draft.ID)
+	assert.NoError(t, err)
+	_, err = postUseCase.AuthorizePost(ctx, contributor, usecase.ActionPostPublish, draft.ID)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+	// 他人の記事はeditorでも編集できない
+	_, err = postUseCase.AuthorizePost(ctx, editor, usecase.ActionPostUpdate, draft.ID)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+	// 共有されたviewerは閲覧できる
+	_, err = postUseCase.AuthorizePost(ctx, viewer, usecase.ActionPostRead, draft.ID)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	posts, total, err := postUseCase.ListForUser(ctx, viewer, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, posts)
+
+	require.NoError(t, postUseCase.Share(ctx, draft.ID, viewer.ID, contributor.ID))
+
+	_, err = postUseCase.AuthorizePost(ctx, viewer, usecase.ActionPostRead, draft.ID)
+	assert.NoError(t, err)
+	_, err = postUseCase.AuthorizePost(ctx, viewer, usecase.ActionPostUpdate, draft.ID)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	posts, total, err = postUseCase.ListForUser(ctx, viewer, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, posts, 1)
+	assert.Equal(t, draft.ID, posts[0].ID)
+
+	shares, err := postUseCase.ListShares(ctx, draft.ID)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	assert.Equal(t, viewer.ID, shares[0].UserID)
+
+	require.NoError(t, postUseCase.Unshare(ctx, draft.ID, viewer.ID))
+	_, err = postUseCase.AuthorizePost(ctx, viewer, usecase.ActionPostRead, draft.ID)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+	err = postUseCase.Unshare(ctx, draft.ID, viewer.ID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	_, err = postUseCase.AuthorizePost(ctx, contributor, usecase.ActionPostRead, 99999)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestPostUseCase_ReviewWorkflow(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postUseCase := usecase.NewPostUseCase(
+		persistence.NewPostRepository(db),
+		persistence.NewCategoryRepository(db),
+		persistence.NewTagRepository(db),
+		userRepo,
+		persistence.NewPostRevisionRepository(db),
+		persistence.NewPostShareRepository(db),
+		persistence.NewReviewNoteRepository(db),
+		usecase.NewAuthorizer(),
+		newTestAudit(db),
+		renderer.NewMarkdownRenderer(renderer.NewMockMermaidRenderer(), nil),
+	)
+
+	newUser := func(username string, role entity.UserRole) *entity.User {
+		user := &entity.User{
+			Username:     username,
+			Email:        username + "@example.com",
+			PasswordHash: "hash",
+			Role:         role,
+			Status:       entity.StatusActive,
+		}
+		require.NoError(t, userRepo.Create(ctx, user))
+		return user
+	}
+	contributor := newUser("contributor", entity.RoleContributor)
+	reviewer := newUser("reviewer", entity.RoleEditor)
+	other := newUser("other", entity.RoleEditor)
+	viewer := newUser("viewer", entity.RoleViewer)
+
+	draft, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "Draft",
+		Slug:     "draft",
+		Content:  "content",
+		Status:   "draft",
+		AuthorID: contributor.ID,
+	})
+	require.NoError(t, err)
+
+	// 著者自身やレビューできないユーザーはレビュー担当者にできない
+	_, err = postUseCase.SubmitForReview(ctx, draft.ID, contributor.ID)
+	assert.ErrorContains(t, err, "invalid reviewer")
+	_, err = postUseCase.SubmitForReview(ctx, draft.ID, viewer.ID)
+	assert.ErrorContains(t, err, "invalid reviewer")
+
+	secondDraft := "content v2"
+	_, err = postUseCase.Update(ctx, draft.ID, &usecase.UpdatePostRequest{Content: &secondDraft, EditorID: contributor.ID})
+	require.NoError(t, err)
+
+	post, err := postUseCase.SubmitForReview(ctx, draft.ID, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusInReview, post.Status)
+
+	// レビュー中の記事は承認以外で公開できない
+	err = postUseCase.Publish(ctx, draft.ID)
+	assert.ErrorIs(t, err, entity.ErrInvalidTransition)
+	status := "published"
+	_, err = postUseCase.Update(ctx, draft.ID, &usecase.UpdatePostRequest{Status: &status, EditorID: contributor.ID})
+	assert.ErrorContains(t, err, "invalid status")
+
+	// レビュー中の記事は下書きに戻すまでタイトル・スラッグ・本文を編集できない
+	content := "changed after submit"
+	_, err = postUseCase.Update(ctx, draft.ID, &usecase.UpdatePostRequest{Content: &content, EditorID: contributor.ID})
+	assert.ErrorIs(t, err, usecase.ErrPostInReview)
+	withdraw := "draft"
+	_, err = postUseCase.Update(ctx, draft.ID, &usecase.UpdatePostRequest{Status: &withdraw, Content: &content, EditorID: contributor.ID})
+	assert.ErrorIs(t, err, usecase.ErrPostInReview)
+	title := "Draft"
+	post, err = postUseCase.Update(ctx, draft.ID, &usecase.UpdatePostRequest{Title: &title, EditorID: contributor.ID})
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusInReview, post.Status)
+	assert.Equal(t, "content v2", post.Content)
+
+	// 以前のリビジョンへの復元でもレビュー中の本文は変更できない
+	revisions, _, err := postUseCase.ListRevisions(ctx, draft.ID, 10, 0)
+	require.NoError(t, err)
+	initial := revisions[len(revisions)-1]
+	require.Equal(t, "content", initial.Content)
+	_, err = postUseCase.RestoreRevision(ctx, initial.ID, contributor.ID)
+	assert.ErrorIs(t, err, usecase.ErrPostInReview)
+
+	// レビュー担当者を変更するとキューが移る
+	_, err = postUseCase.AssignReviewer(ctx, draft.ID, reviewer.ID)
+	require.NoError(t, err)
+	queue, total, err := postUseCase.ListReviewQueue(ctx, reviewer.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, queue, 1)
+	assert.Equal(t, draft.ID, queue[0].ID)
+	_, total, err = postUseCase.ListReviewQueue(ctx, other.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	// 担当のレビュー担当者だけが承認・差し戻しできる
+	_, err = postUseCase.AuthorizePost(ctx, reviewer, usecase.ActionPostReview, draft.ID)
+	assert.NoError(t, err)
+	_, err = postUseCase.AuthorizePost(ctx, other, usecase.ActionPostReview, draft.ID)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+
+	// 差し戻しにはコメントが必要
+	_, err = postUseCase.Reject(ctx, draft.ID, &usecase.RejectReviewRequest{Comment: "  "})
+	assert.ErrorContains(t, err, "review comment is required")
+
+	post, err = postUseCase.Reject(ctx, draft.ID, &usecase.RejectReviewRequest{Comment: "導入を短くしてください"})
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusRejected, post.Status)
+	assert.Equal(t, "導入を短くしてください", post.ReviewComment)
+
+	// 差し戻された記事は承認できず、再提出が必要
+	_, err = postUseCase.Approve(ctx, draft.ID, &usecase.ApproveReviewRequest{})
+	assert.ErrorIs(t, err, entity.ErrInvalidTransition)
+	_, err = postUseCase.SubmitForReview(ctx, draft.ID, reviewer.ID)
+	require.NoError(t, err)
+
+	publishAt := time.Now().Add(time.Hour)
+	post, err = postUseCase.Approve(ctx, draft.ID, &usecase.ApproveReviewRequest{PublishAt: &publishAt})
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusScheduled, post.Status)
+	assert.Empty(t, post.ReviewComment)
+
+	_, total, err = postUseCase.ListReviewQueue(ctx, reviewer.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
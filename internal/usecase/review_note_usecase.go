@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/diff"
+)
+
+const (
+	// maxReviewNoteLength レビューノートの本文の最大文字数
+	maxReviewNoteLength = 5000
+	// maxReviewNoteQuoteLength レビューノートに紐付ける引用の最大文字数
+	maxReviewNoteQuoteLength = 1000
+)
+
+// ReviewNoteUseCase 記事の本文に対するレビューノートのユースケースのインターフェース
+// 記事に対する権限(ActionPostNote)はハンドラーで確認する
+type ReviewNoteUseCase interface {
+	// ListThreads 記事のレビューノートをスレッドとして取得(includeResolvedがfalseの場合は未解決のスレッドのみ)
+	ListThreads(ctx context.Context, postID int64, includeResolved bool) ([]*ReviewNoteThread, error)
+	// GetByID IDでレビューノートを取得
+	GetByID(ctx context.Context, id int64) (*entity.ReviewNote, error)
+	// Create レビューノートを作成(parentIdを指定した場合はスレッドへの返信)
+	Create(ctx context.Context, req *CreateReviewNoteRequest) (*entity.ReviewNote, error)
+	// UpdateBody レビューノートの本文を変更(書いたユーザーのみ)
+	UpdateBody(ctx context.Context, id int64, user *entity.User, body string) (*entity.ReviewNote, error)
+	// Delete レビューノートを削除(書いたユーザーと管理者のみ、スレッドの先頭の場合は返信も削除される)
+	Delete(ctx context.Context, id int64, user *entity.User) error
+	// Resolve スレッドを解決済みにする
+	Resolve(ctx context.Context, id, userID int64) (*entity.ReviewNote, error)
+	// Reopen スレッドを未解決に戻す
+	Reopen(ctx context.Context, id int64) (*entity.ReviewNote, error)
+}
+
+// CreateReviewNoteRequest レビューノート作成リクエスト
+// startLine・endLine・quoteはスレッドの先頭のノートのみ指定でき、quoteだけを指定した場合は本文中の位置から行範囲を求める
+type CreateReviewNoteRequest struct {
+	PostID    int64  `json:"postId"`
+	ParentID  *int64 `json:"parentId"`
+	Body      string `json:"body"`
+	StartLine *int   `json:"startLine"`
+	EndLine   *int   `json:"endLine"`
+	Quote     string `json:"quote"`
+	AuthorID  int64  `json:"-"`
+}
+
+// ReviewNoteThread 返信をまとめたレビューノートのスレッド
+type ReviewNoteThread struct {
+	*entity.ReviewNote
+	Replies []*entity.ReviewNote
+}
+
+// reviewNoteUseCase ReviewNoteUseCaseの実装
+type reviewNoteUseCase struct {
+	noteRepo repository.ReviewNoteRepository
+	postRepo repository.PostRepository
+}
+
+// NewReviewNoteUseCase 新しいReviewNoteUseCaseを作成
+func NewReviewNoteUseCase(noteRepo repository.ReviewNoteRepository, postRepo repository.PostRepository) ReviewNoteUseCase {
+	return &reviewNoteUseCase{
+		noteRepo: noteRepo,
+		postRepo: postRepo,
+	}
+}
+
+// ListThreads 記事のレビューノートをスレッドとして取得
+func (u *reviewNoteUseCase) ListThreads(ctx context.Context, postID int64, includeResolved bool) ([]*ReviewNoteThread, error) {
+	notes, err := u.noteRepo.ListByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make(map[int64]*ReviewNoteThread)
+	roots := make([]*ReviewNoteThread, 0)
+	for _, note := range notes {
+		if note.IsReply() {
+			continue
+		}
+		thread := &ReviewNoteThread{ReviewNote: note, Replies: make([]*entity.ReviewNote, 0)}
+		threads[note.ID] = thread
+		if includeResolved || !note.IsResolved() {
+			roots = append(roots, thread)
+		}
+	}
+	for _, note := range notes {
+		if !note.IsReply() {
+			continue
+		}
+		if thread, ok := threads[*note.ParentID]; ok {
+			thread.Replies = append(thread.Replies, note)
+		}
+	}
+
+	return roots, nil
+}
+
+// GetByID IDでレビューノートを取得
+func (u *reviewNoteUseCase) GetByID(ctx context.Context, id int64) (*entity.ReviewNote, error) {
+	return u.noteRepo.FindByID(ctx, id)
+}
+
+// Create レビューノートを作成
+func (u *reviewNoteUseCase) Create(ctx context.Context, req *CreateReviewNoteRequest) (*entity.ReviewNote, error) {
+	body, err := validateReviewNoteBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &entity.ReviewNote{
+		PostID:   req.PostID,
+		AuthorID: &req.AuthorID,
+		Body:     body,
+	}
+
+	if req.ParentID != nil {
+		if req.StartLine != nil || req.EndLine != nil || req.Quote != "" {
+			return nil, fmt.Errorf("invalid anchor: replies cannot be anchored")
+		}
+		parent, err := u.noteRepo.FindByID(ctx, *req.ParentID)
+		if err != nil || parent.PostID != req.PostID {
+			return nil, fmt.Errorf("parent review note not found")
+		}
+		// 返信への返信はスレッドの先頭にまとめる
+		rootID := parent.ID
+		if parent.IsReply() {
+			rootID = *parent.ParentID
+		}
+		note.ParentID = &rootID
+	} else if req.StartLine != nil || req.EndLine != nil || req.Quote != "" {
+		post, err := u.postRepo.FindByID(ctx, req.PostID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find post: %w", err)
+		}
+		if err := anchorReviewNote(note, post.Content, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.noteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return u.noteRepo.FindByID(ctx, note.ID)
+}
+
+// validateReviewNoteBody レビューノートの本文を検証し、前後の空白を除いた本文を返す
+func validateReviewNoteBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("review note body is required")
+	}
+	if utf8.RuneCountInString(body) > maxReviewNoteLength {
+		return "", fmt.Errorf("review note body must be at most %d characters", maxReviewNoteLength)
+	}
+	return body, nil
+}
+
+// anchorReviewNote リクエストの行範囲・引用を検証してレビューノートを本文に紐付ける
+func anchorReviewNote(note *entity.ReviewNote, content string, req *CreateReviewNoteRequest) error {
+	quote := strings.ReplaceAll(req.Quote, "\r\n", "\n")
+	if utf8.RuneCountInString(quote) > maxReviewNoteQuoteLength {
+		return fmt.Errorf("invalid anchor: quote must be at most %d characters", maxReviewNoteQuoteLength)
+	}
+
+	if req.StartLine == nil && req.EndLine == nil {
+		start, end, ok := entity.LocateQuote(content, quote, 1)
+		if !ok {
+			return fmt.Errorf("invalid anchor: quote not found in content")
+		}
+		note.StartLine, note.EndLine, note.Quote = &start, &end, quote
+		return nil
+	}
+
+	if req.StartLine == nil || req.EndLine == nil {
+		return fmt.Errorf("invalid anchor: startLine and endLine must be specified together")
+	}
+	lines := diff.SplitLines(content)
+	start, end := *req.StartLine, *req.EndLine
+	if start < 1 || start > end || end > len(lines) {
+		return fmt.Errorf("invalid anchor: line range %d-%d is out of the content (%d lines)", start, end, len(lines))
+	}
+	if quote != "" && !strings.Contains(strings.Join(lines[start-1:end], "\n"), quote) {
+		return fmt.Errorf("invalid anchor: quote not found in lines %d-%d", start, end)
+	}
+
+	note.StartLine, note.EndLine, note.Quote = &start, &end, quote
+	return nil
+}
+
+// UpdateBody レビューノートの本文を変更(書いたユーザーのみ)
+func (u *reviewNoteUseCase) UpdateBody(ctx context.Context, id int64, user *entity.User, body string) (*entity.ReviewNote, error) {
+	body, err := validateReviewNoteBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := u.noteRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if note.AuthorID == nil || *note.AuthorID != user.ID {
+		return nil, fmt.Errorf("%w: only the author can edit a review note", ErrForbidden)
+	}
+
+	note.Body = body
+	if err := u.noteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// Delete レビューノートを削除(書いたユーザーと管理者のみ)
+func (u *reviewNoteUseCase) Delete(ctx context.Context, id int64, user *entity.User) error {
+	note, err := u.noteRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	isAuthor := note.AuthorID != nil && *note.AuthorID == user.ID
+	if !isAuthor && user.Role != entity.RoleAdmin {
+		return fmt.Errorf("%w: only the author or an admin can delete a review note", ErrForbidden)
+	}
+
+	return u.noteRepo.Delete(ctx, id)
+}
+
+// Resolve スレッドを解決済みにする
+func (u *reviewNoteUseCase) Resolve(ctx context.Context, id, userID int64) (*entity.ReviewNote, error) {
+	note, err := u.findThread(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if note.IsResolved() {
+		return note, nil
+	}
+
+	note.Resolve(userID, time.Now())
+	if err := u.noteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// Reopen スレッドを未解決に戻す
+func (u *reviewNoteUseCase) Reopen(ctx context.Context, id int64) (*entity.ReviewNote, error) {
+	note, err := u.findThread(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !note.IsResolved() {
+		return note, nil
+	}
+
+	note.Reopen()
+	if err := u.noteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// findThread スレッドの先頭のノートを取得(返信は解決・再開できない)
+func (u *reviewNoteUseCase) findThread(ctx context.Context, id int64) (*entity.ReviewNote, error) {
+	note, err := u.noteRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if note.IsReply() {
+		return nil, fmt.Errorf("invalid review note: only a thread can be resolved")
+	}
+	return note, nil
+}
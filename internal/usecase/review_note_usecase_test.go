@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/infrastructure/renderer"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewNoteUseCase(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	postRepo := persistence.NewPostRepository(db)
+	noteRepo := persistence.NewReviewNoteRepository(db)
+	postUseCase := usecase.NewPostUseCase(
+		postRepo,
+		persistence.NewCategoryRepository(db),
+		persistence.NewTagRepository(db),
+		userRepo,
+		persistence.NewPostRevisionRepository(db),
+		persistence.NewPostShareRepository(db),
+		noteRepo,
+		usecase.NewAuthorizer(),
+		newTestAudit(db),
+		renderer.NewMarkdownRenderer(renderer.NewMockMermaidRenderer(), nil),
+	)
+	noteUseCase := usecase.NewReviewNoteUseCase(noteRepo, postRepo)
+
+	newUser := func(username string, role entity.UserRole) *entity.User {
+		user := &entity.User{
+			Username:     username,
+			Email:        username + "@example.com",
+			PasswordHash: "hash",
+			Role:         role,
+			Status:       entity.StatusActive,
+		}
+		require.NoError(t, userRepo.Create(ctx, user))
+		return user
+	}
+	author := newUser("author", entity.RoleContributor)
+	reviewer := newUser("reviewer", entity.RoleEditor)
+
+	post, err := postUseCase.Create(ctx, &usecase.CreatePostRequest{
+		Title:    "Draft",
+		Slug:     "draft",
+		Content:  "# Title\nfirst paragraph\nsecond paragraph",
+		Status:   "draft",
+		AuthorID: author.ID,
+	})
+	require.NoError(t, err)
+
+	// 引用から行範囲を求める
+	quoted, err := noteUseCase.Create(ctx, &usecase.CreateReviewNoteRequest{
+		PostID:   post.ID,
+		Body:     "言い換えてください",
+		Quote:    "second paragraph",
+		AuthorID: reviewer.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, *quoted.StartLine)
+	assert.Equal(t, 3, *quoted.EndLine)
+
+	start, end := 2, 2
+	lined, err := noteUseCase.Create(ctx, &usecase.CreateReviewNoteRequest{
+		PostID:    post.ID,
+		Body:      "段落を分けてください",
+		StartLine: &start,
+		EndLine:   &end,
+		AuthorID:  reviewer.ID,
+	})
+	require.NoError(t, err)
+
+	// 本文の範囲外や見つからない引用は紐付けられない
+	outOfRange := 10
+	_, err = noteUseCase.Create(ctx, &usecase.CreateReviewNoteRequest{PostID: post.ID, Body: "x", StartLine: &start, EndLine: &outOfRange, AuthorID: reviewer.ID})
+	assert.ErrorContains(t, err, "invalid anchor")
+	_, err = noteUseCase.Create(ctx, &usecase.CreateReviewNoteRequest{PostID: post.ID, Body: "x", Quote: "missing", AuthorID: reviewer.ID})
+	assert.ErrorContains(t, err, "invalid anchor")
+	_, err = noteUseCase.Create(ctx, &usecase.CreateReviewNoteRequest{PostID: post.ID, Body: " ", AuthorID: reviewer.ID})
+	assert.ErrorContains(t, err, "review note body is required")
+
+	// 返信への返信はスレッドの先頭にまとめる
+	reply, err := noteUseCase.Create(ctx, &usecase.CreateReviewNoteRequest{PostID: post.ID, ParentID: &quoted.ID, Body: "直しました", AuthorID: author.ID})
+	require.NoError(t, err)
+	nested, err := noteUseCase.Create(ctx, &usecase.CreateReviewNoteRequest{PostID: post.ID, ParentID: &reply.ID, Body: "確認しました", AuthorID: reviewer.ID})
+	require.NoError(t, err)
+	assert.Equal(t, quoted.ID, *nested.ParentID)
+
+	threads, err := noteUseCase.ListThreads(ctx, post.ID, false)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+	assert.Equal(t, quoted.ID, threads[0].ID)
+	assert.Len(t, threads[0].Replies, 2)
+
+	// 本文を変更すると紐付けた行範囲が更新される
+	content := "# Title\nintro\nfirst paragraph, edited\nsecond paragraph"
+	_, err = postUseCase.Update(ctx, post.ID, &usecase.UpdatePostRequest{Content: &content, EditorID: author.ID})
+	require.NoError(t, err)
+
+	moved, err := noteUseCase.GetByID(ctx, quoted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, *moved.StartLine)
+	assert.False(t, moved.Outdated)
+	outdated, err := noteUseCase.GetByID(ctx, lined.ID)
+	require.NoError(t, err)
+	assert.True(t, outdated.Outdated)
+
+	// 書いたユーザー以外は本文を変更できない
+	_, err = noteUseCase.UpdateBody(ctx, quoted.ID, author, "changed")
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	updated, err := noteUseCase.UpdateBody(ctx, quoted.ID, reviewer, "言い換えをお願いします")
+	require.NoError(t, err)
+	assert.Equal(t, "言い換えをお願いします", updated.Body)
+
+	// 解決済みのスレッドは既定では表示しない
+	_, err = noteUseCase.Resolve(ctx, quoted.ID, author.ID)
+	require.NoError(t, err)
+	_, err = noteUseCase.Resolve(ctx, reply.ID, author.ID)
+	assert.ErrorContains(t, err, "invalid review note")
+	threads, err = noteUseCase.ListThreads(ctx, post.ID, false)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	assert.Equal(t, lined.ID, threads[0].ID)
+	threads, err = noteUseCase.ListThreads(ctx, post.ID, true)
+	require.NoError(t, err)
+	assert.Len(t, threads, 2)
+
+	reopened, err := noteUseCase.Reopen(ctx, quoted.ID)
+	require.NoError(t, err)
+	assert.False(t, reopened.IsResolved())
+
+	// 書いたユーザー以外は削除できない(管理者を除く)
+	err = noteUseCase.Delete(ctx, lined.ID, author)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	require.NoError(t, noteUseCase.Delete(ctx, lined.ID, reviewer))
+}
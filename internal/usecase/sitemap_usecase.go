@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"my-blog-engine/internal/domain/repository"
+)
+
+// SitemapEntryKind サイトマップに掲載するページの種類
+type SitemapEntryKind string
+
+const (
+	SitemapEntryHome     SitemapEntryKind = "home"
+	SitemapEntryCategory SitemapEntryKind = "category"
+	SitemapEntryTag      SitemapEntryKind = "tag"
+	SitemapEntryPost     SitemapEntryKind = "post"
+)
+
+// SitemapEntry サイトマップに掲載するページ
+type SitemapEntry struct {
+	Kind SitemapEntryKind
+	// Slug カテゴリ・タグ・記事のスラッグ(ホームの場合は空文字列)
+	Slug string
+	// LastModified 最終更新日時(不明な場合はゼロ値)
+	LastModified time.Time
+}
+
+// SitemapUseCase サイトマップユースケースのインターフェース
+type SitemapUseCase interface {
+	// Count サイトマップに掲載するページ数を取得
+	Count(ctx context.Context) (int, error)
+	// List ホーム・カテゴリ・タグ・公開記事の順に並べたページのうち、offsetから最大limit件を取得
+	List(ctx context.Context, limit, offset int) ([]*SitemapEntry, error)
+}
+
+// sitemapUseCase SitemapUseCaseの実装
+type sitemapUseCase struct {
+	postRepo     repository.PostRepository
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+}
+
+// NewSitemapUseCase 新しいSitemapUseCaseを作成
+func NewSitemapUseCase(
+	postRepo repository.PostRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+) SitemapUseCase {
+	return &sitemapUseCase{
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+	}
+}
+
+// Count サイトマップに掲載するページ数を取得
+func (u *sitemapUseCase) Count(ctx context.Context) (int, error) {
+	categories, err := u.categoryRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	tags, err := u.tagRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	posts, err := u.postRepo.CountPublished(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count published posts: %w", err)
+	}
+
+	return 1 + len(categories) + len(tags) + posts, nil
+}
+
+// List ホーム・カテゴリ・タグ・公開記事の順に並べたページのうち、offsetから最大limit件を取得
+func (u *sitemapUseCase) List(ctx context.Context, limit, offset int) ([]*SitemapEntry, error) {
+	pages, err := u.listPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*SitemapEntry, 0, limit)
+	if offset < len(pages) {
+		end := min(offset+limit, len(pages))
+		entries = append(entries, pages[offset:end]...)
+	}
+
+	// 残りを公開記事で埋める
+	remaining := limit - len(entries)
+	if remaining <= 0 {
+		return entries, nil
+	}
+
+	posts, err := u.postRepo.ListPublishedSitemapEntries(ctx, remaining, max(offset-len(pages), 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published posts: %w", err)
+	}
+
+	for _, post := range posts {
+		entries = append(entries, &SitemapEntry{
+			Kind:         SitemapEntryPost,
+			Slug:         post.Slug,
+			LastModified: post.UpdatedAt,
+		})
+	}
+
+	return entries, nil
+}
+
+// listPages ホーム・カテゴリ・タグのページを取得
+// カテゴリ・タグの最終更新日時は、自身の更新日時と掲載記事の最終更新日時のうち新しい方
+func (u *sitemapUseCase) listPages(ctx context.Context) ([]*SitemapEntry, error) {
+	categories, err := u.categoryRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	tags, err := u.tagRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	categoryUpdates, err := u.postRepo.LastUpdatedByCategory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category updates: %w", err)
+	}
+
+	tagUpdates, err := u.postRepo.LastUpdatedByTag(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag updates: %w", err)
+	}
+
+	// ホームの最終更新日時は掲載記事のうち最新のもの
+	latest, err := u.postRepo.ListPublishedSitemapEntries(ctx, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published posts: %w", err)
+	}
+
+	home := &SitemapEntry{Kind: SitemapEntryHome}
+	if len(latest) > 0 {
+		home.LastModified = latest[0].UpdatedAt
+	}
+
+	pages := make([]*SitemapEntry, 0, 1+len(categories)+len(tags))
+	pages = append(pages, home)
+
+	categoryLastMod := groupLastModified(categoryUpdates)
+	for _, category := range categories {
+		pages = append(pages, &SitemapEntry{
+			Kind:         SitemapEntryCategory,
+			Slug:         category.Slug,
+			LastModified: laterOf(category.UpdatedAt, categoryLastMod[category.ID]),
+		})
+	}
+
+	tagLastMod := groupLastModified(tagUpdates)
+	for _, tag := range tags {
+		pages = append(pages, &SitemapEntry{
+			Kind:         SitemapEntryTag,
+			Slug:         tag.Slug,
+			LastModified: laterOf(tag.UpdatedAt, tagLastMod[tag.ID]),
+		})
+	}
+
+	return pages, nil
+}
+
+// groupLastModified グループIDごとの最終更新日時のマップを作成
+func groupLastModified(updates []*repository.PostGroupUpdate) map[int64]time.Time {
+	m := make(map[int64]time.Time, len(updates))
+	for _, update := range updates {
+		m[update.GroupID] = update.UpdatedAt
+	}
+	return m
+}
+
+// laterOf 2つの日時のうち新しい方を返す
+func laterOf(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
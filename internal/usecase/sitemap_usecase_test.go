@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSitemapUseCase_List(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	postRepo := persistence.NewPostRepository(db)
+	userRepo := persistence.NewUserRepository(db)
+	categoryRepo := persistence.NewCategoryRepository(db)
+	tagRepo := persistence.NewTagRepository(db)
+	sitemapUseCase := usecase.NewSitemapUseCase(postRepo, categoryRepo, tagRepo)
+
+	user := &entity.User{
+		Username:     "testuser",
+		Email:        "test@example.com",
+		PasswordHash: "hash",
+		Role:         entity.RoleEditor,
+		Status:       entity.StatusActive,
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	category := &entity.Category{Name: "Go", Slug: "go"}
+	require.NoError(t, categoryRepo.Create(ctx, category))
+
+	tag := &entity.Tag{Name: "Backend", Slug: "backend"}
+	require.NoError(t, tagRepo.Create(ctx, tag))
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i, status := range []entity.PostStatus{entity.StatusPublished, entity.StatusPublished, entity.StatusDraft} {
+		publishedAt := base.Add(time.Duration(i) * time.Minute)
+		post := &entity.Post{
+			Title:        "Post",
+			Slug:         "post-" + string(rune('a'+i)),
+			Content:      "content",
+			RenderedHTML: "<p>content</p>",
+			Status:       status,
+			AuthorID:     user.ID,
+			CategoryID:   &category.ID,
+			PublishedAt:  &publishedAt,
+		}
+		require.NoError(t, postRepo.Create(ctx, post))
+	}
+
+	// ホーム + カテゴリ + タグ + 公開記事2件(下書きは含まない)
+	count, err := sitemapUseCase.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	entries, err := sitemapUseCase.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	assert.Equal(t, usecase.SitemapEntryHome, entries[0].Kind)
+	assert.False(t, entries[0].LastModified.IsZero())
+	assert.Equal(t, usecase.SitemapEntryCategory, entries[1].Kind)
+	assert.Equal(t, "go", entries[1].Slug)
+	assert.Equal(t, usecase.SitemapEntryTag, entries[2].Kind)
+	assert.Equal(t, "backend", entries[2].Slug)
+	assert.Equal(t, usecase.SitemapEntryPost, entries[3].Kind)
+	assert.Equal(t, "post-b", entries[3].Slug)
+	assert.Equal(t, "post-a", entries[4].Slug)
+
+	// 固定ページと記事の境界をまたぐ範囲
+	entries, err = sitemapUseCase.List(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "backend", entries[0].Slug)
+	assert.Equal(t, "post-b", entries[1].Slug)
+
+	// 記事のみの範囲
+	entries, err = sitemapUseCase.List(ctx, 10, 4)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "post-a", entries[0].Slug)
+}
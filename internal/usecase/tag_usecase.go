@@ -33,12 +33,14 @@ type UpdateTagRequest struct {
 // tagUseCase TagUseCaseの実装
 type tagUseCase struct {
 	tagRepo repository.TagRepository
+	audit   AuditUseCase
 }
 
 // NewTagUseCase 新しいTagUseCaseを作成
-func NewTagUseCase(tagRepo repository.TagRepository) TagUseCase {
+func NewTagUseCase(tagRepo repository.TagRepository, audit AuditUseCase) TagUseCase {
 	return &tagUseCase{
 		tagRepo: tagRepo,
+		audit:   audit,
 	}
 }
 
@@ -57,6 +59,13 @@ func (u *tagUseCase) Create(ctx context.Context, req *CreateTagRequest) (*entity
 		return nil, fmt.Errorf("failed to create tag: %w", err)
 	}
 
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditTagCreate,
+		TargetType: entity.AuditTargetTag,
+		TargetID:   tag.ID,
+		After:      tagAuditSnapshot(tag),
+	})
+
 	return tag, nil
 }
 
@@ -66,6 +75,7 @@ func (u *tagUseCase) Update(ctx context.Context, id int64, req *UpdateTagRequest
 	if err != nil {
 		return nil, fmt.Errorf("failed to find tag: %w", err)
 	}
+	before := tagAuditSnapshot(tag)
 
 	if req.Name != nil {
 		tag.Name = *req.Name
@@ -78,14 +88,35 @@ func (u *tagUseCase) Update(ctx context.Context, id int64, req *UpdateTagRequest
 		return nil, fmt.Errorf("failed to update tag: %w", err)
 	}
 
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditTagUpdate,
+		TargetType: entity.AuditTargetTag,
+		TargetID:   tag.ID,
+		Before:     before,
+		After:      tagAuditSnapshot(tag),
+	})
+
 	return tag, nil
 }
 
 // Delete タグを削除
 func (u *tagUseCase) Delete(ctx context.Context, id int64) error {
+	tag, err := u.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find tag: %w", err)
+	}
+
 	if err := u.tagRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete tag: %w", err)
 	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditTagDelete,
+		TargetType: entity.AuditTargetTag,
+		TargetID:   id,
+		Before:     tagAuditSnapshot(tag),
+	})
+
 	return nil
 }
 
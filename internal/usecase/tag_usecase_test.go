@@ -17,7 +17,7 @@ func TestTagUseCase_Create(t *testing.T) {
 	defer cleanup()
 
 	tagRepo := persistence.NewTagRepository(db)
-	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -37,7 +37,7 @@ func TestTagUseCase_Update(t *testing.T) {
 	defer cleanup()
 
 	tagRepo := persistence.NewTagRepository(db)
-	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -66,7 +66,7 @@ func TestTagUseCase_Delete(t *testing.T) {
 	defer cleanup()
 
 	tagRepo := persistence.NewTagRepository(db)
-	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -92,7 +92,7 @@ func TestTagUseCase_GetBySlug(t *testing.T) {
 	defer cleanup()
 
 	tagRepo := persistence.NewTagRepository(db)
-	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
@@ -116,7 +116,7 @@ func TestTagUseCase_List(t *testing.T) {
 	defer cleanup()
 
 	tagRepo := persistence.NewTagRepository(db)
-	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, newTestAudit(db))
 
 	ctx := context.Background()
 
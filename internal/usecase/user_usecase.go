@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/domain/repository"
+	"my-blog-engine/internal/infrastructure/auth"
+)
+
+const (
+	// maxUsernameLength ユーザー名の最大文字数
+	maxUsernameLength = 50
+	// maxUserEmailLength メールアドレスの最大文字数
+	maxUserEmailLength = 100
+)
+
+// usernamePattern ユーザー名に使用できる文字(著者ページのURLに使用するため)
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// UserUseCase ユーザー管理ユースケースのインターフェース
+type UserUseCase interface {
+	Create(ctx context.Context, req *CreateUserRequest) (*entity.User, error)
+	Update(ctx context.Context, id int64, req *UpdateUserRequest) (*entity.User, error)
+	ResetPassword(ctx context.Context, id int64, req *ResetPasswordRequest) error
+	Deactivate(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entity.User, error)
+	List(ctx context.Context, limit, offset int) ([]*entity.User, int, error)
+}
+
+// CreateUserRequest ユーザー作成リクエスト
+type CreateUserRequest struct {
+	Username string          `json:"username"`
+	Email    string          `json:"email"`
+	Password string          `json:"password"`
+	Role     entity.UserRole `json:"role"`
+}
+
+// UpdateUserRequest ユーザー更新リクエスト(ロール・ステータスのみ変更可能)
+type UpdateUserRequest struct {
+	Role   *entity.UserRole   `json:"role"`
+	Status *entity.UserStatus `json:"status"`
+}
+
+// ResetPasswordRequest パスワード再設定リクエスト
+type ResetPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+// userUseCase UserUseCaseの実装
+type userUseCase struct {
+	userRepo       repository.UserRepository
+	passwordHasher auth.PasswordHasher
+	passwordPolicy PasswordPolicy
+	audit          AuditUseCase
+}
+
+// NewUserUseCase 新しいUserUseCaseを作成
+func NewUserUseCase(userRepo repository.UserRepository, passwordHasher auth.PasswordHasher, passwordPolicy PasswordPolicy, audit AuditUseCase) UserUseCase {
+	return &userUseCase{
+		userRepo:       userRepo,
+		passwordHasher: passwordHasher,
+		passwordPolicy: passwordPolicy,
+		audit:          audit,
+	}
+}
+
+// Create 新しいユーザーを作成(ロールの指定がない場合はviewer)
+func (u *userUseCase) Create(ctx context.Context, req *CreateUserRequest) (*entity.User, error) {
+	user := &entity.User{
+		Username: strings.TrimSpace(req.Username),
+		Email:    strings.TrimSpace(req.Email),
+		Role:     req.Role,
+		Status:   entity.StatusActive,
+	}
+	if user.Role == "" {
+		user.Role = entity.RoleViewer
+	}
+
+	if err := validateUsername(user.Username); err != nil {
+		return nil, err
+	}
+	if err := validateUserEmail(user.Email); err != nil {
+		return nil, err
+	}
+	if !user.Role.IsValid() {
+		return nil, fmt.Errorf("invalid role: %s", user.Role)
+	}
+	if err := u.passwordPolicy.Validate(ctx, req.Password, user.Username, user.Email); err != nil {
+		return nil, err
+	}
+
+	// 一意制約違反をデータベースのエラーではなく入力エラーとして返す
+	if _, err := u.userRepo.FindByUsername(ctx, user.Username); err == nil {
+		return nil, fmt.Errorf("username already exists")
+	}
+	if _, err := u.userRepo.FindByEmail(ctx, user.Email); err == nil {
+		return nil, fmt.Errorf("email already exists")
+	}
+
+	passwordHash, err := u.passwordHasher.Hash(req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash = passwordHash
+
+	if err := u.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditUserCreate,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+		After:      userAuditSnapshot(user),
+	})
+
+	return user, nil
+}
+
+// Update ユーザーのロール・ステータスを変更
+func (u *userUseCase) Update(ctx context.Context, id int64, req *UpdateUserRequest) (*entity.User, error) {
+	return u.update(ctx, id, req, entity.AuditUserUpdate)
+}
+
+// update ユーザーのロール・ステータスを変更し、指定した操作として監査ログに記録
+func (u *userUseCase) update(ctx context.Context, id int64, req *UpdateUserRequest, action entity.AuditAction) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := userAuditSnapshot(user)
+
+	wasActiveAdmin := user.IsActiveAdmin()
+
+	if req.Role != nil {
+		if !req.Role.IsValid() {
+			return nil, fmt.Errorf("invalid role: %s", *req.Role)
+		}
+		user.Role = *req.Role
+	}
+	if req.Status != nil {
+		if !req.Status.IsValid() {
+			return nil, fmt.Errorf("invalid status: %s", *req.Status)
+		}
+		user.Status = *req.Status
+	}
+
+	// 有効な管理者がいなくなるとユーザー管理ができなくなるため、最後の管理者の降格・無効化は拒否する
+	// (人数の確認と更新は同時の変更で管理者がいなくならないようリポジトリで1つのトランザクションにする)
+	if wasActiveAdmin && !user.IsActiveAdmin() {
+		updated, err := u.userRepo.UpdateKeepingActiveAdmin(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			return nil, fmt.Errorf("cannot remove the last active admin")
+		}
+	} else if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     action,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   id,
+		Before:     before,
+		After:      userAuditSnapshot(user),
+	})
+
+	return user, nil
+}
+
+// ResetPassword ユーザーのパスワードを再設定(再設定前に発行されたトークンは無効になる)
+// パスワードの更新と同じトランザクションでセッションとリフレッシュトークンも失効させる
+func (u *userUseCase) ResetPassword(ctx context.Context, id int64, req *ResetPasswordRequest) error {
+	user, err := u.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.passwordPolicy.Validate(ctx, req.Password, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	passwordHash, err := u.passwordHasher.Hash(req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.ChangePassword(passwordHash, time.Now())
+
+	if err := u.userRepo.UpdatePassword(ctx, user); err != nil {
+		return err
+	}
+
+	// パスワードハッシュは記録しない
+	u.audit.Record(ctx, &AuditEntry{
+		Action:     entity.AuditUserResetPassword,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   id,
+	})
+
+	return nil
+}
+
+// Deactivate ユーザーを無効化
+// 記事は著者の削除に連動して削除されるため、ユーザーは削除せずログインできない状態にする
+func (u *userUseCase) Deactivate(ctx context.Context, id int64) error {
+	status := entity.StatusInactive
+	if _, err := u.update(ctx, id, &UpdateUserRequest{Status: &status}, entity.AuditUserDeactivate); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByID IDでユーザーを取得
+func (u *userUseCase) GetByID(ctx context.Context, id int64) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// List ユーザー一覧を取得
+func (u *userUseCase) List(ctx context.Context, limit, offset int) ([]*entity.User, int, error) {
+	users, err := u.userRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	count, err := u.userRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return users, count, nil
+}
+
+// validateUsername ユーザー名を検証
+func validateUsername(username string) error {
+	if username == "" {
+		return fmt.Errorf("username is required")
+	}
+	if len(username) > maxUsernameLength || !usernamePattern.MatchString(username) {
+		return fmt.Errorf("invalid username: use up to %d letters, digits, '_', '.' or '-'", maxUsernameLength)
+	}
+	return nil
+}
+
+// validateUserEmail メールアドレスを検証
+func validateUserEmail(email string) error {
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > maxUserEmailLength {
+		return fmt.Errorf("invalid email address")
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"my-blog-engine/internal/domain/entity"
+	"my-blog-engine/internal/infrastructure/auth"
+	"my-blog-engine/internal/infrastructure/persistence"
+	"my-blog-engine/internal/usecase"
+	"my-blog-engine/tests/integration/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUserUseCase(t *testing.T) (usecase.UserUseCase, auth.PasswordHasher, func()) {
+	t.Helper()
+
+	db, cleanup := testhelper.SetupTestDB(t)
+
+	userRepo := persistence.NewUserRepository(db)
+	passwordHasher := auth.NewPasswordHasher()
+
+	return usecase.NewUserUseCase(userRepo, passwordHasher, usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), newTestAudit(db)), passwordHasher, cleanup
+}
+
+// createTestUser テスト用にユーザーを作成
+func createTestUser(t *testing.T, userUseCase usecase.UserUseCase, username string, role entity.UserRole) *entity.User {
+	t.Helper()
+
+	user, err := userUseCase.Create(context.Background(), &usecase.CreateUserRequest{
+		Username: username,
+		Email:    username + "@example.com",
+		Password: "password123",
+		Role:     role,
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestUserUseCase_Create(t *testing.T) {
+	userUseCase, passwordHasher, cleanup := setupUserUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	user, err := userUseCase.Create(ctx, &usecase.CreateUserRequest{
+		Username: "editor",
+		Email:    "editor@example.com",
+		Password: "password123",
+		Role:     entity.RoleEditor,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, user.ID)
+	assert.Equal(t, entity.RoleEditor, user.Role)
+	assert.Equal(t, entity.StatusActive, user.Status)
+	// パスワードはハッシュ化して保存する
+	assert.NoError(t, passwordHasher.Verify(user.PasswordHash, "password123"))
+
+	// ロールの指定がない場合はviewer
+	viewer := createTestUser(t, userUseCase, "viewer", "")
+	assert.Equal(t, entity.RoleViewer, viewer.Role)
+
+	users, total, err := userUseCase.List(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, users, 2)
+}
+
+func TestUserUseCase_Create_Invalid(t *testing.T) {
+	userUseCase, _, cleanup := setupUserUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	createTestUser(t, userUseCase, "existing", entity.RoleEditor)
+
+	tests := []struct {
+		name    string
+		req     usecase.CreateUserRequest
+		wantErr string
+	}{
+		{
+			name:    "username is required",
+			req:     usecase.CreateUserRequest{Email: "a@example.com", Password: "password123"},
+			wantErr: "username is required",
+		},
+		{
+			name:    "invalid username",
+			req:     usecase.CreateUserRequest{Username: "a b", Email: "a@example.com", Password: "password123"},
+			wantErr: "invalid username",
+		},
+		{
+			name:    "invalid email",
+			req:     usecase.CreateUserRequest{Username: "newuser", Email: "not-an-email", Password: "password123"},
+			wantErr: "invalid email address",
+		},
+		{
+			name:    "invalid role",
+			req:     usecase.CreateUserRequest{Username: "newuser", Email: "a@example.com", Password: "password123", Role: "owner"},
+			wantErr: "invalid role",
+		},
+		{
+			name:    "short password",
+			req:     usecase.CreateUserRequest{Username: "newuser", Email: "a@example.com", Password: "short"},
+			wantErr: "password must be at least",
+		},
+		{
+			name:    "long password",
+			req:     usecase.CreateUserRequest{Username: "newuser", Email: "a@example.com", Password: strings.Repeat("a", 73)},
+			wantErr: "password is too long",
+		},
+		{
+			name:    "password contains username",
+			req:     usecase.CreateUserRequest{Username: "newuser", Email: "a@example.com", Password: "NewUser-2025"},
+			wantErr: "password must not contain the username",
+		},
+		{
+			name:    "duplicate username",
+			req:     usecase.CreateUserRequest{Username: "existing", Email: "a@example.com", Password: "password123"},
+			wantErr: "username already exists",
+		},
+		{
+			name:    "duplicate email",
+			req:     usecase.CreateUserRequest{Username: "newuser", Email: "existing@example.com", Password: "password123"},
+			wantErr: "email already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := userUseCase.Create(ctx, &tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestUserUseCase_Update(t *testing.T) {
+	userUseCase, _, cleanup := setupUserUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	user := createTestUser(t, userUseCase, "editor", entity.RoleEditor)
+
+	role := entity.RoleAdmin
+	updated, err := userUseCase.Update(ctx, user.ID, &usecase.UpdateUserRequest{Role: &role})
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleAdmin, updated.Role)
+
+	invalid := entity.UserStatus("banned")
+	_, err = userUseCase.Update(ctx, user.ID, &usecase.UpdateUserRequest{Status: &invalid})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status")
+
+	_, err = userUseCase.Update(ctx, 99999, &usecase.UpdateUserRequest{Role: &role})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestUserUseCase_LastActiveAdmin(t *testing.T) {
+	userUseCase, _, cleanup := setupUserUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := createTestUser(t, userUseCase, "admin", entity.RoleAdmin)
+	editor := createTestUser(t, userUseCase, "editor", entity.RoleEditor)
+
+	// 最後の管理者は降格・無効化できない
+	role := entity.RoleEditor
+	_, err := userUseCase.Update(ctx, admin.ID, &usecase.UpdateUserRequest{Role: &role})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "last active admin")
+
+	err = userUseCase.Deactivate(ctx, admin.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "last active admin")
+
+	found, err := userUseCase.GetByID(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.True(t, found.IsActiveAdmin())
+
+	// 他に有効な管理者がいれば降格・無効化できる
+	adminRole := entity.RoleAdmin
+	_, err = userUseCase.Update(ctx, editor.ID, &usecase.UpdateUserRequest{Role: &adminRole})
+	require.NoError(t, err)
+
+	require.NoError(t, userUseCase.Deactivate(ctx, admin.ID))
+	found, err = userUseCase.GetByID(ctx, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusInactive, found.Status)
+
+	// 残った1人は無効化できない
+	err = userUseCase.Deactivate(ctx, editor.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "last active admin")
+
+	// 無効な管理者の再有効化は制限しない
+	active := entity.StatusActive
+	_, err = userUseCase.Update(ctx, admin.ID, &usecase.UpdateUserRequest{Status: &active})
+	require.NoError(t, err)
+}
+
+func TestUserUseCase_LastActiveAdmin_Concurrent(t *testing.T) {
+	userUseCase, _, cleanup := setupUserUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	first := createTestUser(t, userUseCase, "admin1", entity.RoleAdmin)
+	second := createTestUser(t, userUseCase, "admin2", entity.RoleAdmin)
+
+	// 最後の2人の管理者を同時に降格しても、どちらか一方しか成功しない
+	role := entity.RoleEditor
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, admin := range []*entity.User{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = userUseCase.Update(ctx, admin.ID, &usecase.UpdateUserRequest{Role: &role})
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.Contains(t, err.Error(), "last active admin")
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+
+	admins := 0
+	for _, admin := range []*entity.User{first, second} {
+		found, err := userUseCase.GetByID(ctx, admin.ID)
+		require.NoError(t, err)
+		if found.IsActiveAdmin() {
+			admins++
+		}
+	}
+	assert.Equal(t, 1, admins)
+}
+
+func TestUserUseCase_ResetPassword(t *testing.T) {
+	userUseCase, passwordHasher, cleanup := setupUserUseCase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	user := createTestUser(t, userUseCase, "editor", entity.RoleEditor)
+
+	require.NoError(t, userUseCase.ResetPassword(ctx, user.ID, &usecase.ResetPasswordRequest{Password: "new-password"}))
+
+	found, err := userUseCase.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.NoError(t, passwordHasher.Verify(found.PasswordHash, "new-password"))
+	assert.Error(t, passwordHasher.Verify(found.PasswordHash, "password123"))
+
+	err = userUseCase.ResetPassword(ctx, user.ID, &usecase.ResetPasswordRequest{Password: "short"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "password must be at least")
+
+	err = userUseCase.ResetPassword(ctx, 99999, &usecase.ResetPasswordRequest{Password: "new-password"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestUserUseCase_ResetPassword_RevokesSessions(t *testing.T) {
+	db, cleanup := testhelper.SetupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	userRepo := persistence.NewUserRepository(db)
+	passwordHasher := auth.NewPasswordHasher()
+	userUseCase := usecase.NewUserUseCase(userRepo, passwordHasher, usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), newTestAudit(db))
+
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
+		SecretKey:     "test-secret-key-min-32-chars-long",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: 168 * time.Hour,
+	})
+	require.NoError(t, err)
+	authUseCase := usecase.NewAuthUseCase(userRepo, persistence.NewTokenRepository(db), persistence.NewRefreshTokenRepository(db), persistence.NewSessionRepository(db), jwtManager, passwordHasher, usecase.NewPasswordPolicy(usecase.PasswordPolicyConfig{}, nil), newTestMFAUseCase(t, db), newTestLoginProtection(t, db), newTestAudit(db), 15*time.Minute)
+
+	user := createTestUser(t, userUseCase, "editor", entity.RoleEditor)
+	login, err := authUseCase.Login(ctx, "editor", "password123")
+	require.NoError(t, err)
+
+	// 再設定と同じ秒に発行されたトークンも、セッションの失効で使えなくなる
+	require.NoError(t, userUseCase.ResetPassword(ctx, user.ID, &usecase.ResetPasswordRequest{Password: "new-password"}))
+
+	_, err = authUseCase.ValidateToken(ctx, login.AccessToken)
+	assert.ErrorContains(t, err, "revoked")
+	_, err = authUseCase.RefreshToken(ctx, login.RefreshToken)
+	assert.Error(t, err)
+
+	// 再設定前のログインはセッション一覧に残らない
+	relogin, err := authUseCase.Login(ctx, "editor", "new-password")
+	require.NoError(t, err)
+	sessions, err := authUseCase.ListSessions(ctx, relogin.AccessToken)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.True(t, sessions[0].Current)
+}
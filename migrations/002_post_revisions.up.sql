@@ -0,0 +1,16 @@
+-- post_revisionsテーブル
+-- 記事更新ごとのスナップショット(カテゴリ・タグは削除されても履歴として残すため外部キーなし)
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    post_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    category_id BIGINT NULL,
+    tag_ids JSON NULL,
+    editor_id BIGINT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (editor_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_post_revisions_post (post_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
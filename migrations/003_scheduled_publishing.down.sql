@@ -0,0 +1,7 @@
+-- 公開予約中の記事は下書きに戻してからENUMを縮小する
+UPDATE posts SET status = 'draft' WHERE status = 'scheduled';
+
+ALTER TABLE posts
+    DROP INDEX idx_status_scheduled_at,
+    DROP COLUMN scheduled_at,
+    MODIFY COLUMN status ENUM('draft', 'published') NOT NULL DEFAULT 'draft';
@@ -0,0 +1,6 @@
+-- 公開予約
+-- scheduledステータスとscheduled_atを追加し、公開ワーカーが期限到来分を効率よく検索できるようにする
+ALTER TABLE posts
+    MODIFY COLUMN status ENUM('draft', 'published', 'scheduled') NOT NULL DEFAULT 'draft',
+    ADD COLUMN scheduled_at TIMESTAMP NULL AFTER published_at,
+    ADD INDEX idx_status_scheduled_at (status, scheduled_at);
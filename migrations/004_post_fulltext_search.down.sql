@@ -0,0 +1,2 @@
+ALTER TABLE posts
+    DROP INDEX ft_posts_title_content;
@@ -0,0 +1,5 @@
+-- 記事の全文検索用インデックス
+-- 本文の大半が日本語で空白による分かち書きができないため、ngramパーサーを使用する
+-- (トークン長はサーバー設定 ngram_token_size に従う。既定値は2)
+ALTER TABLE posts
+    ADD FULLTEXT INDEX ft_posts_title_content (title, content) WITH PARSER ngram;
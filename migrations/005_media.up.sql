@@ -0,0 +1,18 @@
+-- mediaテーブル
+-- アップロードされた画像のメタデータ(ファイル本体はストレージにstorage_keyで保存)
+CREATE TABLE IF NOT EXISTS media (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    owner_id BIGINT NULL,
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    original_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    alt_text VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_media_owner (owner_id),
+    INDEX idx_media_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
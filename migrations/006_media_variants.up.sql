@@ -0,0 +1,14 @@
+-- media_variantsテーブル
+-- アップロード時に生成した幅別・形式別の画像(レスポンシブ画像のsrcsetに使用)
+CREATE TABLE IF NOT EXISTS media_variants (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    media_id BIGINT NOT NULL,
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (media_id) REFERENCES media(id) ON DELETE CASCADE,
+    INDEX idx_media_variants_media (media_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,21 @@
+-- commentsテーブル
+-- 読者のコメント(parent_idで返信をスレッド化し、承認されたもののみ公開する)
+CREATE TABLE IF NOT EXISTS comments (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    post_id BIGINT NOT NULL,
+    parent_id BIGINT NULL,
+    author_name VARCHAR(100) NOT NULL,
+    author_email VARCHAR(255) NOT NULL DEFAULT '',
+    author_url VARCHAR(255) NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    rendered_html TEXT NOT NULL,
+    status ENUM('pending', 'approved', 'spam', 'deleted') NOT NULL DEFAULT 'pending',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
+    INDEX idx_comments_post_status (post_id, status, created_at),
+    INDEX idx_comments_status_created_at (status, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,17 @@
+-- invitationsテーブル
+-- 新しいユーザーの招待(トークンはSHA-256のハッシュのみを保存し、受諾後は再利用できない)
+CREATE TABLE IF NOT EXISTS invitations (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    email VARCHAR(100) NOT NULL,
+    role ENUM('admin', 'editor', 'viewer') NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    invited_by BIGINT NULL,
+    user_id BIGINT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_invitations_email (email),
+    INDEX idx_invitations_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS password_reset_tokens;
+
+ALTER TABLE users
+    DROP COLUMN password_changed_at;
@@ -0,0 +1,16 @@
+-- パスワード再設定
+-- 再設定トークンはSHA-256のハッシュのみを保存し、使用後は再利用できない
+-- users.password_changed_atより前に発行されたJWTは失効したものとして扱う
+ALTER TABLE users
+    ADD COLUMN password_changed_at TIMESTAMP NULL AFTER status;
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_password_reset_tokens_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
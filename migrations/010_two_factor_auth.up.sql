@@ -0,0 +1,28 @@
+-- 二要素認証(TOTP)
+-- シークレットはアプリケーションで暗号化して保存し、リカバリーコードはSHA-256のハッシュのみを保存する
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id BIGINT PRIMARY KEY,
+    secret VARCHAR(255) NOT NULL,
+    enabled_at TIMESTAMP NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_mfa_recovery_codes_user_code (user_id, code_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ロールごとの二要素認証の必須設定(行がないロールは任意)
+CREATE TABLE IF NOT EXISTS mfa_policies (
+    role ENUM('admin', 'editor', 'viewer') PRIMARY KEY,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
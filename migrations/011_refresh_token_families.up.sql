@@ -0,0 +1,16 @@
+-- リフレッシュトークンのローテーション
+-- ログインごとにファミリーを作り、リフレッシュのたびに同じファミリーの新しいトークンと交換する
+-- ローテーション済み・失効済みのトークンが再利用された場合はファミリー全体を失効させる
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    jti CHAR(36) UNIQUE NOT NULL,
+    family_id CHAR(36) NOT NULL,
+    user_id BIGINT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    rotated_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_refresh_tokens_family_id (family_id),
+    INDEX idx_refresh_tokens_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
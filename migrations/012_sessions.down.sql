@@ -0,0 +1,4 @@
+ALTER TABLE refresh_tokens
+    DROP FOREIGN KEY fk_refresh_tokens_session;
+
+DROP TABLE IF EXISTS sessions;
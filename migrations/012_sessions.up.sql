@@ -0,0 +1,24 @@
+-- ログインセッション
+-- ログインごとに1件作成し、IDはリフレッシュトークンのファミリー(refresh_tokens.family_id)と同じ
+-- 失効したセッションのアクセストークン・リフレッシュトークンは使用できない
+CREATE TABLE IF NOT EXISTS sessions (
+    id CHAR(36) PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_sessions_user_id (user_id, revoked_at, expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 既存のリフレッシュトークンのファミリーをセッションとして登録
+INSERT INTO sessions (id, user_id, expires_at, last_used_at, revoked_at, created_at)
+SELECT family_id, MIN(user_id), MAX(expires_at), MAX(created_at), MAX(revoked_at), MIN(created_at)
+FROM refresh_tokens
+GROUP BY family_id;
+
+ALTER TABLE refresh_tokens
+    ADD CONSTRAINT fk_refresh_tokens_session FOREIGN KEY (family_id) REFERENCES sessions(id) ON DELETE CASCADE;
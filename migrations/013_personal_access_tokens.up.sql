@@ -0,0 +1,17 @@
+-- パーソナルアクセストークン(CIやスクリプトからのAPI利用)
+-- トークンはSHA-256のハッシュのみを保存し、表示用に先頭の数文字を残す
+-- scopesは許可する操作(posts:writeなど)のJSON配列
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    token_prefix VARCHAR(16) NOT NULL,
+    scopes JSON NOT NULL,
+    expires_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_personal_access_tokens_user_id (user_id, revoked_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,27 @@
+-- ログインの総当たり対策
+-- login_throttlesはユーザー名・IPアドレスごとの連続失敗回数とロック期限(指数的に延長する)
+-- login_attemptsは全てのログイン試行の監査ログ
+CREATE TABLE IF NOT EXISTS login_throttles (
+    scope VARCHAR(20) NOT NULL,
+    throttle_key VARCHAR(255) NOT NULL,
+    failure_count INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    last_failure_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (scope, throttle_key),
+    INDEX idx_login_throttles_locked_until (locked_until)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    username VARCHAR(255) NOT NULL,
+    user_id BIGINT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    succeeded BOOLEAN NOT NULL DEFAULT FALSE,
+    failure_reason VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_login_attempts_username (username, created_at),
+    INDEX idx_login_attempts_ip_address (ip_address, created_at),
+    INDEX idx_login_attempts_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
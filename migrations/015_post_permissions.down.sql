@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS post_shares;
+
+-- contributorのユーザー・招待・ポリシーはviewerに戻してからENUMを縮小する
+UPDATE users SET role = 'viewer' WHERE role = 'contributor';
+UPDATE invitations SET role = 'viewer' WHERE role = 'contributor';
+DELETE FROM mfa_policies WHERE role = 'contributor';
+
+ALTER TABLE mfa_policies
+    MODIFY COLUMN role ENUM('admin', 'editor', 'viewer');
+
+ALTER TABLE invitations
+    MODIFY COLUMN role ENUM('admin', 'editor', 'viewer') NOT NULL;
+
+ALTER TABLE users
+    MODIFY COLUMN role ENUM('admin', 'editor', 'viewer') NOT NULL DEFAULT 'viewer';
@@ -0,0 +1,23 @@
+-- 記事単位の権限
+-- contributorは自分の下書きを作成・編集できるが公開はできないロール
+-- post_sharesは下書きを閲覧できるように共有したユーザー
+ALTER TABLE users
+    MODIFY COLUMN role ENUM('admin', 'editor', 'contributor', 'viewer') NOT NULL DEFAULT 'viewer';
+
+ALTER TABLE invitations
+    MODIFY COLUMN role ENUM('admin', 'editor', 'contributor', 'viewer') NOT NULL;
+
+ALTER TABLE mfa_policies
+    MODIFY COLUMN role ENUM('admin', 'editor', 'contributor', 'viewer');
+
+CREATE TABLE IF NOT EXISTS post_shares (
+    post_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    shared_by BIGINT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, user_id),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (shared_by) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_post_shares_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
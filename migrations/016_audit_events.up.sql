@@ -0,0 +1,21 @@
+-- 管理操作の監査ログ(追記のみ、保持期間を過ぎたものは定期的に削除する)
+-- actor_nameは操作時点のユーザー名(ユーザー削除後も誰の操作か分かるようにする)
+-- before_data/after_dataは変更前後のスナップショット(更新時は変更された項目のみ)
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    actor_id BIGINT NULL,
+    actor_name VARCHAR(50) NOT NULL DEFAULT '',
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id BIGINT NULL,
+    before_data JSON NULL,
+    after_data JSON NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_audit_events_actor_id (actor_id, id),
+    INDEX idx_audit_events_action (action, id),
+    INDEX idx_audit_events_target (target_type, target_id, id),
+    INDEX idx_audit_events_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+-- レビュー中・差し戻された記事は下書きに戻してからENUMを縮小する
+UPDATE posts SET status = 'draft' WHERE status IN ('in_review', 'rejected');
+
+ALTER TABLE posts
+    DROP FOREIGN KEY fk_posts_reviewer_id,
+    DROP INDEX idx_posts_reviewer_status,
+    DROP COLUMN reviewed_at,
+    DROP COLUMN submitted_at,
+    DROP COLUMN review_comment,
+    DROP COLUMN reviewer_id,
+    MODIFY COLUMN status ENUM('draft', 'published', 'scheduled') NOT NULL DEFAULT 'draft';
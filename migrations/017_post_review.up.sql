@@ -0,0 +1,11 @@
+-- 記事のレビュー
+-- in_reviewはレビュー担当者の承認待ち、rejectedは差し戻された記事
+-- reviewer_idはレビュー担当者、review_commentは差し戻し時のコメント
+ALTER TABLE posts
+    MODIFY COLUMN status ENUM('draft', 'published', 'scheduled', 'in_review', 'rejected') NOT NULL DEFAULT 'draft',
+    ADD COLUMN reviewer_id BIGINT NULL AFTER author_id,
+    ADD COLUMN review_comment TEXT NULL AFTER reviewer_id,
+    ADD COLUMN submitted_at TIMESTAMP NULL AFTER scheduled_at,
+    ADD COLUMN reviewed_at TIMESTAMP NULL AFTER submitted_at,
+    ADD CONSTRAINT fk_posts_reviewer_id FOREIGN KEY (reviewer_id) REFERENCES users(id) ON DELETE SET NULL,
+    ADD INDEX idx_posts_reviewer_status (reviewer_id, status, submitted_at);
@@ -0,0 +1,24 @@
+-- 記事の本文に対するレビューノート(編集権限を持つユーザーのみ閲覧できる)
+-- parent_idはスレッドの先頭のノート(返信は先頭のノートにまとめる)
+-- start_line/end_line/quoteは紐付けたMarkdown本文の行範囲と引用で、本文の変更時に紐付け直す
+-- outdatedは本文の変更で紐付けた箇所が見つからなくなったノート
+CREATE TABLE IF NOT EXISTS review_notes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    post_id BIGINT NOT NULL,
+    parent_id BIGINT NULL,
+    author_id BIGINT NULL,
+    body TEXT NOT NULL,
+    start_line INT NULL,
+    end_line INT NULL,
+    quote TEXT NULL,
+    outdated BOOLEAN NOT NULL DEFAULT FALSE,
+    resolved_at TIMESTAMP NULL,
+    resolved_by BIGINT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES review_notes(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (resolved_by) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_review_notes_post (post_id, parent_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
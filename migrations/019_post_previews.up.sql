@@ -0,0 +1,4 @@
+-- 記事のプレビューURL
+-- preview_versionはプレビューURLの署名に含めるバージョンで、上げると発行済みのプレビューURLがすべて無効になる
+ALTER TABLE posts
+    ADD COLUMN preview_version INT NOT NULL DEFAULT 0 AFTER reviewed_at;
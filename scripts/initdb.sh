@@ -0,0 +1,10 @@
+#!/usr/bin/env bash
+# MySQLコンテナの初回起動時にマイグレーションを適用する
+# docker-entrypoint-initdb.d は全ての .sql を実行してしまうため、
+# down マイグレーションを除外して *.up.sql のみを番号順に適用する
+set -e
+
+for f in /migrations/*.up.sql; do
+    echo "initdb: applying $(basename "$f")"
+    mysql --protocol=socket -uroot -p"${MYSQL_ROOT_PASSWORD}" "${MYSQL_DATABASE}" < "$f"
+done
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -128,18 +129,25 @@ func runMigrationsFromFile(ctx context.Context, db *bun.DB) error {
 		return fmt.Errorf("failed to find project root: %w", err)
 	}
 
-	migrationFile := filepath.Join(projectRoot, "migrations", "001_initial_schema.up.sql")
-
-	// SQLファイルを読み込み
-	sqlContent, err := os.ReadFile(migrationFile)
+	// 番号順に全てのupマイグレーションを実行
+	migrationFiles, err := filepath.Glob(filepath.Join(projectRoot, "migrations", "*.up.sql"))
 	if err != nil {
-		return fmt.Errorf("failed to read migration file: %w", err)
+		return fmt.Errorf("failed to list migration files: %w", err)
 	}
+	sort.Strings(migrationFiles)
 
-	// マイグレーション実行
-	_, err = db.ExecContext(ctx, string(sqlContent))
-	if err != nil {
-		return fmt.Errorf("failed to execute migration: %w", err)
+	for _, migrationFile := range migrationFiles {
+		// SQLファイルを読み込み
+		sqlContent, err := os.ReadFile(migrationFile)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file %s: %w", filepath.Base(migrationFile), err)
+		}
+
+		// マイグレーション実行
+		_, err = db.ExecContext(ctx, string(sqlContent))
+		if err != nil {
+			return fmt.Errorf("failed to execute migration %s: %w", filepath.Base(migrationFile), err)
+		}
 	}
 
 	return nil
@@ -178,6 +186,23 @@ func cleanupTables(ctx context.Context, t *testing.T, db *bun.DB) {
 
 	// 各テーブルをトランケート
 	tables := []string{
+		"review_notes",
+		"audit_events",
+		"login_attempts",
+		"login_throttles",
+		"personal_access_tokens",
+		"refresh_tokens",
+		"sessions",
+		"mfa_policies",
+		"mfa_recovery_codes",
+		"user_mfa",
+		"password_reset_tokens",
+		"invitations",
+		"comments",
+		"media_variants",
+		"media",
+		"post_shares",
+		"post_revisions",
 		"post_tags",
 		"posts",
 		"tags",